@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"syscall"
 
 	"golang.org/x/term"
@@ -16,15 +17,33 @@ import (
 //go:embed example-config.yml
 var defaultConfig []byte
 
+// configFiles collects repeated -f flags, later files override the earlier ones.
+type configFiles []string
+
+func (c *configFiles) String() string {
+	return strings.Join(*c, ",")
+}
+
+func (c *configFiles) Set(v string) error {
+	*c = append(*c, v)
+	return nil
+}
+
 func main() {
-	var configFile, chainConfigDirectory, stateFile, encryptedFile, password string
-	var dumpConfig, encryptConfig, decryptConfig, devMode bool
-	flag.StringVar(&configFile, "f", "config.yml", "configuration file to use, can also be set with the ENV var 'CONFIG'")
+	var chainConfigDirectory, stateFile, encryptedFile, password, dumpFormat, renderChain, renderType string
+	var dumpConfig, dumpEffective, printSchema, encryptConfig, decryptConfig, devMode bool
+	var files configFiles
+	flag.Var(&files, "f", "configuration file to use, can be repeated to layer files over each other, can also be set with the ENV var 'CONFIG' (comma separated) (default config.yml)")
 	flag.StringVar(&encryptedFile, "encrypted-config", "config.yml.asc", "encrypted config file, only valid with -encrypt or -decrypt flag")
 	flag.StringVar(&password, "password", "", "password to use for encrypting/decrypting the config, if unset will prompt, also can use ENV var 'PASSWORD'")
 	flag.StringVar(&stateFile, "state", ".tenderduty-state.json", "file for storing state between restarts")
 	flag.StringVar(&chainConfigDirectory, "cc", "chains.d", "directory containing additional chain specific configurations")
 	flag.BoolVar(&dumpConfig, "example-config", false, "print the an example config.yml and exit")
+	flag.BoolVar(&printSchema, "print-schema", false, "print a JSON Schema of config.yml for validating it in an editor and exit")
+	flag.BoolVar(&dumpEffective, "dump-config", false, "print the alert settings of each chain with the defaults applied, secrets redacted, and exit")
+	flag.StringVar(&dumpFormat, "dump-format", "yaml", "output format for -dump-config, yaml or json")
+	flag.StringVar(&renderChain, "render-alert", "", "print an example alert for this chain as each enabled destination would receive it, without sending it, and exit")
+	flag.StringVar(&renderType, "alert-type", "ChainStalled", "the alert to print with -render-alert")
 	flag.BoolVar(&encryptConfig, "encrypt", false, "encrypt the file specified by -f to -encrypted-config")
 	flag.BoolVar(&decryptConfig, "decrypt", false, "decrypt the file specified by -encrypted-config to -f")
 	flag.BoolVar(&devMode, "devmode", false, "start up the web server in dev mode (reading files directly instead of embeding them)")
@@ -35,15 +54,43 @@ func main() {
 		os.Exit(0)
 	}
 
-	if configFile == "config.yml" && os.Getenv("CONFIG") != "" {
-		configFile = os.Getenv("CONFIG")
+	if printSchema {
+		if e := td2.PrintSchema(defaultConfig, os.Stdout); e != nil {
+			log.Fatalln(e)
+		}
+		os.Exit(0)
+	}
+
+	if len(files) == 0 && os.Getenv("CONFIG") != "" {
+		files = strings.Split(os.Getenv("CONFIG"), ",")
+	}
+	if len(files) == 0 {
+		files = configFiles{"config.yml"}
 	}
 
 	if os.Getenv("PASSWORD") != "" {
 		password = os.Getenv("PASSWORD")
 	}
 
+	if dumpEffective {
+		if e := td2.DumpConfig(files, chainConfigDirectory, &password, dumpFormat, os.Stdout); e != nil {
+			log.Fatalln(e)
+		}
+		os.Exit(0)
+	}
+
+	if renderChain != "" {
+		if e := td2.RenderAlert(files, chainConfigDirectory, &password, renderChain, renderType, os.Stdout); e != nil {
+			log.Fatalln(e)
+		}
+		os.Exit(0)
+	}
+
 	if encryptConfig || decryptConfig {
+		if len(files) > 1 {
+			log.Fatalln("-encrypt and -decrypt work on a single -f file")
+		}
+		configFile := files[0]
 		if password == "" {
 			fmt.Print("Please enter the encryption password: ")
 			pass, err := term.ReadPassword(int(syscall.Stdin))
@@ -67,7 +114,7 @@ func main() {
 		os.Exit(0)
 	}
 
-	err := td2.Run(configFile, stateFile, chainConfigDirectory, &password, devMode)
+	err := td2.Run(files, stateFile, chainConfigDirectory, &password, devMode)
 	if err != nil {
 		log.Println(err.Error(), "... exiting.")
 	}
@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math"
 	"net/http"
+	"os"
+	"os/exec"
 	"slices"
 	"strconv"
 	"strings"
@@ -15,6 +18,9 @@ import (
 	"time"
 
 	"github.com/PagerDuty/go-pagerduty"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	awssns "github.com/aws/aws-sdk-go-v2/service/sns"
 	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
 	"github.com/firstset/tenderduty/v2/td2/utils"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -25,23 +31,52 @@ type alertMsg struct {
 	disc bool
 	tg   bool
 	slk  bool
-
-	severity string
-	resolved bool
-	chain    string
-	message  string
-	uniqueId string
-	key      string
-
-	tgChannel  string
-	tgKey      string
-	tgMentions string
-
-	discHook     string
-	discMentions string
-
-	slkHook     string
-	slkMentions string
+	sns  bool
+	exe  bool
+
+	severity  string
+	resolved  bool
+	chain     string
+	chainName string
+	chainId   string
+	message   string
+	uniqueId  string
+	key       string
+	extraInfo string
+	firingFor time.Duration // how long the alarm was active, only set when resolving
+	report    bool          // a scheduled summary report rather than an alert
+	oneShot   bool          // a notice with nothing to resolve, it is neither recorded nor deduplicated
+	runbook   string        // the runbook URL configured for the alert type, if any
+
+	pdTitlePrefix string
+	pdFooter      string
+	pdDedupKey    string // the key the incident was opened with, set by shouldNotify for an incident that is open
+
+	tgChannel     string
+	tgKey         string
+	tgMentions    string
+	tgTitlePrefix string
+	tgFooter      string
+	tgButtons     bool
+
+	discHook        string
+	discMentions    string
+	discTitlePrefix string
+	discFooter      string
+
+	slkHook        string
+	slkMentions    string
+	slkTitlePrefix string
+	slkFooter      string
+	slkBlocks      bool
+
+	snsRegion      string
+	snsTopic       string
+	snsTitlePrefix string
+	snsFooter      string
+
+	exeCommand string
+	exeArgs    []string
 
 	alertConfig *AlertConfig
 }
@@ -53,11 +88,37 @@ const (
 	tg
 	di
 	slk
+	sns
+	exe
 )
 
+// String returns the destination's name as used in the notifiers table and the metrics.
+func (d notifyDest) String() string {
+	switch d {
+	case pd:
+		return "pagerduty"
+	case tg:
+		return "telegram"
+	case di:
+		return "discord"
+	case slk:
+		return "slack"
+	case sns:
+		return "sns"
+	case exe:
+		return "exec"
+	}
+	return "unknown"
+}
+
+// alertMsgCache is a sent alarm. Severity is only kept for AllAlarms, it is empty for alarms restored from a state file
+// written before it was stored.
 type alertMsgCache struct {
 	Message  string    `json:"message"`
 	SentTime time.Time `json:"sent_time"`
+	Severity string    `json:"severity,omitempty"`
+	// DedupKey is the key a PagerDuty incident was opened with, only set in SentPdAlarms
+	DedupKey string `json:"dedup_key,omitempty"`
 }
 
 type alarmCache struct {
@@ -67,8 +128,14 @@ type alarmCache struct {
 	SentTgAlarms   map[string]alertMsgCache            `json:"sent_tg_alarms"`
 	SentDiAlarms   map[string]alertMsgCache            `json:"sent_di_alarms"`
 	SentSlkAlarms  map[string]alertMsgCache            `json:"sent_slk_alarms"`
+	SentSnsAlarms  map[string]alertMsgCache            `json:"sent_sns_alarms"`
+	SentExecAlarms map[string]alertMsgCache            `json:"sent_exec_alarms"`
 	AllAlarms      map[string]map[string]alertMsgCache `json:"sent_all_alarms"`
 	flappingAlarms map[string]map[string]alertMsgCache
+	snoozedAlarms  map[string]map[string]time.Time // chain -> unique ID -> snoozed until
+	clearSince     map[string]map[string]time.Time // chain -> unique ID -> when the condition was first seen clear
+	acknowledged   map[string]map[string]bool      // chain -> unique ID -> acknowledged in PagerDuty
+	tgAcknowledged map[string]map[string]bool      // chain -> unique ID -> acknowledged with a Telegram button
 	notifyMux      sync.RWMutex
 }
 
@@ -82,7 +149,7 @@ func (a *alarmCache) clearNoBlocks(cc *ChainConfig) {
 			td.alert(
 				cc.name,
 				fmt.Sprintf("stalled: have not seen a new block on %s in %d minutes", cc.ChainId, intVal(cc.Alerts.Stalled)),
-				"critical",
+				priorityOrCritical(cc.Alerts.StalledPriority),
 				true,
 				&alertID,
 			)
@@ -99,6 +166,22 @@ func (a *alarmCache) getCount(chain string) int {
 	return len(a.AllAlarms[chain])
 }
 
+// worstSeverity returns the highest severity of the chain's active alarms, empty when none has a known severity.
+func (a *alarmCache) worstSeverity(chain string) string {
+	if a.AllAlarms == nil || a.AllAlarms[chain] == nil {
+		return ""
+	}
+	a.notifyMux.RLock()
+	defer a.notifyMux.RUnlock()
+	worst := ""
+	for _, alarm := range a.AllAlarms[chain] {
+		if severityRank(alarm.Severity) > severityRank(worst) {
+			worst = alarm.Severity
+		}
+	}
+	return worst
+}
+
 func (a *alarmCache) clearAll(chain string) {
 	if a.AllAlarms == nil || a.AllAlarms[chain] == nil {
 		return
@@ -119,12 +202,101 @@ func (a *alarmCache) exist(chain string, alertID string) bool {
 	return ok
 }
 
+// activeWithPrefix returns the unique IDs of the chain's active alarms that start with prefix.
+func (a *alarmCache) activeWithPrefix(chain string, prefix string) []string {
+	a.notifyMux.RLock()
+	defer a.notifyMux.RUnlock()
+	ids := make([]string, 0)
+	for id := range a.AllAlarms[chain] {
+		if strings.HasPrefix(id, prefix) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// resolveAll resolves every active alarm of the chain, so that a paused chain doesn't come back with stale alarms.
+func (a *alarmCache) resolveAll(chain string) {
+	a.notifyMux.RLock()
+	active := make(map[string]alertMsgCache, len(a.AllAlarms[chain]))
+	for id, cached := range a.AllAlarms[chain] {
+		active[id] = cached
+	}
+	a.notifyMux.RUnlock()
+	for id, cached := range active {
+		alertID := id
+		// alarms restored from an older state file have no severity, critical passes every threshold
+		td.alert(chain, cached.Message, priorityOrCritical(cached.Severity), true, &alertID)
+	}
+}
+
+// snooze suppresses new notifications for an active alarm until the given time, the alarm itself stays active.
+func (a *alarmCache) snooze(chain string, alertID string, until time.Time) error {
+	a.notifyMux.Lock()
+	defer a.notifyMux.Unlock()
+	if _, ok := a.AllAlarms[chain][alertID]; !ok {
+		return fmt.Errorf("no active alarm %s on %s", alertID, chain)
+	}
+	if a.snoozedAlarms == nil {
+		a.snoozedAlarms = make(map[string]map[string]time.Time)
+	}
+	if a.snoozedAlarms[chain] == nil {
+		a.snoozedAlarms[chain] = make(map[string]time.Time)
+	}
+	a.snoozedAlarms[chain][alertID] = until
+	return nil
+}
+
+// clearedFor records when an active alarm's condition was first seen clear, and reports if it has stayed clear for
+// at least delay. A zero delay resolves straight away.
+func (a *alarmCache) clearedFor(chain string, alertID string, delay time.Duration) bool {
+	if delay <= 0 {
+		return true
+	}
+	a.notifyMux.Lock()
+	defer a.notifyMux.Unlock()
+	if a.clearSince == nil {
+		a.clearSince = make(map[string]map[string]time.Time)
+	}
+	if a.clearSince[chain] == nil {
+		a.clearSince[chain] = make(map[string]time.Time)
+	}
+	since, ok := a.clearSince[chain][alertID]
+	if !ok {
+		a.clearSince[chain][alertID] = time.Now()
+		return false
+	}
+	return time.Since(since) >= delay
+}
+
+// stillFiring restarts the resolve delay, the condition has to be clear for the whole delay again.
+func (a *alarmCache) stillFiring(chain string, alertID string) {
+	a.notifyMux.Lock()
+	defer a.notifyMux.Unlock()
+	delete(a.clearSince[chain], alertID)
+}
+
+// isSnoozed must be called while holding notifyMux, expired snoozes are removed.
+func (a *alarmCache) isSnoozed(chain string, alertID string) bool {
+	until, ok := a.snoozedAlarms[chain][alertID]
+	if !ok {
+		return false
+	}
+	if time.Now().After(until) {
+		delete(a.snoozedAlarms[chain], alertID)
+		return false
+	}
+	return true
+}
+
 // alarms is used to prevent double notifications. TODO: save on exit / load on start
 var alarms = &alarmCache{
 	SentPdAlarms:   make(map[string]alertMsgCache),
 	SentTgAlarms:   make(map[string]alertMsgCache),
 	SentDiAlarms:   make(map[string]alertMsgCache),
 	SentSlkAlarms:  make(map[string]alertMsgCache),
+	SentSnsAlarms:  make(map[string]alertMsgCache),
+	SentExecAlarms: make(map[string]alertMsgCache),
 	AllAlarms:      make(map[string]map[string]alertMsgCache),
 	flappingAlarms: make(map[string]map[string]alertMsgCache),
 	notifyMux:      sync.RWMutex{},
@@ -138,28 +310,64 @@ func shouldNotify(msg *alertMsg, dest notifyDest) bool {
 	switch dest {
 	case pd:
 		if !slices.Contains(SeverityThresholdToSeverities(msg.alertConfig.Pagerduty.SeverityThreshold), msg.severity) {
+			countSuppressed(dest, "threshold", msg)
 			return false
 		}
 		whichMap = alarms.SentPdAlarms
 		service = "PagerDuty"
 	case tg:
 		if !slices.Contains(SeverityThresholdToSeverities(msg.alertConfig.Telegram.SeverityThreshold), msg.severity) {
+			countSuppressed(dest, "threshold", msg)
 			return false
 		}
 		whichMap = alarms.SentTgAlarms
 		service = "Telegram"
 	case di:
 		if !slices.Contains(SeverityThresholdToSeverities(msg.alertConfig.Discord.SeverityThreshold), msg.severity) {
+			countSuppressed(dest, "threshold", msg)
 			return false
 		}
 		whichMap = alarms.SentDiAlarms
 		service = "Discord"
 	case slk:
 		if !slices.Contains(SeverityThresholdToSeverities(msg.alertConfig.Slack.SeverityThreshold), msg.severity) {
+			countSuppressed(dest, "threshold", msg)
 			return false
 		}
 		whichMap = alarms.SentSlkAlarms
 		service = "Slack"
+	case sns:
+		if !slices.Contains(SeverityThresholdToSeverities(msg.alertConfig.SNS.SeverityThreshold), msg.severity) {
+			countSuppressed(dest, "threshold", msg)
+			return false
+		}
+		whichMap = alarms.SentSnsAlarms
+		service = "SNS"
+	case exe:
+		if !slices.Contains(SeverityThresholdToSeverities(msg.alertConfig.Exec.SeverityThreshold), msg.severity) {
+			countSuppressed(dest, "threshold", msg)
+			return false
+		}
+		whichMap = alarms.SentExecAlarms
+		service = "exec"
+	}
+
+	// a notice is sent once and never resolved, there is nothing to deduplicate or keep track of
+	if msg.oneShot {
+		return true
+	}
+
+	// a snoozed alarm stays active but doesn't notify until the snooze expires, resolving is always allowed
+	if !msg.resolved && alarms.isSnoozed(msg.chainName, msg.uniqueId) {
+		l(fmt.Sprintf("😴 Snoozed      alarm on %s (%s) - not notifying %s", msg.chain, msg.message, service))
+		countSuppressed(dest, "snooze", msg)
+		return false
+	}
+
+	if dest == pd {
+		if key := alarms.sentDedupKey(msg.uniqueId); key != "" {
+			msg.pdDedupKey = key
+		}
 	}
 
 	switch {
@@ -167,37 +375,71 @@ func shouldNotify(msg *alertMsg, dest notifyDest) bool {
 		// TODO: this is a temporary solution for sending proposal reminders, ideally we should make this feature more general and configurable
 		// Check if this is a proposal alert that should be re-sent
 		if strings.HasPrefix(msg.uniqueId, "UnvotedGovernanceProposal") {
+			// someone acknowledged the incident in PagerDuty, the other channels don't need reminding either
+			if dest != pd && alarms.isAcknowledged(msg.chainName, msg.uniqueId) {
+				lDebug(fmt.Sprintf("👀 Acknowledged alarm on %s (%s) - not re-sending to %s", msg.chain, msg.message, service))
+				countSuppressed(dest, "acknowledged", msg)
+				return false
+			}
 			// Check if it has been 6 hours since the last (re-)send
 			if whichMap[msg.uniqueId].SentTime.Before(time.Now().Add(-1 * time.Duration(td.GovernanceAlertsReminderInterval) * time.Hour)) {
-				l(fmt.Sprintf("🔄 RE-SENDING ALERT on %s (%s) - notifying %s", msg.chain, msg.message, service))
+				lDebug(fmt.Sprintf("🔄 RE-SENDING ALERT on %s (%s) - notifying %s", msg.chain, msg.message, service))
 				cache := alertMsgCache{
 					Message:  msg.message,
 					SentTime: time.Now(),
+					DedupKey: whichMap[msg.uniqueId].DedupKey,
 				}
 				whichMap[msg.uniqueId] = cache
 				return true
 			}
 		}
+		countSuppressed(dest, "dedup", msg)
 		return false
 	case !whichMap[msg.uniqueId].SentTime.IsZero() && msg.resolved:
+		if alarms.flappingAlarms[msg.chain] == nil {
+			alarms.flappingAlarms[msg.chain] = make(map[string]alertMsgCache)
+		}
+		// a resolve that follows another one within the cooldown is held back until the cooldown ends, the alarm
+		// stays sent so a re-trigger in the meantime isn't repeated either
+		resolveKey := fmt.Sprintf("resolve_%d_%s", dest, msg.uniqueId)
+		if cooldown := resolveCooldown(); cooldown > 0 {
+			if last := alarms.flappingAlarms[msg.chain][resolveKey].SentTime; last.After(time.Now().Add(-cooldown)) {
+				lDebug(fmt.Sprintf("🛑 flapping detected - holding back %s resolve on %s (%s)", service, msg.chain, msg.message))
+				holdBackResolve(msg, dest, last.Add(cooldown))
+				countSuppressed(dest, "flap", msg)
+				return false
+			}
+		}
+		// a pagerduty incident is kept open for at least min_resolve_minutes, so a blip doesn't open and close one
+		if dest == pd {
+			if due := whichMap[msg.uniqueId].SentTime.Add(pdMinResolve(msg.alertConfig)); time.Now().Before(due) {
+				lDebug(fmt.Sprintf("🛑 alert was triggered recently - holding back %s resolve on %s (%s)", service, msg.chain, msg.message))
+				holdBackResolve(msg, dest, due)
+				countSuppressed(dest, "flap", msg)
+				return false
+			}
+		}
 		// alarm is cleared
 		delete(whichMap, msg.uniqueId)
+		alarms.flappingAlarms[msg.chain][resolveKey] = alertMsgCache{Message: msg.message, SentTime: time.Now()}
 		l(fmt.Sprintf("💜 Resolved     alarm on %s (%s) - notifying %s", msg.chain, msg.message, service))
 		return true
 	case msg.resolved:
 		// it looks like we got a duplicate resolution or suppressed it. Note it and move on:
 		l(fmt.Sprintf("😕 Not clearing alarm on %s (%s) - no corresponding alert %s", msg.chain, msg.message, service))
+		countSuppressed(dest, "dedup", msg)
 		return false
 	}
 
-	// check if the alarm is flapping, if we sent the same alert in the last five minutes, show a warning but don't alert
+	// check if the alarm is flapping, if we sent the same alert within the flap window, show a warning but don't alert
 	if alarms.flappingAlarms[msg.chain] == nil {
 		alarms.flappingAlarms[msg.chain] = make(map[string]alertMsgCache)
 	}
 
 	// for pagerduty we perform some basic flap detection
-	if dest == pd && msg.pd && alarms.flappingAlarms[msg.chain][msg.uniqueId].SentTime.After(time.Now().Add(-5*time.Minute)) {
-		l("🛑 flapping detected - suppressing pagerduty notification:", msg.chain, msg.message)
+	if dest == pd && msg.pd && alarms.flappingAlarms[msg.chain][msg.uniqueId].SentTime.After(time.Now().Add(-pdFlapWindow(msg.alertConfig))) {
+		lDebug("🛑 flapping detected - suppressing pagerduty notification:", msg.chain, msg.message)
+		countSuppressed(dest, "flap", msg)
 		return false
 	} else if dest == pd && msg.pd {
 		cache := alertMsgCache{
@@ -212,15 +454,117 @@ func shouldNotify(msg *alertMsg, dest notifyDest) bool {
 		Message:  msg.message,
 		SentTime: time.Now(),
 	}
+	if dest == pd {
+		cache.DedupKey = pagerdutyDedupKey(msg)
+	}
 	whichMap[msg.uniqueId] = cache
 	return true
 }
 
+// sentDedupKey returns the dedup key of the alarm's open PagerDuty incident, empty when there is none. Incidents opened
+// before the key included the chain-id have none stored, they were keyed on the alarm ID alone. Must be called while
+// holding notifyMux.
+func (a *alarmCache) sentDedupKey(id string) string {
+	sent, ok := a.SentPdAlarms[id]
+	if !ok || sent.SentTime.IsZero() {
+		return ""
+	}
+	if sent.DedupKey == "" {
+		return id
+	}
+	return sent.DedupKey
+}
+
+// resolveCooldown is how long after a resolve another resolve of the same alarm to the same destination is held back.
+func resolveCooldown() time.Duration {
+	if td == nil {
+		return 0
+	}
+	return time.Duration(intVal(td.ResolveCooldownMinutes)) * time.Minute
+}
+
+// defaultPdFlapWindow is how long a repeated PagerDuty alert is suppressed for when flap_window_minutes is not set.
+const defaultPdFlapWindow = 5 * time.Minute
+
+// pdFlapWindow is how long after an alert to PagerDuty the same alert is suppressed as flapping.
+func pdFlapWindow(cfg *AlertConfig) time.Duration {
+	if cfg.Pagerduty.FlapWindowMinutes == nil {
+		return defaultPdFlapWindow
+	}
+	return time.Duration(*cfg.Pagerduty.FlapWindowMinutes) * time.Minute
+}
+
+// pdMinResolve is how long a PagerDuty alert has to be open before its resolve is sent.
+func pdMinResolve(cfg *AlertConfig) time.Duration {
+	return time.Duration(intVal(cfg.Pagerduty.MinResolveMinutes)) * time.Minute
+}
+
+// holdBackResolve schedules a resolve that was held back to be sent at until, only once for each alarm and destination.
+// alarms.notifyMux must be held.
+func holdBackResolve(msg *alertMsg, dest notifyDest, until time.Time) {
+	heldKey := fmt.Sprintf("held_%d_%s", dest, msg.uniqueId)
+	if alarms.flappingAlarms[msg.chain][heldKey].SentTime.IsZero() {
+		alarms.flappingAlarms[msg.chain][heldKey] = alertMsgCache{Message: msg.message, SentTime: time.Now()}
+		holdResolve(msg, dest, time.Until(until)+time.Second)
+	}
+}
+
+// holdResolve sends a resolve that was held back by the cooldown once it has passed.
+func holdResolve(msg *alertMsg, dest notifyDest, wait time.Duration) {
+	time.AfterFunc(wait, func() {
+		if !releaseResolve(msg, dest) {
+			return
+		}
+		var err error
+		switch dest {
+		case pd:
+			err = notifyPagerduty(msg)
+		case tg:
+			err = notifyTg(msg)
+		case di:
+			err = notifyDiscord(msg)
+		case slk:
+			err = notifySlack(msg)
+		case sns:
+			err = notifySNS(msg)
+		case exe:
+			err = notifyExec(msg)
+		}
+		if err != nil {
+			lChainError(msg.chain, "error sending held back resolve", err.Error())
+		}
+	})
+}
+
+// releaseResolve clears the held marker of a resolve and reports whether it should still be sent. It is dropped when
+// the alarm fired again in the meantime, the destination is still showing it as firing which is correct again.
+func releaseResolve(msg *alertMsg, dest notifyDest) bool {
+	alarms.notifyMux.Lock()
+	defer alarms.notifyMux.Unlock()
+	delete(alarms.flappingAlarms[msg.chain], fmt.Sprintf("held_%d_%s", dest, msg.uniqueId))
+	if !alarms.AllAlarms[msg.chainName][msg.uniqueId].SentTime.IsZero() {
+		lDebug(fmt.Sprintf("🛑 dropping held back resolve on %s (%s), the alarm is firing again", msg.chain, msg.message))
+		return false
+	}
+	return true
+}
+
 func notifySlack(msg *alertMsg) (err error) {
 	if !msg.slk {
 		return
 	}
-	data, err := json.Marshal(buildSlackMessage(msg))
+	if !shouldNotify(msg, slk) {
+		return
+	}
+	return countNotification("slack", msg, sendSlack(msg))
+}
+
+func sendSlack(msg *alertMsg) (err error) {
+	var payload any = buildSlackMessage(msg)
+	if msg.slkBlocks {
+		payload = buildSlackBlockMessage(msg)
+	}
+	data, err := json.Marshal(payload)
 	if err != nil {
 		return
 	}
@@ -230,7 +574,7 @@ func notifySlack(msg *alertMsg) (err error) {
 		return
 	}
 
-	client := &http.Client{}
+	client := newHTTPClient(0)
 	resp, err := client.Do(req)
 	if err != nil {
 		return
@@ -254,22 +598,101 @@ type Attachment struct {
 	Color     string `json:"color"`
 	Title     string `json:"title"`
 	TitleLink string `json:"title_link"`
+	Footer    string `json:"footer,omitempty"`
 }
 
 func buildSlackMessage(msg *alertMsg) *SlackMessage {
 	prefix := "🚨 ALERT: "
 	color := "danger"
-	if msg.resolved {
-		msg.message = "OK: " + msg.message
+	text := msg.message
+	switch {
+	case msg.report:
+		prefix = summaryPrefix
+		color = "#439FE0"
+	case msg.resolved:
+		// msg is not modified, failed notifications are sent again
+		text = "OK: " + withResolvedAfter(msg.message, msg.firingFor)
 		prefix = "💜 Resolved: "
 		color = "good"
 	}
 	return &SlackMessage{
-		Text: msg.message,
+		Text: withExtraInfo(text, msg.extraInfo),
 		Attachments: []Attachment{
 			{
-				Title: fmt.Sprintf("TenderDuty %s %s %s", prefix, msg.chain, msg.slkMentions),
-				Color: color,
+				Title:     withTitlePrefix(msg.slkTitlePrefix, fmt.Sprintf("TenderDuty %s %s %s", prefix, msg.chain, msg.slkMentions)),
+				TitleLink: msg.runbook,
+				Color:     color,
+				Footer:    msg.slkFooter,
+			},
+		},
+	}
+}
+
+// SlackBlockMessage is a Block Kit payload, Text is only shown where the blocks can't be, e.g. in notifications.
+type SlackBlockMessage struct {
+	Text   string       `json:"text"`
+	Blocks []SlackBlock `json:"blocks"`
+}
+
+type SlackBlock struct {
+	Type     string      `json:"type"`
+	Text     *SlackText  `json:"text,omitempty"`
+	Fields   []SlackText `json:"fields,omitempty"`
+	Elements []SlackText `json:"elements,omitempty"`
+}
+
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// buildSlackBlockMessage is the Block Kit alternative to buildSlackMessage: a header, the message with the chain,
+// severity and status as fields, and the time it was sent, which Slack shows in the reader's timezone.
+func buildSlackBlockMessage(msg *alertMsg) *SlackBlockMessage {
+	prefix := "🚨 ALERT: "
+	status := "firing"
+	text := msg.message
+	switch {
+	case msg.report:
+		prefix = summaryPrefix
+		status = "summary"
+	case msg.resolved:
+		prefix = "💜 Resolved: "
+		status = "resolved"
+		text = withResolvedAfter(msg.message, msg.firingFor)
+	}
+	text = withExtraInfo(text, msg.extraInfo)
+	if msg.slkMentions != "" {
+		text = withExtraInfo(text, msg.slkMentions)
+	}
+
+	now := time.Now()
+	elements := []SlackText{{Type: "mrkdwn", Text: fmt.Sprintf("<!date^%d^{date_short_pretty} {time_secs}|%s>", now.Unix(), now.UTC().Format(time.RFC1123))}}
+	if msg.slkFooter != "" {
+		elements = append(elements, SlackText{Type: "mrkdwn", Text: msg.slkFooter})
+	}
+	if msg.runbook != "" {
+		elements = append(elements, SlackText{Type: "mrkdwn", Text: fmt.Sprintf("<%s|Runbook>", msg.runbook)})
+	}
+	return &SlackBlockMessage{
+		Text: fmt.Sprintf("%s%s: %s", prefix, msg.chain, msg.message),
+		Blocks: []SlackBlock{
+			{
+				Type: "header",
+				Text: &SlackText{Type: "plain_text", Text: withTitlePrefix(msg.slkTitlePrefix, "TenderDuty "+prefix+msg.chain)},
+			},
+			{
+				Type: "section",
+				Text: &SlackText{Type: "mrkdwn", Text: text},
+				Fields: []SlackText{
+					{Type: "mrkdwn", Text: "*Chain*\n" + msg.chain},
+					{Type: "mrkdwn", Text: "*Severity*\n" + msg.severity},
+					{Type: "mrkdwn", Text: "*Status*\n" + status},
+				},
+			},
+			{
+				Type:     "context",
+				Elements: elements,
 			},
 		},
 	}
@@ -282,36 +705,105 @@ func notifyDiscord(msg *alertMsg) (err error) {
 	if !shouldNotify(msg, di) {
 		return nil
 	}
+	return countNotification("discord", msg, sendDiscord(msg))
+}
+
+func sendDiscord(msg *alertMsg) error {
 	discPost := buildDiscordMessage(msg)
-	client := &http.Client{}
+	client := newHTTPClient(0)
 	data, err := json.MarshalIndent(discPost, "", "  ")
 	if err != nil {
-		l("⚠️ Could not notify discord!", err)
+		lWarn("⚠️ Could not notify discord!", err)
 		return err
 	}
 
-	req, err := http.NewRequest("POST", msg.discHook, bytes.NewBuffer(data))
-	if err != nil {
-		l("⚠️ Could not notify discord!", err)
-		return err
-	}
-	req.Header.Set("Content-Type", "application/json")
+	// a rate limited message is retried once, after waiting as long as discord asks
+	var resp *http.Response
+	for attempt := 0; attempt < 2; attempt++ {
+		discordLimits.wait(msg.discHook)
+		req, err := http.NewRequest("POST", msg.discHook, bytes.NewBuffer(data))
+		if err != nil {
+			lWarn("⚠️ Could not notify discord!", err)
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
 
-	resp, err := client.Do(req)
-	if err != nil {
-		l("⚠️ Could not notify discord!", err)
-		return err
+		resp, err = client.Do(req)
+		if err != nil {
+			lWarn("⚠️ Could not notify discord!", err)
+			return err
+		}
+		_ = resp.Body.Close()
+		discordLimits.update(msg.discHook, resp)
+		if resp.StatusCode != http.StatusTooManyRequests {
+			break
+		}
+		lWarn("⚠️ discord rate limited the webhook for", msg.chain)
 	}
-	_ = resp.Body.Close()
 
 	if resp.StatusCode != 204 {
 		log.Println(resp)
-		l("⚠️ Could not notify discord! Returned", resp.StatusCode)
-		return err
+		lWarn("⚠️ Could not notify discord! Returned", resp.StatusCode)
+		return fmt.Errorf("could not notify discord for %s got %d response", msg.chain, resp.StatusCode)
 	}
 	return nil
 }
 
+// discordMaxWait caps how long a send waits for a rate limit, a longer wait is left to the notification retries.
+const discordMaxWait = 30 * time.Second
+
+// discordRateLimits spaces the requests to each webhook using the rate limit headers of the previous response.
+type discordRateLimits struct {
+	mux       sync.Mutex
+	nextAllow map[string]time.Time
+}
+
+var discordLimits = &discordRateLimits{nextAllow: make(map[string]time.Time)}
+
+// wait blocks until the webhook may be used again.
+func (d *discordRateLimits) wait(hook string) {
+	d.mux.Lock()
+	until := d.nextAllow[hook]
+	d.mux.Unlock()
+	if wait := time.Until(until); wait > 0 {
+		if wait > discordMaxWait {
+			wait = discordMaxWait
+		}
+		time.Sleep(wait)
+	}
+}
+
+// update records when the webhook can be used next: after Retry-After on a 429, or once the bucket resets when
+// X-RateLimit-Remaining shows it is used up.
+func (d *discordRateLimits) update(hook string, resp *http.Response) {
+	var wait time.Duration
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		wait = headerSeconds(resp.Header.Get("Retry-After"))
+		if wait == 0 {
+			wait = time.Second
+		}
+	case resp.Header.Get("X-RateLimit-Remaining") == "0":
+		wait = headerSeconds(resp.Header.Get("X-RateLimit-Reset-After"))
+	}
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	if wait > 0 {
+		d.nextAllow[hook] = time.Now().Add(wait)
+	} else {
+		delete(d.nextAllow, hook)
+	}
+}
+
+// headerSeconds parses a header holding a number of seconds, discord sends fractions like 1.5.
+func headerSeconds(v string) time.Duration {
+	secs, err := strconv.ParseFloat(v, 64)
+	if err != nil || secs <= 0 {
+		return 0
+	}
+	return time.Duration(secs * float64(time.Second))
+}
+
 type DiscordMessage struct {
 	Username  string         `json:"username,omitempty"`
 	AvatarUrl string         `json:"avatar_url,omitempty"`
@@ -320,24 +812,73 @@ type DiscordMessage struct {
 }
 
 type DiscordEmbed struct {
-	Title       string `json:"title,omitempty"`
-	Url         string `json:"url,omitempty"`
-	Description string `json:"description"`
-	Color       uint   `json:"color"`
+	Title       string         `json:"title,omitempty"`
+	Url         string         `json:"url,omitempty"`
+	Description string         `json:"description"`
+	Color       uint           `json:"color"`
+	Footer      *DiscordFooter `json:"footer,omitempty"`
+}
+
+type DiscordFooter struct {
+	Text string `json:"text"`
 }
 
 func buildDiscordMessage(msg *alertMsg) *DiscordMessage {
 	prefix := "🚨 ALERT: "
-	if msg.resolved {
+	message := msg.message
+	switch {
+	case msg.report:
+		prefix = summaryPrefix
+	case msg.resolved:
 		prefix = "💜 Resolved: "
+		message = withResolvedAfter(message, msg.firingFor)
+	}
+	embed := DiscordEmbed{
+		Description: withExtraInfo(message, msg.extraInfo),
+	}
+	if msg.discFooter != "" {
+		embed.Footer = &DiscordFooter{Text: msg.discFooter}
+	}
+	// discord only links the embed's title
+	if msg.runbook != "" {
+		embed.Title = "Runbook"
+		embed.Url = msg.runbook
 	}
 	return &DiscordMessage{
 		Username: "Tenderduty",
-		Content:  prefix + msg.chain,
-		Embeds: []DiscordEmbed{{
-			Description: msg.message,
-		}},
+		Content:  withTitlePrefix(msg.discTitlePrefix, prefix+msg.chain),
+		Embeds:   []DiscordEmbed{embed},
+	}
+}
+
+var (
+	// tgBots caches a bot for each token, creating one makes a getMe round-trip that would otherwise happen for every
+	// alert.
+	tgBots    = make(map[string]*tgbotapi.BotAPI)
+	tgBotsMux sync.Mutex
+	// tgApiEndpoint is only changed by tests
+	tgApiEndpoint = tgbotapi.APIEndpoint
+)
+
+func getTgBot(token string) (*tgbotapi.BotAPI, error) {
+	tgBotsMux.Lock()
+	defer tgBotsMux.Unlock()
+	if bot, ok := tgBots[token]; ok {
+		return bot, nil
+	}
+	bot, err := tgbotapi.NewBotAPIWithClient(token, tgApiEndpoint, newHTTPClient(0))
+	if err != nil {
+		return nil, err
 	}
+	tgBots[token] = bot
+	return bot, nil
+}
+
+// dropTgBot removes a cached bot whose token was rejected, so a token that is revoked and later re-enabled works again.
+func dropTgBot(token string) {
+	tgBotsMux.Lock()
+	defer tgBotsMux.Unlock()
+	delete(tgBots, token)
 }
 
 func notifyTg(msg *alertMsg) (err error) {
@@ -347,96 +888,384 @@ func notifyTg(msg *alertMsg) (err error) {
 	if !shouldNotify(msg, tg) {
 		return nil
 	}
-	bot, err := tgbotapi.NewBotAPI(msg.tgKey)
+	return countNotification("telegram", msg, sendTg(msg))
+}
+
+func sendTg(msg *alertMsg) error {
+	bot, err := getTgBot(msg.tgKey)
 	if err != nil {
-		l("notify telegram:", err)
-		return
+		lWarn("notify telegram:", err)
+		return err
 	}
 
-	prefix := "🚨 ALERT: "
-	if msg.resolved {
-		prefix = "💜 Resolved: "
+	mc := tgbotapi.NewMessageToChannel(msg.tgChannel, buildTgMessage(msg))
+	if keyboard := buildTgKeyboard(msg); keyboard != nil {
+		mc.ReplyMarkup = keyboard
 	}
-
-	mc := tgbotapi.NewMessageToChannel(msg.tgChannel, fmt.Sprintf("%s: %s - %s", msg.chain, prefix, msg.message))
 	_, err = bot.Send(mc)
 	if err != nil {
-		l("telegram send:", err)
+		var tgErr *tgbotapi.Error
+		if errors.As(err, &tgErr) && tgErr.Code == http.StatusUnauthorized {
+			dropTgBot(msg.tgKey)
+		}
+		lWarn("telegram send:", err)
 	}
 	return err
 }
 
-func notifyPagerduty(msg *alertMsg) (err error) {
-	if !msg.pd {
+func buildTgMessage(msg *alertMsg) string {
+	prefix := "🚨 ALERT: "
+	message := msg.message
+	switch {
+	case msg.report:
+		prefix = summaryPrefix
+	case msg.resolved:
+		prefix = "💜 Resolved: "
+		message = withResolvedAfter(message, msg.firingFor)
+	}
+	text := fmt.Sprintf("%s: %s - %s", msg.chain, prefix, withExtraInfo(message, msg.extraInfo))
+	return withExtraInfo(withTitlePrefix(msg.tgTitlePrefix, text), msg.tgFooter)
+}
+
+func notifySNS(msg *alertMsg) (err error) {
+	if !msg.sns {
 		return nil
 	}
-	if !shouldNotify(msg, pd) {
+	if !shouldNotify(msg, sns) {
 		return nil
 	}
-	// key from the example, don't spam their api
-	if msg.key == "aaaaaaaaaaaabbbbbbbbbbbbbcccccccccccc" {
-		l("invalid pagerduty key")
-		return
+	return countNotification("sns", msg, sendSNS(msg))
+}
+
+// snsClients caches one client per region, loading the AWS config resolves the credentials which can involve
+// calls to the instance metadata service.
+var (
+	snsClients    = make(map[string]*awssns.Client)
+	snsClientsMux sync.Mutex
+)
+
+// getSNSClient returns the client for a region, credentials come from the default AWS chain so environment
+// variables, the shared config files and IAM roles all work. An empty region is left to the same chain.
+func getSNSClient(ctx context.Context, region string) (*awssns.Client, error) {
+	snsClientsMux.Lock()
+	defer snsClientsMux.Unlock()
+	if client := snsClients[region]; client != nil {
+		return client, nil
 	}
-	action := "trigger"
-	if msg.resolved {
-		action = "resolve"
+	opts := make([]func(*awsconfig.LoadOptions) error, 0)
+	if region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, err
 	}
+	snsClients[region] = awssns.NewFromConfig(cfg)
+	return snsClients[region], nil
+}
+
+func sendSNS(msg *alertMsg) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
-	_, err = pagerduty.ManageEventWithContext(ctx, pagerduty.V2Event{
-		RoutingKey: msg.key,
-		Action:     action,
-		DedupKey:   msg.uniqueId,
-		Payload: &pagerduty.V2Payload{
-			Summary:  msg.message,
-			Source:   msg.uniqueId,
-			Severity: msg.severity,
-		},
-	})
-	return
+	client, err := getSNSClient(ctx, msg.snsRegion)
+	if err != nil {
+		lWarn("notify sns:", err)
+		return err
+	}
+	_, err = client.Publish(ctx, buildSNSPublishInput(msg))
+	if err != nil {
+		lWarn("sns publish:", err)
+	}
+	return err
 }
 
-func getAlarms(chain string) string {
-	alarms.notifyMux.RLock()
-	defer alarms.notifyMux.RUnlock()
-	// don't show this info if the logs are disabled on the dashboard, potentially sensitive info could be leaked.
-	if td.HideLogs || alarms.AllAlarms[chain] == nil {
-		return ""
+// snsSubjectMaxLen is the longest subject SNS accepts, it also has to be printable ASCII.
+const snsSubjectMaxLen = 100
+
+func buildSNSPublishInput(msg *alertMsg) *awssns.PublishInput {
+	prefix := "ALERT"
+	message := msg.message
+	switch {
+	case msg.report:
+		prefix = "SUMMARY"
+	case msg.resolved:
+		prefix = "RESOLVED"
+		message = withResolvedAfter(message, msg.firingFor)
 	}
-	result := ""
-	for k := range alarms.AllAlarms[chain] {
-		result += "🚨 " + alarms.AllAlarms[chain][k].Message + "\n"
+	subject := strings.Map(func(r rune) rune {
+		if r < ' ' || r > '~' {
+			return -1
+		}
+		return r
+	}, withTitlePrefix(msg.snsTitlePrefix, fmt.Sprintf("TenderDuty %s [%s] %s", prefix, msg.severity, msg.chain)))
+	if len(subject) > snsSubjectMaxLen {
+		subject = subject[:snsSubjectMaxLen]
+	}
+	return &awssns.PublishInput{
+		TopicArn: aws.String(msg.snsTopic),
+		Subject:  aws.String(subject),
+		Message:  aws.String(withExtraInfo(withExtraInfo(message, msg.extraInfo), msg.snsFooter)),
 	}
-	return result
 }
 
-// alert creates a universal alert and pushes it to the alertChan to be delivered to appropriate services
-func (c *Config) alert(chainName, message, severity string, resolved bool, id *string) {
-	if id == nil {
+func notifyExec(msg *alertMsg) (err error) {
+	if !msg.exe {
+		return nil
+	}
+	if !shouldNotify(msg, exe) {
+		return nil
+	}
+	return countNotification("exec", msg, sendExec(msg))
+}
+
+// execTimeout is how long the exec command may run before it is killed.
+var execTimeout = 30 * time.Second
+
+// sendExec runs the configured command with the alert in its environment, the output is logged either way.
+func sendExec(msg *alertMsg) error {
+	ctx, cancel := context.WithTimeout(context.Background(), execTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, msg.exeCommand, msg.exeArgs...)
+	cmd.Env = append(os.Environ(), execEnv(msg)...)
+	// children of the command can keep the output open after it was killed, don't wait on them
+	cmd.WaitDelay = time.Second
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() != nil {
+		err = fmt.Errorf("%s timed out after %v", msg.exeCommand, execTimeout)
+	}
+	if err != nil {
+		lWarn("notify exec:", err, strings.TrimSpace(string(out)))
+		return err
+	}
+	if len(out) > 0 {
+		lDebug("notify exec:", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// execEnv describes the alert to the exec command.
+func execEnv(msg *alertMsg) []string {
+	return []string{
+		"TD_CHAIN=" + msg.chainName,
+		"TD_SEVERITY=" + msg.severity,
+		"TD_MESSAGE=" + msg.message,
+		"TD_RESOLVED=" + strconv.FormatBool(msg.resolved),
+		"TD_UNIQUE_ID=" + msg.uniqueId,
+		"TD_REPORT=" + strconv.FormatBool(msg.report),
+	}
+}
+
+func notifyPagerduty(msg *alertMsg) (err error) {
+	if !msg.pd {
+		return nil
+	}
+	if !shouldNotify(msg, pd) {
+		return nil
+	}
+	// key from the example, don't spam their api
+	if msg.key == "aaaaaaaaaaaabbbbbbbbbbbbbcccccccccccc" {
+		lWarn("invalid pagerduty key")
 		return
 	}
+	return countNotification("pagerduty", msg, sendPagerduty(msg))
+}
+
+func sendPagerduty(msg *alertMsg) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	client := pagerduty.NewClient("")
+	client.HTTPClient = newHTTPClient(0)
+	for _, event := range pagerdutyEvents(msg) {
+		if _, err = client.ManageEventWithContext(ctx, &event); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// pagerdutyEvents returns the events to send for an alert, a notice resolves the incident it opens straight away so
+// it doesn't stay open.
+func pagerdutyEvents(msg *alertMsg) []pagerduty.V2Event {
+	events := []pagerduty.V2Event{buildPagerdutyEvent(msg)}
+	if msg.oneShot {
+		resolve := *msg
+		resolve.resolved = true
+		events = append(events, buildPagerdutyEvent(&resolve))
+	}
+	return events
+}
+
+// notifyRetryBackoff is how long to wait before retrying a failed notification, it doubles after every attempt.
+var notifyRetryBackoff = 5 * time.Second
+
+// notifyRetrySlots bounds how many failed notifications can be waiting for a retry, more failures are dropped.
+var notifyRetrySlots = make(chan struct{}, 100)
+
+// notifier is a notification destination. notify decides if the alert should be sent, recording it for
+// de-duplication, and sends it. send only delivers it, and is used for retries since notify already recorded the alert.
+type notifier struct {
+	name   string
+	notify func(*alertMsg) error
+	send   func(*alertMsg) error
+}
+
+var notifiers = []notifier{
+	{name: "pagerduty", notify: notifyPagerduty, send: sendPagerduty},
+	{name: "discord", notify: notifyDiscord, send: sendDiscord},
+	{name: "telegram", notify: notifyTg, send: sendTg},
+	{name: "slack", notify: notifySlack, send: sendSlack},
+	{name: "sns", notify: notifySNS, send: sendSNS},
+	{name: "exec", notify: notifyExec, send: sendExec},
+}
+
+// sendNotifications delivers an alert, or its resolution, to every destination. Failures are retried in the background
+// up to maxRetries times.
+func sendNotifications(msg *alertMsg, maxRetries int) {
+	for _, n := range notifiers {
+		err := n.notify(msg)
+		if err == nil {
+			continue
+		}
+		if maxRetries <= 0 {
+			lChainError(msg.chain, "error sending alert to "+n.name, err.Error())
+			continue
+		}
+		select {
+		case notifyRetrySlots <- struct{}{}:
+			go func(n notifier) {
+				defer func() { <-notifyRetrySlots }()
+				_ = retryNotification(msg, n, maxRetries)
+			}(n)
+		default:
+			lChainError(msg.chain, "error sending alert to "+n.name+", the retry queue is full, dropping it:", err.Error())
+		}
+	}
+}
+
+// retryNotification sends a failed notification again with an exponential backoff, it gives up after maxRetries.
+func retryNotification(msg *alertMsg, n notifier, maxRetries int) (err error) {
+	backoff := notifyRetryBackoff
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		lDebug("retrying alert to", n.name, "for", msg.chain, "in", backoff, "attempt", attempt, "of", maxRetries)
+		time.Sleep(backoff)
+		if err = countNotification(n.name, msg, n.send(msg)); err == nil {
+			lChain(msg.chain, "sent alert to", n.name, "after", attempt, "retries")
+			return nil
+		}
+		backoff *= 2
+	}
+	lChainError(msg.chain, fmt.Sprintf("dropping alert to %s after %d retries:", n.name, maxRetries), err.Error())
+	return err
+}
+
+// pagerdutyDedupKey is derived only from values that are the same for every tenderduty instance watching a validator,
+// the chain-id and the alarm's unique ID, but not the chain's name in the config file. This way several instances will
+// coalesce into a single PagerDuty incident. An incident that is already open keeps the key it was opened with.
+func pagerdutyDedupKey(msg *alertMsg) string {
+	if msg.pdDedupKey != "" {
+		return msg.pdDedupKey
+	}
+	if msg.chainId == "" {
+		return msg.uniqueId
+	}
+	return msg.chainId + "_" + msg.uniqueId
+}
+
+func buildPagerdutyEvent(msg *alertMsg) pagerduty.V2Event {
+	action := "trigger"
+	summary := msg.message
+	if msg.resolved {
+		action = "resolve"
+		summary = withResolvedAfter(summary, msg.firingFor)
+	}
+	payload := &pagerduty.V2Payload{
+		Summary:   withTitlePrefix(msg.pdTitlePrefix, summary),
+		Source:    msg.uniqueId,
+		Severity:  pagerdutySeverity(msg.severity),
+		Group:     msg.pdTitlePrefix,
+		Component: msg.pdFooter,
+	}
+	// extra_info shows which instance won the race to open the incident
+	if msg.extraInfo != "" {
+		payload.Details = map[string]string{"extra_info": msg.extraInfo}
+	}
+	event := pagerduty.V2Event{
+		RoutingKey: msg.key,
+		Action:     action,
+		DedupKey:   pagerdutyDedupKey(msg),
+		Payload:    payload,
+	}
+	if msg.runbook != "" {
+		event.Links = []interface{}{map[string]string{"href": msg.runbook, "text": "Runbook"}}
+	}
+	return event
+}
+
+// withExtraInfo appends a line to a chat message, the chain's extra_info or a destination's footer.
+func withExtraInfo(message, extraInfo string) string {
+	if extraInfo == "" {
+		return message
+	}
+	return message + "\n" + extraInfo
+}
+
+// withTitlePrefix puts a destination's title_prefix in front of the title.
+func withTitlePrefix(prefix, title string) string {
+	if prefix == "" {
+		return title
+	}
+	return prefix + " " + title
+}
+
+// withResolvedAfter appends how long a resolved alarm was firing, alarms restored without a start time are left as is.
+func withResolvedAfter(message string, firingFor time.Duration) string {
+	if firingFor <= 0 {
+		return message
+	}
+	return fmt.Sprintf("%s\nResolved after %s", message, formatFiringDuration(firingFor))
+}
+
+// formatFiringDuration rounds to seconds below a minute and to minutes above, e.g. 45s, 12m or 3h5m.
+func formatFiringDuration(d time.Duration) string {
+	if d = d.Round(time.Second); d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	if d = d.Round(time.Minute); d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+func getAlarms(chain string) string {
+	alarms.notifyMux.RLock()
+	defer alarms.notifyMux.RUnlock()
+	// don't show this info if the logs are disabled on the dashboard, potentially sensitive info could be leaked.
+	if td.HideLogs || alarms.AllAlarms[chain] == nil {
+		return ""
+	}
+	result := ""
+	for k := range alarms.AllAlarms[chain] {
+		result += "🚨 " + alarms.AllAlarms[chain][k].Message + "\n"
+	}
+	return result
+}
+
+// alert creates a universal alert and pushes it to the alertChan to be delivered to appropriate services
+func (c *Config) alert(chainName, message, severity string, resolved bool, id *string) {
+	if id == nil {
+		return
+	}
+	var firingFor time.Duration
+	if resolved {
+		alarms.notifyMux.RLock()
+		if sent := alarms.AllAlarms[chainName][*id].SentTime; !sent.IsZero() {
+			firingFor = time.Since(sent)
+		}
+		alarms.notifyMux.RUnlock()
+	}
 	c.chainsMux.RLock()
-	a := &alertMsg{
-		pd:           boolVal(c.DefaultAlertConfig.Pagerduty.Enabled) && boolVal(c.Chains[chainName].Alerts.Pagerduty.Enabled),
-		disc:         boolVal(c.DefaultAlertConfig.Discord.Enabled) && boolVal(c.Chains[chainName].Alerts.Discord.Enabled),
-		tg:           boolVal(c.DefaultAlertConfig.Telegram.Enabled) && boolVal(c.Chains[chainName].Alerts.Telegram.Enabled),
-		slk:          boolVal(c.DefaultAlertConfig.Slack.Enabled) && boolVal(c.Chains[chainName].Alerts.Slack.Enabled),
-		severity:     severity,
-		resolved:     resolved,
-		chain:        fmt.Sprintf("%s (%s)", chainName, c.Chains[chainName].ChainId),
-		message:      message,
-		uniqueId:     *id,
-		key:          c.Chains[chainName].Alerts.Pagerduty.ApiKey,
-		tgChannel:    c.Chains[chainName].Alerts.Telegram.Channel,
-		tgKey:        c.Chains[chainName].Alerts.Telegram.ApiKey,
-		tgMentions:   strings.Join(c.Chains[chainName].Alerts.Telegram.Mentions, " "),
-		discHook:     c.Chains[chainName].Alerts.Discord.Webhook,
-		discMentions: strings.Join(c.Chains[chainName].Alerts.Discord.Mentions, " "),
-		slkHook:      c.Chains[chainName].Alerts.Slack.Webhook,
-		alertConfig:  &c.Chains[chainName].Alerts,
-	}
-	c.alertChan <- a
+	// the alarm is still recorded when it isn't sent, so it resolves later
+	c.queueAlert(c.newAlertMsg(chainName, message, severity, resolved, *id, firingFor))
 	c.chainsMux.RUnlock()
 	alarms.notifyMux.Lock()
 	defer alarms.notifyMux.Unlock()
@@ -445,6 +1274,11 @@ func (c *Config) alert(chainName, message, severity string, resolved bool, id *s
 	}
 	if resolved && !alarms.AllAlarms[chainName][*id].SentTime.IsZero() {
 		delete(alarms.AllAlarms[chainName], *id)
+		delete(alarms.clearSince[chainName], *id)
+		delete(alarms.acknowledged[chainName], *id)
+		delete(alarms.tgAcknowledged[chainName], *id)
+		c.recordHistory(chainName, message, severity, true, *id)
+		c.recordAudit(chainName, message, severity, true, *id)
 		return
 	} else if resolved {
 		return
@@ -452,15 +1286,115 @@ func (c *Config) alert(chainName, message, severity string, resolved bool, id *s
 	cache := alertMsgCache{
 		Message:  message,
 		SentTime: time.Now(),
+		Severity: severity,
 	}
 	alarms.AllAlarms[chainName][*id] = cache
+	c.recordHistory(chainName, message, severity, false, *id)
+	c.recordAudit(chainName, message, severity, false, *id)
+}
+
+// notice sends a one-shot alert for an event that has nothing to resolve, like a moniker change. It is not recorded as
+// an active alarm and not deduplicated, the history shows it firing and resolving at once.
+func (c *Config) notice(chainName, message, severity string, id string) {
+	c.chainsMux.RLock()
+	a := c.newAlertMsg(chainName, message, severity, false, id, 0)
+	a.oneShot = true
+	c.queueAlert(a)
+	c.chainsMux.RUnlock()
+	c.recordHistory(chainName, message, severity, false, id)
+	c.recordHistory(chainName, message, severity, true, id)
+	c.recordAudit(chainName, message, severity, false, id)
+}
+
+// queueAlert hands an alert to the notification worker. During quiet hours only critical alerts and resolutions go
+// out, while muted only resolutions. c.chainsMux must be held.
+func (c *Config) queueAlert(a *alertMsg) {
+	if !a.resolved && a.severity != "critical" && c.QuietHours.active(time.Now()) {
+		lDebug(fmt.Sprintf("🤫 Quiet hours - not notifying %s alarm on %s (%s)", a.severity, a.chain, a.message))
+	} else if !a.resolved && c.muted(time.Now()) {
+		lDebug(fmt.Sprintf("🔇 Muted - not notifying %s alarm on %s (%s)", a.severity, a.chain, a.message))
+	} else {
+		c.alertChan <- a
+	}
+}
+
+// newAlertMsg fills in an alert for the chain's destinations, c.chainsMux must be held.
+func (c *Config) newAlertMsg(chainName, message, severity string, resolved bool, id string, firingFor time.Duration) *alertMsg {
+	return &alertMsg{
+		pd:              boolVal(c.DefaultAlertConfig.Pagerduty.Enabled) && boolVal(c.Chains[chainName].Alerts.Pagerduty.Enabled),
+		disc:            boolVal(c.DefaultAlertConfig.Discord.Enabled) && boolVal(c.Chains[chainName].Alerts.Discord.Enabled),
+		tg:              boolVal(c.DefaultAlertConfig.Telegram.Enabled) && boolVal(c.Chains[chainName].Alerts.Telegram.Enabled),
+		slk:             boolVal(c.DefaultAlertConfig.Slack.Enabled) && boolVal(c.Chains[chainName].Alerts.Slack.Enabled),
+		sns:             boolVal(c.DefaultAlertConfig.SNS.Enabled) && boolVal(c.Chains[chainName].Alerts.SNS.Enabled),
+		exe:             boolVal(c.DefaultAlertConfig.Exec.Enabled) && boolVal(c.Chains[chainName].Alerts.Exec.Enabled),
+		severity:        severity,
+		resolved:        resolved,
+		chain:           fmt.Sprintf("%s (%s)", chainName, c.Chains[chainName].ChainId),
+		chainName:       chainName,
+		chainId:         c.Chains[chainName].ChainId,
+		message:         message,
+		uniqueId:        id,
+		key:             c.Chains[chainName].Alerts.Pagerduty.ApiKey,
+		extraInfo:       c.Chains[chainName].ExtraInfo,
+		firingFor:       firingFor,
+		pdTitlePrefix:   c.Chains[chainName].Alerts.Pagerduty.TitlePrefix,
+		pdFooter:        c.Chains[chainName].Alerts.Pagerduty.Footer,
+		tgChannel:       resolvedTarget(resolved, c.Chains[chainName].Alerts.Telegram.Channel, c.Chains[chainName].Alerts.Telegram.ResolvedChannel),
+		tgKey:           c.Chains[chainName].Alerts.Telegram.ApiKey,
+		tgMentions:      strings.Join(c.Chains[chainName].Alerts.Telegram.Mentions, " "),
+		tgTitlePrefix:   c.Chains[chainName].Alerts.Telegram.TitlePrefix,
+		tgFooter:        c.Chains[chainName].Alerts.Telegram.Footer,
+		tgButtons:       boolVal(c.Chains[chainName].Alerts.Telegram.InlineButtons),
+		discHook:        resolvedTarget(resolved, c.Chains[chainName].Alerts.Discord.Webhook, c.Chains[chainName].Alerts.Discord.ResolvedWebhook),
+		discMentions:    strings.Join(c.Chains[chainName].Alerts.Discord.Mentions, " "),
+		discTitlePrefix: c.Chains[chainName].Alerts.Discord.TitlePrefix,
+		discFooter:      c.Chains[chainName].Alerts.Discord.Footer,
+		slkHook:         resolvedTarget(resolved, c.Chains[chainName].Alerts.Slack.Webhook, c.Chains[chainName].Alerts.Slack.ResolvedWebhook),
+		slkTitlePrefix:  c.Chains[chainName].Alerts.Slack.TitlePrefix,
+		slkFooter:       c.Chains[chainName].Alerts.Slack.Footer,
+		slkBlocks:       boolVal(c.Chains[chainName].Alerts.Slack.UseBlocks),
+		snsRegion:       c.Chains[chainName].Alerts.SNS.Region,
+		snsTopic:        c.Chains[chainName].Alerts.SNS.TopicARN,
+		snsTitlePrefix:  c.Chains[chainName].Alerts.SNS.TitlePrefix,
+		snsFooter:       c.Chains[chainName].Alerts.SNS.Footer,
+		exeCommand:      c.Chains[chainName].Alerts.Exec.Command,
+		exeArgs:         c.Chains[chainName].Alerts.Exec.Args,
+		alertConfig:     &c.Chains[chainName].Alerts,
+		runbook:         c.Runbooks[alertType(id)],
+	}
+}
+
+// resolvedTarget returns where a message goes: the resolved override for resolve messages when it is set, the usual
+// webhook or channel otherwise.
+func resolvedTarget(resolved bool, target, resolvedOverride string) string {
+	if resolved && resolvedOverride != "" {
+		return resolvedOverride
+	}
+	return target
+}
+
+// alertType is the alert name a unique ID starts with, e.g. ChainStalled for ChainStalled_cosmosvaloper1xxx.
+func alertType(id string) string {
+	name, _, _ := strings.Cut(id, "_")
+	return name
+}
+
+func (c *Config) recordHistory(chainName, message, severity string, resolved bool, id string) {
+	if c.history == nil {
+		return
+	}
+	c.history.record(chainName, alertEvent{Time: time.Now(), ID: id, Message: message, Severity: severity, Resolved: resolved})
 }
 
 func evaluateConsecutiveBlocksMissedAlert(cc *ChainConfig) (bool, bool) {
 	alert, resolved := false, false
+	if cc.inStartupGrace() {
+		return alert, resolved
+	}
 
 	alertID := fmt.Sprintf("ConsecutiveBlocksMissed_%s", cc.ValAddress)
 	if int(cc.statConsecutiveMiss) >= intVal(cc.Alerts.ConsecutiveMissed) {
+		alarms.stillFiring(cc.name, alertID)
 		if !alarms.exist(cc.name, alertID) {
 			// alert on missed block counter!
 			td.alert(
@@ -473,7 +1407,7 @@ func evaluateConsecutiveBlocksMissedAlert(cc *ChainConfig) (bool, bool) {
 			alert = true
 		}
 	} else {
-		if alarms.exist(cc.name, alertID) {
+		if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
 			// clear the alert
 			td.alert(
 				cc.name,
@@ -490,31 +1424,55 @@ func evaluateConsecutiveBlocksMissedAlert(cc *ChainConfig) (bool, bool) {
 	return alert, resolved
 }
 
+// defaultRecoveryBlocks is how many blocks in a row have to be signed for percentage_resolve_on_recovery.
+const defaultRecoveryBlocks = 100
+
 func evaluatePercentageBlocksMissedAlert(cc *ChainConfig) (bool, bool) {
 	alert, resolved := false, false
+	if cc.inStartupGrace() {
+		return alert, resolved
+	}
 
-	alertID := fmt.Sprintf("PercentageBlocksMissed_%s", cc.ValAddress)
-	if 100*float64(cc.valInfo.Missed)/float64(cc.valInfo.Window) >= float64(intVal(cc.Alerts.Window)) {
-		if !alarms.exist(cc.name, alertID) {
-			// alert on missed block counter!
-			td.alert(
-				cc.name,
-				fmt.Sprintf("%s has missed > %d%% of the slashing window's blocks on %s", cc.valInfo.Moniker, intVal(cc.Alerts.Window), cc.ChainId),
-				cc.Alerts.PercentagePriority,
-				false,
-				&alertID,
-			)
-			alert = true
+	// the missed count jumps when the slashing window changes size, one evaluation is skipped so the alert doesn't
+	// resolve and fire again on the carried-over misses
+	if cc.percentWindow != 0 && cc.percentWindow != cc.valInfo.Window {
+		l(fmt.Sprintf("⚙️ slashing window on %s changed from %d to %d blocks, skipping one percentage check", cc.ChainId, cc.percentWindow, cc.valInfo.Window))
+		cc.percentWindow = cc.valInfo.Window
+		return alert, resolved
+	}
+	cc.percentWindow = cc.valInfo.Window
+
+	missedPercent := 100 * float64(cc.valInfo.Missed) / float64(cc.valInfo.Window)
+	// the missed count only decays as the misses leave the window, a validator that is signing again can be treated
+	// as recovered straight away
+	recovered := false
+	if boolVal(cc.Alerts.PercentageResolveOnRecovery) {
+		need := intVal(cc.Alerts.PercentageRecoveryBlocks)
+		if need <= 0 {
+			need = defaultRecoveryBlocks
 		}
-	} else {
-		if alarms.exist(cc.name, alertID) {
-			td.alert(
-				cc.name,
-				fmt.Sprintf("%s has missed > %d%% of the slashing window's blocks on %s", cc.valInfo.Moniker, intVal(cc.Alerts.Window), cc.ChainId),
-				cc.Alerts.PercentagePriority,
-				true,
-				&alertID,
-			)
+		recovered = cc.signedStreak() >= need
+	}
+	for _, threshold := range cc.Alerts.Window {
+		// a single threshold keeps the original ID, so alarms restored from the saved state can still be cleared
+		alertID := fmt.Sprintf("PercentageBlocksMissed_%s", cc.ValAddress)
+		if len(cc.Alerts.Window) > 1 {
+			alertID = fmt.Sprintf("PercentageBlocksMissed_%s_%d", cc.ValAddress, threshold.Percent)
+		}
+		severity := threshold.Severity
+		if severity == "" {
+			severity = cc.Alerts.PercentagePriority
+		}
+		message := fmt.Sprintf("%s has missed > %d%% of the slashing window's blocks on %s", cc.valInfo.Moniker, threshold.Percent, cc.ChainId)
+		if missedPercent >= float64(threshold.Percent) && !recovered {
+			alarms.stillFiring(cc.name, alertID)
+			if !alarms.exist(cc.name, alertID) {
+				// alert on missed block counter!
+				td.alert(cc.name, message, severity, false, &alertID)
+				alert = true
+			}
+		} else if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
+			td.alert(cc.name, message, severity, true, &alertID)
 			resolved = true
 		}
 	}
@@ -523,22 +1481,32 @@ func evaluatePercentageBlocksMissedAlert(cc *ChainConfig) (bool, bool) {
 	return alert, resolved
 }
 
+// priorityOrCritical is used by the alerts that were always critical before their severity could be configured.
+func priorityOrCritical(priority string) string {
+	if priority == "" {
+		return "critical"
+	}
+	return priority
+}
+
 func evaluateNoRPCEndpointsAlert(cc *ChainConfig, noNodesSec *int) (bool, bool) {
 	alert, resolved := false, false
 
 	alertID := fmt.Sprintf("NoRPCEndpoints_%s", cc.ValAddress)
 	if cc.noNodes {
-		*noNodesSec += 2
+		alarms.stillFiring(cc.name, alertID)
+		interval := cc.evalInterval()
+		*noNodesSec += interval
 		if *noNodesSec <= 60*td.NodeDownMin {
-			if *noNodesSec%20 == 0 {
-				l(fmt.Sprintf("no nodes available on %s for %d seconds, deferring alarm", cc.ChainId, *noNodesSec))
+			if *noNodesSec%20 < interval {
+				lDebug(fmt.Sprintf("no nodes available on %s for %d seconds, deferring alarm", cc.ChainId, *noNodesSec))
 			}
 		} else {
 			if !alarms.exist(cc.name, alertID) {
 				td.alert(
 					cc.name,
 					fmt.Sprintf("no RPC endpoints are working for %s", cc.ChainId),
-					"critical",
+					priorityOrCritical(cc.Alerts.NoServersPriority),
 					false,
 					&alertID,
 				)
@@ -546,11 +1514,11 @@ func evaluateNoRPCEndpointsAlert(cc *ChainConfig, noNodesSec *int) (bool, bool)
 			}
 		}
 	} else {
-		if alarms.exist(cc.name, alertID) {
+		if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
 			td.alert(
 				cc.name,
 				fmt.Sprintf("no RPC endpoints are working for %s", cc.ChainId),
-				"critical",
+				priorityOrCritical(cc.Alerts.NoServersPriority),
 				true,
 				&alertID,
 			)
@@ -563,22 +1531,185 @@ func evaluateNoRPCEndpointsAlert(cc *ChainConfig, noNodesSec *int) (bool, bool)
 	return alert, resolved
 }
 
+// evaluateConsensusParticipationAlert alerts when blocks are missed although the validator's prevote or precommit was
+// seen. The validator is signing and taking part in the rounds, but its votes don't reach the proposer in time, which
+// points at the sentries or relays rather than the signer. Each kind is only checked when its threshold is set.
+func evaluateConsensusParticipationAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+	if cc.inStartupGrace() {
+		return alert, resolved
+	}
+
+	prevoteMiss, precommitMiss, blocks := cc.consensusMisses()
+	checks := []struct {
+		kind      string
+		missed    int
+		threshold int
+	}{
+		{kind: "Prevote", missed: prevoteMiss, threshold: intVal(cc.Alerts.PrevoteMissThreshold)},
+		{kind: "Precommit", missed: precommitMiss, threshold: intVal(cc.Alerts.PrecommitMissThreshold)},
+	}
+	for _, check := range checks {
+		if check.threshold <= 0 {
+			continue
+		}
+		alertID := fmt.Sprintf("%sMiss_%s", check.kind, cc.ValAddress)
+		message := fmt.Sprintf("%s missed %d of the last %d blocks on %s after its %s was seen, it is taking part in consensus but its votes are not being included, check the sentries",
+			cc.valInfo.Moniker, check.missed, blocks, cc.name, strings.ToLower(check.kind))
+		if check.missed > check.threshold {
+			alarms.stillFiring(cc.name, alertID)
+			if !alarms.exist(cc.name, alertID) {
+				td.alert(cc.name, message, "warning", false, &alertID)
+				alert = true
+			}
+		} else if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
+			td.alert(cc.name, message, "warning", true, &alertID)
+			resolved = true
+		}
+	}
+	cc.activeAlerts = alarms.getCount(cc.name)
+
+	return alert, resolved
+}
+
+// evaluateUptimeSLAAlert alerts when the validator signed less than min_uptime_percent of the blocks tenderduty saw in
+// the newest uptime_window_blocks. Unlike the percentage alert this is tenderduty's own window rather than the slashing
+// window, and nothing is checked until the whole window was observed.
+func evaluateUptimeSLAAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+	minUptime := floatVal(cc.Alerts.MinUptimePercent)
+	if cc.inStartupGrace() || minUptime <= 0 {
+		return alert, resolved
+	}
+	signed, known, window := cc.uptime()
+	if window == 0 || known < window {
+		return alert, resolved
+	}
+
+	uptime := 100 * float64(signed) / float64(known)
+	alertID := fmt.Sprintf("UptimeSLA_%s", cc.ValAddress)
+	message := fmt.Sprintf("%s signed %.2f%% of the last %d blocks on %s, below the uptime target of %.2f%%", cc.valInfo.Moniker, uptime, known, cc.ChainId, minUptime)
+	if uptime < minUptime {
+		alarms.stillFiring(cc.name, alertID)
+		if !alarms.exist(cc.name, alertID) {
+			td.alert(cc.name, message, "warning", false, &alertID)
+			alert = true
+		}
+	} else if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
+		td.alert(cc.name, message, "warning", true, &alertID)
+		resolved = true
+	}
+
+	cc.activeAlerts = alarms.getCount(cc.name)
+	return alert, resolved
+}
+
+// proposerAnomalyMinExpected is how many proposals the voting power has to predict over the block history before the
+// proposer anomaly alert is checked, below that a single proposal more or less is already a large deviation.
+const proposerAnomalyMinExpected = 5
+
+// evaluateProposerAnomalyAlert alerts when the validator proposed more or fewer of the blocks in the history than its
+// share of the voting power predicts, by more than proposer_anomaly_percent of the expected count. The history is used
+// rather than statTotalProps since the voting power it is compared to changes over time. Nothing is checked until the
+// whole history was observed, or while the validator is not in the active set.
+func evaluateProposerAnomalyAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+	maxDeviation := floatVal(cc.Alerts.ProposerAnomalyPercent)
+	if cc.inStartupGrace() || maxDeviation <= 0 || cc.valInfo == nil || !cc.valInfo.Bonded {
+		return alert, resolved
+	}
+	proposed, known, blocks := cc.proposals()
+	expected := cc.valInfo.VotingPowerPercent * float64(known)
+	if blocks == 0 || known < blocks || expected < proposerAnomalyMinExpected {
+		return alert, resolved
+	}
+
+	deviation := 100 * math.Abs(float64(proposed)-expected) / expected
+	alertID := fmt.Sprintf("ProposerAnomaly_%s", cc.ValAddress)
+	message := fmt.Sprintf("%s proposed %d of the last %d blocks on %s, %.1f were expected from its %.2f%% of the voting power", cc.valInfo.Moniker, proposed, known, cc.ChainId, expected, cc.valInfo.VotingPowerPercent*100)
+	if deviation > maxDeviation {
+		alarms.stillFiring(cc.name, alertID)
+		if !alarms.exist(cc.name, alertID) {
+			td.alert(cc.name, message, "warning", false, &alertID)
+			alert = true
+		}
+	} else if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
+		td.alert(cc.name, message, "warning", true, &alertID)
+		resolved = true
+	}
+
+	cc.activeAlerts = alarms.getCount(cc.name)
+	return alert, resolved
+}
+
+// evaluateConsecutiveVoteMissAlert alerts when blocks are missed in a row with the validator's prevote or precommit
+// seen, a streak points at a partial signer outage faster than the totals over the block history do.
+func evaluateConsecutiveVoteMissAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+	if cc.inStartupGrace() {
+		return alert, resolved
+	}
+
+	checks := []struct {
+		kind      string
+		streak    int
+		threshold int
+	}{
+		{kind: "Prevote", streak: cc.statConsecutivePrevoteMiss, threshold: intVal(cc.Alerts.ConsecutivePrevoteMissed)},
+		{kind: "Precommit", streak: cc.statConsecutivePrecommitMiss, threshold: intVal(cc.Alerts.ConsecutivePrecommitMissed)},
+	}
+	for _, check := range checks {
+		if check.threshold <= 0 {
+			continue
+		}
+		alertID := fmt.Sprintf("Consecutive%sMiss_%s", check.kind, cc.ValAddress)
+		message := fmt.Sprintf("%s has missed %d blocks in a row on %s after its %s was seen",
+			cc.valInfo.Moniker, check.threshold, cc.ChainId, strings.ToLower(check.kind))
+		if check.streak >= check.threshold {
+			alarms.stillFiring(cc.name, alertID)
+			if !alarms.exist(cc.name, alertID) {
+				td.alert(cc.name, message, cc.Alerts.ConsecutivePriority, false, &alertID)
+				alert = true
+			}
+		} else if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
+			td.alert(cc.name, message, cc.Alerts.ConsecutivePriority, true, &alertID)
+			resolved = true
+		}
+	}
+	cc.activeAlerts = alarms.getCount(cc.name)
+
+	return alert, resolved
+}
+
 func evaluateChainStalledAlert(cc *ChainConfig) (bool, bool) {
 	alert, resolved := false, false
+	if cc.inStartupGrace() {
+		return alert, resolved
+	}
 
 	if !cc.lastBlockTime.IsZero() {
 		alertID := fmt.Sprintf("ChainStalled_%s", cc.ValAddress)
-		if !cc.lastBlockAlarm && cc.lastBlockTime.Before(time.Now().Add(time.Duration(-intVal(cc.Alerts.Stalled))*time.Minute)) {
-			cc.lastBlockAlarm = true
-			td.alert(
-				cc.name,
-				fmt.Sprintf("stalled: have not seen a new block on %s in %d minutes", cc.ChainId, intVal(cc.Alerts.Stalled)),
-				"critical",
-				false,
-				&alertID,
-			)
-			alert = true
-		} else if !cc.lastBlockTime.Before(time.Now().Add(time.Duration(-intVal(cc.Alerts.Stalled)) * time.Minute)) {
+		cutoff := time.Now().Add(time.Duration(-intVal(cc.Alerts.Stalled)) * time.Minute)
+		stalled := cc.lastBlockTime.Before(cutoff)
+		// if the nodes are still reporting new heights only the websocket is broken, leave it to the websocket lag alarm
+		if stalled && boolVal(cc.Alerts.WebsocketLagAlerts) && cc.polledAdvancedSince(cutoff) {
+			stalled = false
+		}
+		if stalled {
+			alarms.stillFiring(cc.name, alertID)
+			if !cc.lastBlockAlarm {
+				cc.lastBlockAlarm = true
+				td.alert(
+					cc.name,
+					fmt.Sprintf("stalled: have not seen a new block on %s in %d minutes", cc.ChainId, intVal(cc.Alerts.Stalled)),
+					priorityOrCritical(cc.Alerts.StalledPriority),
+					false,
+					&alertID,
+				)
+				alert = true
+			}
+		} else if !alarms.exist(cc.name, alertID) || cc.resolveDue(alertID) {
+			// without an alarm in the cache there is nothing to hold back, only the flag is reset
 			alarms.clearNoBlocks(cc)
 			cc.lastBlockAlarm = false
 			resolved = true
@@ -589,6 +1720,85 @@ func evaluateChainStalledAlert(cc *ChainConfig) (bool, bool) {
 	return alert, resolved
 }
 
+// evaluateWebsocketLagAlert fires when the websocket has stopped delivering NewBlock events while the node health
+// checks still see the chain advancing, this separates a broken websocket from a stalled chain.
+func evaluateWebsocketLagAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+
+	alertID := fmt.Sprintf("WebsocketLag_%s", cc.ValAddress)
+	message := fmt.Sprintf("websocket for %s has not delivered a new block in %d minutes, but the RPC nodes report the chain is still producing blocks", cc.ChainId, intVal(cc.Alerts.WebsocketLag))
+	if cc.wsLagging(time.Now().Add(time.Duration(-intVal(cc.Alerts.WebsocketLag)) * time.Minute)) {
+		alarms.stillFiring(cc.name, alertID)
+		if !alarms.exist(cc.name, alertID) {
+			td.alert(cc.name, message, "warning", false, &alertID)
+			alert = true
+		}
+	} else {
+		if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
+			td.alert(cc.name, message, "warning", true, &alertID)
+			resolved = true
+		}
+	}
+
+	cc.activeAlerts = alarms.getCount(cc.name)
+	return alert, resolved
+}
+
+// evaluateBlockTimeSlowdownAlert fires when the average block time goes above MaxBlockTimeSeconds, giving an early
+// warning before the chain stalls.
+func evaluateBlockTimeSlowdownAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+	maxBlockTime := floatVal(cc.Alerts.MaxBlockTimeSeconds)
+	average := cc.averageBlockTime()
+	if cc.inStartupGrace() || maxBlockTime <= 0 || average == 0 {
+		return alert, resolved
+	}
+
+	alertID := fmt.Sprintf("BlockTimeSlowdown_%s", cc.ValAddress)
+	message := fmt.Sprintf("block production on %s has slowed down, the average block time is %.1fs, above the maximum of %.1fs", cc.ChainId, average, maxBlockTime)
+	if average > maxBlockTime {
+		alarms.stillFiring(cc.name, alertID)
+		if !alarms.exist(cc.name, alertID) {
+			td.alert(cc.name, message, "warning", false, &alertID)
+			alert = true
+		}
+	} else if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
+		td.alert(cc.name, message, "warning", true, &alertID)
+		resolved = true
+	}
+
+	cc.activeAlerts = alarms.getCount(cc.name)
+	return alert, resolved
+}
+
+// evaluateHeightStuckAlert fires when neither the websocket nor any node has reported a new height for
+// HeightStuckChecks health check refreshes. Unlike the stalled alarm it doesn't rely on block times, which a lagging
+// node can keep updating while its height is frozen.
+func evaluateHeightStuckAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+	checks := intVal(cc.Alerts.HeightStuckChecks)
+	if cc.inStartupGrace() || checks <= 0 {
+		return alert, resolved
+	}
+
+	height, refreshes := cc.heightStuck()
+	alertID := fmt.Sprintf("HeightStuck_%s", cc.ValAddress)
+	message := fmt.Sprintf("height on %s has been stuck at %d for %d checks", cc.ChainId, height, refreshes)
+	if refreshes >= checks {
+		alarms.stillFiring(cc.name, alertID)
+		if !alarms.exist(cc.name, alertID) {
+			td.alert(cc.name, message, "critical", false, &alertID)
+			alert = true
+		}
+	} else if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
+		td.alert(cc.name, fmt.Sprintf("height on %s is advancing again at %d", cc.ChainId, height), "critical", true, &alertID)
+		resolved = true
+	}
+
+	cc.activeAlerts = alarms.getCount(cc.name)
+	return alert, resolved
+}
+
 func evaluateValidatorInactiveAlert(cc *ChainConfig) (bool, bool) {
 	alert, resolved := false, false
 
@@ -624,11 +1834,178 @@ func evaluateValidatorInactiveAlert(cc *ChainConfig) (bool, bool) {
 	return alert, resolved
 }
 
+// evaluateValidatorMissingAlert fires when the chain answers that the validator does not exist at all, e.g. it was
+// removed some time after being tombstoned, and resolves once it is found again. Queries that fail don't count.
+func evaluateValidatorMissingAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+
+	alertID := fmt.Sprintf("ValidatorMissing_%s", cc.ValAddress)
+	message := fmt.Sprintf("validator %s was not found in the validator set of %s", cc.ValAddress, cc.ChainId)
+	if cc.validatorMissing {
+		alarms.stillFiring(cc.name, alertID)
+		if !alarms.exist(cc.name, alertID) {
+			td.alert(cc.name, message, "critical", false, &alertID)
+			alert = true
+		}
+	} else if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
+		td.alert(cc.name, message, "critical", true, &alertID)
+		resolved = true
+	}
+
+	cc.activeAlerts = alarms.getCount(cc.name)
+	return alert, resolved
+}
+
+// evaluateMonikerChangeAlert sends a one-shot info alert when the moniker differs from the previous check, there is
+// nothing to resolve.
+func evaluateMonikerChangeAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+	if cc.valInfo == nil || cc.lastValInfo == nil || cc.lastValInfo.Moniker == "" || cc.valInfo.Moniker == "" {
+		return alert, resolved
+	}
+	if cc.lastValInfo.Moniker == cc.valInfo.Moniker || cc.monikerAlerted == cc.valInfo.Moniker {
+		return alert, resolved
+	}
+
+	// lastValInfo is only refreshed with valInfo, remember the change so it is sent once
+	cc.monikerAlerted = cc.valInfo.Moniker
+	alertID := fmt.Sprintf("MonikerChange_%s_%d", cc.ValAddress, time.Now().Unix())
+	td.notice(
+		cc.name,
+		fmt.Sprintf("moniker of validator %s on %s changed from %q to %q", cc.ValAddress, cc.ChainId, cc.lastValInfo.Moniker, cc.valInfo.Moniker),
+		"info",
+		alertID,
+	)
+	alert = true
+
+	cc.activeAlerts = alarms.getCount(cc.name)
+	return alert, resolved
+}
+
+// evaluateConsKeyChangeAlert sends a one-shot critical alert when the consensus key differs from the previous check.
+// A rotated key is usually a planned migration, but one the operator doesn't know about is worth waking up for. There is
+// nothing to resolve.
+func evaluateConsKeyChangeAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+	if cc.valInfo == nil || cc.lastValInfo == nil || len(cc.lastValInfo.Conspub) == 0 || len(cc.valInfo.Conspub) == 0 {
+		return alert, resolved
+	}
+	previous, current := fmt.Sprintf("%X", cc.lastValInfo.Conspub), fmt.Sprintf("%X", cc.valInfo.Conspub)
+	if previous == current || cc.consKeyAlerted == current {
+		return alert, resolved
+	}
+
+	// lastValInfo is only refreshed with valInfo, remember the change so it is sent once
+	cc.consKeyAlerted = current
+	alertID := fmt.Sprintf("ConsKeyChange_%s_%d", cc.ValAddress, time.Now().Unix())
+	td.notice(
+		cc.name,
+		fmt.Sprintf("consensus key of validator %s on %s changed, its address went from %s to %s", cc.ValAddress, cc.ChainId, previous, current),
+		"critical",
+		alertID,
+	)
+	alert = true
+
+	cc.activeAlerts = alarms.getCount(cc.name)
+	return alert, resolved
+}
+
+// evaluateFirstSignAlert sends a one-shot info alert when a validator that was seen outside the active set is bonded
+// and signs its first block, as a confirmation when onboarding. The inactive alert resolving is a separate event.
+func evaluateFirstSignAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+	// until the validator info was queried Bonded is only the zero value
+	if cc.valInfo == nil || cc.valInfoUpdated.IsZero() {
+		return alert, resolved
+	}
+	if !cc.valInfo.Bonded {
+		cc.firstSignPending = true
+		return alert, resolved
+	}
+	if !cc.firstSignPending || cc.signedStreak() == 0 {
+		return alert, resolved
+	}
+
+	cc.firstSignPending = false
+	alertID := fmt.Sprintf("FirstSign_%s_%d", cc.ValAddress, time.Now().Unix())
+	td.notice(
+		cc.name,
+		fmt.Sprintf("%s is in the active set on %s and signing blocks", cc.valInfo.Moniker, cc.ChainId),
+		"info",
+		alertID,
+	)
+	alert = true
+
+	cc.activeAlerts = alarms.getCount(cc.name)
+	return alert, resolved
+}
+
+// chainParamEpsilon is the smallest change in community tax or inflation that is reported, smaller differences are
+// rounding noise from the queries.
+const chainParamEpsilon = 1e-6
+
+// evaluateChainParamChangeAlert sends a one-shot info alert when governance changes the community tax or the
+// inflation rate, since both feed into the APR.
+func evaluateChainParamChangeAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+	// the total supply is set together with the tax and inflation, it is zero until the chain info was queried
+	if cc.totalSupply == 0 {
+		return alert, resolved
+	}
+	changes := make([]string, 0)
+	if cc.paramsSeen && math.Abs(cc.communityTax-cc.lastCommunityTax) > chainParamEpsilon {
+		changes = append(changes, fmt.Sprintf("community tax from %.2f%% to %.2f%%", cc.lastCommunityTax*100, cc.communityTax*100))
+	}
+	if cc.paramsSeen && math.Abs(cc.inflationRate-cc.lastInflationRate) > chainParamEpsilon {
+		changes = append(changes, fmt.Sprintf("inflation from %.2f%% to %.2f%%", cc.lastInflationRate*100, cc.inflationRate*100))
+	}
+	cc.paramsSeen = true
+	cc.lastCommunityTax = cc.communityTax
+	cc.lastInflationRate = cc.inflationRate
+	if len(changes) == 0 {
+		return alert, resolved
+	}
+
+	alertID := fmt.Sprintf("ChainParamChange_%s_%d", cc.ValAddress, time.Now().Unix())
+	td.notice(
+		cc.name,
+		fmt.Sprintf("economic parameters changed on %s: %s", cc.ChainId, strings.Join(changes, ", ")),
+		"info",
+		alertID,
+	)
+	alert = true
+
+	cc.activeAlerts = alarms.getCount(cc.name)
+	return alert, resolved
+}
+
+// evaluateSlashEventAlert sends a one-shot critical alert for every slash event seen in a block. Like the moniker
+// change there is nothing to resolve, the jailed or tombstoned state is covered by evaluateValidatorInactiveAlert.
+func evaluateSlashEventAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+	for _, slash := range cc.takeSlashes() {
+		message := fmt.Sprintf("validator %s was slashed on %s at height %d", cc.ValAddress, cc.ChainId, slash.Height)
+		if slash.Reason != "" {
+			message += fmt.Sprintf(", reason: %s", slash.Reason)
+		}
+		if slash.Amount != "" {
+			message += fmt.Sprintf(", burned: %s", slash.Amount)
+		}
+		alertID := fmt.Sprintf("SlashEvent_%s_%d", cc.ValAddress, slash.Height)
+		td.notice(cc.name, message, "critical", alertID)
+		alert = true
+	}
+
+	cc.activeAlerts = alarms.getCount(cc.name)
+	return alert, resolved
+}
+
 func evaluateConsecutiveEmptyBlocksAlert(cc *ChainConfig) (bool, bool) {
 	alert, resolved := false, false
 
 	alertID := fmt.Sprintf("ConsecutiveEmptyBlocks_%s", cc.ValAddress)
 	if int(cc.statConsecutiveEmpty) >= intVal(cc.Alerts.ConsecutiveEmpty) {
+		alarms.stillFiring(cc.name, alertID)
 		if !alarms.exist(cc.name, alertID) {
 			td.alert(
 				cc.name,
@@ -640,7 +2017,7 @@ func evaluateConsecutiveEmptyBlocksAlert(cc *ChainConfig) (bool, bool) {
 			alert = true
 		}
 	} else {
-		if alarms.exist(cc.name, alertID) {
+		if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
 			td.alert(
 				cc.name,
 				fmt.Sprintf("%s has proposed %d consecutive empty blocks on %s", cc.valInfo.Moniker, intVal(cc.Alerts.ConsecutiveEmpty), cc.ChainId),
@@ -666,6 +2043,7 @@ func evaluatePercentageEmptyBlocksAlert(cc *ChainConfig) (bool, bool) {
 
 	alertID := fmt.Sprintf("PercentageEmptyBlocks_%s", cc.ValAddress)
 	if emptyBlocksPercent >= float64(intVal(cc.Alerts.EmptyWindow)) {
+		alarms.stillFiring(cc.name, alertID)
 		if !alarms.exist(cc.name, alertID) {
 			td.alert(
 				cc.name,
@@ -681,20 +2059,133 @@ func evaluatePercentageEmptyBlocksAlert(cc *ChainConfig) (bool, bool) {
 			)
 			alert = true
 		}
-	} else {
-		if alarms.exist(cc.name, alertID) {
-			td.alert(
-				cc.name,
-				fmt.Sprintf("%s has > %d%% empty blocks (%d of %d proposed blocks) on %s",
-					cc.valInfo.Moniker,
-					intVal(cc.Alerts.EmptyWindow),
-					int(cc.statTotalPropsEmpty),
-					int(cc.statTotalProps),
-					cc.ChainId),
-				cc.Alerts.EmptyPercentagePriority,
-				true,
-				&alertID,
-			)
+	} else {
+		if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
+			td.alert(
+				cc.name,
+				fmt.Sprintf("%s has > %d%% empty blocks (%d of %d proposed blocks) on %s",
+					cc.valInfo.Moniker,
+					intVal(cc.Alerts.EmptyWindow),
+					int(cc.statTotalPropsEmpty),
+					int(cc.statTotalProps),
+					cc.ChainId),
+				cc.Alerts.EmptyPercentagePriority,
+				true,
+				&alertID,
+			)
+			resolved = true
+		}
+	}
+
+	cc.activeAlerts = alarms.getCount(cc.name)
+	return alert, resolved
+}
+
+func evaluateRPCNodeDownAlert(cc *ChainConfig) (bool, bool) {
+	batched, alert, resolved := evaluateRPCNodesDownBatch(cc)
+
+	for _, node := range cc.Nodes {
+		alertID := fmt.Sprintf("RPCNodeDown_%s_%s", cc.ValAddress, node.Url)
+		if node.down {
+			alarms.stillFiring(cc.name, alertID)
+		}
+		if node.AlertIfDown && node.down && !node.wasDown && !node.downSince.IsZero() &&
+			time.Since(node.downSince) > time.Duration(td.NodeDownMin)*time.Minute {
+			// the node is listed in the batched alert instead
+			if !alarms.exist(cc.name, alertID) && !batched {
+				td.alert(
+					cc.name,
+					fmt.Sprintf("Severity: %s\nRPC node %s has been down for > %d minutes on %s", td.NodeDownSeverity, node.Url, td.NodeDownMin, cc.ChainId),
+					td.NodeDownSeverity,
+					false,
+					&alertID,
+				)
+				alert = true
+			}
+		} else if node.AlertIfDown && !node.down && node.wasDown {
+			// wasDown stays set until the resolve delay has passed
+			if alarms.exist(cc.name, alertID) && !cc.resolveDue(alertID) {
+				continue
+			}
+			node.wasDown = false
+			if alarms.exist(cc.name, alertID) {
+				td.alert(
+					cc.name,
+					fmt.Sprintf("Severity: %s\nRPC node %s has been down for > %d minutes on %s", td.NodeDownSeverity, node.Url, td.NodeDownMin, cc.ChainId),
+					td.NodeDownSeverity,
+					true,
+					&alertID,
+				)
+				resolved = true
+			}
+		}
+	}
+
+	cc.activeAlerts = alarms.getCount(cc.name)
+	return alert, resolved
+}
+
+// evaluateRPCNodesDownBatch sends a single alert listing the down nodes when at least node_down_batch_threshold of the
+// chain's nodes are down together, e.g. during a network blip, and reports whether the per-node alerts are held back.
+// It resolves once fewer nodes are down, the nodes still down then alert on their own.
+func evaluateRPCNodesDownBatch(cc *ChainConfig) (batched bool, alert bool, resolved bool) {
+	if td.NodeDownBatchThreshold <= 0 {
+		return
+	}
+	monitored := 0
+	down := make([]string, 0)
+	for _, node := range cc.Nodes {
+		if !node.AlertIfDown {
+			continue
+		}
+		monitored++
+		if node.down && !node.downSince.IsZero() && time.Since(node.downSince) > time.Duration(td.NodeDownMin)*time.Minute {
+			down = append(down, fmt.Sprintf("%s (down since %s)", node.Url, cc.formatTime(node.downSince)))
+		}
+	}
+
+	alertID := fmt.Sprintf("RPCNodesDown_%s", cc.ValAddress)
+	message := fmt.Sprintf("Severity: %s\n%d of %d RPC nodes have been down for > %d minutes on %s:\n%s",
+		td.NodeDownSeverity, len(down), monitored, td.NodeDownMin, cc.ChainId, strings.Join(down, "\n"))
+	if len(down) >= td.NodeDownBatchThreshold {
+		alarms.stillFiring(cc.name, alertID)
+		if !alarms.exist(cc.name, alertID) {
+			td.alert(cc.name, message, td.NodeDownSeverity, false, &alertID)
+			alert = true
+		}
+		return true, alert, resolved
+	}
+	if alarms.exist(cc.name, alertID) {
+		// the nodes stay batched until the resolve delay has passed
+		if !cc.resolveDue(alertID) {
+			return true, alert, resolved
+		}
+		td.alert(cc.name, message, td.NodeDownSeverity, true, &alertID)
+		resolved = true
+	}
+	return false, alert, resolved
+}
+
+// evaluateLowPeersAlert fires for each node that reports fewer peers than MinPeers, these nodes are likely to fall
+// behind soon.
+func evaluateLowPeersAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+
+	for _, node := range cc.Nodes {
+		peers, known := node.getPeers()
+		if !known {
+			continue
+		}
+		alertID := fmt.Sprintf("LowPeers_%s_%s", cc.ValAddress, node.Url)
+		message := fmt.Sprintf("RPC node %s has %d peers, below the minimum of %d on %s", node.Url, peers, intVal(cc.Alerts.MinPeers), cc.ChainId)
+		if peers < intVal(cc.Alerts.MinPeers) {
+			alarms.stillFiring(cc.name, alertID)
+			if !alarms.exist(cc.name, alertID) {
+				td.alert(cc.name, message, "warning", false, &alertID)
+				alert = true
+			}
+		} else if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
+			td.alert(cc.name, message, "warning", true, &alertID)
 			resolved = true
 		}
 	}
@@ -703,35 +2194,52 @@ func evaluatePercentageEmptyBlocksAlert(cc *ChainConfig) (bool, bool) {
 	return alert, resolved
 }
 
-func evaluateRPCNodeDownAlert(cc *ChainConfig) (bool, bool) {
+// evaluateNodeSyncingAlert fires for each node that has reported catching_up for longer than SyncingMaxMinutes, a
+// node stuck re-syncing passes the up/down check but is of no use for monitoring.
+func evaluateNodeSyncingAlert(cc *ChainConfig) (bool, bool) {
 	alert, resolved := false, false
 
 	for _, node := range cc.Nodes {
-		alertID := fmt.Sprintf("RPCNodeDown_%s_%s", cc.ValAddress, node.Url)
-		if node.AlertIfDown && node.down && !node.wasDown && !node.downSince.IsZero() &&
-			time.Since(node.downSince) > time.Duration(td.NodeDownMin)*time.Minute {
+		alertID := fmt.Sprintf("NodeSyncing_%s_%s", cc.ValAddress, node.Url)
+		message := fmt.Sprintf("RPC node %s has been catching up for > %d minutes on %s", node.Url, intVal(cc.Alerts.SyncingMaxMinutes), cc.ChainId)
+		since := node.catchingUpSince()
+		if !since.IsZero() && time.Since(since) > time.Duration(intVal(cc.Alerts.SyncingMaxMinutes))*time.Minute {
+			alarms.stillFiring(cc.name, alertID)
 			if !alarms.exist(cc.name, alertID) {
-				td.alert(
-					cc.name,
-					fmt.Sprintf("Severity: %s\nRPC node %s has been down for > %d minutes on %s", td.NodeDownSeverity, node.Url, td.NodeDownMin, cc.ChainId),
-					td.NodeDownSeverity,
-					false,
-					&alertID,
-				)
+				td.alert(cc.name, message, "warning", false, &alertID)
 				alert = true
 			}
-		} else if node.AlertIfDown && !node.down && node.wasDown {
-			node.wasDown = false
-			if alarms.exist(cc.name, alertID) {
-				td.alert(
-					cc.name,
-					fmt.Sprintf("Severity: %s\nRPC node %s has been down for > %d minutes on %s", td.NodeDownSeverity, node.Url, td.NodeDownMin, cc.ChainId),
-					td.NodeDownSeverity,
-					true,
-					&alertID,
-				)
-				resolved = true
+		} else if since.IsZero() && alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
+			td.alert(cc.name, message, "warning", true, &alertID)
+			resolved = true
+		}
+	}
+
+	cc.activeAlerts = alarms.getCount(cc.name)
+	return alert, resolved
+}
+
+// evaluateWrongChainIdAlert fires for each node that reports a different chain-id than configured, these nodes are
+// never used, but without this alert the chain might silently be monitored through a single remaining node.
+func evaluateWrongChainIdAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+
+	for _, node := range cc.Nodes {
+		network := node.getNetwork()
+		if network == "" {
+			continue
+		}
+		alertID := fmt.Sprintf("WrongChainId_%s_%s", cc.ValAddress, node.Url)
+		message := fmt.Sprintf("RPC node %s is on chain-id %s, but %s is expected, it will not be used", node.Url, network, cc.ChainId)
+		if network != cc.ChainId {
+			alarms.stillFiring(cc.name, alertID)
+			if !alarms.exist(cc.name, alertID) {
+				td.alert(cc.name, message, "critical", false, &alertID)
+				alert = true
 			}
+		} else if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
+			td.alert(cc.name, message, "critical", true, &alertID)
+			resolved = true
 		}
 	}
 
@@ -755,7 +2263,7 @@ func evaluateStakeChangeAlert(cc *ChainConfig) (bool, bool) {
 		alertID := fmt.Sprintf("StakeChange_%s", cc.ValAddress)
 		severity := "warning"
 		unit := "base"
-		if cc.denomMetadata != nil && cc.Provider.Name != "namada" {
+		if cc.denomMetadata != nil {
 			var stakeNowConverted, stakeBeforeConverted float64
 			var displayUnit string
 			var err0, err1 error
@@ -766,17 +2274,16 @@ func evaluateStakeChangeAlert(cc *ChainConfig) (bool, bool) {
 				stakeBefore = stakeBeforeConverted
 				unit = displayUnit
 			}
-		} else if cc.Provider.Name == "namada" {
-			unit = "NAM"
 		}
 		message := fmt.Sprintf("%s's stake has %s by %.1g%% (%.1g %s now) compared to the previous check (%.1g %s)", cc.valInfo.Moniker, trend, math.Abs(stakeChangePercent)*100, stakeNow, unit, stakeBefore, unit)
 		if math.Abs(stakeChangePercent) >= threshold {
+			alarms.stillFiring(cc.name, alertID)
 			if !alarms.exist(cc.name, alertID) {
 				td.alert(cc.name, message, severity, false, &alertID)
 				alert = true
 			}
 		} else {
-			if alarms.exist(cc.name, alertID) {
+			if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
 				td.alert(cc.name, message, severity, true, &alertID)
 				resolved = true
 			}
@@ -787,6 +2294,309 @@ func evaluateStakeChangeAlert(cc *ChainConfig) (bool, bool) {
 	return alert, resolved
 }
 
+// evaluateVotingPowerShareAlert alerts when the validator's share of the bonded tokens drops below
+// MinVotingPowerPercent, and resolves once it is back above it.
+func evaluateVotingPowerShareAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+	minPercent := floatVal(cc.Alerts.MinVotingPowerPercent)
+	// VotingPowerPercent stays 0 until the staking pool could be queried
+	if cc.valInfo == nil || cc.valInfo.VotingPowerPercent == 0 || minPercent <= 0 {
+		return alert, resolved
+	}
+
+	share := cc.valInfo.VotingPowerPercent * 100
+	alertID := fmt.Sprintf("VotingPowerShare_%s", cc.ValAddress)
+	message := fmt.Sprintf("%s has %.3f%% of the voting power on %s, below the minimum of %.3f%%", cc.valInfo.Moniker, share, cc.ChainId, minPercent)
+	if share < minPercent {
+		alarms.stillFiring(cc.name, alertID)
+		if !alarms.exist(cc.name, alertID) {
+			td.alert(cc.name, message, "warning", false, &alertID)
+			alert = true
+		}
+	} else if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
+		td.alert(cc.name, message, "warning", true, &alertID)
+		resolved = true
+	}
+
+	cc.activeAlerts = alarms.getCount(cc.name)
+	return alert, resolved
+}
+
+// evaluateValidatorRankAlert warns when the validator's rank by voting power is worse than max_validator_rank, the set
+// comes from the cached snapshot so it is not queried on every evaluation.
+func evaluateValidatorRankAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+	maxRank := intVal(cc.Alerts.MaxValidatorRank)
+	if cc.valInfo == nil || len(cc.valInfo.Conspub) == 0 || maxRank <= 0 {
+		return alert, resolved
+	}
+	snapshot, err := getValidatorSetCached(cc)
+	if err != nil {
+		lDebug("could not get the validator set for", cc.name, err)
+		return alert, resolved
+	}
+	rank, share, found := snapshot.rank(cc.valInfo.Conspub)
+	// a validator outside the set is covered by the inactive alert
+	if !found {
+		return alert, resolved
+	}
+
+	alertID := fmt.Sprintf("ValidatorRank_%s", cc.ValAddress)
+	message := fmt.Sprintf("%s is ranked #%d of %d by voting power (%.2f%%) on %s, below the minimum rank of #%d", cc.valInfo.Moniker, rank, len(snapshot.Validators), 100*share, cc.ChainId, maxRank)
+	if rank > maxRank {
+		alarms.stillFiring(cc.name, alertID)
+		if !alarms.exist(cc.name, alertID) {
+			td.alert(cc.name, message, "warning", false, &alertID)
+			alert = true
+		}
+	} else if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
+		td.alert(cc.name, message, "warning", true, &alertID)
+		resolved = true
+	}
+
+	cc.activeAlerts = alarms.getCount(cc.name)
+	return alert, resolved
+}
+
+// evaluateDelegatedTokensAlert alerts when the validator's delegated tokens are below MinDelegatedTokens or above
+// MaxDelegatedTokens, each bound fires and resolves on its own. The bounds are in display units, so nothing is checked
+// until the chain's denom metadata is known.
+func evaluateDelegatedTokensAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+	// DelegatedTokens stays 0 until the validator could be queried
+	if cc.valInfo == nil || cc.valInfo.DelegatedTokens == 0 || cc.denomMetadata == nil {
+		return alert, resolved
+	}
+	delegated, unit, err := utils.ConvertFloatInBaseUnitToDisplayUnit(cc.valInfo.DelegatedTokens, *cc.denomMetadata)
+	if err != nil {
+		return alert, resolved
+	}
+
+	minTokens, maxTokens := floatVal(cc.Alerts.MinDelegatedTokens), floatVal(cc.Alerts.MaxDelegatedTokens)
+	bounds := []struct {
+		id       string
+		limit    float64
+		crossed  bool
+		relation string
+	}{
+		{id: "DelegatedTokensBelow", limit: minTokens, crossed: delegated < minTokens, relation: "below the minimum"},
+		{id: "DelegatedTokensAbove", limit: maxTokens, crossed: delegated > maxTokens, relation: "above the maximum"},
+	}
+	for _, b := range bounds {
+		if b.limit <= 0 {
+			continue
+		}
+		alertID := fmt.Sprintf("%s_%s", b.id, cc.ValAddress)
+		message := fmt.Sprintf("%s has %.2f %s delegated on %s, %s of %.2f %s", cc.valInfo.Moniker, delegated, unit, cc.ChainId, b.relation, b.limit, unit)
+		if b.crossed {
+			alarms.stillFiring(cc.name, alertID)
+			if !alarms.exist(cc.name, alertID) {
+				td.alert(cc.name, message, "warning", false, &alertID)
+				alert = true
+			}
+		} else if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
+			td.alert(cc.name, message, "warning", true, &alertID)
+			resolved = true
+		}
+	}
+
+	cc.activeAlerts = alarms.getCount(cc.name)
+	return alert, resolved
+}
+
+// evaluateLowGasBalanceAlert warns when the operator account's balance of the fee denom drops below min_gas_balance,
+// relayers and oracles sending from it would fail to pay fees. It resolves once the account is topped up.
+func evaluateLowGasBalanceAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+	minBalance := floatVal(cc.Alerts.MinGasBalance)
+	if cc.valInfo == nil || cc.valInfo.GasBalance == nil || cc.denomMetadata == nil || minBalance <= 0 {
+		return alert, resolved
+	}
+	balance, unit, err := utils.ConvertFloatInBaseUnitToDisplayUnit(*cc.valInfo.GasBalance, *cc.denomMetadata)
+	if err != nil {
+		return alert, resolved
+	}
+
+	alertID := fmt.Sprintf("LowGasBalance_%s", cc.ValAddress)
+	message := fmt.Sprintf("%s's operator account has %.2f %s left for fees on %s, below the minimum of %.2f %s", cc.valInfo.Moniker, balance, unit, cc.ChainId, minBalance, unit)
+	if balance < minBalance {
+		alarms.stillFiring(cc.name, alertID)
+		if !alarms.exist(cc.name, alertID) {
+			td.alert(cc.name, message, "warning", false, &alertID)
+			alert = true
+		}
+	} else if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
+		td.alert(cc.name, message, "warning", true, &alertID)
+		resolved = true
+	}
+
+	cc.activeAlerts = alarms.getCount(cc.name)
+	return alert, resolved
+}
+
+// commissionRateEpsilon keeps a rate exactly at the edge of the tolerance from alerting because of float rounding.
+const commissionRateEpsilon = 1e-9
+
+// evaluateCommissionRateAlert alerts when the on-chain commission rate is further than commission_rate_tolerance from
+// expected_commission_rate, e.g. after a fat-fingered commission change, and resolves once it is corrected.
+func evaluateCommissionRateAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+	// the commission rate is only the zero value until the validator info was queried
+	if cc.valInfo == nil || cc.valInfoUpdated.IsZero() || cc.Alerts.ExpectedCommissionRate == nil {
+		return alert, resolved
+	}
+
+	expected, tolerance := *cc.Alerts.ExpectedCommissionRate, floatVal(cc.Alerts.CommissionRateTolerance)
+	alertID := fmt.Sprintf("CommissionRate_%s", cc.ValAddress)
+	message := fmt.Sprintf("%s has a commission rate of %.2f%% on %s, expected %.2f%% ± %.2f%%",
+		cc.valInfo.Moniker, cc.valInfo.CommissionRate*100, cc.ChainId, expected*100, tolerance*100)
+	if math.Abs(cc.valInfo.CommissionRate-expected) > tolerance+commissionRateEpsilon {
+		alarms.stillFiring(cc.name, alertID)
+		if !alarms.exist(cc.name, alertID) {
+			td.alert(cc.name, message, "warning", false, &alertID)
+			alert = true
+		}
+	} else if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
+		td.alert(cc.name, message, "warning", true, &alertID)
+		resolved = true
+	}
+
+	cc.activeAlerts = alarms.getCount(cc.name)
+	return alert, resolved
+}
+
+// evaluateUnbondingAlert alerts once for every unbonding entry of the operator's self-delegation, identified by the
+// height it was created at and its completion time, and resolves the alarm when the entry is no longer returned because
+// it has completed.
+func evaluateUnbondingAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+	if cc.valInfo == nil || cc.valInfo.Unbonding == nil {
+		return alert, resolved
+	}
+
+	const severity = "warning"
+	prefix := fmt.Sprintf("Unbonding_%s_", cc.ValAddress)
+	current := make(map[string]bool)
+	for _, entry := range cc.valInfo.Unbonding {
+		// several entries can be created at the same height, the completion time tells them apart
+		alertID := fmt.Sprintf("%s%d_%d", prefix, entry.CreationHeight, entry.CompletionTime.Unix())
+		current[alertID] = true
+		if alarms.exist(cc.name, alertID) {
+			continue
+		}
+		balance := entry.Balance.String() + " base"
+		if amount, err := entry.Balance.ToDec().Float64(); err != nil {
+			lWarn("⚠️ could not convert the unbonding balance", entry.Balance, "on", cc.name, err)
+		} else {
+			balance = fmt.Sprintf("%.2f base", amount)
+			if cc.denomMetadata != nil {
+				if converted, displayUnit, err := utils.ConvertFloatInBaseUnitToDisplayUnit(amount, *cc.denomMetadata); err == nil {
+					balance = fmt.Sprintf("%.2f %s", converted, displayUnit)
+				}
+			}
+		}
+		message := fmt.Sprintf("%s started unbonding %s of self-delegation on %s at height %d, completing at %s",
+			cc.valInfo.Moniker, balance, cc.name, entry.CreationHeight, cc.formatTime(entry.CompletionTime))
+		td.alert(cc.name, message, severity, false, &alertID)
+		alert = true
+	}
+
+	for _, alertID := range alarms.activeWithPrefix(cc.name, prefix) {
+		if current[alertID] {
+			continue
+		}
+		height := strings.SplitN(strings.TrimPrefix(alertID, prefix), "_", 2)[0]
+		message := fmt.Sprintf("%s's self-delegation unbonding started at height %s has completed on %s",
+			cc.valInfo.Moniker, height, cc.name)
+		td.alert(cc.name, message, severity, true, &alertID)
+		resolved = true
+	}
+	cc.activeAlerts = alarms.getCount(cc.name)
+
+	return alert, resolved
+}
+
+// evaluateIBCClientExpiryAlert warns when a watched IBC client expires within ibc_client_expiry_hours, and sends a
+// critical alert once it has expired or was frozen, a relayer can no longer update it then.
+func evaluateIBCClientExpiryAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+	if cc.valInfo == nil || cc.valInfo.IBCClients == nil {
+		return alert, resolved
+	}
+
+	window := time.Duration(intVal(cc.Alerts.IBCClientExpiryHours)) * time.Hour
+	for _, client := range cc.valInfo.IBCClients {
+		knownExpiry := !client.ExpiresAt.IsZero()
+		expired := client.Status == "Expired" || client.Status == "Frozen" || (knownExpiry && time.Now().After(client.ExpiresAt))
+		state := "expired"
+		if client.Status == "Frozen" {
+			state = "frozen"
+		}
+		checks := []struct {
+			id       string
+			severity string
+			firing   bool
+			message  string
+		}{
+			{
+				id:       "IBCClientExpiring",
+				severity: "warning",
+				firing:   !expired && knownExpiry && time.Until(client.ExpiresAt) < window,
+				message:  fmt.Sprintf("ibc client %s on %s expires at %s unless it is updated", client.ClientID, cc.ChainId, cc.formatTime(client.ExpiresAt)),
+			},
+			{
+				id:       "IBCClientExpired",
+				severity: "critical",
+				firing:   expired,
+				message:  fmt.Sprintf("ibc client %s on %s is %s", client.ClientID, cc.ChainId, state),
+			},
+		}
+		for _, c := range checks {
+			alertID := fmt.Sprintf("%s_%s_%s", c.id, cc.ValAddress, client.ClientID)
+			if c.firing {
+				alarms.stillFiring(cc.name, alertID)
+				if !alarms.exist(cc.name, alertID) {
+					td.alert(cc.name, c.message, c.severity, false, &alertID)
+					alert = true
+				}
+			} else if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
+				td.alert(cc.name, c.message, c.severity, true, &alertID)
+				resolved = true
+			}
+		}
+	}
+
+	cc.activeAlerts = alarms.getCount(cc.name)
+	return alert, resolved
+}
+
+// evaluateUpcomingUpgradeAlert warns when the chain is within upgrade_warning_blocks of a scheduled software upgrade,
+// and resolves once the upgrade height was reached, or the plan was cancelled.
+func evaluateUpcomingUpgradeAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+	alertID := fmt.Sprintf("UpcomingUpgrade_%s", cc.ValAddress)
+	remaining := cc.upgradeHeight - cc.lastBlockNum
+	firing := cc.upgradeHeight > 0 && cc.lastBlockNum > 0 && remaining > 0 && remaining <= int64(intVal(cc.Alerts.UpgradeWarningBlocks))
+	message := fmt.Sprintf("upgrade %s on %s is scheduled at height %d, %d blocks from now", cc.upgradeName, cc.ChainId, cc.upgradeHeight, remaining)
+	if firing {
+		alarms.stillFiring(cc.name, alertID)
+		if !alarms.exist(cc.name, alertID) {
+			td.alert(cc.name, message, "warning", false, &alertID)
+			alert = true
+		}
+	} else if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
+		message = fmt.Sprintf("upgrade %s on %s reached height %d", cc.upgradeName, cc.ChainId, cc.upgradeHeight)
+		if cc.upgradeHeight == 0 {
+			message = fmt.Sprintf("the scheduled upgrade on %s was cancelled", cc.ChainId)
+		}
+		td.alert(cc.name, message, "warning", true, &alertID)
+		resolved = true
+	}
+
+	cc.activeAlerts = alarms.getCount(cc.name)
+	return alert, resolved
+}
+
 func evaluateUnclaimedRewardsAlert(cc *ChainConfig) (bool, bool) {
 	alert, resolved := false, false
 
@@ -824,6 +2634,7 @@ func evaluateUnclaimedRewardsAlert(cc *ChainConfig) (bool, bool) {
 			alertID := fmt.Sprintf("UnclaimedRewards_%s", cc.ValAddress)
 			const severity = "warning"
 			if totalRewardsConverted > threshold {
+				alarms.stillFiring(cc.name, alertID)
 				if !alarms.exist(cc.name, alertID) {
 					message := fmt.Sprintf("%s has more than %.0f (%.0f currently) %s unclaimed rewards on %s",
 						cc.valInfo.Moniker, threshold, totalRewardsConverted, td.PriceConversion.Currency, cc.name)
@@ -831,7 +2642,7 @@ func evaluateUnclaimedRewardsAlert(cc *ChainConfig) (bool, bool) {
 					alert = true
 				}
 			} else {
-				if alarms.exist(cc.name, alertID) {
+				if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
 					message := fmt.Sprintf("%s has more than %.0f %s unclaimed rewards on %s",
 						cc.valInfo.Moniker, threshold, td.PriceConversion.Currency, cc.name)
 					td.alert(cc.name, message, severity, true, &alertID)
@@ -846,6 +2657,50 @@ func evaluateUnclaimedRewardsAlert(cc *ChainConfig) (bool, bool) {
 	return alert, resolved
 }
 
+// defaultCommissionStaleHours is how long the commission can grow without a withdrawal when commission_stale_hours is
+// not set.
+const defaultCommissionStaleHours = 48
+
+// evaluateCommissionStaleAlert warns when the validator's commission has kept growing for CommissionStaleHours without
+// being withdrawn, e.g. because an auto-withdrawal job stopped. A drop in the commission counts as a withdrawal, and so
+// does the first evaluation since the history before it is unknown.
+func evaluateCommissionStaleAlert(cc *ChainConfig) (bool, bool) {
+	alert, resolved := false, false
+	if cc.valInfo == nil || cc.valInfo.Commission == nil {
+		return alert, resolved
+	}
+
+	commission := 0.0
+	if len(*cc.valInfo.Commission) > 0 {
+		commission = (*cc.valInfo.Commission)[0].Amount.MustFloat64()
+	}
+	now := time.Now()
+	if cc.commissionWithdrawn.IsZero() || commission == 0 || commission < cc.commissionLast {
+		cc.commissionWithdrawn = now
+	}
+	cc.commissionLast = commission
+
+	hours := intVal(cc.Alerts.CommissionStaleHours)
+	if hours <= 0 {
+		hours = defaultCommissionStaleHours
+	}
+	alertID := fmt.Sprintf("CommissionStale_%s", cc.ValAddress)
+	message := fmt.Sprintf("commission of %s on %s has not been withdrawn for more than %d hours", cc.valInfo.Moniker, cc.ChainId, hours)
+	if now.Sub(cc.commissionWithdrawn) > time.Duration(hours)*time.Hour {
+		alarms.stillFiring(cc.name, alertID)
+		if !alarms.exist(cc.name, alertID) {
+			td.alert(cc.name, message, "warning", false, &alertID)
+			alert = true
+		}
+	} else if alarms.exist(cc.name, alertID) && cc.resolveDue(alertID) {
+		td.alert(cc.name, message, "warning", true, &alertID)
+		resolved = true
+	}
+
+	cc.activeAlerts = alarms.getCount(cc.name)
+	return alert, resolved
+}
+
 func evaluateUnvotedGovernanceProposalAlert(cc *ChainConfig) (bool, bool) {
 	alert, resolved := false, false
 
@@ -859,7 +2714,7 @@ func evaluateUnvotedGovernanceProposalAlert(cc *ChainConfig) (bool, bool) {
 
 	for _, proposal := range cc.unvotedOpenGovProposals {
 		alertID := fmt.Sprintf(idTemplate, cc.ValAddress, proposal.ProposalId)
-		deadline := fmt.Sprintf(", deadline: %s UTC", proposal.VotingEndTime.Format("2006-01-02 15:04"))
+		deadline := ", deadline: " + cc.formatTime(proposal.VotingEndTime)
 		if cc.Provider.Name == "namada" {
 			deadline = ""
 		}
@@ -914,10 +2769,22 @@ func evaluateUnvotedGovernanceProposalAlert(cc *ChainConfig) (bool, bool) {
 	return alert, resolved
 }
 
+// defaultEvalInterval is the number of seconds watch waits between evaluations without eval_interval_seconds
+const defaultEvalInterval = 2
+
+// evalInterval returns the number of seconds between alert evaluations for the chain.
+func (cc *ChainConfig) evalInterval() int {
+	if cc.EvalIntervalSeconds == nil || *cc.EvalIntervalSeconds < 1 {
+		return defaultEvalInterval
+	}
+	return *cc.EvalIntervalSeconds
+}
+
 // watch handles monitoring for missed blocks, stalled chain, node downtime
 // and also updates a few prometheus stats
 // FIXME: not watching for nodes that are lagging the head block!
 func (cc *ChainConfig) watch() {
+	cc.watchStart = time.Now()
 	// wait until we have a moniker:
 	noNodesSec := 0
 	for {
@@ -929,7 +2796,7 @@ func (cc *ChainConfig) watch() {
 					td.alert(
 						cc.name,
 						fmt.Sprintf("no RPC endpoints are working for %s", cc.ChainId),
-						"critical",
+						priorityOrCritical(cc.Alerts.NoServersPriority),
 						false,
 						&alertID,
 					)
@@ -945,38 +2812,107 @@ func (cc *ChainConfig) watch() {
 	// initial stat creation for nodes, we only update again if the node is positive
 	if td.Prom {
 		for _, node := range cc.Nodes {
-			td.statsChan <- cc.mkUpdate(metricNodeDownSeconds, 0, node.Url)
+			td.sendStat(cc.mkUpdate(metricNodeDownSeconds, 0, node.Url))
 		}
 	}
 
 	for {
-		time.Sleep(2 * time.Second)
+		time.Sleep(time.Duration(cc.evalInterval()) * time.Second)
+
+		// paused for maintenance through the API
+		if cc.isPaused() {
+			continue
+		}
 
 		// alert if we can't monitor
 		if boolVal(cc.Alerts.AlertIfNoServers) {
 			evaluateNoRPCEndpointsAlert(cc, &noNodesSec)
 		}
 
+		// a chain halted for a scheduled upgrade is expected to stall and miss blocks
+		upgrading := cc.upgradeHalted()
+
 		// stalled chain detection
-		if boolVal(cc.Alerts.StalledAlerts) {
+		if boolVal(cc.Alerts.StalledAlerts) && !upgrading {
 			evaluateChainStalledAlert(cc)
 		}
 
-		// jailed detection - only alert if it changes.
+		// websocket stopped delivering blocks while the chain is still advancing
+		if boolVal(cc.Alerts.WebsocketLagAlerts) {
+			evaluateWebsocketLagAlert(cc)
+		}
+
+		// block production slowing down before a stall
+		if boolVal(cc.Alerts.BlockTimeSlowdownAlerts) && !upgrading {
+			evaluateBlockTimeSlowdownAlert(cc)
+		}
+
+		// height not advancing across health check refreshes
+		if boolVal(cc.Alerts.HeightStuckAlerts) && !upgrading {
+			evaluateHeightStuckAlert(cc)
+		}
+
+		// jailed detection - only alert if it changes, and validators that are gone from the set entirely
 		if boolVal(cc.Alerts.AlertIfInactive) {
 			evaluateValidatorInactiveAlert(cc)
+			evaluateValidatorMissingAlert(cc)
+		}
+
+		// moniker changes, sent once per change
+		if boolVal(cc.Alerts.MonikerChangeAlerts) {
+			evaluateMonikerChangeAlert(cc)
+		}
+
+		// consensus key rotations, sent once per change
+		if boolVal(cc.Alerts.ConsKeyChangeAlerts) {
+			evaluateConsKeyChangeAlert(cc)
+		}
+
+		// confirmation that a validator which joined the active set is signing
+		if boolVal(cc.Alerts.FirstSignAlert) {
+			evaluateFirstSignAlert(cc)
+		}
+
+		// community tax or inflation changed since the last refresh
+		if boolVal(cc.Alerts.ChainParamAlerts) {
+			evaluateChainParamChangeAlert(cc)
+		}
+
+		// slash events seen in a block, sent once per slash
+		if boolVal(cc.Alerts.SlashEventAlerts) {
+			evaluateSlashEventAlert(cc)
 		}
 
 		// consecutive missed block alarms:
-		if boolVal(cc.Alerts.ConsecutiveAlerts) {
+		if boolVal(cc.Alerts.ConsecutiveAlerts) && !upgrading {
 			evaluateConsecutiveBlocksMissedAlert(cc)
 		}
 
-		// window percentage missed block alarms
-		if boolVal(cc.Alerts.PercentageAlerts) {
+		// window percentage missed block alarms, the window is unknown without a slashing module
+		if boolVal(cc.Alerts.PercentageAlerts) && cc.hasSlashing() && !upgrading {
 			evaluatePercentageBlocksMissedAlert(cc)
 		}
 
+		// blocks missed while the validator's prevotes or precommits were seen
+		if boolVal(cc.Alerts.ConsensusParticipationAlerts) {
+			evaluateConsensusParticipationAlert(cc)
+		}
+
+		// signed share of the observed blocks below the uptime target
+		if boolVal(cc.Alerts.UptimeSLAAlerts) {
+			evaluateUptimeSLAAlert(cc)
+		}
+
+		// proposed share of the observed blocks far from the voting power share
+		if boolVal(cc.Alerts.ProposerAnomalyAlerts) {
+			evaluateProposerAnomalyAlert(cc)
+		}
+
+		// blocks missed in a row while the validator's prevotes or precommits were seen
+		if boolVal(cc.Alerts.ConsecutiveVoteMissAlerts) {
+			evaluateConsecutiveVoteMissAlert(cc)
+		}
+
 		// empty blocks alarm handling
 		if boolVal(cc.Alerts.ConsecutiveEmptyAlerts) {
 			evaluateConsecutiveEmptyBlocksAlert(cc)
@@ -990,28 +2926,96 @@ func (cc *ChainConfig) watch() {
 		// node down alarms
 		evaluateRPCNodeDownAlert(cc)
 
+		// node peer count alarms
+		if boolVal(cc.Alerts.PeerAlerts) {
+			evaluateLowPeersAlert(cc)
+		}
+
+		// nodes stuck catching up
+		if boolVal(cc.Alerts.NodeSyncingAlerts) {
+			evaluateNodeSyncingAlert(cc)
+		}
+
+		// nodes on a different network than configured
+		if boolVal(cc.Alerts.WrongChainIdAlerts) {
+			evaluateWrongChainIdAlert(cc)
+		}
+
 		// validator stake change alerts
 		if boolVal(cc.Alerts.StakeChangeAlerts) {
 			evaluateStakeChangeAlert(cc)
 		}
 
+		// voting power share below the configured floor
+		if boolVal(cc.Alerts.VotingPowerAlerts) {
+			evaluateVotingPowerShareAlert(cc)
+		}
+
+		// rank by voting power worse than the configured position
+		if boolVal(cc.Alerts.ValidatorRankAlerts) {
+			evaluateValidatorRankAlert(cc)
+		}
+
+		// delegated tokens outside the configured floor or ceiling
+		if boolVal(cc.Alerts.DelegatedTokensAlerts) {
+			evaluateDelegatedTokensAlert(cc)
+		}
+
+		// commission rate outside the intended range
+		if boolVal(cc.Alerts.CommissionRateAlerts) {
+			evaluateCommissionRateAlert(cc)
+		}
+
+		// self-delegation unbonding alerts
+		if boolVal(cc.Alerts.UnbondingAlerts) {
+			evaluateUnbondingAlert(cc)
+		}
+
+		// watched ibc clients close to expiry, expired or frozen
+		if boolVal(cc.Alerts.IBCClientExpiryAlerts) {
+			evaluateIBCClientExpiryAlert(cc)
+		}
+
+		// a scheduled software upgrade is close
+		if boolVal(cc.Alerts.UpcomingUpgradeAlerts) {
+			evaluateUpcomingUpgradeAlert(cc)
+		}
+
 		// validator unclaimed rewards alert
 		if boolVal(cc.Alerts.UnclaimedRewardsAlerts) && td.PriceConversion.Enabled && cc.valInfo.SelfDelegationRewards != nil && cc.valInfo.Commission != nil {
 			evaluateUnclaimedRewardsAlert(cc)
 		}
 
+		// commission growing without being withdrawn
+		if boolVal(cc.Alerts.CommissionStaleAlerts) {
+			evaluateCommissionStaleAlert(cc)
+		}
+
+		// operator account running out of gas for fees
+		if boolVal(cc.Alerts.GasBalanceAlerts) {
+			evaluateLowGasBalanceAlert(cc)
+		}
+
 		// there are open proposals that the validator has not voted on
 		if boolVal(cc.Alerts.GovernanceAlerts) {
 			evaluateUnvotedGovernanceProposalAlert(cc)
 		}
 
 		if td.Prom {
+			up := 0.0
+			if cc.monitoringUp() {
+				up = 1
+			}
+			td.sendStat(cc.mkUpdate(metricChainUp, up, ""))
 			// raw block timer, ignoring finalized state
-			td.statsChan <- cc.mkUpdate(metricLastBlockSecondsNotFinal, time.Since(cc.lastBlockTime).Seconds(), "")
+			td.sendStat(cc.mkUpdate(metricLastBlockSecondsNotFinal, time.Since(cc.lastBlockTime).Seconds(), ""))
+			if wsSeen := cc.wsLastSeen(); !wsSeen.IsZero() {
+				td.sendStat(cc.mkUpdate(metricWebsocketLastBlock, float64(wsSeen.Unix()), ""))
+			}
 			// update node-down times for prometheus
 			for _, node := range cc.Nodes {
 				if node.down && !node.downSince.IsZero() {
-					td.statsChan <- cc.mkUpdate(metricNodeDownSeconds, time.Since(node.downSince).Seconds(), node.Url)
+					td.sendStat(cc.mkUpdate(metricNodeDownSeconds, time.Since(node.downSince).Seconds(), node.Url))
 				}
 			}
 		}
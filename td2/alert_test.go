@@ -2,14 +2,24 @@ package tenderduty
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
+	"sort"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/PagerDuty/go-pagerduty"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
 	gov "github.com/cosmos/cosmos-sdk/x/gov/types"
+	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
+	upgrade "github.com/cosmos/cosmos-sdk/x/upgrade/types"
 )
 
 // Helper function to create test config with minimal required fields
@@ -145,6 +155,69 @@ func TestAlarmCacheGetCount(t *testing.T) {
 	}
 }
 
+func TestAlarmCacheWorstSeverity(t *testing.T) {
+	cache := &alarmCache{
+		AllAlarms: map[string]map[string]alertMsgCache{
+			"chain1": {
+				"alert1": {Message: "test1", SentTime: time.Now(), Severity: "info"},
+				"alert2": {Message: "test2", SentTime: time.Now(), Severity: "critical"},
+				"alert3": {Message: "test3", SentTime: time.Now(), Severity: "warning"},
+			},
+			"chain2": {
+				"alert4": {Message: "test4", SentTime: time.Now(), Severity: "info"},
+				"alert5": {Message: "test5", SentTime: time.Now(), Severity: "warning"},
+			},
+			"chain3": {
+				"alert6": {Message: "restored from an old state file", SentTime: time.Now()},
+			},
+		},
+		notifyMux: sync.RWMutex{},
+	}
+
+	tests := []struct {
+		name     string
+		chain    string
+		expected string
+	}{
+		{name: "critical outranks the others", chain: "chain1", expected: "critical"},
+		{name: "warning outranks info", chain: "chain2", expected: "warning"},
+		{name: "alarms without a severity", chain: "chain3", expected: ""},
+		{name: "non-existing chain", chain: "chain4", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := cache.worstSeverity(tt.chain)
+			if result != tt.expected {
+				t.Errorf("alarmCache.worstSeverity(%s) = %q, want %q", tt.chain, result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("alerts store their severity", func(t *testing.T) {
+		originalAlarms := alarms
+		alarms = &alarmCache{AllAlarms: make(map[string]map[string]alertMsgCache), notifyMux: sync.RWMutex{}}
+		defer func() { alarms = originalAlarms }()
+		originalTd := td
+		td = createTestConfig()
+		defer func() { td = originalTd }()
+
+		warningID, criticalID := "warning_alert", "critical_alert"
+		td.alert("test-chain", "a warning", "warning", false, &warningID)
+		if got := alarms.worstSeverity("test-chain"); got != "warning" {
+			t.Errorf("expected warning, got %q", got)
+		}
+		td.alert("test-chain", "a critical alert", "critical", false, &criticalID)
+		if got := alarms.worstSeverity("test-chain"); got != "critical" {
+			t.Errorf("expected critical, got %q", got)
+		}
+		td.alert("test-chain", "a critical alert", "critical", true, &criticalID)
+		if got := alarms.worstSeverity("test-chain"); got != "warning" {
+			t.Errorf("expected warning once the critical alert resolved, got %q", got)
+		}
+	})
+}
+
 func TestAlarmCacheClearAll(t *testing.T) {
 	cache := &alarmCache{
 		AllAlarms: map[string]map[string]alertMsgCache{
@@ -282,6 +355,244 @@ func TestShouldNotify(t *testing.T) {
 	}
 }
 
+func TestShouldNotifySnoozed(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	tests := []struct {
+		name         string
+		snoozedUntil time.Time
+		resolved     bool
+		alreadySent  bool
+		expected     bool
+	}{
+		{
+			name:         "snoozed alarm should not notify",
+			snoozedUntil: time.Now().Add(time.Hour),
+			expected:     false,
+		},
+		{
+			name:         "expired snooze should notify",
+			snoozedUntil: time.Now().Add(-time.Minute),
+			expected:     true,
+		},
+		{
+			name:         "resolve while snoozed should notify",
+			snoozedUntil: time.Now().Add(time.Hour),
+			resolved:     true,
+			alreadySent:  true,
+			expected:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAlarms.SentTgAlarms = make(map[string]alertMsgCache)
+			testAlarms.flappingAlarms = make(map[string]map[string]alertMsgCache)
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{
+				"test-chain": {"test_alert": {Message: "test alert", SentTime: time.Now()}},
+			}
+			testAlarms.snoozedAlarms = nil
+			if err := testAlarms.snooze("test-chain", "test_alert", tt.snoozedUntil); err != nil {
+				t.Fatal(err)
+			}
+			if tt.alreadySent {
+				testAlarms.SentTgAlarms["test_alert"] = alertMsgCache{Message: "test alert", SentTime: time.Now()}
+			}
+
+			msg := &alertMsg{
+				chainName: "test-chain",
+				uniqueId:  "test_alert",
+				severity:  "critical",
+				resolved:  tt.resolved,
+				alertConfig: &AlertConfig{
+					Telegram: TeleConfig{SeverityThreshold: "info"},
+				},
+			}
+			if result := shouldNotify(msg, tg); result != tt.expected {
+				t.Errorf("shouldNotify() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+
+	// the expired snooze should have been cleaned up
+	testAlarms.snoozedAlarms = nil
+	_ = testAlarms.snooze("test-chain", "test_alert", time.Now().Add(-time.Minute))
+	testAlarms.isSnoozed("test-chain", "test_alert")
+	if _, ok := testAlarms.snoozedAlarms["test-chain"]["test_alert"]; ok {
+		t.Error("expected an expired snooze to be removed")
+	}
+}
+
+func TestShouldNotifyResolveCooldown(t *testing.T) {
+	testAlarms := &alarmCache{
+		SentTgAlarms:   make(map[string]alertMsgCache),
+		AllAlarms:      make(map[string]map[string]alertMsgCache),
+		flappingAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux:      sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	cooldown := 5
+	td.ResolveCooldownMinutes = &cooldown
+	defer func() { td = originalTd }()
+
+	newMsg := func(resolved bool) *alertMsg {
+		return &alertMsg{
+			chain:       "test-chain (test-chain-1)",
+			chainName:   "test-chain",
+			uniqueId:    "test_alert",
+			severity:    "critical",
+			resolved:    resolved,
+			alertConfig: &AlertConfig{Telegram: TeleConfig{SeverityThreshold: "info"}},
+		}
+	}
+	heldKey := fmt.Sprintf("held_%d_test_alert", tg)
+
+	// a rapid trigger/resolve cycle, the second resolve is held back and the re-trigger is not repeated
+	steps := []struct {
+		name     string
+		resolved bool
+		expected bool
+		held     bool
+	}{
+		{name: "first trigger is sent", expected: true},
+		{name: "first resolve is sent", resolved: true, expected: true},
+		{name: "second trigger is sent", expected: true},
+		{name: "second resolve is held back", resolved: true, expected: false, held: true},
+		{name: "third trigger is not repeated while the resolve is held", expected: false, held: true},
+		{name: "third resolve is held without scheduling again", resolved: true, expected: false, held: true},
+	}
+	for _, step := range steps {
+		if result := shouldNotify(newMsg(step.resolved), tg); result != step.expected {
+			t.Errorf("%s: shouldNotify() = %v, want %v", step.name, result, step.expected)
+		}
+		if held := !testAlarms.flappingAlarms["test-chain (test-chain-1)"][heldKey].SentTime.IsZero(); held != step.held {
+			t.Errorf("%s: expected held %v, got %v", step.name, step.held, held)
+		}
+	}
+
+	releaseTests := []struct {
+		name     string
+		firing   bool
+		expected bool
+	}{
+		{name: "held resolve is sent once the alarm stays clear", expected: true},
+		{name: "held resolve is dropped when the alarm fired again", firing: true, expected: false},
+	}
+	for _, tt := range releaseTests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{"test-chain": {}}
+			if tt.firing {
+				testAlarms.AllAlarms["test-chain"]["test_alert"] = alertMsgCache{Message: "test alert", SentTime: time.Now()}
+			}
+			testAlarms.flappingAlarms["test-chain (test-chain-1)"][heldKey] = alertMsgCache{SentTime: time.Now()}
+			if result := releaseResolve(newMsg(true), tg); result != tt.expected {
+				t.Errorf("releaseResolve() = %v, want %v", result, tt.expected)
+			}
+			if _, ok := testAlarms.flappingAlarms["test-chain (test-chain-1)"][heldKey]; ok {
+				t.Error("expected the held marker to be cleared")
+			}
+		})
+	}
+
+	// once the cooldown has passed the resolve goes out
+	testAlarms.flappingAlarms["test-chain (test-chain-1)"][fmt.Sprintf("resolve_%d_test_alert", tg)] = alertMsgCache{SentTime: time.Now().Add(-6 * time.Minute)}
+	if !shouldNotify(newMsg(true), tg) {
+		t.Error("expected the resolve to be sent after the cooldown")
+	}
+
+	// without a cooldown every resolve is sent
+	cooldown = 0
+	if !shouldNotify(newMsg(false), tg) || !shouldNotify(newMsg(true), tg) {
+		t.Error("expected the trigger and resolve to be sent with the cooldown disabled")
+	}
+}
+
+func TestShouldNotifyPagerdutyWindows(t *testing.T) {
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name       string
+		flapWindow *int
+		minResolve *int
+		resolved   bool
+		sentAgo    time.Duration // since the previous alert, or the alert being resolved
+		expected   bool
+		held       bool
+	}{
+		{name: "default window suppresses a repeat after 3 minutes", sentAgo: 3 * time.Minute},
+		{name: "default window allows a repeat after 6 minutes", sentAgo: 6 * time.Minute, expected: true},
+		{name: "custom window suppresses a repeat after 10 minutes", flapWindow: intPtr(15), sentAgo: 10 * time.Minute},
+		{name: "custom window allows a repeat after 3 minutes", flapWindow: intPtr(1), sentAgo: 3 * time.Minute, expected: true},
+		{name: "zero window disables flap detection", flapWindow: intPtr(0), sentAgo: 10 * time.Second, expected: true},
+		{name: "resolve is held back until the minimum", minResolve: intPtr(10), resolved: true, sentAgo: 2 * time.Minute, held: true},
+		{name: "resolve is sent after the minimum", minResolve: intPtr(10), resolved: true, sentAgo: 11 * time.Minute, expected: true},
+		{name: "resolve is sent right away by default", resolved: true, sentAgo: 10 * time.Second, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAlarms.SentPdAlarms = make(map[string]alertMsgCache)
+			testAlarms.flappingAlarms = map[string]map[string]alertMsgCache{"test-chain (test-chain-1)": {}}
+			sent := alertMsgCache{Message: "test alert", SentTime: time.Now().Add(-tt.sentAgo)}
+			if tt.resolved {
+				testAlarms.SentPdAlarms["test_alert"] = sent
+			} else {
+				testAlarms.flappingAlarms["test-chain (test-chain-1)"]["test_alert"] = sent
+			}
+
+			msg := &alertMsg{
+				pd:        true,
+				chain:     "test-chain (test-chain-1)",
+				chainName: "test-chain",
+				uniqueId:  "test_alert",
+				severity:  "critical",
+				resolved:  tt.resolved,
+				alertConfig: &AlertConfig{Pagerduty: PDConfig{
+					SeverityThreshold: "critical",
+					FlapWindowMinutes: tt.flapWindow,
+					MinResolveMinutes: tt.minResolve,
+				}},
+			}
+			if result := shouldNotify(msg, pd); result != tt.expected {
+				t.Errorf("shouldNotify() = %v, want %v", result, tt.expected)
+			}
+			heldKey := fmt.Sprintf("held_%d_test_alert", pd)
+			if held := !testAlarms.flappingAlarms["test-chain (test-chain-1)"][heldKey].SentTime.IsZero(); held != tt.held {
+				t.Errorf("expected held %v, got %v", tt.held, held)
+			}
+		})
+	}
+}
+
+func TestSnoozeRequiresActiveAlarm(t *testing.T) {
+	a := &alarmCache{AllAlarms: make(map[string]map[string]alertMsgCache)}
+	if err := a.snooze("test-chain", "missing", time.Now().Add(time.Hour)); err == nil {
+		t.Error("expected an error when snoozing an alarm that isn't active")
+	}
+}
+
 func TestBuildSlackMessage(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -324,6 +635,60 @@ func TestBuildSlackMessage(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "resolved message with duration",
+			msg: &alertMsg{
+				chain:     "test-chain",
+				message:   "Test resolved message",
+				resolved:  true,
+				firingFor: 12 * time.Minute,
+			},
+			expected: &SlackMessage{
+				Text: "OK: Test resolved message\nResolved after 12m",
+				Attachments: []Attachment{
+					{
+						Title: "TenderDuty 💜 Resolved:  test-chain ",
+						Color: "good",
+					},
+				},
+			},
+		},
+		{
+			name: "alert message with extra info",
+			msg: &alertMsg{
+				chain:     "test-chain",
+				message:   "Test alert message",
+				extraInfo: "instance-a",
+			},
+			expected: &SlackMessage{
+				Text: "Test alert message\ninstance-a",
+				Attachments: []Attachment{
+					{
+						Title: "TenderDuty 🚨 ALERT:  test-chain ",
+						Color: "danger",
+					},
+				},
+			},
+		},
+		{
+			name: "alert message with title prefix and footer",
+			msg: &alertMsg{
+				chain:          "test-chain",
+				message:        "Test alert message",
+				slkTitlePrefix: "[prod]",
+				slkFooter:      "td-eu-1",
+			},
+			expected: &SlackMessage{
+				Text: "Test alert message",
+				Attachments: []Attachment{
+					{
+						Title:  "[prod] TenderDuty 🚨 ALERT:  test-chain ",
+						Color:  "danger",
+						Footer: "td-eu-1",
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -336,6 +701,84 @@ func TestBuildSlackMessage(t *testing.T) {
 	}
 }
 
+func TestBuildSlackBlockMessage(t *testing.T) {
+	tests := []struct {
+		name            string
+		msg             *alertMsg
+		expectedHeader  string
+		expectedText    string
+		expectedFields  []SlackText
+		expectedContext int
+	}{
+		{
+			name: "alert message",
+			msg: &alertMsg{
+				chain:       "test-chain",
+				message:     "Test alert message",
+				severity:    "critical",
+				slkMentions: "@here",
+			},
+			expectedHeader: "TenderDuty 🚨 ALERT: test-chain",
+			expectedText:   "Test alert message\n@here",
+			expectedFields: []SlackText{
+				{Type: "mrkdwn", Text: "*Chain*\ntest-chain"},
+				{Type: "mrkdwn", Text: "*Severity*\ncritical"},
+				{Type: "mrkdwn", Text: "*Status*\nfiring"},
+			},
+			expectedContext: 1,
+		},
+		{
+			name: "resolved message with title prefix and footer",
+			msg: &alertMsg{
+				chain:          "test-chain",
+				message:        "Test resolved message",
+				severity:       "warning",
+				resolved:       true,
+				firingFor:      12 * time.Minute,
+				extraInfo:      "instance-a",
+				slkTitlePrefix: "[prod]",
+				slkFooter:      "td-eu-1",
+			},
+			expectedHeader: "[prod] TenderDuty 💜 Resolved: test-chain",
+			expectedText:   "Test resolved message\nResolved after 12m\ninstance-a",
+			expectedFields: []SlackText{
+				{Type: "mrkdwn", Text: "*Chain*\ntest-chain"},
+				{Type: "mrkdwn", Text: "*Severity*\nwarning"},
+				{Type: "mrkdwn", Text: "*Status*\nresolved"},
+			},
+			expectedContext: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := buildSlackBlockMessage(tt.msg)
+			if len(result.Blocks) != 3 {
+				t.Fatalf("expected a header, section and context block, got %+v", result.Blocks)
+			}
+			header, section, context := result.Blocks[0], result.Blocks[1], result.Blocks[2]
+			if header.Type != "header" || header.Text.Type != "plain_text" || header.Text.Text != tt.expectedHeader {
+				t.Errorf("expected header %q, got %+v", tt.expectedHeader, header.Text)
+			}
+			if section.Type != "section" || section.Text.Text != tt.expectedText {
+				t.Errorf("expected section text %q, got %+v", tt.expectedText, section.Text)
+			}
+			if !reflect.DeepEqual(section.Fields, tt.expectedFields) {
+				t.Errorf("expected fields %+v, got %+v", tt.expectedFields, section.Fields)
+			}
+			if context.Type != "context" || len(context.Elements) != tt.expectedContext || !strings.HasPrefix(context.Elements[0].Text, "<!date^") {
+				t.Errorf("expected the timestamp in the context block, got %+v", context.Elements)
+			}
+			if tt.msg.slkFooter != "" && context.Elements[1].Text != tt.msg.slkFooter {
+				t.Errorf("expected the footer in the context block, got %+v", context.Elements)
+			}
+			if !strings.Contains(result.Text, tt.msg.message) {
+				t.Errorf("expected the fallback text to contain the message, got %q", result.Text)
+			}
+		})
+	}
+}
+
 func TestBuildDiscordMessage(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -376,6 +819,61 @@ func TestBuildDiscordMessage(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "resolved message with duration and extra info",
+			msg: &alertMsg{
+				chain:     "test-chain",
+				message:   "Test resolved message",
+				resolved:  true,
+				firingFor: 3*time.Hour + 5*time.Minute,
+				extraInfo: "instance-a",
+			},
+			expected: &DiscordMessage{
+				Username: "Tenderduty",
+				Content:  "💜 Resolved: test-chain",
+				Embeds: []DiscordEmbed{
+					{
+						Description: "Test resolved message\nResolved after 3h5m\ninstance-a",
+					},
+				},
+			},
+		},
+		{
+			name: "alert message with extra info",
+			msg: &alertMsg{
+				chain:     "test-chain",
+				message:   "Test alert message",
+				extraInfo: "instance-a",
+			},
+			expected: &DiscordMessage{
+				Username: "Tenderduty",
+				Content:  "🚨 ALERT: test-chain",
+				Embeds: []DiscordEmbed{
+					{
+						Description: "Test alert message\ninstance-a",
+					},
+				},
+			},
+		},
+		{
+			name: "alert message with title prefix and footer",
+			msg: &alertMsg{
+				chain:           "test-chain",
+				message:         "Test alert message",
+				discTitlePrefix: "[prod]",
+				discFooter:      "td-eu-1",
+			},
+			expected: &DiscordMessage{
+				Username: "Tenderduty",
+				Content:  "[prod] 🚨 ALERT: test-chain",
+				Embeds: []DiscordEmbed{
+					{
+						Description: "Test alert message",
+						Footer:      &DiscordFooter{Text: "td-eu-1"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -388,320 +886,4247 @@ func TestBuildDiscordMessage(t *testing.T) {
 	}
 }
 
-func TestNotifySlack(t *testing.T) {
+func TestBuildTgMessage(t *testing.T) {
 	tests := []struct {
-		name           string
-		msg            *alertMsg
-		serverResponse int
-		expectError    bool
+		name     string
+		msg      *alertMsg
+		expected string
 	}{
 		{
-			name: "successful notification",
-			msg: &alertMsg{
-				slk:         true,
-				chain:       "test-chain",
-				message:     "test message",
-				resolved:    false,
-				slkMentions: "@here",
-				slkHook:     "", // will be set to test server URL
-			},
-			serverResponse: 200,
-			expectError:    false,
+			name:     "alert message",
+			msg:      &alertMsg{chain: "test-chain", message: "Test alert message"},
+			expected: "test-chain: 🚨 ALERT:  - Test alert message",
 		},
 		{
-			name: "server error",
-			msg: &alertMsg{
-				slk:         true,
-				chain:       "test-chain",
-				message:     "test message",
-				resolved:    false,
-				slkMentions: "@here",
-				slkHook:     "", // will be set to test server URL
-			},
-			serverResponse: 500,
-			expectError:    true,
+			name:     "resolved message with duration",
+			msg:      &alertMsg{chain: "test-chain", message: "Test resolved message", resolved: true, firingFor: 12*time.Minute + 20*time.Second},
+			expected: "test-chain: 💜 Resolved:  - Test resolved message\nResolved after 12m",
 		},
 		{
-			name: "slack disabled",
-			msg: &alertMsg{
-				slk: false,
-			},
-			expectError: false,
+			name:     "resolved message without a start time",
+			msg:      &alertMsg{chain: "test-chain", message: "Test resolved message", resolved: true},
+			expected: "test-chain: 💜 Resolved:  - Test resolved message",
+		},
+		{
+			name:     "alert message with title prefix and footer",
+			msg:      &alertMsg{chain: "test-chain", message: "Test alert message", tgTitlePrefix: "[prod]", tgFooter: "td-eu-1"},
+			expected: "[prod] test-chain: 🚨 ALERT:  - Test alert message\ntd-eu-1",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if tt.msg.slk {
-				// Create test server
-				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-					w.WriteHeader(tt.serverResponse)
-				}))
-				defer server.Close()
-				tt.msg.slkHook = server.URL
-			}
-
-			err := notifySlack(tt.msg)
-			if tt.expectError && err == nil {
-				t.Errorf("Expected error but got none")
-			}
-			if !tt.expectError && err != nil {
-				t.Errorf("Expected no error but got: %v", err)
+			if result := buildTgMessage(tt.msg); result != tt.expected {
+				t.Errorf("buildTgMessage() = %q, want %q", result, tt.expected)
 			}
 		})
 	}
 }
 
-func TestConfigAlert(t *testing.T) {
-	// Create test config
-	config := &Config{
-		alertChan: make(chan *alertMsg, 10),
-		chainsMux: sync.RWMutex{},
-		Chains: map[string]*ChainConfig{
-			"test-chain": {
-				ChainId:    "test-chain-1",
-				ValAddress: "testval123",
-				Alerts: AlertConfig{
-					Pagerduty: PDConfig{
-						Enabled: &[]bool{true}[0],
-						ApiKey:  "test-key",
-					},
-					Discord: DiscordConfig{
-						Enabled: &[]bool{false}[0],
-					},
-					Telegram: TeleConfig{
-						Enabled: &[]bool{true}[0],
-						ApiKey:  "tg-key",
-						Channel: "test-channel",
-					},
-					Slack: SlackConfig{
-						Enabled: &[]bool{false}[0],
-					},
-				},
-			},
+func TestBuildSNSPublishInput(t *testing.T) {
+	tests := []struct {
+		name            string
+		msg             *alertMsg
+		expectedSubject string
+		expectedMessage string
+	}{
+		{
+			name:            "alert message",
+			msg:             &alertMsg{chain: "test-chain (test-chain-1)", severity: "critical", message: "Test alert message", snsTopic: "arn:aws:sns:us-east-1:123456789012:td"},
+			expectedSubject: "TenderDuty ALERT [critical] test-chain (test-chain-1)",
+			expectedMessage: "Test alert message",
 		},
-		DefaultAlertConfig: AlertConfig{
-			Pagerduty: PDConfig{
-				Enabled: &[]bool{true}[0],
-			},
-			Discord: DiscordConfig{
-				Enabled: &[]bool{true}[0],
-			},
-			Telegram: TeleConfig{
-				Enabled: &[]bool{true}[0],
-			},
-			Slack: SlackConfig{
-				Enabled: &[]bool{true}[0],
-			},
+		{
+			name:            "resolved message with extra info",
+			msg:             &alertMsg{chain: "test-chain (test-chain-1)", severity: "warning", message: "Test resolved message", resolved: true, firingFor: 3 * time.Minute, extraInfo: "from instance a", snsTopic: "arn:aws:sns:us-east-1:123456789012:td"},
+			expectedSubject: "TenderDuty RESOLVED [warning] test-chain (test-chain-1)",
+			expectedMessage: "Test resolved message\nResolved after 3m\nfrom instance a",
 		},
-	}
-
-	alertID := "test_alert_id"
-	config.alert("test-chain", "test message", "critical", false, &alertID)
-
-	// Check that alert was sent to channel
-	select {
-	case alertMsg := <-config.alertChan:
-		if alertMsg.message != "test message" {
-			t.Errorf("Expected message 'test message', got '%s'", alertMsg.message)
-		}
-		if alertMsg.severity != "critical" {
-			t.Errorf("Expected severity 'critical', got '%s'", alertMsg.severity)
-		}
-		if alertMsg.uniqueId != "test_alert_id" {
-			t.Errorf("Expected uniqueId 'test_alert_id', got '%s'", alertMsg.uniqueId)
-		}
-		if alertMsg.pd != true {
-			t.Errorf("Expected pagerduty to be enabled")
-		}
-		if alertMsg.tg != true {
-			t.Errorf("Expected telegram to be enabled")
-		}
-		if alertMsg.disc != false {
-			t.Errorf("Expected discord to be disabled")
-		}
-		if alertMsg.slk != false {
-			t.Errorf("Expected slack to be disabled")
-		}
-	case <-time.After(time.Second):
-		t.Error("Alert was not sent to channel")
-	}
-}
-
-func TestApplyAlertDefaultsCustom(t *testing.T) {
-	// Create default config
-	defaultConfig := &AlertConfig{
-		Stalled:           &[]int{10}[0],
-		StalledAlerts:     &[]bool{true}[0],
-		ConsecutiveMissed: &[]int{5}[0],
-		ConsecutiveAlerts: &[]bool{true}[0],
-		Pagerduty: PDConfig{
-			Enabled:         &[]bool{true}[0],
-			DefaultSeverity: "critical",
+		{
+			name:            "subject is ascii and truncated",
+			msg:             &alertMsg{chain: "🚀 " + strings.Repeat("x", 120), severity: "info", message: "Test alert message", snsTopic: "arn:aws:sns:us-east-1:123456789012:td"},
+			expectedSubject: ("TenderDuty ALERT [info]  " + strings.Repeat("x", 120))[:snsSubjectMaxLen],
+			expectedMessage: "Test alert message",
 		},
-	}
-
-	// Create chain config with some values set
-	chainConfig := &AlertConfig{
-		Stalled: &[]int{15}[0], // This should not be overridden
-		// StalledAlerts not set, should get default
-		// ConsecutiveMissed not set, should get default
-		ConsecutiveAlerts: &[]bool{false}[0], // This should not be overridden
-		Pagerduty: PDConfig{
-			Enabled: &[]bool{false}[0], // This should not be overridden
-			// DefaultSeverity not set, should get default
+		{
+			name:            "alert message with title prefix and footer",
+			msg:             &alertMsg{chain: "test-chain (test-chain-1)", severity: "critical", message: "Test alert message", snsTitlePrefix: "[prod]", snsFooter: "td-eu-1", snsTopic: "arn:aws:sns:us-east-1:123456789012:td"},
+			expectedSubject: "[prod] TenderDuty ALERT [critical] test-chain (test-chain-1)",
+			expectedMessage: "Test alert message\ntd-eu-1",
 		},
 	}
 
-	applyAlertDefaults(chainConfig, defaultConfig)
-
-	// Check that zero values were filled in
-	if *chainConfig.Stalled != 15 {
-		t.Errorf("Expected Stalled to remain 15, got %d", *chainConfig.Stalled)
-	}
-	if *chainConfig.StalledAlerts != true {
-		t.Errorf("Expected StalledAlerts to be set to default true")
-	}
-	if *chainConfig.ConsecutiveMissed != 5 {
-		t.Errorf("Expected ConsecutiveMissed to be set to default 5, got %d", *chainConfig.ConsecutiveMissed)
-	}
-	if *chainConfig.ConsecutiveAlerts != false {
-		t.Errorf("Expected ConsecutiveAlerts to remain false")
-	}
-	if *chainConfig.Pagerduty.Enabled != false {
-		t.Errorf("Expected Pagerduty.Enabled to remain false")
-	}
-	if chainConfig.Pagerduty.DefaultSeverity != "critical" {
-		t.Errorf("Expected Pagerduty.DefaultSeverity to be set to default 'critical', got '%s'", chainConfig.Pagerduty.DefaultSeverity)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := buildSNSPublishInput(tt.msg)
+			if *input.Subject != tt.expectedSubject {
+				t.Errorf("subject = %q, want %q", *input.Subject, tt.expectedSubject)
+			}
+			if *input.Message != tt.expectedMessage {
+				t.Errorf("message = %q, want %q", *input.Message, tt.expectedMessage)
+			}
+			if *input.TopicArn != tt.msg.snsTopic {
+				t.Errorf("topic = %q, want %q", *input.TopicArn, tt.msg.snsTopic)
+			}
+		})
 	}
 }
 
-func TestValidateConfig(t *testing.T) {
+func TestSendExec(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out")
 	tests := []struct {
-		name          string
-		config        *Config
-		expectFatal   bool
-		expectWarning bool
-		description   string
+		name        string
+		args        []string
+		timeout     time.Duration
+		expectError bool
+		expected    string
 	}{
 		{
-			name: "valid config",
-			config: &Config{
-				EnableDash:  true,
-				Listen:      "http://localhost:8080",
-				NodeDownMin: 5,
-				DefaultAlertConfig: AlertConfig{
-					Pagerduty: PDConfig{
-						Enabled: &[]bool{true}[0],
-						ApiKey:  "ValidKeyWithoutSpecialChars",
-					},
-				},
-				GovernanceAlertsReminderInterval: 6,
-				Chains: map[string]*ChainConfig{
-					"test": {
-						ChainId: "test-1",
-					},
-				},
-			},
-			expectFatal:   false,
-			expectWarning: false,
-			description:   "Valid configuration should not produce errors or warnings",
+			name:     "should pass the alert in the environment",
+			args:     []string{"-c", `printf '%s|%s|%s|%s|%s' "$TD_CHAIN" "$TD_SEVERITY" "$TD_MESSAGE" "$TD_RESOLVED" "$TD_UNIQUE_ID" >"$0"`, out},
+			timeout:  5 * time.Second,
+			expected: "test-chain|critical|test message|true|TestAlert_testval123",
 		},
 		{
-			name: "invalid pagerduty key",
-			config: &Config{
-				DefaultAlertConfig: AlertConfig{
-					Pagerduty: PDConfig{
-						Enabled: &[]bool{true}[0],
-						ApiKey:  "invalid+key-with_special",
-					},
-				},
-				Chains: map[string]*ChainConfig{
-					"test": {
-						ChainId: "test-1",
-					},
-				},
-			},
-			expectFatal: true,
-			description: "Invalid PagerDuty key should produce fatal error",
+			name:        "should fail when the command fails",
+			args:        []string{"-c", "echo broken; exit 3"},
+			timeout:     5 * time.Second,
+			expectError: true,
 		},
 		{
-			name: "node down minutes too low",
-			config: &Config{
-				NodeDownMin: 2,
-				Chains: map[string]*ChainConfig{
-					"test": {
-						ChainId: "test-1",
-					},
-				},
-			},
-			expectWarning: true,
-			description:   "NodeDownMin < 3 should produce warning",
+			name:        "should fail when the command times out",
+			args:        []string{"-c", "sleep 5"},
+			timeout:     100 * time.Millisecond,
+			expectError: true,
 		},
 	}
 
+	defer func(timeout time.Duration) { execTimeout = timeout }(execTimeout)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Set default values that might be modified by validateConfig
-			if tt.config.GovernanceAlertsReminderInterval == 0 {
-				tt.config.GovernanceAlertsReminderInterval = 0 // Let validateConfig set it
+			execTimeout = tt.timeout
+			err := sendExec(&alertMsg{
+				exe:        true,
+				chainName:  "test-chain",
+				severity:   "critical",
+				message:    "test message",
+				resolved:   true,
+				uniqueId:   "TestAlert_testval123",
+				exeCommand: "sh",
+				exeArgs:    tt.args,
+			})
+			if (err != nil) != tt.expectError {
+				t.Fatalf("expected error %v, got %v", tt.expectError, err)
 			}
-
-			fatal, problems := validateConfig(tt.config)
-
-			if tt.expectFatal && !fatal {
-				t.Errorf("%s: Expected fatal error but got none. Problems: %v", tt.description, problems)
+			if tt.expected == "" {
+				return
 			}
-			if !tt.expectFatal && fatal {
-				t.Errorf("%s: Expected no fatal error but got one. Problems: %v", tt.description, problems)
+			b, err := os.ReadFile(out)
+			if err != nil {
+				t.Fatal(err)
 			}
-			if tt.expectWarning && len(problems) == 0 {
-				t.Errorf("%s: Expected warnings but got none", tt.description)
+			if string(b) != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, string(b))
 			}
+		})
+	}
+}
 
-			// Check that governance reminder interval is set to default when invalid
-			if tt.config.GovernanceAlertsReminderInterval <= 0 {
-				if tt.config.GovernanceAlertsReminderInterval != 6 {
-					t.Errorf("Expected GovernanceAlertsReminderInterval to be set to 6, got %d", tt.config.GovernanceAlertsReminderInterval)
-				}
+func TestFormatFiringDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		expected string
+	}{
+		{duration: 1500 * time.Millisecond, expected: "2s"},
+		{duration: 59*time.Second + 600*time.Millisecond, expected: "1m"},
+		{duration: 12*time.Minute + 20*time.Second, expected: "12m"},
+		{duration: 59*time.Minute + 40*time.Second, expected: "1h0m"},
+		{duration: 26*time.Hour + 5*time.Minute, expected: "26h5m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if result := formatFiringDuration(tt.duration); result != tt.expected {
+				t.Errorf("formatFiringDuration(%s) = %s, want %s", tt.duration, result, tt.expected)
 			}
 		})
 	}
 }
 
-// TestChainConfigMkUpdate tests the mkUpdate method
-func TestChainConfigMkUpdate(t *testing.T) {
-	cc := &ChainConfig{
-		name:    "test-chain",
-		ChainId: "test-chain-1",
-		valInfo: &ValInfo{
-			Moniker: "test-validator",
-		},
+func TestResolvedTargets(t *testing.T) {
+	c := createTestConfig()
+	alerts := &c.Chains["test-chain"].Alerts
+
+	tests := []struct {
+		name             string
+		resolvedOverride bool
+		resolved         bool
+		expectedDiscord  string
+		expectedSlack    string
+		expectedTelegram string
+	}{
+		{name: "alerts go to the usual targets", resolvedOverride: true, expectedDiscord: "https://discord.example.com/oncall", expectedSlack: "https://slack.example.com/oncall", expectedTelegram: "-100"},
+		{name: "resolves go to the resolved targets", resolvedOverride: true, resolved: true, expectedDiscord: "https://discord.example.com/log", expectedSlack: "https://slack.example.com/log", expectedTelegram: "-200"},
+		{name: "resolves use the usual targets without overrides", resolved: true, expectedDiscord: "https://discord.example.com/oncall", expectedSlack: "https://slack.example.com/oncall", expectedTelegram: "-100"},
 	}
 
-	update := cc.mkUpdate(metricNodeDownSeconds, 123.45, "http://node1.example.com")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alerts.Discord.Webhook, alerts.Discord.ResolvedWebhook = "https://discord.example.com/oncall", ""
+			alerts.Slack.Webhook, alerts.Slack.ResolvedWebhook = "https://slack.example.com/oncall", ""
+			alerts.Telegram.Channel, alerts.Telegram.ResolvedChannel = "-100", ""
+			if tt.resolvedOverride {
+				alerts.Discord.ResolvedWebhook = "https://discord.example.com/log"
+				alerts.Slack.ResolvedWebhook = "https://slack.example.com/log"
+				alerts.Telegram.ResolvedChannel = "-200"
+			}
 
-	if update.metric != metricNodeDownSeconds {
+			msg := c.newAlertMsg("test-chain", "test alert", "critical", tt.resolved, "ChainStalled_testval123", 0)
+			if msg.discHook != tt.expectedDiscord {
+				t.Errorf("expected discord webhook %s, got %s", tt.expectedDiscord, msg.discHook)
+			}
+			if msg.slkHook != tt.expectedSlack {
+				t.Errorf("expected slack webhook %s, got %s", tt.expectedSlack, msg.slkHook)
+			}
+			if msg.tgChannel != tt.expectedTelegram {
+				t.Errorf("expected telegram channel %s, got %s", tt.expectedTelegram, msg.tgChannel)
+			}
+		})
+	}
+}
+
+func TestRunbookLinks(t *testing.T) {
+	c := createTestConfig()
+	c.Runbooks = map[string]string{
+		"ChainStalled":            "https://runbooks.example.com/chain-stalled",
+		"PriceConversionDisabled": "https://runbooks.example.com/prices",
+	}
+
+	tests := []struct {
+		name     string
+		id       string
+		expected string
+	}{
+		{name: "should link the runbook of the alert type", id: "ChainStalled_testval123", expected: "https://runbooks.example.com/chain-stalled"},
+		{name: "should match an id without a suffix", id: "PriceConversionDisabled", expected: "https://runbooks.example.com/prices"},
+		{name: "should not link another alert type", id: "RPCNodeDown_testval123_http://node1.example.com"},
+		{name: "should not match a longer alert name", id: "ChainStalledAgain_testval123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := c.newAlertMsg("test-chain", "test alert", "critical", false, tt.id, 0)
+			if msg.runbook != tt.expected {
+				t.Fatalf("expected runbook %q, got %q", tt.expected, msg.runbook)
+			}
+
+			if link := buildSlackMessage(msg).Attachments[0].TitleLink; link != tt.expected {
+				t.Errorf("expected the slack title link %q, got %q", tt.expected, link)
+			}
+			if embed := buildDiscordMessage(msg).Embeds[0]; embed.Url != tt.expected || (tt.expected != "") != (embed.Title != "") {
+				t.Errorf("expected the discord embed to link %q, got %+v", tt.expected, embed)
+			}
+			event := buildPagerdutyEvent(msg)
+			switch {
+			case tt.expected == "" && event.Links != nil:
+				t.Errorf("expected no pagerduty links, got %v", event.Links)
+			case tt.expected != "" && !reflect.DeepEqual(event.Links, []interface{}{map[string]string{"href": tt.expected, "text": "Runbook"}}):
+				t.Errorf("expected a pagerduty link to %q, got %v", tt.expected, event.Links)
+			}
+		})
+	}
+}
+
+func TestNotice(t *testing.T) {
+	testAlarms := &alarmCache{
+		SentPdAlarms:   make(map[string]alertMsgCache),
+		SentTgAlarms:   make(map[string]alertMsgCache),
+		AllAlarms:      make(map[string]map[string]alertMsgCache),
+		flappingAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux:      sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	td.history = newMemoryAlertHistory(10)
+	defer func() { td = originalTd }()
+
+	td.notice("test-chain", "moniker changed", "critical", "MonikerChange_testval123_1")
+	if len(td.alertChan) != 1 {
+		t.Fatalf("expected one notification, got %d", len(td.alertChan))
+	}
+	msg := <-td.alertChan
+	msg.alertConfig = &AlertConfig{
+		Pagerduty: PDConfig{SeverityThreshold: "critical"},
+		Telegram:  TeleConfig{SeverityThreshold: "critical"},
+	}
+
+	for _, dest := range []notifyDest{pd, tg} {
+		for i := 0; i < 2; i++ {
+			if !shouldNotify(msg, dest) {
+				t.Errorf("expected the notice to be sent to %d every time", dest)
+			}
+		}
+	}
+	if len(testAlarms.SentPdAlarms) != 0 || len(testAlarms.SentTgAlarms) != 0 {
+		t.Errorf("expected the notice not to be recorded as sent, got %v and %v", testAlarms.SentPdAlarms, testAlarms.SentTgAlarms)
+	}
+	if len(testAlarms.AllAlarms["test-chain"]) != 0 {
+		t.Errorf("expected no active alarms, got %v", testAlarms.AllAlarms["test-chain"])
+	}
+
+	events := pagerdutyEvents(msg)
+	if len(events) != 2 || events[0].Action != "trigger" || events[1].Action != "resolve" || events[0].DedupKey != events[1].DedupKey {
+		t.Errorf("expected the pagerduty incident to be opened and resolved, got %+v", events)
+	}
+	msg.tgButtons = true
+	if buildTgKeyboard(msg) != nil {
+		t.Error("expected no telegram buttons for a notice")
+	}
+
+	history := td.history.since("test-chain", time.Time{})
+	if len(history) != 2 || history[0].Resolved || !history[1].Resolved {
+		t.Errorf("expected the history to show the notice fired and resolved, got %+v", history)
+	}
+}
+
+func TestBuildPagerdutyEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      *alertMsg
+		expected pagerduty.V2Event
+	}{
+		{
+			name: "trigger with extra info",
+			msg: &alertMsg{
+				key:       "routing-key",
+				chain:     "Osmosis (osmosis-1)",
+				chainId:   "osmosis-1",
+				message:   "Test alert message",
+				severity:  "critical",
+				uniqueId:  "ChainStalled_osmovaloper1",
+				extraInfo: "instance-a",
+			},
+			expected: pagerduty.V2Event{
+				RoutingKey: "routing-key",
+				Action:     "trigger",
+				DedupKey:   "osmosis-1_ChainStalled_osmovaloper1",
+				Payload: &pagerduty.V2Payload{
+					Summary:  "Test alert message",
+					Source:   "ChainStalled_osmovaloper1",
+					Severity: "critical",
+					Details:  map[string]string{"extra_info": "instance-a"},
+				},
+			},
+		},
+		{
+			name: "resolve without extra info",
+			msg: &alertMsg{
+				key:       "routing-key",
+				chain:     "osmo (osmosis-1)",
+				chainId:   "osmosis-1",
+				message:   "Test alert message",
+				severity:  "critical",
+				uniqueId:  "ChainStalled_osmovaloper1",
+				resolved:  true,
+				firingFor: 45 * time.Second,
+			},
+			expected: pagerduty.V2Event{
+				RoutingKey: "routing-key",
+				Action:     "resolve",
+				DedupKey:   "osmosis-1_ChainStalled_osmovaloper1",
+				Payload: &pagerduty.V2Payload{
+					Summary:  "Test alert message\nResolved after 45s",
+					Source:   "ChainStalled_osmovaloper1",
+					Severity: "critical",
+				},
+			},
+		},
+		{
+			name: "trigger with title prefix and footer",
+			msg: &alertMsg{
+				key:           "routing-key",
+				chain:         "Osmosis (osmosis-1)",
+				chainId:       "osmosis-1",
+				message:       "Test alert message",
+				severity:      "critical",
+				uniqueId:      "ChainStalled_osmovaloper1",
+				pdTitlePrefix: "[prod]",
+				pdFooter:      "td-eu-1",
+			},
+			expected: pagerduty.V2Event{
+				RoutingKey: "routing-key",
+				Action:     "trigger",
+				DedupKey:   "osmosis-1_ChainStalled_osmovaloper1",
+				Payload: &pagerduty.V2Payload{
+					Summary:   "[prod] Test alert message",
+					Source:    "ChainStalled_osmovaloper1",
+					Severity:  "critical",
+					Group:     "[prod]",
+					Component: "td-eu-1",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := buildPagerdutyEvent(tt.msg)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("buildPagerdutyEvent() = %+v, want %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPagerdutyDedupKeyIgnoresChainName(t *testing.T) {
+	a := &alertMsg{chain: "Osmosis (osmosis-1)", chainId: "osmosis-1", uniqueId: "ChainStalled_osmovaloper1"}
+	b := &alertMsg{chain: "osmo-backup (osmosis-1)", chainId: "osmosis-1", uniqueId: "ChainStalled_osmovaloper1"}
+	if pagerdutyDedupKey(a) != pagerdutyDedupKey(b) {
+		t.Errorf("expected the same dedup key for both instances, got %s and %s", pagerdutyDedupKey(a), pagerdutyDedupKey(b))
+	}
+}
+
+func TestPagerdutyDedupKeyOfOpenIncident(t *testing.T) {
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	const id = "ChainStalled_osmovaloper1"
+	tests := []struct {
+		name     string
+		sent     *alertMsgCache
+		resolved bool
+		expected string
+	}{
+		{
+			name:     "should open an incident with the chain-id key",
+			expected: "osmosis-1_" + id,
+		},
+		{
+			name:     "should resolve with the key the incident was opened with",
+			sent:     &alertMsgCache{Message: "stalled", SentTime: time.Now().Add(-time.Hour), DedupKey: "osmosis-1_" + id},
+			resolved: true,
+			expected: "osmosis-1_" + id,
+		},
+		{
+			name:     "should resolve an incident restored from an older state file with the alarm ID",
+			sent:     &alertMsgCache{Message: "stalled", SentTime: time.Now().Add(-time.Hour)},
+			resolved: true,
+			expected: id,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAlarms.SentPdAlarms = make(map[string]alertMsgCache)
+			testAlarms.flappingAlarms = make(map[string]map[string]alertMsgCache)
+			if tt.sent != nil {
+				testAlarms.SentPdAlarms[id] = *tt.sent
+			}
+
+			msg := &alertMsg{
+				pd:          true,
+				chain:       "Osmosis (osmosis-1)",
+				chainName:   "Osmosis",
+				chainId:     "osmosis-1",
+				uniqueId:    id,
+				severity:    "critical",
+				resolved:    tt.resolved,
+				alertConfig: &AlertConfig{Pagerduty: PDConfig{SeverityThreshold: "critical"}},
+			}
+			if !shouldNotify(msg, pd) {
+				t.Fatal("expected the pagerduty notification to be sent")
+			}
+			if key := buildPagerdutyEvent(msg).DedupKey; key != tt.expected {
+				t.Errorf("expected dedup key %s, got %s", tt.expected, key)
+			}
+			if !tt.resolved && testAlarms.SentPdAlarms[id].DedupKey != tt.expected {
+				t.Errorf("expected the dedup key to be stored, got %q", testAlarms.SentPdAlarms[id].DedupKey)
+			}
+		})
+	}
+}
+
+func TestNotifySlack(t *testing.T) {
+	testAlarms := &alarmCache{
+		SentSlkAlarms:  make(map[string]alertMsgCache),
+		AllAlarms:      make(map[string]map[string]alertMsgCache),
+		flappingAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux:      sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	tests := []struct {
+		name           string
+		msg            *alertMsg
+		serverResponse int
+		expectError    bool
+	}{
+		{
+			name: "successful notification",
+			msg: &alertMsg{
+				slk:         true,
+				chain:       "test-chain",
+				message:     "test message",
+				uniqueId:    "test_alert_1",
+				severity:    "critical",
+				resolved:    false,
+				slkMentions: "@here",
+				slkHook:     "", // will be set to test server URL
+				alertConfig: &AlertConfig{},
+			},
+			serverResponse: 200,
+			expectError:    false,
+		},
+		{
+			name: "server error",
+			msg: &alertMsg{
+				slk:         true,
+				chain:       "test-chain",
+				message:     "test message",
+				uniqueId:    "test_alert_2",
+				severity:    "critical",
+				resolved:    false,
+				slkMentions: "@here",
+				slkHook:     "", // will be set to test server URL
+				alertConfig: &AlertConfig{},
+			},
+			serverResponse: 500,
+			expectError:    true,
+		},
+		{
+			name: "slack disabled",
+			msg: &alertMsg{
+				slk: false,
+			},
+			expectError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.msg.slk {
+				// Create test server
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(tt.serverResponse)
+				}))
+				defer server.Close()
+				tt.msg.slkHook = server.URL
+			}
+
+			err := notifySlack(tt.msg)
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestRetryNotification(t *testing.T) {
+	originalBackoff := notifyRetryBackoff
+	notifyRetryBackoff = time.Millisecond
+	defer func() { notifyRetryBackoff = originalBackoff }()
+
+	tests := []struct {
+		name             string
+		dest             string
+		resolved         bool
+		failures         int
+		maxRetries       int
+		expectError      bool
+		expectedRequests int
+	}{
+		{
+			name:             "slack recovers before the retries run out",
+			dest:             "slack",
+			failures:         2,
+			maxRetries:       3,
+			expectedRequests: 3,
+		},
+		{
+			name:             "slack is dropped after the last retry",
+			dest:             "slack",
+			failures:         10,
+			maxRetries:       2,
+			expectError:      true,
+			expectedRequests: 2,
+		},
+		{
+			name:             "discord resolution is retried",
+			dest:             "discord",
+			resolved:         true,
+			failures:         1,
+			maxRetries:       3,
+			expectedRequests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			var bodies []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				b, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(b))
+				if requests <= tt.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				if tt.dest == "discord" {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			msg := &alertMsg{
+				slk:      true,
+				disc:     true,
+				chain:    "test-chain",
+				message:  "test message",
+				resolved: tt.resolved,
+				slkHook:  server.URL,
+				discHook: server.URL,
+			}
+			var n notifier
+			for _, candidate := range notifiers {
+				if candidate.name == tt.dest {
+					n = candidate
+				}
+			}
+
+			err := retryNotification(msg, n, tt.maxRetries)
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+			if requests != tt.expectedRequests {
+				t.Errorf("expected %d requests, got %d", tt.expectedRequests, requests)
+			}
+			for _, body := range bodies {
+				if tt.resolved && !strings.Contains(body, "Resolved") {
+					t.Errorf("expected every retry to be a resolution, got %s", body)
+				}
+				if strings.Contains(body, "OK: OK:") {
+					t.Errorf("message was modified between retries: %s", body)
+				}
+			}
+		})
+	}
+}
+
+func TestSendDiscordRateLimited(t *testing.T) {
+	tests := []struct {
+		name             string
+		responses        []int
+		expectError      bool
+		expectedRequests int
+	}{
+		{
+			name:             "retried once after a 429",
+			responses:        []int{http.StatusTooManyRequests, http.StatusNoContent},
+			expectedRequests: 2,
+		},
+		{
+			name:             "gives up after a second 429",
+			responses:        []int{http.StatusTooManyRequests, http.StatusTooManyRequests},
+			expectError:      true,
+			expectedRequests: 2,
+		},
+		{
+			name:             "other errors are not retried",
+			responses:        []int{http.StatusInternalServerError},
+			expectError:      true,
+			expectedRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			var sent []time.Time
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				sent = append(sent, time.Now())
+				status := tt.responses[requests]
+				requests++
+				if status == http.StatusTooManyRequests {
+					w.Header().Set("Retry-After", "0.2")
+				}
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			err := sendDiscord(&alertMsg{disc: true, chain: "test-chain", message: "test message", discHook: server.URL})
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+			if requests != tt.expectedRequests {
+				t.Errorf("expected %d requests, got %d", tt.expectedRequests, requests)
+			}
+			if len(sent) > 1 && sent[1].Sub(sent[0]) < 200*time.Millisecond {
+				t.Errorf("retry was sent after %s, before Retry-After passed", sent[1].Sub(sent[0]))
+			}
+		})
+	}
+}
+
+func TestDiscordRateLimitsRemaining(t *testing.T) {
+	limits := &discordRateLimits{nextAllow: make(map[string]time.Time)}
+	resp := &http.Response{StatusCode: http.StatusNoContent, Header: http.Header{}}
+	resp.Header.Set("X-RateLimit-Remaining", "0")
+	resp.Header.Set("X-RateLimit-Reset-After", "0.2")
+	limits.update("hook", resp)
+
+	start := time.Now()
+	limits.wait("hook")
+	if waited := time.Since(start); waited < 150*time.Millisecond {
+		t.Errorf("expected to wait for the bucket to reset, waited %s", waited)
+	}
+
+	resp.Header.Set("X-RateLimit-Remaining", "4")
+	limits.update("hook", resp)
+	if _, ok := limits.nextAllow["hook"]; ok {
+		t.Error("expected no wait while requests remain")
+	}
+}
+
+func TestNotifyTgReusesBot(t *testing.T) {
+	testAlarms := &alarmCache{
+		SentTgAlarms:   make(map[string]alertMsgCache),
+		AllAlarms:      make(map[string]map[string]alertMsgCache),
+		flappingAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux:      sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	var getMeCalls, sendCalls int
+	var callsMux sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callsMux.Lock()
+		defer callsMux.Unlock()
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/getMe"):
+			getMeCalls++
+			_, _ = w.Write([]byte(`{"ok":true,"result":{"id":1,"is_bot":true,"first_name":"tenderduty","username":"tenderduty_bot"}}`))
+		case strings.HasSuffix(r.URL.Path, "/sendMessage"):
+			sendCalls++
+			_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":1,"date":0,"chat":{"id":1,"type":"channel"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	originalEndpoint := tgApiEndpoint
+	tgApiEndpoint = server.URL + "/bot%s/%s"
+	defer func() { tgApiEndpoint = originalEndpoint }()
+	defer dropTgBot("test-token")
+
+	for i := 0; i < 3; i++ {
+		err := notifyTg(&alertMsg{
+			tg:          true,
+			chain:       "test-chain",
+			message:     "test message",
+			uniqueId:    fmt.Sprintf("test-alert-%d", i),
+			severity:    "critical",
+			tgKey:       "test-token",
+			tgChannel:   "@test",
+			alertConfig: &AlertConfig{},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if getMeCalls != 1 {
+		t.Errorf("expected the bot to be created once, getMe was called %d times", getMeCalls)
+	}
+	if sendCalls != 3 {
+		t.Errorf("expected 3 messages to be sent, got %d", sendCalls)
+	}
+}
+
+func TestConfigAlert(t *testing.T) {
+	// Create test config
+	config := &Config{
+		alertChan: make(chan *alertMsg, 10),
+		chainsMux: sync.RWMutex{},
+		Chains: map[string]*ChainConfig{
+			"test-chain": {
+				ChainId:    "test-chain-1",
+				ValAddress: "testval123",
+				Alerts: AlertConfig{
+					Pagerduty: PDConfig{
+						Enabled: &[]bool{true}[0],
+						ApiKey:  "test-key",
+					},
+					Discord: DiscordConfig{
+						Enabled: &[]bool{false}[0],
+					},
+					Telegram: TeleConfig{
+						Enabled: &[]bool{true}[0],
+						ApiKey:  "tg-key",
+						Channel: "test-channel",
+					},
+					Slack: SlackConfig{
+						Enabled: &[]bool{false}[0],
+					},
+				},
+			},
+		},
+		DefaultAlertConfig: AlertConfig{
+			Pagerduty: PDConfig{
+				Enabled: &[]bool{true}[0],
+			},
+			Discord: DiscordConfig{
+				Enabled: &[]bool{true}[0],
+			},
+			Telegram: TeleConfig{
+				Enabled: &[]bool{true}[0],
+			},
+			Slack: SlackConfig{
+				Enabled: &[]bool{true}[0],
+			},
+		},
+	}
+
+	alertID := "test_alert_id"
+	config.alert("test-chain", "test message", "critical", false, &alertID)
+
+	// Check that alert was sent to channel
+	select {
+	case alertMsg := <-config.alertChan:
+		if alertMsg.message != "test message" {
+			t.Errorf("Expected message 'test message', got '%s'", alertMsg.message)
+		}
+		if alertMsg.severity != "critical" {
+			t.Errorf("Expected severity 'critical', got '%s'", alertMsg.severity)
+		}
+		if alertMsg.uniqueId != "test_alert_id" {
+			t.Errorf("Expected uniqueId 'test_alert_id', got '%s'", alertMsg.uniqueId)
+		}
+		if alertMsg.pd != true {
+			t.Errorf("Expected pagerduty to be enabled")
+		}
+		if alertMsg.tg != true {
+			t.Errorf("Expected telegram to be enabled")
+		}
+		if alertMsg.disc != false {
+			t.Errorf("Expected discord to be disabled")
+		}
+		if alertMsg.slk != false {
+			t.Errorf("Expected slack to be disabled")
+		}
+	case <-time.After(time.Second):
+		t.Error("Alert was not sent to channel")
+	}
+}
+
+func TestConfigAlertQuietHours(t *testing.T) {
+	// a two hour window around the current time
+	now := time.Now().UTC()
+	inWindow := QuietHoursConfig{Enabled: true, Start: now.Add(-time.Hour).Format("15:04"), End: now.Add(time.Hour).Format("15:04")}
+	outOfWindow := QuietHoursConfig{Enabled: true, Start: now.Add(time.Hour).Format("15:04"), End: now.Add(2 * time.Hour).Format("15:04")}
+
+	tests := []struct {
+		name     string
+		quiet    QuietHoursConfig
+		severity string
+		resolved bool
+		sent     bool
+	}{
+		{name: "warning in the window is held back", quiet: inWindow, severity: "warning", sent: false},
+		{name: "info in the window is held back", quiet: inWindow, severity: "info", sent: false},
+		{name: "critical in the window is sent", quiet: inWindow, severity: "critical", sent: true},
+		{name: "resolve in the window is sent", quiet: inWindow, severity: "warning", resolved: true, sent: true},
+		{name: "warning outside the window is sent", quiet: outOfWindow, severity: "warning", sent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalAlarms := alarms
+			defer func() { alarms = originalAlarms }()
+			alarms = &alarmCache{AllAlarms: make(map[string]map[string]alertMsgCache), notifyMux: sync.RWMutex{}}
+
+			config := createTestConfig()
+			config.QuietHours = tt.quiet
+			if err := config.QuietHours.parse(); err != nil {
+				t.Fatal(err)
+			}
+			alertID := "QuietHours_testval123"
+			if tt.resolved {
+				alarms.AllAlarms["test-chain"] = map[string]alertMsgCache{alertID: {Message: "test message", SentTime: time.Now()}}
+			}
+			config.alert("test-chain", "test message", tt.severity, tt.resolved, &alertID)
+
+			if sent := len(config.alertChan) == 1; sent != tt.sent {
+				t.Errorf("expected sent %v, got %v", tt.sent, sent)
+			}
+			// the alarm is recorded either way so that it can resolve later
+			if active := alarms.exist("test-chain", alertID); active == tt.resolved {
+				t.Errorf("expected the alarm to be active %v, got %v", !tt.resolved, active)
+			}
+		})
+	}
+}
+
+func TestApplyAlertDefaultsCustom(t *testing.T) {
+	// Create default config
+	defaultConfig := &AlertConfig{
+		Stalled:           &[]int{10}[0],
+		StalledAlerts:     &[]bool{true}[0],
+		ConsecutiveMissed: &[]int{5}[0],
+		ConsecutiveAlerts: &[]bool{true}[0],
+		Pagerduty: PDConfig{
+			Enabled:         &[]bool{true}[0],
+			DefaultSeverity: "critical",
+		},
+	}
+
+	// Create chain config with some values set
+	chainConfig := &AlertConfig{
+		Stalled: &[]int{15}[0], // This should not be overridden
+		// StalledAlerts not set, should get default
+		// ConsecutiveMissed not set, should get default
+		ConsecutiveAlerts: &[]bool{false}[0], // This should not be overridden
+		Pagerduty: PDConfig{
+			Enabled: &[]bool{false}[0], // This should not be overridden
+			// DefaultSeverity not set, should get default
+		},
+	}
+
+	applyAlertDefaults(chainConfig, defaultConfig)
+
+	// Check that zero values were filled in
+	if *chainConfig.Stalled != 15 {
+		t.Errorf("Expected Stalled to remain 15, got %d", *chainConfig.Stalled)
+	}
+	if *chainConfig.StalledAlerts != true {
+		t.Errorf("Expected StalledAlerts to be set to default true")
+	}
+	if *chainConfig.ConsecutiveMissed != 5 {
+		t.Errorf("Expected ConsecutiveMissed to be set to default 5, got %d", *chainConfig.ConsecutiveMissed)
+	}
+	if *chainConfig.ConsecutiveAlerts != false {
+		t.Errorf("Expected ConsecutiveAlerts to remain false")
+	}
+	if *chainConfig.Pagerduty.Enabled != false {
+		t.Errorf("Expected Pagerduty.Enabled to remain false")
+	}
+	if chainConfig.Pagerduty.DefaultSeverity != "critical" {
+		t.Errorf("Expected Pagerduty.DefaultSeverity to be set to default 'critical', got '%s'", chainConfig.Pagerduty.DefaultSeverity)
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        *Config
+		expectFatal   bool
+		expectWarning bool
+		description   string
+	}{
+		{
+			name: "valid config",
+			config: &Config{
+				EnableDash:  true,
+				Listen:      "http://localhost:8080",
+				NodeDownMin: 5,
+				DefaultAlertConfig: AlertConfig{
+					Pagerduty: PDConfig{
+						Enabled: &[]bool{true}[0],
+						ApiKey:  "ValidKeyWithoutSpecialChars",
+					},
+				},
+				GovernanceAlertsReminderInterval: 6,
+				Chains: map[string]*ChainConfig{
+					"test": {
+						ChainId: "test-1",
+					},
+				},
+			},
+			expectFatal:   false,
+			expectWarning: false,
+			description:   "Valid configuration should not produce errors or warnings",
+		},
+		{
+			name: "invalid pagerduty key",
+			config: &Config{
+				DefaultAlertConfig: AlertConfig{
+					Pagerduty: PDConfig{
+						Enabled: &[]bool{true}[0],
+						ApiKey:  "invalid+key-with_special",
+					},
+				},
+				Chains: map[string]*ChainConfig{
+					"test": {
+						ChainId: "test-1",
+					},
+				},
+			},
+			expectFatal: true,
+			description: "Invalid PagerDuty key should produce fatal error",
+		},
+		{
+			name: "node down minutes too low",
+			config: &Config{
+				NodeDownMin: 2,
+				Chains: map[string]*ChainConfig{
+					"test": {
+						ChainId: "test-1",
+					},
+				},
+			},
+			expectWarning: true,
+			description:   "NodeDownMin < 3 should produce warning",
+		},
+		{
+			name: "invalid log format",
+			config: &Config{
+				NodeDownMin: 5,
+				LogFormat:   "xml",
+				Chains: map[string]*ChainConfig{
+					"test": {
+						ChainId: "test-1",
+					},
+				},
+			},
+			expectFatal: true,
+			description: "Unknown log_format should produce fatal error",
+		},
+		{
+			name: "invalid log level",
+			config: &Config{
+				NodeDownMin: 5,
+				LogLevel:    "verbose",
+				Chains: map[string]*ChainConfig{
+					"test": {
+						ChainId: "test-1",
+					},
+				},
+			},
+			expectFatal: true,
+			description: "Unknown log_level should produce fatal error",
+		},
+		{
+			name: "unknown alert priority",
+			config: &Config{
+				NodeDownMin: 5,
+				Chains: map[string]*ChainConfig{
+					"test": {
+						ChainId: "test-1",
+						Alerts:  AlertConfig{ConsecutivePriority: "urgent"},
+					},
+				},
+			},
+			expectFatal: true,
+			description: "A priority that isn't a known severity should produce fatal error",
+		},
+		{
+			name: "block history size out of range",
+			config: &Config{
+				NodeDownMin:      5,
+				BlockHistorySize: 10,
+				Chains: map[string]*ChainConfig{
+					"test": {
+						ChainId: "test-1",
+					},
+				},
+			},
+			expectWarning: true,
+			description:   "block_history_size below the minimum should produce warning",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set default values that might be modified by validateConfig
+			if tt.config.GovernanceAlertsReminderInterval == 0 {
+				tt.config.GovernanceAlertsReminderInterval = 0 // Let validateConfig set it
+			}
+
+			fatal, problems := validateConfig(tt.config)
+
+			if tt.expectFatal && !fatal {
+				t.Errorf("%s: Expected fatal error but got none. Problems: %v", tt.description, problems)
+			}
+			if !tt.expectFatal && fatal {
+				t.Errorf("%s: Expected no fatal error but got one. Problems: %v", tt.description, problems)
+			}
+			if tt.expectWarning && len(problems) == 0 {
+				t.Errorf("%s: Expected warnings but got none", tt.description)
+			}
+
+			// Check that governance reminder interval is set to default when invalid
+			if tt.config.GovernanceAlertsReminderInterval <= 0 {
+				if tt.config.GovernanceAlertsReminderInterval != 6 {
+					t.Errorf("Expected GovernanceAlertsReminderInterval to be set to 6, got %d", tt.config.GovernanceAlertsReminderInterval)
+				}
+			}
+		})
+	}
+}
+
+// TestChainConfigMkUpdate tests the mkUpdate method
+func TestChainConfigMkUpdate(t *testing.T) {
+	cc := &ChainConfig{
+		name:    "test-chain",
+		ChainId: "test-chain-1",
+		valInfo: &ValInfo{
+			Moniker: "test-validator",
+		},
+	}
+
+	update := cc.mkUpdate(metricNodeDownSeconds, 123.45, "http://node1.example.com")
+
+	if update.metric != metricNodeDownSeconds {
 		t.Errorf("Expected metric to be metricNodeDownSeconds")
 	}
-	if update.counter != 123.45 {
-		t.Errorf("Expected counter to be 123.45, got %f", update.counter)
+	if update.counter != 123.45 {
+		t.Errorf("Expected counter to be 123.45, got %f", update.counter)
+	}
+	if update.name != "test-chain" {
+		t.Errorf("Expected name to be 'test-chain', got '%s'", update.name)
+	}
+	if update.chainId != "test-chain-1" {
+		t.Errorf("Expected chainId to be 'test-chain-1', got '%s'", update.chainId)
+	}
+	if update.moniker != "test-validator" {
+		t.Errorf("Expected moniker to be 'test-validator', got '%s'", update.moniker)
+	}
+	if update.endpoint != "http://node1.example.com" {
+		t.Errorf("Expected endpoint to be 'http://node1.example.com', got '%s'", update.endpoint)
+	}
+}
+
+func TestEvaluateConsecutiveBlocksMissedAlert(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	// Setup test td
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name                   string
+		consecutiveMiss        float64
+		consecutiveMissedAlert int
+		existingAlert          bool
+		expectedAlert          bool
+		expectedResolved       bool
+		description            string
+	}{
+		{
+			name:                   "should trigger alert when consecutive misses exceed threshold",
+			consecutiveMiss:        5,
+			consecutiveMissedAlert: 3,
+			existingAlert:          false,
+			expectedAlert:          true,
+			expectedResolved:       false,
+			description:            "Alert should trigger when consecutive misses exceed threshold",
+		},
+		{
+			name:                   "should not trigger duplicate alert",
+			consecutiveMiss:        5,
+			consecutiveMissedAlert: 3,
+			existingAlert:          true,
+			expectedAlert:          false,
+			expectedResolved:       false,
+			description:            "Should not trigger duplicate alert when already exists",
+		},
+		{
+			name:                   "should resolve alert when consecutive misses drop below threshold",
+			consecutiveMiss:        2,
+			consecutiveMissedAlert: 3,
+			existingAlert:          true,
+			expectedAlert:          false,
+			expectedResolved:       true,
+			description:            "Should resolve alert when consecutive misses drop below threshold",
+		},
+		{
+			name:                   "should not resolve non-existing alert",
+			consecutiveMiss:        2,
+			consecutiveMissedAlert: 3,
+			existingAlert:          false,
+			expectedAlert:          false,
+			expectedResolved:       false,
+			description:            "Should not resolve alert that doesn't exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset alarms for each test
+			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+
+			cc := &ChainConfig{
+				name:                "test-chain",
+				ChainId:             "test-chain-1",
+				ValAddress:          "testval123",
+				statConsecutiveMiss: tt.consecutiveMiss,
+				valInfo:             &ValInfo{Moniker: "test-validator"},
+				Alerts: AlertConfig{
+					ConsecutiveMissed:   &tt.consecutiveMissedAlert,
+					ConsecutivePriority: "critical",
+				},
+			}
+
+			if tt.existingAlert {
+				testAlarms.AllAlarms["test-chain"] = make(map[string]alertMsgCache)
+				testAlarms.AllAlarms["test-chain"]["ConsecutiveBlocksMissed_testval123"] = alertMsgCache{
+					Message:  "test alert",
+					SentTime: time.Now(),
+				}
+			}
+
+			alert, resolved := evaluateConsecutiveBlocksMissedAlert(cc)
+
+			if alert != tt.expectedAlert {
+				t.Errorf("%s: expected alert %v, got %v", tt.description, tt.expectedAlert, alert)
+			}
+			if resolved != tt.expectedResolved {
+				t.Errorf("%s: expected resolved %v, got %v", tt.description, tt.expectedResolved, resolved)
+			}
+		})
+	}
+}
+
+func TestEvaluatePercentageBlocksMissedAlert(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	// Setup test td
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name             string
+		missed           int64
+		window           int64
+		windowThreshold  int
+		existingAlert    bool
+		expectedAlert    bool
+		expectedResolved bool
+		description      string
+	}{
+		{
+			name:             "should trigger alert when percentage exceeds threshold",
+			missed:           15,
+			window:           100,
+			windowThreshold:  10,
+			existingAlert:    false,
+			expectedAlert:    true,
+			expectedResolved: false,
+			description:      "Alert should trigger when missed percentage exceeds threshold",
+		},
+		{
+			name:             "should not trigger duplicate alert",
+			missed:           15,
+			window:           100,
+			windowThreshold:  10,
+			existingAlert:    true,
+			expectedAlert:    false,
+			expectedResolved: false,
+			description:      "Should not trigger duplicate alert when already exists",
+		},
+		{
+			name:             "should resolve alert when percentage drops below threshold",
+			missed:           5,
+			window:           100,
+			windowThreshold:  10,
+			existingAlert:    true,
+			expectedAlert:    false,
+			expectedResolved: true,
+			description:      "Should resolve alert when percentage drops below threshold",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset alarms for each test
+			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+
+			cc := &ChainConfig{
+				name:       "test-chain",
+				ChainId:    "test-chain-1",
+				ValAddress: "testval123",
+				valInfo: &ValInfo{
+					Moniker: "test-validator",
+					Missed:  tt.missed,
+					Window:  tt.window,
+				},
+				Alerts: AlertConfig{
+					Window:             WindowLadder{{Percent: tt.windowThreshold}},
+					PercentagePriority: "warning",
+				},
+			}
+
+			if tt.existingAlert {
+				testAlarms.AllAlarms["test-chain"] = make(map[string]alertMsgCache)
+				testAlarms.AllAlarms["test-chain"]["PercentageBlocksMissed_testval123"] = alertMsgCache{
+					Message:  "test alert",
+					SentTime: time.Now(),
+				}
+			}
+
+			alert, resolved := evaluatePercentageBlocksMissedAlert(cc)
+
+			if alert != tt.expectedAlert {
+				t.Errorf("%s: expected alert %v, got %v", tt.description, tt.expectedAlert, alert)
+			}
+			if resolved != tt.expectedResolved {
+				t.Errorf("%s: expected resolved %v, got %v", tt.description, tt.expectedResolved, resolved)
+			}
+		})
+	}
+}
+
+func TestEvaluatePercentageBlocksMissedAlertRecovery(t *testing.T) {
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	trueBool, falseBool := true, false
+	recoveryBlocks := 5
+	tests := []struct {
+		name             string
+		resolveOnRecover *bool
+		streak           int
+		existingAlert    bool
+		expectedAlert    bool
+		expectedResolved bool
+	}{
+		{name: "recovered validator resolves while the window count is high", resolveOnRecover: &trueBool, streak: 5, existingAlert: true, expectedResolved: true},
+		{name: "recovered validator does not alert again", resolveOnRecover: &trueBool, streak: 8},
+		{name: "too few signed blocks keeps the alert", resolveOnRecover: &trueBool, streak: 4, existingAlert: true},
+		{name: "missing again alerts", resolveOnRecover: &trueBool, streak: 0, expectedAlert: true},
+		{name: "disabled waits for the window", resolveOnRecover: &falseBool, streak: 8, existingAlert: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+			cc := &ChainConfig{
+				name:       "test-chain",
+				ChainId:    "test-chain-1",
+				ValAddress: "testval123",
+				valInfo:    &ValInfo{Moniker: "test-validator", Missed: 15, Window: 100},
+				Alerts: AlertConfig{
+					Window:                      WindowLadder{{Percent: 10}},
+					PercentagePriority:          "warning",
+					PercentageResolveOnRecovery: tt.resolveOnRecover,
+					PercentageRecoveryBlocks:    &recoveryBlocks,
+				},
+			}
+			// newest block first, the streak of signed blocks is followed by a miss
+			blocks := make([]int, 10)
+			for i := range blocks {
+				blocks[i] = int(StatusSigned)
+			}
+			if tt.streak < len(blocks) {
+				blocks[tt.streak] = int(Statusmissed)
+			}
+			cc.setConsensusMisses(blocks)
+
+			if tt.existingAlert {
+				testAlarms.AllAlarms["test-chain"] = map[string]alertMsgCache{
+					"PercentageBlocksMissed_testval123": {Message: "test alert", SentTime: time.Now()},
+				}
+			}
+
+			alert, resolved := evaluatePercentageBlocksMissedAlert(cc)
+			if alert != tt.expectedAlert {
+				t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
+			}
+			if resolved != tt.expectedResolved {
+				t.Errorf("expected resolved %v, got %v", tt.expectedResolved, resolved)
+			}
+			for len(td.alertChan) > 0 {
+				<-td.alertChan
+			}
+		})
+	}
+}
+
+func TestEvaluatePercentageBlocksMissedAlertWindowChange(t *testing.T) {
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	cc := &ChainConfig{
+		name:       "test-chain",
+		ChainId:    "test-chain-1",
+		ValAddress: "testval123",
+		valInfo:    &ValInfo{Moniker: "test-validator", Missed: 15, Window: 100},
+		Alerts: AlertConfig{
+			Window:             WindowLadder{{Percent: 10}},
+			PercentagePriority: "warning",
+		},
+	}
+
+	steps := []struct {
+		name             string
+		missed           int64
+		window           int64
+		expectedAlert    bool
+		expectedResolved bool
+	}{
+		{name: "should alert on the first evaluation", missed: 15, window: 100, expectedAlert: true},
+		{name: "should skip the evaluation the window changes on", missed: 5, window: 200},
+		{name: "should resolve on the next evaluation", missed: 5, window: 200, expectedResolved: true},
+		{name: "should skip again when the window shrinks", missed: 30, window: 100},
+		{name: "should alert after the skipped evaluation", missed: 30, window: 100, expectedAlert: true},
+	}
+
+	for _, step := range steps {
+		cc.valInfo.Missed, cc.valInfo.Window = step.missed, step.window
+		alert, resolved := evaluatePercentageBlocksMissedAlert(cc)
+		if alert != step.expectedAlert {
+			t.Errorf("%s: expected alert %v, got %v", step.name, step.expectedAlert, alert)
+		}
+		if resolved != step.expectedResolved {
+			t.Errorf("%s: expected resolved %v, got %v", step.name, step.expectedResolved, resolved)
+		}
+		for len(td.alertChan) > 0 {
+			<-td.alertChan
+		}
+	}
+}
+
+func TestEvaluatePercentageBlocksMissedAlertLadder(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	// Setup test td
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	cc := &ChainConfig{
+		name:       "test-chain",
+		ChainId:    "test-chain-1",
+		ValAddress: "testval123",
+		valInfo: &ValInfo{
+			Moniker: "test-validator",
+			Window:  100,
+		},
+		Alerts: AlertConfig{
+			Window:             WindowLadder{{Percent: 5, Severity: "warning"}, {Percent: 10, Severity: "critical"}},
+			PercentagePriority: "warning",
+		},
+	}
+
+	// the missed count moves up through both thresholds and back down again
+	steps := []struct {
+		missed         int64
+		expectedActive []string
+	}{
+		{missed: 0, expectedActive: []string{}},
+		{missed: 6, expectedActive: []string{"PercentageBlocksMissed_testval123_5"}},
+		{missed: 12, expectedActive: []string{"PercentageBlocksMissed_testval123_10", "PercentageBlocksMissed_testval123_5"}},
+		{missed: 7, expectedActive: []string{"PercentageBlocksMissed_testval123_5"}},
+		{missed: 1, expectedActive: []string{}},
+	}
+
+	for _, step := range steps {
+		cc.valInfo.Missed = step.missed
+		evaluatePercentageBlocksMissedAlert(cc)
+		// drain the alert channel so it never fills up
+		for len(td.alertChan) > 0 {
+			<-td.alertChan
+		}
+
+		active := make([]string, 0)
+		for id := range testAlarms.AllAlarms["test-chain"] {
+			active = append(active, id)
+		}
+		sort.Strings(active)
+		if !reflect.DeepEqual(active, step.expectedActive) {
+			t.Errorf("missed %d: expected active alarms %v, got %v", step.missed, step.expectedActive, active)
+		}
+	}
+}
+
+func TestEvaluateChainStalledAlert(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	// Setup test td
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name             string
+		lastBlockTime    time.Time
+		stalledMinutes   int
+		lastBlockAlarm   bool
+		expectedAlert    bool
+		expectedResolved bool
+		description      string
+	}{
+		{
+			name:             "should trigger alert when chain is stalled",
+			lastBlockTime:    time.Now().Add(-15 * time.Minute),
+			stalledMinutes:   10,
+			lastBlockAlarm:   false,
+			expectedAlert:    true,
+			expectedResolved: false,
+			description:      "Should trigger alert when chain has been stalled longer than threshold",
+		},
+		{
+			name:             "should not trigger duplicate alert",
+			lastBlockTime:    time.Now().Add(-15 * time.Minute),
+			stalledMinutes:   10,
+			lastBlockAlarm:   true,
+			expectedAlert:    false,
+			expectedResolved: false,
+			description:      "Should not trigger duplicate alert when already alarmed",
+		},
+		{
+			name:             "should resolve alert when chain recovers",
+			lastBlockTime:    time.Now().Add(-5 * time.Minute),
+			stalledMinutes:   10,
+			lastBlockAlarm:   true,
+			expectedAlert:    false,
+			expectedResolved: true,
+			description:      "Should resolve alert when chain recovers from stall",
+		},
+		{
+			name:             "should handle zero lastBlockTime",
+			lastBlockTime:    time.Time{},
+			stalledMinutes:   10,
+			lastBlockAlarm:   false,
+			expectedAlert:    false,
+			expectedResolved: false,
+			description:      "Should handle zero lastBlockTime gracefully",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset alarms for each test
+			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+
+			cc := &ChainConfig{
+				name:           "test-chain",
+				ChainId:        "test-chain-1",
+				ValAddress:     "testval123",
+				lastBlockTime:  tt.lastBlockTime,
+				lastBlockAlarm: tt.lastBlockAlarm,
+				Alerts: AlertConfig{
+					Stalled: &tt.stalledMinutes,
+				},
+			}
+
+			alert, resolved := evaluateChainStalledAlert(cc)
+
+			if alert != tt.expectedAlert {
+				t.Errorf("%s: expected alert %v, got %v", tt.description, tt.expectedAlert, alert)
+			}
+			if resolved != tt.expectedResolved {
+				t.Errorf("%s: expected resolved %v, got %v", tt.description, tt.expectedResolved, resolved)
+			}
+		})
+	}
+}
+
+func TestStalledAndNoServersPriority(t *testing.T) {
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	stalledMinutes := 10
+	tests := []struct {
+		name             string
+		configured       string
+		expectedSeverity string
+	}{
+		{name: "defaults to critical", configured: "", expectedSeverity: "critical"},
+		{name: "configured severity is used", configured: "warning", expectedSeverity: "warning"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+			cc := &ChainConfig{
+				name:          "test-chain",
+				ChainId:       "test-chain-1",
+				ValAddress:    "testval123",
+				lastBlockTime: time.Now().Add(-15 * time.Minute),
+				noNodes:       true,
+				Alerts: AlertConfig{
+					Stalled:           &stalledMinutes,
+					StalledPriority:   tt.configured,
+					NoServersPriority: tt.configured,
+				},
+			}
+
+			if alert, _ := evaluateChainStalledAlert(cc); !alert {
+				t.Fatal("expected the stalled alert to fire")
+			}
+			if msg := <-td.alertChan; msg.severity != tt.expectedSeverity {
+				t.Errorf("expected stalled severity %s, got %s", tt.expectedSeverity, msg.severity)
+			}
+
+			noNodesSec := 0
+			if alert, _ := evaluateNoRPCEndpointsAlert(cc, &noNodesSec); !alert {
+				t.Fatal("expected the no RPC endpoints alert to fire")
+			}
+			if msg := <-td.alertChan; msg.severity != tt.expectedSeverity {
+				t.Errorf("expected no RPC endpoints severity %s, got %s", tt.expectedSeverity, msg.severity)
+			}
+		})
+	}
+}
+
+func TestStartupGraceSuppressesAlerts(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	// Setup test td
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	evaluators := map[string]func(cc *ChainConfig) (bool, bool){
+		"stalled":     evaluateChainStalledAlert,
+		"consecutive": evaluateConsecutiveBlocksMissedAlert,
+		"percentage":  evaluatePercentageBlocksMissedAlert,
+	}
+
+	tests := []struct {
+		name          string
+		watchStart    time.Time
+		expectedAlert bool
+	}{
+		{
+			name:       "should suppress alerts during the grace period",
+			watchStart: time.Now().Add(-2 * time.Minute),
+		},
+		{
+			name:          "should alert once the grace period is over",
+			watchStart:    time.Now().Add(-10 * time.Minute),
+			expectedAlert: true,
+		},
+	}
+
+	for _, tt := range tests {
+		for evaluatorName, evaluate := range evaluators {
+			t.Run(tt.name+" ("+evaluatorName+")", func(t *testing.T) {
+				// Reset alarms for each test
+				testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+				for len(td.alertChan) > 0 {
+					<-td.alertChan
+				}
+
+				grace, stalled, consecutive := 5, 10, 5
+				cc := &ChainConfig{
+					name:                "test-chain",
+					ChainId:             "test-chain-1",
+					ValAddress:          "testval123",
+					watchStart:          tt.watchStart,
+					lastBlockTime:       time.Now().Add(-15 * time.Minute),
+					statConsecutiveMiss: 10,
+					valInfo: &ValInfo{
+						Moniker: "test-validator",
+						Missed:  50,
+						Window:  100,
+					},
+					Alerts: AlertConfig{
+						StartupGraceMinutes: &grace,
+						Stalled:             &stalled,
+						ConsecutiveMissed:   &consecutive,
+						Window:              WindowLadder{{Percent: 10}},
+					},
+				}
+
+				alert, _ := evaluate(cc)
+				if alert != tt.expectedAlert {
+					t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
+				}
+			})
+		}
+	}
+}
+
+func TestEvaluateValidatorInactiveAlert(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	// Setup test td
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name             string
+		currentBonded    bool
+		previousBonded   bool
+		tombstoned       bool
+		expectedAlert    bool
+		expectedResolved bool
+		description      string
+	}{
+		{
+			name:             "should trigger alert when validator becomes inactive",
+			currentBonded:    false,
+			previousBonded:   true,
+			tombstoned:       false,
+			expectedAlert:    true,
+			expectedResolved: false,
+			description:      "Should alert when validator becomes jailed",
+		},
+		{
+			name:             "should trigger alert when validator becomes tombstoned",
+			currentBonded:    false,
+			previousBonded:   true,
+			tombstoned:       true,
+			expectedAlert:    true,
+			expectedResolved: false,
+			description:      "Should alert when validator becomes tombstoned",
+		},
+		{
+			name:             "should resolve alert when validator becomes active",
+			currentBonded:    true,
+			previousBonded:   false,
+			tombstoned:       false,
+			expectedAlert:    false,
+			expectedResolved: true,
+			description:      "Should resolve alert when validator becomes active again",
+		},
+		{
+			name:             "should not alert if no state change",
+			currentBonded:    true,
+			previousBonded:   true,
+			tombstoned:       false,
+			expectedAlert:    false,
+			expectedResolved: false,
+			description:      "Should not alert if validator state hasn't changed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset alarms for each test
+			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+
+			cc := &ChainConfig{
+				name:       "test-chain",
+				ChainId:    "test-chain-1",
+				ValAddress: "testval123",
+				valInfo: &ValInfo{
+					Moniker:    "test-validator",
+					Bonded:     tt.currentBonded,
+					Tombstoned: tt.tombstoned,
+				},
+				lastValInfo: &ValInfo{
+					Moniker: "test-validator",
+					Bonded:  tt.previousBonded,
+				},
+			}
+
+			alert, resolved := evaluateValidatorInactiveAlert(cc)
+
+			if alert != tt.expectedAlert {
+				t.Errorf("%s: expected alert %v, got %v", tt.description, tt.expectedAlert, alert)
+			}
+			if resolved != tt.expectedResolved {
+				t.Errorf("%s: expected resolved %v, got %v", tt.description, tt.expectedResolved, resolved)
+			}
+		})
+	}
+}
+
+func TestEvaluateConsecutiveEmptyBlocksAlert(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	// Setup test td
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name                      string
+		consecutiveEmpty          float64
+		consecutiveEmptyThreshold int
+		existingAlert             bool
+		expectedAlert             bool
+		expectedResolved          bool
+		description               string
+	}{
+		{
+			name:                      "should trigger alert when consecutive empty blocks exceed threshold",
+			consecutiveEmpty:          5,
+			consecutiveEmptyThreshold: 3,
+			existingAlert:             false,
+			expectedAlert:             true,
+			expectedResolved:          false,
+			description:               "Should alert when consecutive empty blocks exceed threshold",
+		},
+		{
+			name:                      "should not trigger duplicate alert",
+			consecutiveEmpty:          5,
+			consecutiveEmptyThreshold: 3,
+			existingAlert:             true,
+			expectedAlert:             false,
+			expectedResolved:          false,
+			description:               "Should not trigger duplicate alert",
+		},
+		{
+			name:                      "should resolve alert when consecutive empty blocks drop below threshold",
+			consecutiveEmpty:          2,
+			consecutiveEmptyThreshold: 3,
+			existingAlert:             true,
+			expectedAlert:             false,
+			expectedResolved:          true,
+			description:               "Should resolve alert when consecutive empty blocks drop",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset alarms for each test
+			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+
+			cc := &ChainConfig{
+				name:                 "test-chain",
+				ChainId:              "test-chain-1",
+				ValAddress:           "testval123",
+				statConsecutiveEmpty: tt.consecutiveEmpty,
+				valInfo:              &ValInfo{Moniker: "test-validator"},
+				Alerts: AlertConfig{
+					ConsecutiveEmpty:         &tt.consecutiveEmptyThreshold,
+					ConsecutiveEmptyPriority: "warning",
+				},
+			}
+
+			if tt.existingAlert {
+				testAlarms.AllAlarms["test-chain"] = make(map[string]alertMsgCache)
+				testAlarms.AllAlarms["test-chain"]["ConsecutiveEmptyBlocks_testval123"] = alertMsgCache{
+					Message:  "test alert",
+					SentTime: time.Now(),
+				}
+			}
+
+			alert, resolved := evaluateConsecutiveEmptyBlocksAlert(cc)
+
+			if alert != tt.expectedAlert {
+				t.Errorf("%s: expected alert %v, got %v", tt.description, tt.expectedAlert, alert)
+			}
+			if resolved != tt.expectedResolved {
+				t.Errorf("%s: expected resolved %v, got %v", tt.description, tt.expectedResolved, resolved)
+			}
+		})
+	}
+}
+
+func TestEvaluatePercentageEmptyBlocksAlert(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	// Setup test td
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name                 string
+		totalProps           float64
+		totalPropsEmpty      float64
+		emptyWindowThreshold int
+		existingAlert        bool
+		expectedAlert        bool
+		expectedResolved     bool
+		description          string
+	}{
+		{
+			name:                 "should trigger alert when empty percentage exceeds threshold",
+			totalProps:           100,
+			totalPropsEmpty:      15,
+			emptyWindowThreshold: 10,
+			existingAlert:        false,
+			expectedAlert:        true,
+			expectedResolved:     false,
+			description:          "Should alert when empty block percentage exceeds threshold",
+		},
+		{
+			name:                 "should not trigger duplicate alert",
+			totalProps:           100,
+			totalPropsEmpty:      15,
+			emptyWindowThreshold: 10,
+			existingAlert:        true,
+			expectedAlert:        false,
+			expectedResolved:     false,
+			description:          "Should not trigger duplicate alert",
+		},
+		{
+			name:                 "should resolve alert when percentage drops below threshold",
+			totalProps:           100,
+			totalPropsEmpty:      5,
+			emptyWindowThreshold: 10,
+			existingAlert:        true,
+			expectedAlert:        false,
+			expectedResolved:     true,
+			description:          "Should resolve alert when percentage drops below threshold",
+		},
+		{
+			name:                 "should handle zero total props",
+			totalProps:           0,
+			totalPropsEmpty:      0,
+			emptyWindowThreshold: 10,
+			existingAlert:        false,
+			expectedAlert:        false,
+			expectedResolved:     false,
+			description:          "Should handle zero total props gracefully",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset alarms for each test
+			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+
+			cc := &ChainConfig{
+				name:                "test-chain",
+				ChainId:             "test-chain-1",
+				ValAddress:          "testval123",
+				statTotalProps:      tt.totalProps,
+				statTotalPropsEmpty: tt.totalPropsEmpty,
+				valInfo:             &ValInfo{Moniker: "test-validator"},
+				Alerts: AlertConfig{
+					EmptyWindow:             &tt.emptyWindowThreshold,
+					EmptyPercentagePriority: "warning",
+				},
+			}
+
+			if tt.existingAlert {
+				testAlarms.AllAlarms["test-chain"] = make(map[string]alertMsgCache)
+				testAlarms.AllAlarms["test-chain"]["PercentageEmptyBlocks_testval123"] = alertMsgCache{
+					Message:  "test alert",
+					SentTime: time.Now(),
+				}
+			}
+
+			alert, resolved := evaluatePercentageEmptyBlocksAlert(cc)
+
+			if alert != tt.expectedAlert {
+				t.Errorf("%s: expected alert %v, got %v", tt.description, tt.expectedAlert, alert)
+			}
+			if resolved != tt.expectedResolved {
+				t.Errorf("%s: expected resolved %v, got %v", tt.description, tt.expectedResolved, resolved)
+			}
+		})
+	}
+}
+
+func TestEvaluateNoRPCEndpointsAlert(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	// Setup test td
+	originalTd := td
+	td = createTestConfig()
+	td.NodeDownMin = 1
+	td.NodeDownSeverity = "warning"
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name               string
+		noNodes            bool
+		noNodesSec         int
+		existingAlert      bool
+		expectedAlert      bool
+		expectedResolved   bool
+		evalInterval       *int
+		expectedNoNodesSec int
+		description        string
+	}{
+		{
+			name:               "should not trigger if node is down but not for enough waiting time",
+			noNodes:            true,
+			noNodesSec:         0,
+			existingAlert:      false,
+			expectedAlert:      false,
+			expectedResolved:   false,
+			expectedNoNodesSec: 2,
+			description:        "Should not alert when nodes have been down shorter than threshold",
+		},
+		{
+			name:               "should trigger when node is down for enough waiting time",
+			noNodes:            true,
+			noNodesSec:         60, // in this test we have set td.NodeDownMin = 1
+			existingAlert:      false,
+			expectedAlert:      true,
+			expectedResolved:   false,
+			expectedNoNodesSec: 62, // evaluation is done every 2 seconds
+			description:        "Should alert when nodes have been down longer than threshold",
+		},
+		{
+			name:               "should not trigger duplicate alert",
+			noNodes:            true,
+			noNodesSec:         120,
+			existingAlert:      true,
+			expectedAlert:      false,
+			expectedResolved:   false,
+			expectedNoNodesSec: 122,
+			description:        "Should not trigger duplicate alert",
+		},
+		{
+			name:               "should resolve alert when nodes recover",
+			noNodes:            false,
+			noNodesSec:         120,
+			existingAlert:      true,
+			expectedAlert:      false,
+			expectedResolved:   true,
+			expectedNoNodesSec: 0,
+			description:        "Should resolve alert when nodes recover",
+		},
+		{
+			name:               "should increment counter when nodes still down but below threshold",
+			noNodes:            true,
+			noNodesSec:         30,
+			existingAlert:      false,
+			expectedAlert:      false,
+			expectedResolved:   false,
+			expectedNoNodesSec: 32,
+			description:        "Should increment counter but not alert when below threshold",
+		},
+		{
+			name:               "should increment counter by the configured evaluation interval",
+			noNodes:            true,
+			noNodesSec:         30,
+			evalInterval:       intPtr(10),
+			expectedNoNodesSec: 40,
+			description:        "Should count the seconds between evaluations",
+		},
+		{
+			name:               "should trigger sooner with a longer evaluation interval",
+			noNodes:            true,
+			noNodesSec:         55,
+			evalInterval:       intPtr(10),
+			expectedAlert:      true,
+			expectedNoNodesSec: 65,
+			description:        "Should alert once the interval pushes the counter past the threshold",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset alarms for each test
+			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+
+			cc := &ChainConfig{
+				name:                "test-chain",
+				ChainId:             "test-chain-1",
+				ValAddress:          "testval123",
+				noNodes:             tt.noNodes,
+				EvalIntervalSeconds: tt.evalInterval,
+			}
+
+			if tt.existingAlert {
+				testAlarms.AllAlarms["test-chain"] = make(map[string]alertMsgCache)
+				testAlarms.AllAlarms["test-chain"]["NoRPCEndpoints_testval123"] = alertMsgCache{
+					Message:  "test alert",
+					SentTime: time.Now(),
+				}
+			}
+
+			noNodesSec := tt.noNodesSec
+			alert, resolved := evaluateNoRPCEndpointsAlert(cc, &noNodesSec)
+
+			if alert != tt.expectedAlert {
+				t.Errorf("%s: expected alert %v, got %v", tt.description, tt.expectedAlert, alert)
+			}
+			if resolved != tt.expectedResolved {
+				t.Errorf("%s: expected resolved %v, got %v", tt.description, tt.expectedResolved, resolved)
+			}
+			if noNodesSec != tt.expectedNoNodesSec {
+				t.Errorf("%s: expected noNodesSec %d, got %d", tt.description, tt.expectedNoNodesSec, noNodesSec)
+			}
+		})
+	}
+}
+
+func TestEvaluateRPCNodeDownAlert(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	// Setup test td
+	originalTd := td
+	td = createTestConfig()
+	td.NodeDownMin = 2
+	td.NodeDownSeverity = "warning"
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name             string
+		nodes            []*NodeConfig
+		existingAlert    bool
+		expectedAlert    bool
+		expectedResolved bool
+		description      string
+	}{
+		{
+			name: "should trigger alert when node is down longer than threshold",
+			nodes: []*NodeConfig{
+				{
+					Url:         "http://node1.example.com",
+					AlertIfDown: true,
+					down:        true,
+					wasDown:     false,
+					downSince:   time.Now().Add(-5 * time.Minute),
+				},
+			},
+			existingAlert:    false,
+			expectedAlert:    true,
+			expectedResolved: false,
+			description:      "Should alert when node is down longer than threshold",
+		},
+		{
+			name: "should not trigger duplicate alert",
+			nodes: []*NodeConfig{
+				{
+					Url:         "http://node1.example.com",
+					AlertIfDown: true,
+					down:        true,
+					wasDown:     false,
+					downSince:   time.Now().Add(-5 * time.Minute),
+				},
+			},
+			existingAlert:    true,
+			expectedAlert:    false,
+			expectedResolved: false,
+			description:      "Should not trigger duplicate alert",
+		},
+		{
+			name: "should resolve alert when node recovers",
+			nodes: []*NodeConfig{
+				{
+					Url:         "http://node1.example.com",
+					AlertIfDown: true,
+					down:        false,
+					wasDown:     true,
+					downSince:   time.Now().Add(-5 * time.Minute),
+				},
+			},
+			existingAlert:    true,
+			expectedAlert:    false,
+			expectedResolved: true,
+			description:      "Should resolve alert when node recovers",
+		},
+		{
+			name: "should not alert if AlertIfDown is false",
+			nodes: []*NodeConfig{
+				{
+					Url:         "http://node1.example.com",
+					AlertIfDown: false,
+					down:        true,
+					wasDown:     false,
+					downSince:   time.Now().Add(-5 * time.Minute),
+				},
+			},
+			existingAlert:    false,
+			expectedAlert:    false,
+			expectedResolved: false,
+			description:      "Should not alert if AlertIfDown is disabled",
+		},
+		{
+			name: "should not alert if node just went down",
+			nodes: []*NodeConfig{
+				{
+					Url:         "http://node1.example.com",
+					AlertIfDown: true,
+					down:        true,
+					wasDown:     false,
+					downSince:   time.Now().Add(-30 * time.Second),
+				},
+			},
+			existingAlert:    false,
+			expectedAlert:    false,
+			expectedResolved: false,
+			description:      "Should not alert if node hasn't been down long enough",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset alarms for each test
+			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+
+			cc := &ChainConfig{
+				name:       "test-chain",
+				ChainId:    "test-chain-1",
+				ValAddress: "testval123",
+				Nodes:      tt.nodes,
+			}
+
+			if tt.existingAlert && len(tt.nodes) > 0 {
+				testAlarms.AllAlarms["test-chain"] = make(map[string]alertMsgCache)
+				alertID := fmt.Sprintf("RPCNodeDown_%s_%s", cc.ValAddress, tt.nodes[0].Url)
+				testAlarms.AllAlarms["test-chain"][alertID] = alertMsgCache{
+					Message:  "test alert",
+					SentTime: time.Now(),
+				}
+			}
+
+			alert, resolved := evaluateRPCNodeDownAlert(cc)
+
+			if alert != tt.expectedAlert {
+				t.Errorf("%s: expected alert %v, got %v", tt.description, tt.expectedAlert, alert)
+			}
+			if resolved != tt.expectedResolved {
+				t.Errorf("%s: expected resolved %v, got %v", tt.description, tt.expectedResolved, resolved)
+			}
+		})
+	}
+}
+
+func TestEvaluateRPCNodeDownAlertBatch(t *testing.T) {
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	td.NodeDownMin = 2
+	td.NodeDownSeverity = "warning"
+	td.NodeDownBatchThreshold = 2
+	defer func() { td = originalTd }()
+
+	nodes := []*NodeConfig{
+		{Url: "http://node1.example.com", AlertIfDown: true},
+		{Url: "http://node2.example.com", AlertIfDown: true},
+		{Url: "http://node3.example.com", AlertIfDown: true},
+	}
+	cc := &ChainConfig{
+		name:       "test-chain",
+		ChainId:    "test-chain-1",
+		ValAddress: "testval123",
+		Nodes:      nodes,
+	}
+	// setDown mimics the health check, a node that comes back keeps wasDown until its alarm resolved
+	setDown := func(down ...bool) {
+		for i, node := range nodes {
+			switch {
+			case down[i] && !node.down:
+				node.down, node.downSince = true, time.Now().Add(-5*time.Minute)
+			case !down[i] && node.down:
+				node.down, node.wasDown, node.downSince = false, true, time.Unix(0, 0)
+			}
+		}
+	}
+
+	steps := []struct {
+		name            string
+		down            []bool
+		expectedAlerts  []string
+		expectedResolve []string
+		expectedActive  []string
+	}{
+		{
+			name:           "one node down alerts on its own",
+			down:           []bool{true, false, false},
+			expectedAlerts: []string{"RPCNodeDown_testval123_http://node1.example.com"},
+			expectedActive: []string{"RPCNodeDown_testval123_http://node1.example.com"},
+		},
+		{
+			name:           "more nodes down are batched into one alert",
+			down:           []bool{true, true, true},
+			expectedAlerts: []string{"RPCNodesDown_testval123"},
+			expectedActive: []string{"RPCNodeDown_testval123_http://node1.example.com", "RPCNodesDown_testval123"},
+		},
+		{
+			name:           "no new alerts while the batch is firing",
+			down:           []bool{true, false, true},
+			expectedActive: []string{"RPCNodeDown_testval123_http://node1.example.com", "RPCNodesDown_testval123"},
+		},
+		{
+			name:            "the batch resolves below the threshold and the node still down alerts",
+			down:            []bool{false, false, true},
+			expectedAlerts:  []string{"RPCNodeDown_testval123_http://node3.example.com"},
+			expectedResolve: []string{"RPCNodesDown_testval123", "RPCNodeDown_testval123_http://node1.example.com"},
+			expectedActive:  []string{"RPCNodeDown_testval123_http://node3.example.com"},
+		},
+		{
+			name:            "all nodes back resolves the rest",
+			down:            []bool{false, false, false},
+			expectedResolve: []string{"RPCNodeDown_testval123_http://node3.example.com"},
+			expectedActive:  []string{},
+		},
+	}
+
+	for _, step := range steps {
+		setDown(step.down...)
+		evaluateRPCNodeDownAlert(cc)
+
+		alerts, resolves := make([]string, 0), make([]string, 0)
+		for len(td.alertChan) > 0 {
+			msg := <-td.alertChan
+			if msg.resolved {
+				resolves = append(resolves, msg.uniqueId)
+				continue
+			}
+			alerts = append(alerts, msg.uniqueId)
+			if msg.uniqueId == "RPCNodesDown_testval123" && !strings.Contains(msg.message, "3 of 3 RPC nodes") {
+				t.Errorf("%s: expected the batched alert to count the down nodes, got %q", step.name, msg.message)
+			}
+		}
+		sort.Strings(alerts)
+		sort.Strings(resolves)
+		sort.Strings(step.expectedResolve)
+		if !reflect.DeepEqual(alerts, append([]string{}, step.expectedAlerts...)) {
+			t.Errorf("%s: expected alerts %v, got %v", step.name, step.expectedAlerts, alerts)
+		}
+		if !reflect.DeepEqual(resolves, append([]string{}, step.expectedResolve...)) {
+			t.Errorf("%s: expected resolves %v, got %v", step.name, step.expectedResolve, resolves)
+		}
+		active := make([]string, 0)
+		for id := range testAlarms.AllAlarms["test-chain"] {
+			active = append(active, id)
+		}
+		sort.Strings(active)
+		if !reflect.DeepEqual(active, step.expectedActive) {
+			t.Errorf("%s: expected active alarms %v, got %v", step.name, step.expectedActive, active)
+		}
+	}
+}
+
+func TestEvaluateStakeChangeAlert(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	// Setup test td
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name              string
+		currentStake      float64
+		previousStake     float64
+		increaseThreshold float64
+		dropThreshold     float64
+		existingAlert     bool
+		expectedAlert     bool
+		expectedResolved  bool
+		description       string
+	}{
+		{
+			name:              "should trigger alert when stake increases above threshold",
+			currentStake:      1200.0,
+			previousStake:     1000.0,
+			increaseThreshold: 0.15,
+			dropThreshold:     0.10,
+			existingAlert:     false,
+			expectedAlert:     true,
+			expectedResolved:  false,
+			description:       "Should alert when stake increases by more than 15%",
+		},
+		{
+			name:              "should trigger alert when stake drops below threshold",
+			currentStake:      800.0,
+			previousStake:     1000.0,
+			increaseThreshold: 0.15,
+			dropThreshold:     0.15,
+			existingAlert:     false,
+			expectedAlert:     true,
+			expectedResolved:  false,
+			description:       "Should alert when stake drops by more than 15%",
+		},
+		{
+			name:              "should not trigger duplicate alert",
+			currentStake:      1200.0,
+			previousStake:     1000.0,
+			increaseThreshold: 0.15,
+			dropThreshold:     0.10,
+			existingAlert:     true,
+			expectedAlert:     false,
+			expectedResolved:  false,
+			description:       "Should not trigger duplicate alert",
+		},
+		{
+			name:              "should resolve alert when stake change is within threshold",
+			currentStake:      1050.0,
+			previousStake:     1000.0,
+			increaseThreshold: 0.10,
+			dropThreshold:     0.10,
+			existingAlert:     true,
+			expectedAlert:     false,
+			expectedResolved:  true,
+			description:       "Should resolve alert when stake change is within acceptable range",
+		},
+		{
+			name:              "should not alert when change is within threshold",
+			currentStake:      1050.0,
+			previousStake:     1000.0,
+			increaseThreshold: 0.10,
+			dropThreshold:     0.10,
+			existingAlert:     false,
+			expectedAlert:     false,
+			expectedResolved:  false,
+			description:       "Should not alert when stake change is within threshold",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset alarms for each test
+			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+
+			cc := &ChainConfig{
+				name:       "test-chain",
+				ChainId:    "test-chain-1",
+				ValAddress: "testval123",
+				valInfo: &ValInfo{
+					Moniker:         "test-validator",
+					DelegatedTokens: tt.currentStake,
+				},
+				lastValInfo: &ValInfo{
+					DelegatedTokens: tt.previousStake,
+				},
+				Alerts: AlertConfig{
+					StakeChangeIncreaseThreshold: &tt.increaseThreshold,
+					StakeChangeDropThreshold:     &tt.dropThreshold,
+				},
+			}
+
+			if tt.existingAlert {
+				testAlarms.AllAlarms["test-chain"] = make(map[string]alertMsgCache)
+				testAlarms.AllAlarms["test-chain"]["StakeChange_testval123"] = alertMsgCache{
+					Message:  "test alert",
+					SentTime: time.Now(),
+				}
+			}
+
+			alert, resolved := evaluateStakeChangeAlert(cc)
+
+			if alert != tt.expectedAlert {
+				t.Errorf("%s: expected alert %v, got %v", tt.description, tt.expectedAlert, alert)
+			}
+			if resolved != tt.expectedResolved {
+				t.Errorf("%s: expected resolved %v, got %v", tt.description, tt.expectedResolved, resolved)
+			}
+		})
+	}
+}
+
+func TestEvaluateCommissionStaleAlert(t *testing.T) {
+	originalAlarms := alarms
+	alarms = &alarmCache{AllAlarms: make(map[string]map[string]alertMsgCache), notifyMux: sync.RWMutex{}}
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	cc := td.Chains["test-chain"]
+	cc.Alerts.CommissionStaleHours = intPtr(24)
+	cc.valInfo = &ValInfo{Moniker: "test-validator"}
+	alertID := "CommissionStale_" + cc.ValAddress
+
+	steps := []struct {
+		name       string
+		commission string
+		age        time.Duration // how long before the evaluation the last withdrawal was seen, 0 keeps it as is
+		firing     bool
+		notified   int
+	}{
+		{name: "first evaluation starts the count", commission: "10"},
+		{name: "growing within the window does not alert", commission: "20", age: 23 * time.Hour},
+		{name: "growing beyond the window alerts", commission: "30", age: 25 * time.Hour, firing: true, notified: 1},
+		{name: "still growing is not repeated", commission: "40", firing: true},
+		{name: "a withdrawal resolves the alert", commission: "0.5", notified: 1},
+		{name: "growing again after a recent withdrawal does not alert", commission: "5"},
+	}
+	for _, step := range steps {
+		if step.age > 0 {
+			cc.commissionWithdrawn = time.Now().Add(-step.age)
+		}
+		cc.valInfo.Commission = &sdk.DecCoins{sdk.NewDecCoinFromDec("uatom", sdk.MustNewDecFromStr(step.commission))}
+		evaluateCommissionStaleAlert(cc)
+		if firing := alarms.exist(cc.name, alertID); firing != step.firing {
+			t.Errorf("%s: expected firing %v, got %v", step.name, step.firing, firing)
+		}
+		if len(td.alertChan) != step.notified {
+			t.Errorf("%s: expected %d notifications, got %d", step.name, step.notified, len(td.alertChan))
+		}
+		for len(td.alertChan) > 0 {
+			<-td.alertChan
+		}
+	}
+
+	// without commission_stale_hours the default applies
+	cc.Alerts.CommissionStaleHours = nil
+	cc.commissionWithdrawn = time.Now().Add(-(defaultCommissionStaleHours - 1) * time.Hour)
+	cc.valInfo.Commission = &sdk.DecCoins{sdk.NewDecCoinFromDec("uatom", sdk.NewDec(50))}
+	if alert, _ := evaluateCommissionStaleAlert(cc); alert {
+		t.Errorf("expected no alert within the default %d hours", defaultCommissionStaleHours)
+	}
+}
+
+func TestEvaluateUnvotedGovernanceProposalAlert(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	// Setup test td
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name             string
+		unvotedProposals []gov.Proposal
+		existingAlerts   map[string]bool
+		expectedAlert    bool
+		expectedResolved bool
+		description      string
+	}{
+		{
+			name: "should trigger alert for new unvoted proposal",
+			unvotedProposals: []gov.Proposal{
+				{
+					ProposalId:    1,
+					VotingEndTime: time.Now().Add(24 * time.Hour),
+				},
+			},
+			existingAlerts:   map[string]bool{},
+			expectedAlert:    true,
+			expectedResolved: false,
+			description:      "Should alert for new unvoted governance proposal",
+		},
+		{
+			name: "should not trigger duplicate alert",
+			unvotedProposals: []gov.Proposal{
+				{
+					ProposalId:    1,
+					VotingEndTime: time.Now().Add(24 * time.Hour),
+				},
+			},
+			existingAlerts: map[string]bool{
+				"UnvotedGovernanceProposal_testval123_1": true,
+			},
+			expectedAlert:    false,
+			expectedResolved: false,
+			description:      "Should not trigger duplicate alert for same proposal",
+		},
+		{
+			name:             "should resolve alert when proposal is voted on",
+			unvotedProposals: []gov.Proposal{},
+			existingAlerts: map[string]bool{
+				"UnvotedGovernanceProposal_testval123_1": true,
+			},
+			expectedAlert:    false,
+			expectedResolved: true,
+			description:      "Should resolve alert when proposal is no longer unvoted",
+		},
+		{
+			name: "should handle multiple proposals",
+			unvotedProposals: []gov.Proposal{
+				{
+					ProposalId:    1,
+					VotingEndTime: time.Now().Add(24 * time.Hour),
+				},
+				{
+					ProposalId:    2,
+					VotingEndTime: time.Now().Add(48 * time.Hour),
+				},
+			},
+			existingAlerts:   map[string]bool{},
+			expectedAlert:    true,
+			expectedResolved: false,
+			description:      "Should handle multiple unvoted proposals",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset alarms for each test
+			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+
+			if len(tt.existingAlerts) > 0 {
+				testAlarms.AllAlarms["test-chain"] = make(map[string]alertMsgCache)
+				for alertID := range tt.existingAlerts {
+					testAlarms.AllAlarms["test-chain"][alertID] = alertMsgCache{
+						Message:  "test governance alert",
+						SentTime: time.Now(),
+					}
+				}
+			}
+
+			cc := &ChainConfig{
+				name:                    "test-chain",
+				ChainId:                 "test-chain-1",
+				ValAddress:              "testval123",
+				unvotedOpenGovProposals: tt.unvotedProposals,
+				Provider:                ProviderConfig{Name: "cosmos"},
+			}
+
+			alert, resolved := evaluateUnvotedGovernanceProposalAlert(cc)
+
+			if alert != tt.expectedAlert {
+				t.Errorf("%s: expected alert %v, got %v", tt.description, tt.expectedAlert, alert)
+			}
+			if resolved != tt.expectedResolved {
+				t.Errorf("%s: expected resolved %v, got %v", tt.description, tt.expectedResolved, resolved)
+			}
+		})
+	}
+}
+
+func TestEvaluateWebsocketLagAlert(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	// Setup test td
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name             string
+		wsHeight         int64
+		wsSeen           time.Time
+		polledHeight     int64
+		existingAlert    bool
+		expectedAlert    bool
+		expectedResolved bool
+	}{
+		{
+			name:          "should trigger alert when websocket lags but chain advances",
+			wsHeight:      100,
+			wsSeen:        time.Now().Add(-10 * time.Minute),
+			polledHeight:  200,
+			expectedAlert: true,
+		},
+		{
+			name:          "should trigger alert when websocket never delivered a block",
+			wsHeight:      0,
+			wsSeen:        time.Now().Add(-10 * time.Minute),
+			polledHeight:  200,
+			expectedAlert: true,
+		},
+		{
+			name:         "should not alert when the chain itself is stalled",
+			wsHeight:     100,
+			wsSeen:       time.Now().Add(-10 * time.Minute),
+			polledHeight: 100,
+		},
+		{
+			name:          "should not trigger duplicate alert",
+			wsHeight:      100,
+			wsSeen:        time.Now().Add(-10 * time.Minute),
+			polledHeight:  200,
+			existingAlert: true,
+		},
+		{
+			name:             "should resolve alert when websocket recovers",
+			wsHeight:         200,
+			wsSeen:           time.Now().Add(-5 * time.Second),
+			polledHeight:     200,
+			existingAlert:    true,
+			expectedResolved: true,
+		},
+		{
+			name:         "should not alert before the websocket has connected",
+			polledHeight: 200,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset alarms for each test
+			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+
+			lagMinutes := 5
+			cc := &ChainConfig{
+				name:         "test-chain",
+				ChainId:      "test-chain-1",
+				ValAddress:   "testval123",
+				wsHeight:     tt.wsHeight,
+				wsSeen:       tt.wsSeen,
+				polledHeight: tt.polledHeight,
+				Alerts: AlertConfig{
+					WebsocketLag: &lagMinutes,
+				},
+			}
+
+			if tt.existingAlert {
+				testAlarms.AllAlarms["test-chain"] = make(map[string]alertMsgCache)
+				testAlarms.AllAlarms["test-chain"]["WebsocketLag_testval123"] = alertMsgCache{
+					Message:  "test alert",
+					SentTime: time.Now(),
+				}
+			}
+
+			alert, resolved := evaluateWebsocketLagAlert(cc)
+
+			if alert != tt.expectedAlert {
+				t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
+			}
+			if resolved != tt.expectedResolved {
+				t.Errorf("expected resolved %v, got %v", tt.expectedResolved, resolved)
+			}
+		})
+	}
+}
+
+func TestEvaluateChainStalledAlertWithWebsocketLag(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	// Setup test td
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name             string
+		wsLagAlerts      bool
+		polledSeen       time.Time
+		lastBlockAlarm   bool
+		expectedAlert    bool
+		expectedResolved bool
+	}{
+		{
+			name:             "should not alert stalled when nodes still report new heights",
+			wsLagAlerts:      true,
+			polledSeen:       time.Now().Add(-30 * time.Second),
+			expectedResolved: true,
+		},
+		{
+			name:             "should resolve stalled when nodes start reporting new heights",
+			wsLagAlerts:      true,
+			polledSeen:       time.Now().Add(-30 * time.Second),
+			lastBlockAlarm:   true,
+			expectedResolved: true,
+		},
+		{
+			name:          "should alert stalled when nodes have not advanced either",
+			wsLagAlerts:   true,
+			polledSeen:    time.Now().Add(-15 * time.Minute),
+			expectedAlert: true,
+		},
+		{
+			name:          "should alert stalled when websocket lag alerts are disabled",
+			wsLagAlerts:   false,
+			polledSeen:    time.Now().Add(-30 * time.Second),
+			expectedAlert: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset alarms for each test
+			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+
+			stalledMinutes := 10
+			cc := &ChainConfig{
+				name:           "test-chain",
+				ChainId:        "test-chain-1",
+				ValAddress:     "testval123",
+				lastBlockTime:  time.Now().Add(-15 * time.Minute),
+				lastBlockAlarm: tt.lastBlockAlarm,
+				polledSeen:     tt.polledSeen,
+				Alerts: AlertConfig{
+					Stalled:            &stalledMinutes,
+					WebsocketLagAlerts: &tt.wsLagAlerts,
+				},
+			}
+
+			alert, resolved := evaluateChainStalledAlert(cc)
+
+			if alert != tt.expectedAlert {
+				t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
+			}
+			if resolved != tt.expectedResolved {
+				t.Errorf("expected resolved %v, got %v", tt.expectedResolved, resolved)
+			}
+		})
+	}
+}
+
+func TestEvaluateNodeSyncingAlert(t *testing.T) {
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name             string
+		syncingFor       time.Duration // 0 means caught up
+		existingAlert    bool
+		expectedAlert    bool
+		expectedResolved bool
+	}{
+		{name: "should alert when catching up for too long", syncingFor: 45 * time.Minute, expectedAlert: true},
+		{name: "should not alert a node that just started catching up", syncingFor: 5 * time.Minute},
+		{name: "should not duplicate the alert", syncingFor: 45 * time.Minute, existingAlert: true},
+		{name: "should keep the alert while still catching up", syncingFor: 5 * time.Minute, existingAlert: true},
+		{name: "should resolve once caught up", existingAlert: true, expectedResolved: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+			maxMinutes := 30
+			node := &NodeConfig{Url: "tcp://node1:26657"}
+			if tt.syncingFor > 0 {
+				node.syncingSince = time.Now().Add(-tt.syncingFor)
+			}
+			cc := &ChainConfig{
+				name:       "test-chain",
+				ChainId:    "test-chain-1",
+				ValAddress: "testval123",
+				Nodes:      []*NodeConfig{node},
+				Alerts:     AlertConfig{SyncingMaxMinutes: &maxMinutes},
+			}
+			if tt.existingAlert {
+				testAlarms.AllAlarms["test-chain"] = map[string]alertMsgCache{
+					"NodeSyncing_testval123_tcp://node1:26657": {Message: "test alert", SentTime: time.Now()},
+				}
+			}
+
+			alert, resolved := evaluateNodeSyncingAlert(cc)
+			if alert != tt.expectedAlert {
+				t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
+			}
+			if resolved != tt.expectedResolved {
+				t.Errorf("expected resolved %v, got %v", tt.expectedResolved, resolved)
+			}
+			for len(td.alertChan) > 0 {
+				<-td.alertChan
+			}
+		})
+	}
+
+	// the start of the catch up is kept until the node reports it is synced
+	node := &NodeConfig{}
+	node.setCatchingUp(true)
+	first := node.catchingUpSince()
+	node.setCatchingUp(true)
+	if first.IsZero() || !node.catchingUpSince().Equal(first) {
+		t.Errorf("expected the catch up start to be kept, got %v then %v", first, node.catchingUpSince())
+	}
+	node.setCatchingUp(false)
+	if !node.catchingUpSince().IsZero() {
+		t.Error("expected the catch up start to be cleared once synced")
+	}
+}
+
+func TestEvaluateLowPeersAlert(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	// Setup test td
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name             string
+		peers            int
+		peersKnown       bool
+		existingAlert    bool
+		expectedAlert    bool
+		expectedResolved bool
+	}{
+		{
+			name:          "should trigger alert when peers drop below minimum",
+			peers:         2,
+			peersKnown:    true,
+			expectedAlert: true,
+		},
+		{
+			name:          "should not trigger duplicate alert",
+			peers:         2,
+			peersKnown:    true,
+			existingAlert: true,
+		},
+		{
+			name:             "should resolve alert when peers recover",
+			peers:            10,
+			peersKnown:       true,
+			existingAlert:    true,
+			expectedResolved: true,
+		},
+		{
+			name:       "should not alert at the minimum",
+			peers:      5,
+			peersKnown: true,
+		},
+		{
+			name: "should not alert before net_info has succeeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset alarms for each test
+			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+
+			minPeers := 5
+			node := &NodeConfig{Url: "http://node1.example.com"}
+			if tt.peersKnown {
+				node.setPeers(tt.peers)
+			}
+			cc := &ChainConfig{
+				name:       "test-chain",
+				ChainId:    "test-chain-1",
+				ValAddress: "testval123",
+				Nodes:      []*NodeConfig{node},
+				Alerts: AlertConfig{
+					MinPeers: &minPeers,
+				},
+			}
+
+			if tt.existingAlert {
+				testAlarms.AllAlarms["test-chain"] = make(map[string]alertMsgCache)
+				testAlarms.AllAlarms["test-chain"]["LowPeers_testval123_http://node1.example.com"] = alertMsgCache{
+					Message:  "test alert",
+					SentTime: time.Now(),
+				}
+			}
+
+			alert, resolved := evaluateLowPeersAlert(cc)
+
+			if alert != tt.expectedAlert {
+				t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
+			}
+			if resolved != tt.expectedResolved {
+				t.Errorf("expected resolved %v, got %v", tt.expectedResolved, resolved)
+			}
+		})
+	}
+}
+
+func TestEvaluateUnbondingAlert(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	// Setup test td
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	completion := time.Now().Add(21 * 24 * time.Hour)
+	entry := func(height int64, completesAfter time.Duration) staking.UnbondingDelegationEntry {
+		return staking.UnbondingDelegationEntry{
+			CreationHeight: height,
+			CompletionTime: completion.Add(completesAfter),
+			InitialBalance: sdk.NewInt(1000000),
+			Balance:        sdk.NewInt(1000000),
+		}
+	}
+
+	unbondingID := func(height int64, completesAfter time.Duration) string {
+		return fmt.Sprintf("Unbonding_testval123_%d_%d", height, completion.Add(completesAfter).Unix())
+	}
+
+	tests := []struct {
+		name             string
+		existingAlerts   []string
+		unbonding        []staking.UnbondingDelegationEntry
+		expectedAlert    bool
+		expectedResolved bool
+		expectedActive   []string
+	}{
+		{
+			name:           "should not alert before unbonding entries have been queried",
+			existingAlerts: []string{unbondingID(100, 0)},
+			expectedActive: []string{unbondingID(100, 0)},
+		},
+		{
+			name:           "should not alert without unbonding entries",
+			unbonding:      []staking.UnbondingDelegationEntry{},
+			expectedActive: []string{},
+		},
+		{
+			name:           "should alert on a new unbonding entry",
+			unbonding:      []staking.UnbondingDelegationEntry{entry(100, 0)},
+			expectedAlert:  true,
+			expectedActive: []string{unbondingID(100, 0)},
+		},
+		{
+			name:           "should not trigger duplicate alert",
+			existingAlerts: []string{unbondingID(100, 0)},
+			unbonding:      []staking.UnbondingDelegationEntry{entry(100, 0)},
+			expectedActive: []string{unbondingID(100, 0)},
+		},
+		{
+			name:           "should alert on each entry created at the same height",
+			unbonding:      []staking.UnbondingDelegationEntry{entry(100, 0), entry(100, time.Minute)},
+			expectedAlert:  true,
+			expectedActive: []string{unbondingID(100, 0), unbondingID(100, time.Minute)},
+		},
+		{
+			name:             "should alert on a second entry and resolve a completed one",
+			existingAlerts:   []string{unbondingID(100, 0)},
+			unbonding:        []staking.UnbondingDelegationEntry{entry(200, 0)},
+			expectedAlert:    true,
+			expectedResolved: true,
+			expectedActive:   []string{unbondingID(200, 0)},
+		},
+		{
+			name:             "should resolve when all entries have completed",
+			existingAlerts:   []string{unbondingID(100, 0), unbondingID(200, 0)},
+			unbonding:        []staking.UnbondingDelegationEntry{},
+			expectedResolved: true,
+			expectedActive:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset alarms for each test
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{"test-chain": {}}
+			for _, id := range tt.existingAlerts {
+				testAlarms.AllAlarms["test-chain"][id] = alertMsgCache{Message: "test alert", SentTime: time.Now()}
+			}
+
+			cc := &ChainConfig{
+				name:       "test-chain",
+				ChainId:    "test-chain-1",
+				ValAddress: "testval123",
+				valInfo: &ValInfo{
+					Moniker:   "test-validator",
+					Unbonding: tt.unbonding,
+				},
+			}
+
+			alert, resolved := evaluateUnbondingAlert(cc)
+			// drain the alert channel so it never fills up
+			for len(td.alertChan) > 0 {
+				<-td.alertChan
+			}
+
+			if alert != tt.expectedAlert {
+				t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
+			}
+			if resolved != tt.expectedResolved {
+				t.Errorf("expected resolved %v, got %v", tt.expectedResolved, resolved)
+			}
+			active := make([]string, 0)
+			for id := range testAlarms.AllAlarms["test-chain"] {
+				active = append(active, id)
+			}
+			sort.Strings(active)
+			if !reflect.DeepEqual(active, tt.expectedActive) {
+				t.Errorf("expected active alarms %v, got %v", tt.expectedActive, active)
+			}
+		})
+	}
+}
+func TestEvaluateIBCClientExpiryAlert(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	// Setup test td
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	client := func(status string, expiresIn time.Duration) IBCClientStatus {
+		return IBCClientStatus{ClientID: "07-tendermint-0", Status: status, ExpiresAt: time.Now().Add(expiresIn)}
+	}
+
+	tests := []struct {
+		name             string
+		existingAlerts   []string
+		clients          []IBCClientStatus
+		expectedAlert    bool
+		expectedResolved bool
+		expectedActive   []string
+	}{
+		{
+			name:           "should not alert before the clients have been queried",
+			existingAlerts: []string{"IBCClientExpiring_testval123_07-tendermint-0"},
+			expectedActive: []string{"IBCClientExpiring_testval123_07-tendermint-0"},
+		},
+		{
+			name:           "should not alert when the client is far from expiry",
+			clients:        []IBCClientStatus{client("Active", 10*24*time.Hour)},
+			expectedActive: []string{},
+		},
+		{
+			name:           "should warn when the client expires within the window",
+			clients:        []IBCClientStatus{client("Active", 24*time.Hour)},
+			expectedAlert:  true,
+			expectedActive: []string{"IBCClientExpiring_testval123_07-tendermint-0"},
+		},
+		{
+			name:             "should replace the warning once the client has expired",
+			existingAlerts:   []string{"IBCClientExpiring_testval123_07-tendermint-0"},
+			clients:          []IBCClientStatus{client("Expired", -time.Hour)},
+			expectedAlert:    true,
+			expectedResolved: true,
+			expectedActive:   []string{"IBCClientExpired_testval123_07-tendermint-0"},
+		},
+		{
+			name:           "should alert on a frozen client",
+			clients:        []IBCClientStatus{client("Frozen", 10*24*time.Hour)},
+			expectedAlert:  true,
+			expectedActive: []string{"IBCClientExpired_testval123_07-tendermint-0"},
+		},
+		{
+			name:           "should alert on a client past its expiry without a status",
+			clients:        []IBCClientStatus{client("", -time.Hour)},
+			expectedAlert:  true,
+			expectedActive: []string{"IBCClientExpired_testval123_07-tendermint-0"},
+		},
+		{
+			name:           "should not alert on a client without a known expiry",
+			clients:        []IBCClientStatus{{ClientID: "06-solomachine-0", Status: "Active"}},
+			expectedActive: []string{},
+		},
+		{
+			name:             "should resolve once the client was updated",
+			existingAlerts:   []string{"IBCClientExpiring_testval123_07-tendermint-0"},
+			clients:          []IBCClientStatus{client("Active", 10*24*time.Hour)},
+			expectedResolved: true,
+			expectedActive:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset alarms for each test
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{"test-chain": {}}
+			for _, id := range tt.existingAlerts {
+				testAlarms.AllAlarms["test-chain"][id] = alertMsgCache{Message: "test alert", SentTime: time.Now()}
+			}
+
+			expiryHours := 72
+			cc := &ChainConfig{
+				name:       "test-chain",
+				ChainId:    "test-chain-1",
+				ValAddress: "testval123",
+				valInfo: &ValInfo{
+					Moniker:    "test-validator",
+					IBCClients: tt.clients,
+				},
+				Alerts: AlertConfig{IBCClientExpiryHours: &expiryHours},
+			}
+
+			alert, resolved := evaluateIBCClientExpiryAlert(cc)
+			// drain the alert channel so it never fills up
+			for len(td.alertChan) > 0 {
+				<-td.alertChan
+			}
+
+			if alert != tt.expectedAlert {
+				t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
+			}
+			if resolved != tt.expectedResolved {
+				t.Errorf("expected resolved %v, got %v", tt.expectedResolved, resolved)
+			}
+			active := make([]string, 0)
+			for id := range testAlarms.AllAlarms["test-chain"] {
+				active = append(active, id)
+			}
+			sort.Strings(active)
+			if !reflect.DeepEqual(active, tt.expectedActive) {
+				t.Errorf("expected active alarms %v, got %v", tt.expectedActive, active)
+			}
+		})
+	}
+}
+
+func TestEvaluateUpcomingUpgradeAlert(t *testing.T) {
+	testAlarms := &alarmCache{
+		AllAlarms: map[string]map[string]alertMsgCache{"test-chain": {}},
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	cc := &ChainConfig{
+		name:       "test-chain",
+		ChainId:    "test-chain-1",
+		ValAddress: "testval123",
+		Alerts:     AlertConfig{UpgradeWarningBlocks: intPtr(50)},
+	}
+	plan := &upgrade.Plan{Name: "v2", Height: 100}
+
+	// the steps run in order, the chain advances towards the upgrade planned at height 100
+	steps := []struct {
+		name             string
+		height           int64
+		plan             *upgrade.Plan
+		expectedAlert    bool
+		expectedResolved bool
+		expectedMessage  string
+	}{
+		{name: "should not alert before the warning blocks", height: 40, plan: plan},
+		{
+			name:            "should alert within the warning blocks",
+			height:          60,
+			plan:            plan,
+			expectedAlert:   true,
+			expectedMessage: "upgrade v2 on test-chain-1 is scheduled at height 100, 40 blocks from now",
+		},
+		{name: "should alert only once", height: 80, plan: plan},
+		{name: "should keep firing while halted before the upgrade", height: 99, plan: plan},
+		{
+			name:             "should resolve once the upgrade height is reached",
+			height:           100,
+			expectedResolved: true,
+			expectedMessage:  "upgrade v2 on test-chain-1 reached height 100",
+		},
+		{name: "should alert again for the next upgrade", height: 160, plan: &upgrade.Plan{Name: "v3", Height: 200}, expectedAlert: true},
+		{
+			name:             "should resolve when the plan is cancelled",
+			height:           170,
+			expectedResolved: true,
+			expectedMessage:  "the scheduled upgrade on test-chain-1 was cancelled",
+		},
+	}
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			cc.lastBlockNum = step.height
+			cc.setUpgradePlan(step.plan)
+
+			alert, resolved := evaluateUpcomingUpgradeAlert(cc)
+			for len(td.alertChan) > 0 {
+				msg := <-td.alertChan
+				if step.expectedMessage != "" && msg.message != step.expectedMessage {
+					t.Errorf("expected message %q, got %q", step.expectedMessage, msg.message)
+				}
+			}
+			if alert != step.expectedAlert {
+				t.Errorf("expected alert %v, got %v", step.expectedAlert, alert)
+			}
+			if resolved != step.expectedResolved {
+				t.Errorf("expected resolved %v, got %v", step.expectedResolved, resolved)
+			}
+		})
+	}
+}
+
+func TestEvaluateConsensusParticipationAlert(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	// Setup test td
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	// blocks builds a history with the given number of prevote and precommit misses, the rest are signed
+	blocks := func(prevote, precommit int) []int {
+		b := make([]int, 100)
+		for i := range b {
+			switch {
+			case i < prevote:
+				b[i] = int(StatusPrevote)
+			case i < prevote+precommit:
+				b[i] = int(StatusPrecommit)
+			default:
+				b[i] = int(StatusSigned)
+			}
+		}
+		return b
+	}
+
+	tests := []struct {
+		name               string
+		blocks             []int
+		prevoteThreshold   int
+		precommitThreshold int
+		existingAlerts     []string
+		expectedAlert      bool
+		expectedResolved   bool
+		expectedActive     []string
+	}{
+		{
+			name:               "should alert on prevote misses above the threshold",
+			blocks:             blocks(6, 0),
+			prevoteThreshold:   5,
+			precommitThreshold: 5,
+			expectedAlert:      true,
+			expectedActive:     []string{"PrevoteMiss_testval123"},
+		},
+		{
+			name:               "should alert on precommit misses above the threshold",
+			blocks:             blocks(0, 6),
+			prevoteThreshold:   5,
+			precommitThreshold: 5,
+			expectedAlert:      true,
+			expectedActive:     []string{"PrecommitMiss_testval123"},
+		},
+		{
+			name:               "should not alert at the threshold",
+			blocks:             blocks(5, 5),
+			prevoteThreshold:   5,
+			precommitThreshold: 5,
+			expectedActive:     []string{},
+		},
+		{
+			name:               "should not count plain missed blocks",
+			blocks:             append(blocks(0, 0)[:90], make([]int, 10)...),
+			prevoteThreshold:   5,
+			precommitThreshold: 5,
+			expectedActive:     []string{},
+		},
+		{
+			name:           "should not alert when the thresholds are not set",
+			blocks:         blocks(50, 50),
+			expectedActive: []string{},
+		},
+		{
+			name:               "should resolve only the recovered kind",
+			blocks:             blocks(0, 10),
+			prevoteThreshold:   5,
+			precommitThreshold: 5,
+			existingAlerts:     []string{"PrevoteMiss_testval123", "PrecommitMiss_testval123"},
+			expectedResolved:   true,
+			expectedActive:     []string{"PrecommitMiss_testval123"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset alarms for each test
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{"test-chain": {}}
+			for _, id := range tt.existingAlerts {
+				testAlarms.AllAlarms["test-chain"][id] = alertMsgCache{Message: "test alert", SentTime: time.Now()}
+			}
+
+			cc := &ChainConfig{
+				name:       "test-chain",
+				ChainId:    "test-chain-1",
+				ValAddress: "testval123",
+				valInfo:    &ValInfo{Moniker: "test-validator"},
+				Alerts: AlertConfig{
+					PrevoteMissThreshold:   &tt.prevoteThreshold,
+					PrecommitMissThreshold: &tt.precommitThreshold,
+				},
+			}
+			cc.setConsensusMisses(tt.blocks)
+
+			alert, resolved := evaluateConsensusParticipationAlert(cc)
+			// drain the alert channel so it never fills up
+			for len(td.alertChan) > 0 {
+				<-td.alertChan
+			}
+
+			if alert != tt.expectedAlert {
+				t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
+			}
+			if resolved != tt.expectedResolved {
+				t.Errorf("expected resolved %v, got %v", tt.expectedResolved, resolved)
+			}
+			active := make([]string, 0)
+			for id := range testAlarms.AllAlarms["test-chain"] {
+				active = append(active, id)
+			}
+			sort.Strings(active)
+			if !reflect.DeepEqual(active, tt.expectedActive) {
+				t.Errorf("expected active alarms %v, got %v", tt.expectedActive, active)
+			}
+		})
+	}
+}
+
+func TestEvaluateUptimeSLAAlert(t *testing.T) {
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	// blocks builds a history of 100 blocks, newest first, with missed blocks and blocks from before the start
+	blocks := func(missed, unknown int) []int {
+		b := make([]int, 100)
+		for i := range b {
+			switch {
+			case i < missed:
+				b[i] = int(Statusmissed)
+			case i >= len(b)-unknown:
+				b[i] = -1
+			default:
+				b[i] = int(StatusSigned)
+			}
+		}
+		return b
+	}
+
+	tests := []struct {
+		name             string
+		blocks           []int
+		minUptime        float64
+		window           int
+		existingAlert    bool
+		expectedAlert    bool
+		expectedResolved bool
+		expectedMessage  string
+	}{
+		{
+			name:            "should alert below the uptime target",
+			blocks:          blocks(2, 0),
+			minUptime:       99,
+			expectedAlert:   true,
+			expectedMessage: "test-validator signed 98.00% of the last 100 blocks on test-chain-1, below the uptime target of 99.00%",
+		},
+		{
+			name:      "should not alert at the uptime target",
+			blocks:    blocks(1, 0),
+			minUptime: 99,
+		},
+		{
+			name:             "should resolve once back above the target",
+			blocks:           blocks(0, 0),
+			minUptime:        99,
+			existingAlert:    true,
+			expectedResolved: true,
+		},
+		{
+			name:            "should only count the configured window",
+			blocks:          blocks(2, 50),
+			minUptime:       99,
+			window:          20,
+			expectedAlert:   true,
+			expectedMessage: "test-validator signed 90.00% of the last 20 blocks on test-chain-1, below the uptime target of 99.00%",
+		},
+		{
+			name:      "should wait until the whole window was observed",
+			blocks:    blocks(2, 50),
+			minUptime: 99,
+		},
+		{
+			name:   "should not alert without a target",
+			blocks: blocks(50, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{"test-chain": {}}
+			if tt.existingAlert {
+				testAlarms.AllAlarms["test-chain"]["UptimeSLA_testval123"] = alertMsgCache{Message: "test alert", SentTime: time.Now()}
+			}
+			cc := &ChainConfig{
+				name:       "test-chain",
+				ChainId:    "test-chain-1",
+				ValAddress: "testval123",
+				valInfo:    &ValInfo{Moniker: "test-validator"},
+				Alerts:     AlertConfig{MinUptimePercent: &tt.minUptime, UptimeWindowBlocks: &tt.window},
+			}
+			cc.setConsensusMisses(tt.blocks)
+
+			alert, resolved := evaluateUptimeSLAAlert(cc)
+			for len(td.alertChan) > 0 {
+				msg := <-td.alertChan
+				if tt.expectedMessage != "" && msg.message != tt.expectedMessage {
+					t.Errorf("expected message %q, got %q", tt.expectedMessage, msg.message)
+				}
+			}
+			if alert != tt.expectedAlert {
+				t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
+			}
+			if resolved != tt.expectedResolved {
+				t.Errorf("expected resolved %v, got %v", tt.expectedResolved, resolved)
+			}
+		})
+	}
+}
+
+func TestEvaluateProposerAnomalyAlert(t *testing.T) {
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	// blocks builds a history of 100 blocks, newest first, with proposed blocks and blocks from before the start
+	blocks := func(proposed, unknown int) []int {
+		b := make([]int, 100)
+		for i := range b {
+			switch {
+			case i < proposed:
+				b[i] = int(StatusProposed)
+			case i >= len(b)-unknown:
+				b[i] = -1
+			default:
+				b[i] = int(StatusSigned)
+			}
+		}
+		return b
+	}
+
+	tests := []struct {
+		name             string
+		blocks           []int
+		votingPower      float64
+		bonded           bool
+		maxDeviation     float64
+		existingAlert    bool
+		expectedAlert    bool
+		expectedResolved bool
+		expectedMessage  string
+	}{
+		{
+			name:            "should alert when proposing far fewer blocks than expected",
+			blocks:          blocks(2, 0),
+			votingPower:     0.1,
+			bonded:          true,
+			maxDeviation:    50,
+			expectedAlert:   true,
+			expectedMessage: "test-validator proposed 2 of the last 100 blocks on test-chain-1, 10.0 were expected from its 10.00% of the voting power",
+		},
+		{
+			name:          "should alert when proposing far more blocks than expected",
+			blocks:        blocks(30, 0),
+			votingPower:   0.1,
+			bonded:        true,
+			maxDeviation:  50,
+			expectedAlert: true,
+		},
+		{
+			name:         "should not alert within the deviation",
+			blocks:       blocks(7, 0),
+			votingPower:  0.1,
+			bonded:       true,
+			maxDeviation: 50,
+		},
+		{
+			name:             "should resolve once the proposals are back in line",
+			blocks:           blocks(10, 0),
+			votingPower:      0.1,
+			bonded:           true,
+			maxDeviation:     50,
+			existingAlert:    true,
+			expectedResolved: true,
+		},
+		{
+			name:         "should wait until the whole history was observed",
+			blocks:       blocks(0, 10),
+			votingPower:  0.1,
+			bonded:       true,
+			maxDeviation: 50,
+		},
+		{
+			name:         "should not alert when too few proposals are expected",
+			blocks:       blocks(0, 0),
+			votingPower:  0.01,
+			bonded:       true,
+			maxDeviation: 50,
+		},
+		{
+			name:         "should not alert outside the active set",
+			blocks:       blocks(0, 0),
+			votingPower:  0.1,
+			maxDeviation: 50,
+		},
+		{
+			name:        "should not alert without a deviation",
+			blocks:      blocks(0, 0),
+			votingPower: 0.1,
+			bonded:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{"test-chain": {}}
+			if tt.existingAlert {
+				testAlarms.AllAlarms["test-chain"]["ProposerAnomaly_testval123"] = alertMsgCache{Message: "test alert", SentTime: time.Now()}
+			}
+			cc := &ChainConfig{
+				name:       "test-chain",
+				ChainId:    "test-chain-1",
+				ValAddress: "testval123",
+				valInfo:    &ValInfo{Moniker: "test-validator", Bonded: tt.bonded, VotingPowerPercent: tt.votingPower},
+				Alerts:     AlertConfig{ProposerAnomalyPercent: &tt.maxDeviation},
+			}
+			cc.setConsensusMisses(tt.blocks)
+
+			alert, resolved := evaluateProposerAnomalyAlert(cc)
+			for len(td.alertChan) > 0 {
+				msg := <-td.alertChan
+				if tt.expectedMessage != "" && msg.message != tt.expectedMessage {
+					t.Errorf("expected message %q, got %q", tt.expectedMessage, msg.message)
+				}
+			}
+			if alert != tt.expectedAlert {
+				t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
+			}
+			if resolved != tt.expectedResolved {
+				t.Errorf("expected resolved %v, got %v", tt.expectedResolved, resolved)
+			}
+		})
 	}
-	if update.name != "test-chain" {
-		t.Errorf("Expected name to be 'test-chain', got '%s'", update.name)
+}
+
+func TestEvaluateMonikerChangeAlert(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
 	}
-	if update.chainId != "test-chain-1" {
-		t.Errorf("Expected chainId to be 'test-chain-1', got '%s'", update.chainId)
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	// Setup test td
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name           string
+		lastMoniker    string
+		moniker        string
+		monikerAlerted string
+		expectedAlert  bool
+	}{
+		{
+			name:        "should not alert when the moniker is unchanged",
+			lastMoniker: "test-validator",
+			moniker:     "test-validator",
+		},
+		{
+			name:          "should alert when the moniker changes",
+			lastMoniker:   "test-validator",
+			moniker:       "renamed-validator",
+			expectedAlert: true,
+		},
+		{
+			name:           "should not alert twice for the same change",
+			lastMoniker:    "test-validator",
+			moniker:        "renamed-validator",
+			monikerAlerted: "renamed-validator",
+		},
+		{
+			name:    "should not alert without a previous moniker",
+			moniker: "test-validator",
+		},
 	}
-	if update.moniker != "test-validator" {
-		t.Errorf("Expected moniker to be 'test-validator', got '%s'", update.moniker)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{"test-chain": {}}
+
+			cc := &ChainConfig{
+				name:           "test-chain",
+				ChainId:        "test-chain-1",
+				ValAddress:     "testval123",
+				valInfo:        &ValInfo{Moniker: tt.moniker},
+				lastValInfo:    &ValInfo{Moniker: tt.lastMoniker},
+				monikerAlerted: tt.monikerAlerted,
+			}
+
+			alert, resolved := evaluateMonikerChangeAlert(cc)
+			sent := len(td.alertChan)
+			for len(td.alertChan) > 0 {
+				msg := <-td.alertChan
+				if !msg.oneShot || msg.severity != "info" || !strings.HasPrefix(msg.uniqueId, "MonikerChange_testval123_") {
+					t.Errorf("unexpected alert %s with severity %s", msg.uniqueId, msg.severity)
+				}
+			}
+
+			if alert != tt.expectedAlert {
+				t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
+			}
+			if resolved {
+				t.Error("expected a moniker change never to resolve")
+			}
+			if tt.expectedAlert != (sent == 1) {
+				t.Errorf("expected alert %v, but %d notifications were queued", tt.expectedAlert, sent)
+			}
+			if len(testAlarms.AllAlarms["test-chain"]) != 0 {
+				t.Errorf("expected no active alarms, got %v", testAlarms.AllAlarms["test-chain"])
+			}
+			if tt.expectedAlert && cc.monikerAlerted != tt.moniker {
+				t.Errorf("expected the change to %q to be remembered, got %q", tt.moniker, cc.monikerAlerted)
+			}
+		})
 	}
-	if update.endpoint != "http://node1.example.com" {
-		t.Errorf("Expected endpoint to be 'http://node1.example.com', got '%s'", update.endpoint)
+}
+
+func TestEvaluateConsKeyChangeAlert(t *testing.T) {
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name           string
+		lastConspub    []byte
+		conspub        []byte
+		consKeyAlerted string
+		expectedAlert  bool
+	}{
+		{
+			name:        "should not alert when the key is unchanged",
+			lastConspub: []byte{0x01, 0x02},
+			conspub:     []byte{0x01, 0x02},
+		},
+		{
+			name:          "should alert when the key changes",
+			lastConspub:   []byte{0x01, 0x02},
+			conspub:       []byte{0x03, 0x04},
+			expectedAlert: true,
+		},
+		{
+			name:           "should not alert twice for the same change",
+			lastConspub:    []byte{0x01, 0x02},
+			conspub:        []byte{0x03, 0x04},
+			consKeyAlerted: "0304",
+		},
+		{
+			name:    "should not alert without a previous key",
+			conspub: []byte{0x01, 0x02},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{"test-chain": {}}
+
+			cc := &ChainConfig{
+				name:           "test-chain",
+				ChainId:        "test-chain-1",
+				ValAddress:     "testval123",
+				valInfo:        &ValInfo{Conspub: tt.conspub},
+				lastValInfo:    &ValInfo{Conspub: tt.lastConspub},
+				consKeyAlerted: tt.consKeyAlerted,
+			}
+
+			alert, resolved := evaluateConsKeyChangeAlert(cc)
+			sent := len(td.alertChan)
+			for len(td.alertChan) > 0 {
+				msg := <-td.alertChan
+				if !msg.oneShot || msg.severity != "critical" || !strings.HasPrefix(msg.uniqueId, "ConsKeyChange_testval123_") || !strings.Contains(msg.message, "from 0102 to 0304") {
+					t.Errorf("unexpected alert %s with severity %s: %s", msg.uniqueId, msg.severity, msg.message)
+				}
+			}
+
+			if alert != tt.expectedAlert {
+				t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
+			}
+			if resolved {
+				t.Error("expected a consensus key change never to resolve")
+			}
+			if tt.expectedAlert != (sent == 1) {
+				t.Errorf("expected alert %v, but %d notifications were queued", tt.expectedAlert, sent)
+			}
+			if len(testAlarms.AllAlarms["test-chain"]) != 0 {
+				t.Errorf("expected no active alarms, got %v", testAlarms.AllAlarms["test-chain"])
+			}
+			if tt.expectedAlert && cc.consKeyAlerted != "0304" {
+				t.Errorf("expected the change to be remembered, got %q", cc.consKeyAlerted)
+			}
+		})
+	}
+}
+
+func TestEvaluateFirstSignAlert(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	// Setup test td
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	type check struct {
+		bonded        bool
+		newest        StatusType
+		expectedAlert bool
+	}
+	tests := []struct {
+		name    string
+		queried bool
+		checks  []check
+	}{
+		{
+			name:    "should alert once on the first signed block after being bonded",
+			queried: true,
+			checks: []check{
+				{bonded: false, newest: Statusmissed},
+				{bonded: true, newest: Statusmissed},
+				{bonded: true, newest: StatusSigned, expectedAlert: true},
+				{bonded: true, newest: StatusSigned},
+			},
+		},
+		{
+			name:    "should not alert for a validator that was bonded from the start",
+			queried: true,
+			checks: []check{
+				{bonded: true, newest: StatusSigned},
+				{bonded: true, newest: StatusProposed},
+			},
+		},
+		{
+			name:    "should alert again after leaving and rejoining the active set",
+			queried: true,
+			checks: []check{
+				{bonded: false, newest: Statusmissed},
+				{bonded: true, newest: StatusProposed, expectedAlert: true},
+				{bonded: false, newest: Statusmissed},
+				{bonded: true, newest: StatusSigned, expectedAlert: true},
+			},
+		},
+		{
+			name: "should not alert before the validator info was queried",
+			checks: []check{
+				{bonded: false, newest: Statusmissed},
+				{bonded: true, newest: StatusSigned},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{"test-chain": {}}
+
+			cc := &ChainConfig{
+				name:       "test-chain",
+				ChainId:    "test-chain-1",
+				ValAddress: "testval123",
+				valInfo:    &ValInfo{Moniker: "test-validator"},
+			}
+			if tt.queried {
+				cc.valInfoUpdated = time.Now()
+			}
+			for i, c := range tt.checks {
+				cc.valInfo.Bonded = c.bonded
+				cc.setConsensusMisses([]int{int(c.newest), int(Statusmissed)})
+
+				alert, resolved := evaluateFirstSignAlert(cc)
+				sent := len(td.alertChan)
+				for len(td.alertChan) > 0 {
+					msg := <-td.alertChan
+					if !msg.oneShot || msg.severity != "info" || !strings.HasPrefix(msg.uniqueId, "FirstSign_testval123_") {
+						t.Errorf("unexpected alert %s with severity %s", msg.uniqueId, msg.severity)
+					}
+				}
+
+				if alert != c.expectedAlert || c.expectedAlert != (sent == 1) {
+					t.Errorf("check %d: expected alert %v, got %v with %d notifications queued", i, c.expectedAlert, alert, sent)
+				}
+				if resolved {
+					t.Errorf("check %d: expected the first sign alert never to resolve", i)
+				}
+				if len(testAlarms.AllAlarms["test-chain"]) != 0 {
+					t.Errorf("check %d: expected no active alarms, got %v", i, testAlarms.AllAlarms["test-chain"])
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluateChainParamChangeAlert(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	// Setup test td
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name              string
+		totalSupply       float64
+		paramsSeen        bool
+		lastCommunityTax  float64
+		lastInflationRate float64
+		communityTax      float64
+		inflationRate     float64
+		expectedAlert     bool
+		expectedMessage   string
+	}{
+		{
+			name:          "should only record the values on the first refresh",
+			totalSupply:   1000,
+			communityTax:  0.02,
+			inflationRate: 0.07,
+		},
+		{
+			name:              "should not alert when nothing changed",
+			totalSupply:       1000,
+			paramsSeen:        true,
+			lastCommunityTax:  0.02,
+			lastInflationRate: 0.07,
+			communityTax:      0.02,
+			inflationRate:     0.07,
+		},
+		{
+			name:              "should ignore rounding noise",
+			totalSupply:       1000,
+			paramsSeen:        true,
+			lastCommunityTax:  0.02,
+			lastInflationRate: 0.07,
+			communityTax:      0.0200000001,
+			inflationRate:     0.0699999999,
+		},
+		{
+			name:              "should alert when the community tax changes",
+			totalSupply:       1000,
+			paramsSeen:        true,
+			lastCommunityTax:  0.02,
+			lastInflationRate: 0.07,
+			communityTax:      0.05,
+			inflationRate:     0.07,
+			expectedAlert:     true,
+			expectedMessage:   "economic parameters changed on test-chain-1: community tax from 2.00% to 5.00%",
+		},
+		{
+			name:              "should alert once for both changes",
+			totalSupply:       1000,
+			paramsSeen:        true,
+			lastCommunityTax:  0.02,
+			lastInflationRate: 0.07,
+			communityTax:      0.05,
+			inflationRate:     0.1,
+			expectedAlert:     true,
+			expectedMessage:   "economic parameters changed on test-chain-1: community tax from 2.00% to 5.00%, inflation from 7.00% to 10.00%",
+		},
+		{
+			name:              "should not alert before the chain info is known",
+			paramsSeen:        true,
+			lastCommunityTax:  0.02,
+			lastInflationRate: 0.07,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{"test-chain": {}}
+
+			cc := &ChainConfig{
+				name:              "test-chain",
+				ChainId:           "test-chain-1",
+				ValAddress:        "testval123",
+				totalSupply:       tt.totalSupply,
+				communityTax:      tt.communityTax,
+				inflationRate:     tt.inflationRate,
+				paramsSeen:        tt.paramsSeen,
+				lastCommunityTax:  tt.lastCommunityTax,
+				lastInflationRate: tt.lastInflationRate,
+			}
+
+			alert, resolved := evaluateChainParamChangeAlert(cc)
+			sent := len(td.alertChan)
+			for len(td.alertChan) > 0 {
+				msg := <-td.alertChan
+				if !msg.oneShot || msg.severity != "info" || !strings.HasPrefix(msg.uniqueId, "ChainParamChange_testval123_") {
+					t.Errorf("unexpected alert %s with severity %s", msg.uniqueId, msg.severity)
+				}
+				if msg.message != tt.expectedMessage {
+					t.Errorf("expected message %q, got %q", tt.expectedMessage, msg.message)
+				}
+			}
+
+			if alert != tt.expectedAlert {
+				t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
+			}
+			if resolved {
+				t.Error("expected a parameter change never to resolve")
+			}
+			if tt.expectedAlert != (sent == 1) {
+				t.Errorf("expected alert %v, but %d notifications were queued", tt.expectedAlert, sent)
+			}
+			if len(testAlarms.AllAlarms["test-chain"]) != 0 {
+				t.Errorf("expected no active alarms, got %v", testAlarms.AllAlarms["test-chain"])
+			}
+			if tt.totalSupply != 0 && (!cc.paramsSeen || cc.lastCommunityTax != tt.communityTax || cc.lastInflationRate != tt.inflationRate) {
+				t.Errorf("expected the current values to be remembered, got %v %v", cc.lastCommunityTax, cc.lastInflationRate)
+			}
+		})
+	}
+}
+
+func TestEvaluateSlashEventAlert(t *testing.T) {
+	// Setup test alarm cache
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	// Setup test td
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name             string
+		slashes          []*slashEvent
+		expectedMessages []string
+	}{
+		{
+			name: "should not alert without a slash",
+		},
+		{
+			name:             "should alert with the reason and amount",
+			slashes:          []*slashEvent{{Height: 100, Reason: "missing_signature", Amount: "5000uatom"}},
+			expectedMessages: []string{"validator testval123 was slashed on test-chain-1 at height 100, reason: missing_signature, burned: 5000uatom"},
+		},
+		{
+			name:    "should alert once per slash",
+			slashes: []*slashEvent{{Height: 100}, {Height: 200, Reason: "double_sign"}},
+			expectedMessages: []string{
+				"validator testval123 was slashed on test-chain-1 at height 100",
+				"validator testval123 was slashed on test-chain-1 at height 200, reason: double_sign",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{"test-chain": {}}
+
+			cc := &ChainConfig{
+				name:       "test-chain",
+				ChainId:    "test-chain-1",
+				ValAddress: "testval123",
+			}
+			for _, slash := range tt.slashes {
+				cc.recordSlash(slash)
+			}
+
+			alert, resolved := evaluateSlashEventAlert(cc)
+			messages := make([]string, 0)
+			for len(td.alertChan) > 0 {
+				msg := <-td.alertChan
+				if !msg.oneShot || msg.severity != "critical" || !strings.HasPrefix(msg.uniqueId, "SlashEvent_testval123_") {
+					t.Errorf("unexpected alert %s with severity %s", msg.uniqueId, msg.severity)
+				}
+				messages = append(messages, msg.message)
+			}
+
+			if alert != (len(tt.expectedMessages) > 0) {
+				t.Errorf("expected alert %v, got %v", len(tt.expectedMessages) > 0, alert)
+			}
+			if resolved {
+				t.Error("expected a slash never to resolve")
+			}
+			if len(tt.expectedMessages) > 0 && !reflect.DeepEqual(messages, tt.expectedMessages) {
+				t.Errorf("expected messages %v, got %v", tt.expectedMessages, messages)
+			}
+			if len(testAlarms.AllAlarms["test-chain"]) != 0 {
+				t.Errorf("expected no active alarms, got %v", testAlarms.AllAlarms["test-chain"])
+			}
+			if len(cc.takeSlashes()) != 0 {
+				t.Error("expected the slashes to be taken")
+			}
+		})
 	}
 }
 
-func TestEvaluateConsecutiveBlocksMissedAlert(t *testing.T) {
+func TestEvaluateConsecutiveVoteMissAlert(t *testing.T) {
 	// Setup test alarm cache
 	testAlarms := &alarmCache{
 		AllAlarms: make(map[string]map[string]alertMsgCache),
@@ -717,90 +5142,115 @@ func TestEvaluateConsecutiveBlocksMissedAlert(t *testing.T) {
 	defer func() { td = originalTd }()
 
 	tests := []struct {
-		name                   string
-		consecutiveMiss        float64
-		consecutiveMissedAlert int
-		existingAlert          bool
-		expectedAlert          bool
-		expectedResolved       bool
-		description            string
+		name               string
+		statuses           []StatusType
+		prevoteThreshold   int
+		precommitThreshold int
+		existingAlerts     []string
+		expectedAlert      bool
+		expectedResolved   bool
+		expectedActive     []string
 	}{
 		{
-			name:                   "should trigger alert when consecutive misses exceed threshold",
-			consecutiveMiss:        5,
-			consecutiveMissedAlert: 3,
-			existingAlert:          false,
-			expectedAlert:          true,
-			expectedResolved:       false,
-			description:            "Alert should trigger when consecutive misses exceed threshold",
+			name:               "should alert on a prevote streak at the threshold",
+			statuses:           []StatusType{StatusSigned, StatusPrevote, StatusPrevote, StatusPrevote},
+			prevoteThreshold:   3,
+			precommitThreshold: 3,
+			expectedAlert:      true,
+			expectedActive:     []string{"ConsecutivePrevoteMiss_testval123"},
 		},
 		{
-			name:                   "should not trigger duplicate alert",
-			consecutiveMiss:        5,
-			consecutiveMissedAlert: 3,
-			existingAlert:          true,
-			expectedAlert:          false,
-			expectedResolved:       false,
-			description:            "Should not trigger duplicate alert when already exists",
+			name:               "should alert on a precommit streak at the threshold",
+			statuses:           []StatusType{StatusPrecommit, StatusPrecommit, StatusPrecommit},
+			prevoteThreshold:   3,
+			precommitThreshold: 3,
+			expectedAlert:      true,
+			expectedActive:     []string{"ConsecutivePrecommitMiss_testval123"},
 		},
 		{
-			name:                   "should resolve alert when consecutive misses drop below threshold",
-			consecutiveMiss:        2,
-			consecutiveMissedAlert: 3,
-			existingAlert:          true,
-			expectedAlert:          false,
-			expectedResolved:       true,
-			description:            "Should resolve alert when consecutive misses drop below threshold",
+			name:               "should not alert when the streak is broken",
+			statuses:           []StatusType{StatusPrevote, StatusPrevote, StatusSigned, StatusPrevote},
+			prevoteThreshold:   3,
+			precommitThreshold: 3,
+			expectedActive:     []string{},
 		},
 		{
-			name:                   "should not resolve non-existing alert",
-			consecutiveMiss:        2,
-			consecutiveMissedAlert: 3,
-			existingAlert:          false,
-			expectedAlert:          false,
-			expectedResolved:       false,
-			description:            "Should not resolve alert that doesn't exist",
+			name:               "should not mix prevote and precommit misses",
+			statuses:           []StatusType{StatusPrevote, StatusPrecommit, StatusPrevote, StatusPrecommit},
+			prevoteThreshold:   2,
+			precommitThreshold: 2,
+			expectedActive:     []string{},
+		},
+		{
+			name:               "should not count plain missed blocks",
+			statuses:           []StatusType{Statusmissed, Statusmissed, Statusmissed},
+			prevoteThreshold:   2,
+			precommitThreshold: 2,
+			expectedActive:     []string{},
+		},
+		{
+			name:           "should not alert when the thresholds are not set",
+			statuses:       []StatusType{StatusPrevote, StatusPrevote, StatusPrevote},
+			expectedActive: []string{},
+		},
+		{
+			name:               "should resolve only the recovered kind",
+			statuses:           []StatusType{StatusPrecommit, StatusPrecommit},
+			prevoteThreshold:   2,
+			precommitThreshold: 2,
+			existingAlerts:     []string{"ConsecutivePrevoteMiss_testval123", "ConsecutivePrecommitMiss_testval123"},
+			expectedResolved:   true,
+			expectedActive:     []string{"ConsecutivePrecommitMiss_testval123"},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Reset alarms for each test
-			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{"test-chain": {}}
+			for _, id := range tt.existingAlerts {
+				testAlarms.AllAlarms["test-chain"][id] = alertMsgCache{Message: "test alert", SentTime: time.Now()}
+			}
 
 			cc := &ChainConfig{
-				name:                "test-chain",
-				ChainId:             "test-chain-1",
-				ValAddress:          "testval123",
-				statConsecutiveMiss: tt.consecutiveMiss,
-				valInfo:             &ValInfo{Moniker: "test-validator"},
+				name:       "test-chain",
+				ChainId:    "test-chain-1",
+				ValAddress: "testval123",
+				valInfo:    &ValInfo{Moniker: "test-validator"},
 				Alerts: AlertConfig{
-					ConsecutiveMissed:   &tt.consecutiveMissedAlert,
-					ConsecutivePriority: "critical",
+					ConsecutivePrevoteMissed:   &tt.prevoteThreshold,
+					ConsecutivePrecommitMissed: &tt.precommitThreshold,
 				},
 			}
-
-			if tt.existingAlert {
-				testAlarms.AllAlarms["test-chain"] = make(map[string]alertMsgCache)
-				testAlarms.AllAlarms["test-chain"]["ConsecutiveBlocksMissed_testval123"] = alertMsgCache{
-					Message:  "test alert",
-					SentTime: time.Now(),
-				}
+			for _, status := range tt.statuses {
+				cc.recordVoteStreak(status)
 			}
 
-			alert, resolved := evaluateConsecutiveBlocksMissedAlert(cc)
+			alert, resolved := evaluateConsecutiveVoteMissAlert(cc)
+			// drain the alert channel so it never fills up
+			for len(td.alertChan) > 0 {
+				<-td.alertChan
+			}
 
 			if alert != tt.expectedAlert {
-				t.Errorf("%s: expected alert %v, got %v", tt.description, tt.expectedAlert, alert)
+				t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
 			}
 			if resolved != tt.expectedResolved {
-				t.Errorf("%s: expected resolved %v, got %v", tt.description, tt.expectedResolved, resolved)
+				t.Errorf("expected resolved %v, got %v", tt.expectedResolved, resolved)
+			}
+			active := make([]string, 0)
+			for id := range testAlarms.AllAlarms["test-chain"] {
+				active = append(active, id)
+			}
+			sort.Strings(active)
+			if !reflect.DeepEqual(active, tt.expectedActive) {
+				t.Errorf("expected active alarms %v, got %v", tt.expectedActive, active)
 			}
 		})
 	}
 }
 
-func TestEvaluatePercentageBlocksMissedAlert(t *testing.T) {
+func TestResolveDelay(t *testing.T) {
 	// Setup test alarm cache
 	testAlarms := &alarmCache{
 		AllAlarms: make(map[string]map[string]alertMsgCache),
@@ -815,89 +5265,88 @@ func TestEvaluatePercentageBlocksMissedAlert(t *testing.T) {
 	td = createTestConfig()
 	defer func() { td = originalTd }()
 
+	const alertID = "ConsecutiveBlocksMissed_testval123"
 	tests := []struct {
 		name             string
-		missed           int64
-		window           int64
-		windowThreshold  int
-		existingAlert    bool
-		expectedAlert    bool
+		steps            []float64 // consecutive misses seen by each evaluation
+		clearedAgo       time.Duration
 		expectedResolved bool
-		description      string
+		expectedActive   bool
 	}{
 		{
-			name:             "should trigger alert when percentage exceeds threshold",
-			missed:           15,
-			window:           100,
-			windowThreshold:  10,
-			existingAlert:    false,
-			expectedAlert:    true,
-			expectedResolved: false,
-			description:      "Alert should trigger when missed percentage exceeds threshold",
+			name:           "should not resolve on the first clear check",
+			steps:          []float64{0},
+			expectedActive: true,
 		},
 		{
-			name:             "should not trigger duplicate alert",
-			missed:           15,
-			window:           100,
-			windowThreshold:  10,
-			existingAlert:    true,
-			expectedAlert:    false,
-			expectedResolved: false,
-			description:      "Should not trigger duplicate alert when already exists",
+			name:           "should not resolve before the delay has passed",
+			steps:          []float64{0, 0},
+			clearedAgo:     30 * time.Second,
+			expectedActive: true,
 		},
 		{
-			name:             "should resolve alert when percentage drops below threshold",
-			missed:           5,
-			window:           100,
-			windowThreshold:  10,
-			existingAlert:    true,
-			expectedAlert:    false,
+			name:             "should resolve once clear for the delay",
+			steps:            []float64{0},
+			clearedAgo:       61 * time.Second,
 			expectedResolved: true,
-			description:      "Should resolve alert when percentage drops below threshold",
+		},
+		{
+			name:           "should restart the delay when the condition fires again",
+			steps:          []float64{5, 0},
+			clearedAgo:     61 * time.Second,
+			expectedActive: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Reset alarms for each test
-			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{
+				"test-chain": {alertID: {Message: "test alert", SentTime: time.Now()}},
+			}
+			testAlarms.clearSince = nil
+			if tt.clearedAgo > 0 {
+				testAlarms.clearSince = map[string]map[string]time.Time{
+					"test-chain": {alertID: time.Now().Add(-tt.clearedAgo)},
+				}
+			}
 
+			threshold, delay := 5, 60
 			cc := &ChainConfig{
 				name:       "test-chain",
 				ChainId:    "test-chain-1",
 				ValAddress: "testval123",
-				valInfo: &ValInfo{
-					Moniker: "test-validator",
-					Missed:  tt.missed,
-					Window:  tt.window,
-				},
+				valInfo:    &ValInfo{Moniker: "test-validator"},
 				Alerts: AlertConfig{
-					Window:             &tt.windowThreshold,
-					PercentagePriority: "warning",
+					ConsecutiveMissed:   &threshold,
+					ResolveDelaySeconds: &delay,
 				},
 			}
 
-			if tt.existingAlert {
-				testAlarms.AllAlarms["test-chain"] = make(map[string]alertMsgCache)
-				testAlarms.AllAlarms["test-chain"]["PercentageBlocksMissed_testval123"] = alertMsgCache{
-					Message:  "test alert",
-					SentTime: time.Now(),
-				}
+			resolved := false
+			for _, misses := range tt.steps {
+				cc.statConsecutiveMiss = misses
+				_, r := evaluateConsecutiveBlocksMissedAlert(cc)
+				resolved = resolved || r
 			}
-
-			alert, resolved := evaluatePercentageBlocksMissedAlert(cc)
-
-			if alert != tt.expectedAlert {
-				t.Errorf("%s: expected alert %v, got %v", tt.description, tt.expectedAlert, alert)
+			// drain the alert channel so it never fills up
+			for len(td.alertChan) > 0 {
+				<-td.alertChan
 			}
+
 			if resolved != tt.expectedResolved {
-				t.Errorf("%s: expected resolved %v, got %v", tt.description, tt.expectedResolved, resolved)
+				t.Errorf("expected resolved %v, got %v", tt.expectedResolved, resolved)
+			}
+			if active := alarms.exist("test-chain", alertID); active != tt.expectedActive {
+				t.Errorf("expected alarm active %v, got %v", tt.expectedActive, active)
+			}
+			if tt.expectedResolved && !testAlarms.clearSince["test-chain"][alertID].IsZero() {
+				t.Error("expected the clear timer to be removed once resolved")
 			}
 		})
 	}
 }
 
-func TestEvaluateChainStalledAlert(t *testing.T) {
+func TestChainStalledResolveDelay(t *testing.T) {
 	// Setup test alarm cache
 	testAlarms := &alarmCache{
 		AllAlarms: make(map[string]map[string]alertMsgCache),
@@ -912,82 +5361,82 @@ func TestEvaluateChainStalledAlert(t *testing.T) {
 	td = createTestConfig()
 	defer func() { td = originalTd }()
 
+	const alertID = "ChainStalled_testval123"
 	tests := []struct {
 		name             string
-		lastBlockTime    time.Time
-		stalledMinutes   int
-		lastBlockAlarm   bool
-		expectedAlert    bool
+		steps            []time.Duration // age of the last block seen by each evaluation
+		clearedAgo       time.Duration
 		expectedResolved bool
-		description      string
+		expectedActive   bool
 	}{
 		{
-			name:             "should trigger alert when chain is stalled",
-			lastBlockTime:    time.Now().Add(-15 * time.Minute),
-			stalledMinutes:   10,
-			lastBlockAlarm:   false,
-			expectedAlert:    true,
-			expectedResolved: false,
-			description:      "Should trigger alert when chain has been stalled longer than threshold",
-		},
-		{
-			name:             "should not trigger duplicate alert",
-			lastBlockTime:    time.Now().Add(-15 * time.Minute),
-			stalledMinutes:   10,
-			lastBlockAlarm:   true,
-			expectedAlert:    false,
-			expectedResolved: false,
-			description:      "Should not trigger duplicate alert when already alarmed",
+			name:           "should not resolve on the first clear check",
+			steps:          []time.Duration{time.Minute},
+			expectedActive: true,
 		},
 		{
-			name:             "should resolve alert when chain recovers",
-			lastBlockTime:    time.Now().Add(-5 * time.Minute),
-			stalledMinutes:   10,
-			lastBlockAlarm:   true,
-			expectedAlert:    false,
+			name:             "should resolve once clear for the delay",
+			steps:            []time.Duration{time.Minute},
+			clearedAgo:       61 * time.Second,
 			expectedResolved: true,
-			description:      "Should resolve alert when chain recovers from stall",
 		},
 		{
-			name:             "should handle zero lastBlockTime",
-			lastBlockTime:    time.Time{},
-			stalledMinutes:   10,
-			lastBlockAlarm:   false,
-			expectedAlert:    false,
-			expectedResolved: false,
-			description:      "Should handle zero lastBlockTime gracefully",
+			name:           "should restart the delay when the chain stalls again",
+			steps:          []time.Duration{15 * time.Minute, time.Minute},
+			clearedAgo:     61 * time.Second,
+			expectedActive: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Reset alarms for each test
-			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{
+				"test-chain": {alertID: {Message: "test alert", SentTime: time.Now()}},
+			}
+			testAlarms.clearSince = nil
+			if tt.clearedAgo > 0 {
+				testAlarms.clearSince = map[string]map[string]time.Time{
+					"test-chain": {alertID: time.Now().Add(-tt.clearedAgo)},
+				}
+			}
 
+			stalled, delay := 10, 60
 			cc := &ChainConfig{
 				name:           "test-chain",
 				ChainId:        "test-chain-1",
 				ValAddress:     "testval123",
-				lastBlockTime:  tt.lastBlockTime,
-				lastBlockAlarm: tt.lastBlockAlarm,
+				lastBlockAlarm: true,
 				Alerts: AlertConfig{
-					Stalled: &tt.stalledMinutes,
+					Stalled:             &stalled,
+					ResolveDelaySeconds: &delay,
 				},
 			}
 
-			alert, resolved := evaluateChainStalledAlert(cc)
-
-			if alert != tt.expectedAlert {
-				t.Errorf("%s: expected alert %v, got %v", tt.description, tt.expectedAlert, alert)
+			resolved := false
+			for _, age := range tt.steps {
+				cc.lastBlockTime = time.Now().Add(-age)
+				_, r := evaluateChainStalledAlert(cc)
+				resolved = resolved || r
+			}
+			// drain the alert channel so it never fills up
+			for len(td.alertChan) > 0 {
+				<-td.alertChan
 			}
+
 			if resolved != tt.expectedResolved {
-				t.Errorf("%s: expected resolved %v, got %v", tt.description, tt.expectedResolved, resolved)
+				t.Errorf("expected resolved %v, got %v", tt.expectedResolved, resolved)
+			}
+			if active := alarms.exist("test-chain", alertID); active != tt.expectedActive {
+				t.Errorf("expected alarm active %v, got %v", tt.expectedActive, active)
+			}
+			if cc.lastBlockAlarm != tt.expectedActive {
+				t.Errorf("expected lastBlockAlarm %v, got %v", tt.expectedActive, cc.lastBlockAlarm)
 			}
 		})
 	}
 }
 
-func TestEvaluateValidatorInactiveAlert(t *testing.T) {
+func TestEvaluateBlockTimeSlowdownAlert(t *testing.T) {
 	// Setup test alarm cache
 	testAlarms := &alarmCache{
 		AllAlarms: make(map[string]map[string]alertMsgCache),
@@ -1004,84 +5453,88 @@ func TestEvaluateValidatorInactiveAlert(t *testing.T) {
 
 	tests := []struct {
 		name             string
-		currentBonded    bool
-		previousBonded   bool
-		tombstoned       bool
+		average          float64
+		maxBlockTime     float64
+		existingAlerts   []string
 		expectedAlert    bool
 		expectedResolved bool
-		description      string
+		expectedActive   []string
 	}{
 		{
-			name:             "should trigger alert when validator becomes inactive",
-			currentBonded:    false,
-			previousBonded:   true,
-			tombstoned:       false,
-			expectedAlert:    true,
-			expectedResolved: false,
-			description:      "Should alert when validator becomes jailed",
+			name:           "should alert when the average is above the maximum",
+			average:        20,
+			maxBlockTime:   15,
+			expectedAlert:  true,
+			expectedActive: []string{"BlockTimeSlowdown_testval123"},
 		},
 		{
-			name:             "should trigger alert when validator becomes tombstoned",
-			currentBonded:    false,
-			previousBonded:   true,
-			tombstoned:       true,
-			expectedAlert:    true,
-			expectedResolved: false,
-			description:      "Should alert when validator becomes tombstoned",
+			name:           "should not alert below the maximum",
+			average:        6,
+			maxBlockTime:   15,
+			expectedActive: []string{},
 		},
 		{
-			name:             "should resolve alert when validator becomes active",
-			currentBonded:    true,
-			previousBonded:   false,
-			tombstoned:       false,
-			expectedAlert:    false,
-			expectedResolved: true,
-			description:      "Should resolve alert when validator becomes active again",
+			name:           "should not alert before an average is known",
+			maxBlockTime:   15,
+			expectedActive: []string{},
 		},
 		{
-			name:             "should not alert if no state change",
-			currentBonded:    true,
-			previousBonded:   true,
-			tombstoned:       false,
-			expectedAlert:    false,
-			expectedResolved: false,
-			description:      "Should not alert if validator state hasn't changed",
+			name:           "should not alert without a maximum",
+			average:        20,
+			expectedActive: []string{},
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Reset alarms for each test
-			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
-
-			cc := &ChainConfig{
-				name:       "test-chain",
-				ChainId:    "test-chain-1",
-				ValAddress: "testval123",
-				valInfo: &ValInfo{
-					Moniker:    "test-validator",
-					Bonded:     tt.currentBonded,
-					Tombstoned: tt.tombstoned,
-				},
-				lastValInfo: &ValInfo{
-					Moniker: "test-validator",
-					Bonded:  tt.previousBonded,
-				},
+		{
+			name:             "should resolve when the average recovers",
+			average:          6,
+			maxBlockTime:     15,
+			existingAlerts:   []string{"BlockTimeSlowdown_testval123"},
+			expectedResolved: true,
+			expectedActive:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset alarms for each test
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{"test-chain": {}}
+			for _, id := range tt.existingAlerts {
+				testAlarms.AllAlarms["test-chain"][id] = alertMsgCache{Message: "test alert", SentTime: time.Now()}
 			}
 
-			alert, resolved := evaluateValidatorInactiveAlert(cc)
+			cc := &ChainConfig{
+				name:         "test-chain",
+				ChainId:      "test-chain-1",
+				ValAddress:   "testval123",
+				valInfo:      &ValInfo{Moniker: "test-validator"},
+				blockTimeEMA: tt.average,
+				Alerts:       AlertConfig{MaxBlockTimeSeconds: &tt.maxBlockTime},
+			}
+
+			alert, resolved := evaluateBlockTimeSlowdownAlert(cc)
+			// drain the alert channel so it never fills up
+			for len(td.alertChan) > 0 {
+				<-td.alertChan
+			}
 
 			if alert != tt.expectedAlert {
-				t.Errorf("%s: expected alert %v, got %v", tt.description, tt.expectedAlert, alert)
+				t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
 			}
 			if resolved != tt.expectedResolved {
-				t.Errorf("%s: expected resolved %v, got %v", tt.description, tt.expectedResolved, resolved)
+				t.Errorf("expected resolved %v, got %v", tt.expectedResolved, resolved)
+			}
+			active := make([]string, 0)
+			for id := range testAlarms.AllAlarms["test-chain"] {
+				active = append(active, id)
+			}
+			sort.Strings(active)
+			if !reflect.DeepEqual(active, tt.expectedActive) {
+				t.Errorf("expected active alarms %v, got %v", tt.expectedActive, active)
 			}
 		})
 	}
 }
 
-func TestEvaluateConsecutiveEmptyBlocksAlert(t *testing.T) {
+func TestEvaluateWrongChainIdAlert(t *testing.T) {
 	// Setup test alarm cache
 	testAlarms := &alarmCache{
 		AllAlarms: make(map[string]map[string]alertMsgCache),
@@ -1096,82 +5549,90 @@ func TestEvaluateConsecutiveEmptyBlocksAlert(t *testing.T) {
 	td = createTestConfig()
 	defer func() { td = originalTd }()
 
+	const node1 = "http://node1.example.com"
 	tests := []struct {
-		name                      string
-		consecutiveEmpty          float64
-		consecutiveEmptyThreshold int
-		existingAlert             bool
-		expectedAlert             bool
-		expectedResolved          bool
-		description               string
+		name             string
+		network          string
+		existingAlerts   []string
+		expectedAlert    bool
+		expectedResolved bool
+		expectedActive   []string
 	}{
 		{
-			name:                      "should trigger alert when consecutive empty blocks exceed threshold",
-			consecutiveEmpty:          5,
-			consecutiveEmptyThreshold: 3,
-			existingAlert:             false,
-			expectedAlert:             true,
-			expectedResolved:          false,
-			description:               "Should alert when consecutive empty blocks exceed threshold",
+			name:           "should alert when the node is on another chain",
+			network:        "other-chain-1",
+			expectedAlert:  true,
+			expectedActive: []string{"WrongChainId_testval123_" + node1},
 		},
 		{
-			name:                      "should not trigger duplicate alert",
-			consecutiveEmpty:          5,
-			consecutiveEmptyThreshold: 3,
-			existingAlert:             true,
-			expectedAlert:             false,
-			expectedResolved:          false,
-			description:               "Should not trigger duplicate alert",
+			name:           "should not alert when the chain-id matches",
+			network:        "test-chain-1",
+			expectedActive: []string{},
 		},
 		{
-			name:                      "should resolve alert when consecutive empty blocks drop below threshold",
-			consecutiveEmpty:          2,
-			consecutiveEmptyThreshold: 3,
-			existingAlert:             true,
-			expectedAlert:             false,
-			expectedResolved:          true,
-			description:               "Should resolve alert when consecutive empty blocks drop",
+			name:           "should not alert before the node answered",
+			expectedActive: []string{},
+		},
+		{
+			name:           "should not trigger duplicate alert",
+			network:        "other-chain-1",
+			existingAlerts: []string{"WrongChainId_testval123_" + node1},
+			expectedActive: []string{"WrongChainId_testval123_" + node1},
+		},
+		{
+			name:             "should resolve when the node is on the right chain again",
+			network:          "test-chain-1",
+			existingAlerts:   []string{"WrongChainId_testval123_" + node1},
+			expectedResolved: true,
+			expectedActive:   []string{},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Reset alarms for each test
-			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{"test-chain": {}}
+			for _, id := range tt.existingAlerts {
+				testAlarms.AllAlarms["test-chain"][id] = alertMsgCache{Message: "test alert", SentTime: time.Now()}
+			}
 
+			node := &NodeConfig{Url: node1}
+			if tt.network != "" {
+				node.setNetwork(tt.network)
+			}
 			cc := &ChainConfig{
-				name:                 "test-chain",
-				ChainId:              "test-chain-1",
-				ValAddress:           "testval123",
-				statConsecutiveEmpty: tt.consecutiveEmpty,
-				valInfo:              &ValInfo{Moniker: "test-validator"},
-				Alerts: AlertConfig{
-					ConsecutiveEmpty:         &tt.consecutiveEmptyThreshold,
-					ConsecutiveEmptyPriority: "warning",
-				},
+				name:       "test-chain",
+				ChainId:    "test-chain-1",
+				ValAddress: "testval123",
+				valInfo:    &ValInfo{Moniker: "test-validator"},
+				Nodes:      []*NodeConfig{node},
 			}
 
-			if tt.existingAlert {
-				testAlarms.AllAlarms["test-chain"] = make(map[string]alertMsgCache)
-				testAlarms.AllAlarms["test-chain"]["ConsecutiveEmptyBlocks_testval123"] = alertMsgCache{
-					Message:  "test alert",
-					SentTime: time.Now(),
-				}
+			alert, resolved := evaluateWrongChainIdAlert(cc)
+			// drain the alert channel so it never fills up
+			for len(td.alertChan) > 0 {
+				<-td.alertChan
 			}
 
-			alert, resolved := evaluateConsecutiveEmptyBlocksAlert(cc)
-
 			if alert != tt.expectedAlert {
-				t.Errorf("%s: expected alert %v, got %v", tt.description, tt.expectedAlert, alert)
+				t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
 			}
 			if resolved != tt.expectedResolved {
-				t.Errorf("%s: expected resolved %v, got %v", tt.description, tt.expectedResolved, resolved)
+				t.Errorf("expected resolved %v, got %v", tt.expectedResolved, resolved)
+			}
+			active := make([]string, 0)
+			for id := range testAlarms.AllAlarms["test-chain"] {
+				active = append(active, id)
+			}
+			sort.Strings(active)
+			if !reflect.DeepEqual(active, tt.expectedActive) {
+				t.Errorf("expected active alarms %v, got %v", tt.expectedActive, active)
 			}
 		})
 	}
 }
 
-func TestEvaluatePercentageEmptyBlocksAlert(t *testing.T) {
+func TestEvaluateVotingPowerShareAlert(t *testing.T) {
 	// Setup test alarm cache
 	testAlarms := &alarmCache{
 		AllAlarms: make(map[string]map[string]alertMsgCache),
@@ -1187,96 +5648,92 @@ func TestEvaluatePercentageEmptyBlocksAlert(t *testing.T) {
 	defer func() { td = originalTd }()
 
 	tests := []struct {
-		name                 string
-		totalProps           float64
-		totalPropsEmpty      float64
-		emptyWindowThreshold int
-		existingAlert        bool
-		expectedAlert        bool
-		expectedResolved     bool
-		description          string
+		name             string
+		share            float64
+		minPercent       float64
+		existingAlerts   []string
+		expectedAlert    bool
+		expectedResolved bool
+		expectedMessage  string
+		expectedActive   []string
 	}{
 		{
-			name:                 "should trigger alert when empty percentage exceeds threshold",
-			totalProps:           100,
-			totalPropsEmpty:      15,
-			emptyWindowThreshold: 10,
-			existingAlert:        false,
-			expectedAlert:        true,
-			expectedResolved:     false,
-			description:          "Should alert when empty block percentage exceeds threshold",
+			name:            "should alert when the share is below the minimum",
+			share:           0.004,
+			minPercent:      0.5,
+			expectedAlert:   true,
+			expectedMessage: "test-validator has 0.400% of the voting power on test-chain-1, below the minimum of 0.500%",
+			expectedActive:  []string{"VotingPowerShare_testval123"},
 		},
 		{
-			name:                 "should not trigger duplicate alert",
-			totalProps:           100,
-			totalPropsEmpty:      15,
-			emptyWindowThreshold: 10,
-			existingAlert:        true,
-			expectedAlert:        false,
-			expectedResolved:     false,
-			description:          "Should not trigger duplicate alert",
+			name:           "should not alert above the minimum",
+			share:          0.01,
+			minPercent:     0.5,
+			expectedActive: []string{},
 		},
 		{
-			name:                 "should resolve alert when percentage drops below threshold",
-			totalProps:           100,
-			totalPropsEmpty:      5,
-			emptyWindowThreshold: 10,
-			existingAlert:        true,
-			expectedAlert:        false,
-			expectedResolved:     true,
-			description:          "Should resolve alert when percentage drops below threshold",
+			name:           "should not alert before the share is known",
+			minPercent:     0.5,
+			expectedActive: []string{},
 		},
 		{
-			name:                 "should handle zero total props",
-			totalProps:           0,
-			totalPropsEmpty:      0,
-			emptyWindowThreshold: 10,
-			existingAlert:        false,
-			expectedAlert:        false,
-			expectedResolved:     false,
-			description:          "Should handle zero total props gracefully",
+			name:           "should not alert without a minimum",
+			share:          0.004,
+			expectedActive: []string{},
+		},
+		{
+			name:             "should resolve when the share recovers",
+			share:            0.006,
+			minPercent:       0.5,
+			existingAlerts:   []string{"VotingPowerShare_testval123"},
+			expectedResolved: true,
+			expectedActive:   []string{},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Reset alarms for each test
-			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{"test-chain": {}}
+			for _, id := range tt.existingAlerts {
+				testAlarms.AllAlarms["test-chain"][id] = alertMsgCache{Message: "test alert", SentTime: time.Now()}
+			}
 
 			cc := &ChainConfig{
-				name:                "test-chain",
-				ChainId:             "test-chain-1",
-				ValAddress:          "testval123",
-				statTotalProps:      tt.totalProps,
-				statTotalPropsEmpty: tt.totalPropsEmpty,
-				valInfo:             &ValInfo{Moniker: "test-validator"},
-				Alerts: AlertConfig{
-					EmptyWindow:             &tt.emptyWindowThreshold,
-					EmptyPercentagePriority: "warning",
-				},
+				name:       "test-chain",
+				ChainId:    "test-chain-1",
+				ValAddress: "testval123",
+				valInfo:    &ValInfo{Moniker: "test-validator", VotingPowerPercent: tt.share},
+				Alerts:     AlertConfig{MinVotingPowerPercent: &tt.minPercent},
 			}
 
-			if tt.existingAlert {
-				testAlarms.AllAlarms["test-chain"] = make(map[string]alertMsgCache)
-				testAlarms.AllAlarms["test-chain"]["PercentageEmptyBlocks_testval123"] = alertMsgCache{
-					Message:  "test alert",
-					SentTime: time.Now(),
+			alert, resolved := evaluateVotingPowerShareAlert(cc)
+			for len(td.alertChan) > 0 {
+				msg := <-td.alertChan
+				if tt.expectedMessage != "" && msg.message != tt.expectedMessage {
+					t.Errorf("expected message %q, got %q", tt.expectedMessage, msg.message)
 				}
 			}
 
-			alert, resolved := evaluatePercentageEmptyBlocksAlert(cc)
-
 			if alert != tt.expectedAlert {
-				t.Errorf("%s: expected alert %v, got %v", tt.description, tt.expectedAlert, alert)
+				t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
 			}
 			if resolved != tt.expectedResolved {
-				t.Errorf("%s: expected resolved %v, got %v", tt.description, tt.expectedResolved, resolved)
+				t.Errorf("expected resolved %v, got %v", tt.expectedResolved, resolved)
+			}
+			active := make([]string, 0)
+			for id := range testAlarms.AllAlarms["test-chain"] {
+				active = append(active, id)
+			}
+			sort.Strings(active)
+			if !reflect.DeepEqual(active, tt.expectedActive) {
+				t.Errorf("expected active alarms %v, got %v", tt.expectedActive, active)
 			}
 		})
 	}
 }
 
-func TestEvaluateNoRPCEndpointsAlert(t *testing.T) {
+func TestEvaluateHeightStuckAlert(t *testing.T) {
 	// Setup test alarm cache
 	testAlarms := &alarmCache{
 		AllAlarms: make(map[string]map[string]alertMsgCache),
@@ -1289,109 +5746,86 @@ func TestEvaluateNoRPCEndpointsAlert(t *testing.T) {
 	// Setup test td
 	originalTd := td
 	td = createTestConfig()
-	td.NodeDownMin = 1
-	td.NodeDownSeverity = "warning"
 	defer func() { td = originalTd }()
 
 	tests := []struct {
-		name               string
-		noNodes            bool
-		noNodesSec         int
-		existingAlert      bool
-		expectedAlert      bool
-		expectedResolved   bool
-		expectedNoNodesSec int
-		description        string
+		name             string
+		refreshes        int
+		checks           int
+		existingAlerts   []string
+		expectedAlert    bool
+		expectedResolved bool
+		expectedActive   []string
 	}{
 		{
-			name:               "should not trigger if node is down but not for enough waiting time",
-			noNodes:            true,
-			noNodesSec:         0,
-			existingAlert:      false,
-			expectedAlert:      false,
-			expectedResolved:   false,
-			expectedNoNodesSec: 2,
-			description:        "Should not alert when nodes have been down shorter than threshold",
-		},
-		{
-			name:               "should trigger when node is down for enough waiting time",
-			noNodes:            true,
-			noNodesSec:         60, // in this test we have set td.NodeDownMin = 1
-			existingAlert:      false,
-			expectedAlert:      true,
-			expectedResolved:   false,
-			expectedNoNodesSec: 62, // evaluation is done every 2 seconds
-			description:        "Should alert when nodes have been down longer than threshold",
+			name:           "should alert when the height is stuck for enough checks",
+			refreshes:      3,
+			checks:         3,
+			expectedAlert:  true,
+			expectedActive: []string{"HeightStuck_testval123"},
 		},
 		{
-			name:               "should not trigger duplicate alert",
-			noNodes:            true,
-			noNodesSec:         120,
-			existingAlert:      true,
-			expectedAlert:      false,
-			expectedResolved:   false,
-			expectedNoNodesSec: 122,
-			description:        "Should not trigger duplicate alert",
+			name:           "should not alert before enough checks",
+			refreshes:      2,
+			checks:         3,
+			expectedActive: []string{},
 		},
 		{
-			name:               "should resolve alert when nodes recover",
-			noNodes:            false,
-			noNodesSec:         120,
-			existingAlert:      true,
-			expectedAlert:      false,
-			expectedResolved:   true,
-			expectedNoNodesSec: 0,
-			description:        "Should resolve alert when nodes recover",
+			name:           "should not alert when disabled",
+			refreshes:      5,
+			expectedActive: []string{},
 		},
 		{
-			name:               "should increment counter when nodes still down but below threshold",
-			noNodes:            true,
-			noNodesSec:         30,
-			existingAlert:      false,
-			expectedAlert:      false,
-			expectedResolved:   false,
-			expectedNoNodesSec: 32,
-			description:        "Should increment counter but not alert when below threshold",
+			name:             "should resolve when the height advances",
+			checks:           3,
+			existingAlerts:   []string{"HeightStuck_testval123"},
+			expectedResolved: true,
+			expectedActive:   []string{},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Reset alarms for each test
-			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{"test-chain": {}}
+			for _, id := range tt.existingAlerts {
+				testAlarms.AllAlarms["test-chain"][id] = alertMsgCache{Message: "test alert", SentTime: time.Now()}
+			}
 
 			cc := &ChainConfig{
-				name:       "test-chain",
-				ChainId:    "test-chain-1",
-				ValAddress: "testval123",
-				noNodes:    tt.noNodes,
+				name:           "test-chain",
+				ChainId:        "test-chain-1",
+				ValAddress:     "testval123",
+				stuckHeight:    1000,
+				stuckRefreshes: tt.refreshes,
+				Alerts:         AlertConfig{HeightStuckChecks: &tt.checks},
 			}
 
-			if tt.existingAlert {
-				testAlarms.AllAlarms["test-chain"] = make(map[string]alertMsgCache)
-				testAlarms.AllAlarms["test-chain"]["NoRPCEndpoints_testval123"] = alertMsgCache{
-					Message:  "test alert",
-					SentTime: time.Now(),
-				}
+			alert, resolved := evaluateHeightStuckAlert(cc)
+			// drain the alert channel so it never fills up
+			for len(td.alertChan) > 0 {
+				<-td.alertChan
 			}
 
-			noNodesSec := tt.noNodesSec
-			alert, resolved := evaluateNoRPCEndpointsAlert(cc, &noNodesSec)
-
 			if alert != tt.expectedAlert {
-				t.Errorf("%s: expected alert %v, got %v", tt.description, tt.expectedAlert, alert)
+				t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
 			}
 			if resolved != tt.expectedResolved {
-				t.Errorf("%s: expected resolved %v, got %v", tt.description, tt.expectedResolved, resolved)
+				t.Errorf("expected resolved %v, got %v", tt.expectedResolved, resolved)
 			}
-			if noNodesSec != tt.expectedNoNodesSec {
-				t.Errorf("%s: expected noNodesSec %d, got %d", tt.description, tt.expectedNoNodesSec, noNodesSec)
+			active := make([]string, 0)
+			for id := range testAlarms.AllAlarms["test-chain"] {
+				active = append(active, id)
+			}
+			sort.Strings(active)
+			if !reflect.DeepEqual(active, tt.expectedActive) {
+				t.Errorf("expected active alarms %v, got %v", tt.expectedActive, active)
 			}
 		})
 	}
 }
 
-func TestEvaluateRPCNodeDownAlert(t *testing.T) {
+func TestEvaluateDelegatedTokensAlert(t *testing.T) {
 	// Setup test alarm cache
 	testAlarms := &alarmCache{
 		AllAlarms: make(map[string]map[string]alertMsgCache),
@@ -1404,135 +5838,129 @@ func TestEvaluateRPCNodeDownAlert(t *testing.T) {
 	// Setup test td
 	originalTd := td
 	td = createTestConfig()
-	td.NodeDownMin = 2
-	td.NodeDownSeverity = "warning"
 	defer func() { td = originalTd }()
 
+	metadata := &bank.Metadata{
+		DenomUnits: []*bank.DenomUnit{{Denom: "uatom", Exponent: 0}, {Denom: "atom", Exponent: 6}},
+		Base:       "uatom",
+		Display:    "atom",
+	}
+
 	tests := []struct {
 		name             string
-		nodes            []*NodeConfig
-		existingAlert    bool
+		delegated        float64
+		minTokens        float64
+		maxTokens        float64
+		metadata         *bank.Metadata
+		existingAlerts   []string
 		expectedAlert    bool
 		expectedResolved bool
-		description      string
+		expectedMessage  string
+		expectedActive   []string
 	}{
 		{
-			name: "should trigger alert when node is down longer than threshold",
-			nodes: []*NodeConfig{
-				{
-					Url:         "http://node1.example.com",
-					AlertIfDown: true,
-					down:        true,
-					wasDown:     false,
-					downSince:   time.Now().Add(-5 * time.Minute),
-				},
-			},
-			existingAlert:    false,
-			expectedAlert:    true,
-			expectedResolved: false,
-			description:      "Should alert when node is down longer than threshold",
+			name:            "should alert below the minimum",
+			delegated:       900_000_000_000,
+			minTokens:       1_000_000,
+			metadata:        metadata,
+			expectedAlert:   true,
+			expectedMessage: "test-validator has 900000.00 atom delegated on test-chain-1, below the minimum of 1000000.00 atom",
+			expectedActive:  []string{"DelegatedTokensBelow_testval123"},
+		},
+		{
+			name:            "should alert above the maximum",
+			delegated:       6_000_000_000_000,
+			minTokens:       1_000_000,
+			maxTokens:       5_000_000,
+			metadata:        metadata,
+			expectedAlert:   true,
+			expectedMessage: "test-validator has 6000000.00 atom delegated on test-chain-1, above the maximum of 5000000.00 atom",
+			expectedActive:  []string{"DelegatedTokensAbove_testval123"},
 		},
 		{
-			name: "should not trigger duplicate alert",
-			nodes: []*NodeConfig{
-				{
-					Url:         "http://node1.example.com",
-					AlertIfDown: true,
-					down:        true,
-					wasDown:     false,
-					downSince:   time.Now().Add(-5 * time.Minute),
-				},
-			},
-			existingAlert:    true,
-			expectedAlert:    false,
-			expectedResolved: false,
-			description:      "Should not trigger duplicate alert",
+			name:           "should not alert between the bounds",
+			delegated:      2_000_000_000_000,
+			minTokens:      1_000_000,
+			maxTokens:      5_000_000,
+			metadata:       metadata,
+			expectedActive: []string{},
 		},
 		{
-			name: "should resolve alert when node recovers",
-			nodes: []*NodeConfig{
-				{
-					Url:         "http://node1.example.com",
-					AlertIfDown: true,
-					down:        false,
-					wasDown:     true,
-					downSince:   time.Now().Add(-5 * time.Minute),
-				},
-			},
-			existingAlert:    true,
-			expectedAlert:    false,
-			expectedResolved: true,
-			description:      "Should resolve alert when node recovers",
+			name:           "should not alert without denom metadata",
+			delegated:      900_000_000_000,
+			minTokens:      1_000_000,
+			expectedActive: []string{},
 		},
 		{
-			name: "should not alert if AlertIfDown is false",
-			nodes: []*NodeConfig{
-				{
-					Url:         "http://node1.example.com",
-					AlertIfDown: false,
-					down:        true,
-					wasDown:     false,
-					downSince:   time.Now().Add(-5 * time.Minute),
-				},
-			},
-			existingAlert:    false,
-			expectedAlert:    false,
-			expectedResolved: false,
-			description:      "Should not alert if AlertIfDown is disabled",
+			name:           "should not alert before the delegation is known",
+			minTokens:      1_000_000,
+			metadata:       metadata,
+			expectedActive: []string{},
 		},
 		{
-			name: "should not alert if node just went down",
-			nodes: []*NodeConfig{
-				{
-					Url:         "http://node1.example.com",
-					AlertIfDown: true,
-					down:        true,
-					wasDown:     false,
-					downSince:   time.Now().Add(-30 * time.Second),
-				},
-			},
-			existingAlert:    false,
-			expectedAlert:    false,
-			expectedResolved: false,
-			description:      "Should not alert if node hasn't been down long enough",
+			name:             "should resolve the minimum once recovered",
+			delegated:        1_500_000_000_000,
+			minTokens:        1_000_000,
+			metadata:         metadata,
+			existingAlerts:   []string{"DelegatedTokensBelow_testval123"},
+			expectedResolved: true,
+			expectedActive:   []string{},
+		},
+		{
+			name:             "should resolve the maximum once back under it",
+			delegated:        4_000_000_000_000,
+			maxTokens:        5_000_000,
+			metadata:         metadata,
+			existingAlerts:   []string{"DelegatedTokensAbove_testval123"},
+			expectedResolved: true,
+			expectedActive:   []string{},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Reset alarms for each test
-			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{"test-chain": {}}
+			for _, id := range tt.existingAlerts {
+				testAlarms.AllAlarms["test-chain"][id] = alertMsgCache{Message: "test alert", SentTime: time.Now()}
+			}
 
 			cc := &ChainConfig{
-				name:       "test-chain",
-				ChainId:    "test-chain-1",
-				ValAddress: "testval123",
-				Nodes:      tt.nodes,
+				name:          "test-chain",
+				ChainId:       "test-chain-1",
+				ValAddress:    "testval123",
+				valInfo:       &ValInfo{Moniker: "test-validator", DelegatedTokens: tt.delegated},
+				denomMetadata: tt.metadata,
+				Alerts:        AlertConfig{MinDelegatedTokens: &tt.minTokens, MaxDelegatedTokens: &tt.maxTokens},
 			}
 
-			if tt.existingAlert && len(tt.nodes) > 0 {
-				testAlarms.AllAlarms["test-chain"] = make(map[string]alertMsgCache)
-				alertID := fmt.Sprintf("RPCNodeDown_%s_%s", cc.ValAddress, tt.nodes[0].Url)
-				testAlarms.AllAlarms["test-chain"][alertID] = alertMsgCache{
-					Message:  "test alert",
-					SentTime: time.Now(),
+			alert, resolved := evaluateDelegatedTokensAlert(cc)
+			for len(td.alertChan) > 0 {
+				msg := <-td.alertChan
+				if tt.expectedMessage != "" && msg.message != tt.expectedMessage {
+					t.Errorf("expected message %q, got %q", tt.expectedMessage, msg.message)
 				}
 			}
 
-			alert, resolved := evaluateRPCNodeDownAlert(cc)
-
 			if alert != tt.expectedAlert {
-				t.Errorf("%s: expected alert %v, got %v", tt.description, tt.expectedAlert, alert)
+				t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
 			}
 			if resolved != tt.expectedResolved {
-				t.Errorf("%s: expected resolved %v, got %v", tt.description, tt.expectedResolved, resolved)
+				t.Errorf("expected resolved %v, got %v", tt.expectedResolved, resolved)
+			}
+			active := make([]string, 0)
+			for id := range testAlarms.AllAlarms["test-chain"] {
+				active = append(active, id)
+			}
+			sort.Strings(active)
+			if !reflect.DeepEqual(active, tt.expectedActive) {
+				t.Errorf("expected active alarms %v, got %v", tt.expectedActive, active)
 			}
 		})
 	}
 }
 
-func TestEvaluateStakeChangeAlert(t *testing.T) {
-	// Setup test alarm cache
+func TestEvaluateLowGasBalanceAlert(t *testing.T) {
 	testAlarms := &alarmCache{
 		AllAlarms: make(map[string]map[string]alertMsgCache),
 		notifyMux: sync.RWMutex{},
@@ -1541,122 +5969,107 @@ func TestEvaluateStakeChangeAlert(t *testing.T) {
 	alarms = testAlarms
 	defer func() { alarms = originalAlarms }()
 
-	// Setup test td
 	originalTd := td
 	td = createTestConfig()
 	defer func() { td = originalTd }()
 
+	metadata := &bank.Metadata{
+		DenomUnits: []*bank.DenomUnit{{Denom: "uatom", Exponent: 0}, {Denom: "atom", Exponent: 6}},
+		Base:       "uatom",
+		Display:    "atom",
+	}
+
 	tests := []struct {
-		name              string
-		currentStake      float64
-		previousStake     float64
-		increaseThreshold float64
-		dropThreshold     float64
-		existingAlert     bool
-		expectedAlert     bool
-		expectedResolved  bool
-		description       string
+		name             string
+		balance          *float64
+		minBalance       float64
+		metadata         *bank.Metadata
+		existingAlert    bool
+		expectedAlert    bool
+		expectedResolved bool
+		expectedMessage  string
 	}{
 		{
-			name:              "should trigger alert when stake increases above threshold",
-			currentStake:      1200.0,
-			previousStake:     1000.0,
-			increaseThreshold: 0.15,
-			dropThreshold:     0.10,
-			existingAlert:     false,
-			expectedAlert:     true,
-			expectedResolved:  false,
-			description:       "Should alert when stake increases by more than 15%",
+			name:            "should alert below the minimum",
+			balance:         floatPtr(500_000),
+			minBalance:      1,
+			metadata:        metadata,
+			expectedAlert:   true,
+			expectedMessage: "test-validator's operator account has 0.50 atom left for fees on test-chain-1, below the minimum of 1.00 atom",
 		},
 		{
-			name:              "should trigger alert when stake drops below threshold",
-			currentStake:      800.0,
-			previousStake:     1000.0,
-			increaseThreshold: 0.15,
-			dropThreshold:     0.15,
-			existingAlert:     false,
-			expectedAlert:     true,
-			expectedResolved:  false,
-			description:       "Should alert when stake drops by more than 15%",
+			name:       "should not alert above the minimum",
+			balance:    floatPtr(2_000_000),
+			minBalance: 1,
+			metadata:   metadata,
 		},
 		{
-			name:              "should not trigger duplicate alert",
-			currentStake:      1200.0,
-			previousStake:     1000.0,
-			increaseThreshold: 0.15,
-			dropThreshold:     0.10,
-			existingAlert:     true,
-			expectedAlert:     false,
-			expectedResolved:  false,
-			description:       "Should not trigger duplicate alert",
+			name:          "should not alert again while firing",
+			balance:       floatPtr(0),
+			minBalance:    1,
+			metadata:      metadata,
+			existingAlert: true,
 		},
 		{
-			name:              "should resolve alert when stake change is within threshold",
-			currentStake:      1050.0,
-			previousStake:     1000.0,
-			increaseThreshold: 0.10,
-			dropThreshold:     0.10,
-			existingAlert:     true,
-			expectedAlert:     false,
-			expectedResolved:  true,
-			description:       "Should resolve alert when stake change is within acceptable range",
+			name:             "should resolve once topped up",
+			balance:          floatPtr(5_000_000),
+			minBalance:       1,
+			metadata:         metadata,
+			existingAlert:    true,
+			expectedResolved: true,
 		},
 		{
-			name:              "should not alert when change is within threshold",
-			currentStake:      1050.0,
-			previousStake:     1000.0,
-			increaseThreshold: 0.10,
-			dropThreshold:     0.10,
-			existingAlert:     false,
-			expectedAlert:     false,
-			expectedResolved:  false,
-			description:       "Should not alert when stake change is within threshold",
+			name:       "should not alert before the balance is known",
+			minBalance: 1,
+			metadata:   metadata,
+		},
+		{
+			name:       "should not alert without denom metadata",
+			balance:    floatPtr(0),
+			minBalance: 1,
+		},
+		{
+			name:     "should not alert without a minimum",
+			balance:  floatPtr(0),
+			metadata: metadata,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Reset alarms for each test
-			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{"test-chain": {}}
+			if tt.existingAlert {
+				testAlarms.AllAlarms["test-chain"]["LowGasBalance_testval123"] = alertMsgCache{Message: "test alert", SentTime: time.Now()}
+			}
 
 			cc := &ChainConfig{
-				name:       "test-chain",
-				ChainId:    "test-chain-1",
-				ValAddress: "testval123",
-				valInfo: &ValInfo{
-					Moniker:         "test-validator",
-					DelegatedTokens: tt.currentStake,
-				},
-				lastValInfo: &ValInfo{
-					DelegatedTokens: tt.previousStake,
-				},
-				Alerts: AlertConfig{
-					StakeChangeIncreaseThreshold: &tt.increaseThreshold,
-					StakeChangeDropThreshold:     &tt.dropThreshold,
-				},
+				name:          "test-chain",
+				ChainId:       "test-chain-1",
+				ValAddress:    "testval123",
+				valInfo:       &ValInfo{Moniker: "test-validator", GasBalance: tt.balance},
+				denomMetadata: tt.metadata,
+				Alerts:        AlertConfig{MinGasBalance: &tt.minBalance},
 			}
 
-			if tt.existingAlert {
-				testAlarms.AllAlarms["test-chain"] = make(map[string]alertMsgCache)
-				testAlarms.AllAlarms["test-chain"]["StakeChange_testval123"] = alertMsgCache{
-					Message:  "test alert",
-					SentTime: time.Now(),
+			alert, resolved := evaluateLowGasBalanceAlert(cc)
+			for len(td.alertChan) > 0 {
+				msg := <-td.alertChan
+				if tt.expectedMessage != "" && msg.message != tt.expectedMessage {
+					t.Errorf("expected message %q, got %q", tt.expectedMessage, msg.message)
 				}
 			}
 
-			alert, resolved := evaluateStakeChangeAlert(cc)
-
 			if alert != tt.expectedAlert {
-				t.Errorf("%s: expected alert %v, got %v", tt.description, tt.expectedAlert, alert)
+				t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
 			}
 			if resolved != tt.expectedResolved {
-				t.Errorf("%s: expected resolved %v, got %v", tt.description, tt.expectedResolved, resolved)
+				t.Errorf("expected resolved %v, got %v", tt.expectedResolved, resolved)
 			}
 		})
 	}
 }
 
-func TestEvaluateUnvotedGovernanceProposalAlert(t *testing.T) {
+func TestEvaluateCommissionRateAlert(t *testing.T) {
 	// Setup test alarm cache
 	testAlarms := &alarmCache{
 		AllAlarms: make(map[string]map[string]alertMsgCache),
@@ -1671,101 +6084,105 @@ func TestEvaluateUnvotedGovernanceProposalAlert(t *testing.T) {
 	td = createTestConfig()
 	defer func() { td = originalTd }()
 
+	expected := 0.05
 	tests := []struct {
 		name             string
-		unvotedProposals []gov.Proposal
-		existingAlerts   map[string]bool
+		rate             float64
+		expected         *float64
+		tolerance        float64
+		notQueried       bool
+		existingAlerts   []string
 		expectedAlert    bool
 		expectedResolved bool
-		description      string
+		expectedMessage  string
+		expectedActive   []string
 	}{
 		{
-			name: "should trigger alert for new unvoted proposal",
-			unvotedProposals: []gov.Proposal{
-				{
-					ProposalId:    1,
-					VotingEndTime: time.Now().Add(24 * time.Hour),
-				},
-			},
-			existingAlerts:   map[string]bool{},
-			expectedAlert:    true,
-			expectedResolved: false,
-			description:      "Should alert for new unvoted governance proposal",
+			name:            "should alert on a fat-fingered commission",
+			rate:            1,
+			expected:        &expected,
+			tolerance:       0.01,
+			expectedAlert:   true,
+			expectedMessage: "test-validator has a commission rate of 100.00% on test-chain-1, expected 5.00% ± 1.00%",
+			expectedActive:  []string{"CommissionRate_testval123"},
 		},
 		{
-			name: "should not trigger duplicate alert",
-			unvotedProposals: []gov.Proposal{
-				{
-					ProposalId:    1,
-					VotingEndTime: time.Now().Add(24 * time.Hour),
-				},
-			},
-			existingAlerts: map[string]bool{
-				"UnvotedGovernanceProposal_testval123_1": true,
-			},
-			expectedAlert:    false,
-			expectedResolved: false,
-			description:      "Should not trigger duplicate alert for same proposal",
+			name:            "should alert below the range",
+			rate:            0.01,
+			expected:        &expected,
+			tolerance:       0.01,
+			expectedAlert:   true,
+			expectedMessage: "test-validator has a commission rate of 1.00% on test-chain-1, expected 5.00% ± 1.00%",
+			expectedActive:  []string{"CommissionRate_testval123"},
 		},
 		{
-			name:             "should resolve alert when proposal is voted on",
-			unvotedProposals: []gov.Proposal{},
-			existingAlerts: map[string]bool{
-				"UnvotedGovernanceProposal_testval123_1": true,
-			},
-			expectedAlert:    false,
-			expectedResolved: true,
-			description:      "Should resolve alert when proposal is no longer unvoted",
+			name:           "should not alert at the edge of the tolerance",
+			rate:           0.06,
+			expected:       &expected,
+			tolerance:      0.01,
+			expectedActive: []string{},
 		},
 		{
-			name: "should handle multiple proposals",
-			unvotedProposals: []gov.Proposal{
-				{
-					ProposalId:    1,
-					VotingEndTime: time.Now().Add(24 * time.Hour),
-				},
-				{
-					ProposalId:    2,
-					VotingEndTime: time.Now().Add(48 * time.Hour),
-				},
-			},
-			existingAlerts:   map[string]bool{},
-			expectedAlert:    true,
-			expectedResolved: false,
-			description:      "Should handle multiple unvoted proposals",
+			name:           "should not alert without an expected rate",
+			rate:           1,
+			expectedActive: []string{},
+		},
+		{
+			name:           "should not alert before the validator was queried",
+			expected:       &expected,
+			notQueried:     true,
+			expectedActive: []string{},
+		},
+		{
+			name:             "should resolve once corrected",
+			rate:             0.05,
+			expected:         &expected,
+			existingAlerts:   []string{"CommissionRate_testval123"},
+			expectedResolved: true,
+			expectedActive:   []string{},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Reset alarms for each test
-			testAlarms.AllAlarms = make(map[string]map[string]alertMsgCache)
-
-			if len(tt.existingAlerts) > 0 {
-				testAlarms.AllAlarms["test-chain"] = make(map[string]alertMsgCache)
-				for alertID := range tt.existingAlerts {
-					testAlarms.AllAlarms["test-chain"][alertID] = alertMsgCache{
-						Message:  "test governance alert",
-						SentTime: time.Now(),
-					}
-				}
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{"test-chain": {}}
+			for _, id := range tt.existingAlerts {
+				testAlarms.AllAlarms["test-chain"][id] = alertMsgCache{Message: "test alert", SentTime: time.Now()}
 			}
 
 			cc := &ChainConfig{
-				name:                    "test-chain",
-				ChainId:                 "test-chain-1",
-				ValAddress:              "testval123",
-				unvotedOpenGovProposals: tt.unvotedProposals,
-				Provider:                ProviderConfig{Name: "cosmos"},
+				name:       "test-chain",
+				ChainId:    "test-chain-1",
+				ValAddress: "testval123",
+				valInfo:    &ValInfo{Moniker: "test-validator", CommissionRate: tt.rate},
+				Alerts:     AlertConfig{ExpectedCommissionRate: tt.expected, CommissionRateTolerance: &tt.tolerance},
+			}
+			if !tt.notQueried {
+				cc.valInfoUpdated = time.Now()
 			}
 
-			alert, resolved := evaluateUnvotedGovernanceProposalAlert(cc)
+			alert, resolved := evaluateCommissionRateAlert(cc)
+			for len(td.alertChan) > 0 {
+				msg := <-td.alertChan
+				if tt.expectedMessage != "" && msg.message != tt.expectedMessage {
+					t.Errorf("expected message %q, got %q", tt.expectedMessage, msg.message)
+				}
+			}
 
 			if alert != tt.expectedAlert {
-				t.Errorf("%s: expected alert %v, got %v", tt.description, tt.expectedAlert, alert)
+				t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
 			}
 			if resolved != tt.expectedResolved {
-				t.Errorf("%s: expected resolved %v, got %v", tt.description, tt.expectedResolved, resolved)
+				t.Errorf("expected resolved %v, got %v", tt.expectedResolved, resolved)
+			}
+			active := make([]string, 0)
+			for id := range testAlarms.AllAlarms["test-chain"] {
+				active = append(active, id)
+			}
+			sort.Strings(active)
+			if !reflect.DeepEqual(active, tt.expectedActive) {
+				t.Errorf("expected active alarms %v, got %v", tt.expectedActive, active)
 			}
 		})
 	}
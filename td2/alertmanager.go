@@ -0,0 +1,144 @@
+package tenderduty
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const apiAlertmanagerPath = "/api/v1/alertmanager"
+
+// alertmanagerPayload is the part of an Alertmanager webhook tenderduty uses, see
+// https://prometheus.io/docs/alerting/latest/configuration/#webhook_config
+type alertmanagerPayload struct {
+	Version string              `json:"version"`
+	Alerts  []alertmanagerAlert `json:"alerts"`
+}
+
+type alertmanagerAlert struct {
+	Status      string            `json:"status"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	Fingerprint string            `json:"fingerprint"`
+}
+
+// alertmanagerResponse counts the alerts that were sent on and the ones that matched no chain.
+type alertmanagerResponse struct {
+	Forwarded int      `json:"forwarded"`
+	Skipped   []string `json:"skipped"`
+}
+
+// alertmanagerHandler receives Alertmanager webhooks and sends their alerts to the destinations of the chain named by
+// the alert's chain label, or the chain whose chain_id matches its chain_id label.
+func alertmanagerHandler(writer http.ResponseWriter, request *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	if !requireAPIToken(writer, request) {
+		return
+	}
+	if request.Method != http.MethodPost {
+		apiError(writer, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var payload alertmanagerPayload
+	if err := json.NewDecoder(http.MaxBytesReader(writer, request.Body, 1<<20)).Decode(&payload); err != nil {
+		apiError(writer, http.StatusBadRequest, "invalid alertmanager payload: "+err.Error())
+		return
+	}
+	if payload.Version != "" && payload.Version != "4" {
+		apiError(writer, http.StatusBadRequest, "unsupported alertmanager payload version "+payload.Version)
+		return
+	}
+
+	resp := alertmanagerResponse{Skipped: make([]string, 0)}
+	for _, a := range payload.Alerts {
+		chain := alertmanagerChain(a.Labels)
+		if chain == "" {
+			// a retry can't fix an unknown chain, so it is reported rather than failing the webhook
+			resp.Skipped = append(resp.Skipped, a.Labels["alertname"])
+			continue
+		}
+		if forwardAlertmanagerAlert(chain, a) {
+			resp.Forwarded++
+		}
+	}
+	j, _ := json.Marshal(resp)
+	_, _ = writer.Write(j)
+}
+
+// alertmanagerChain returns the name of the chain an alert belongs to, empty if none matches.
+func alertmanagerChain(labels map[string]string) string {
+	td.chainsMux.RLock()
+	defer td.chainsMux.RUnlock()
+	if name := labels["chain"]; name != "" {
+		if _, ok := td.Chains[name]; ok {
+			return name
+		}
+	}
+	if chainId := labels["chain_id"]; chainId != "" {
+		for name, cc := range td.Chains {
+			if cc.ChainId == chainId {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// forwardAlertmanagerAlert raises or resolves the tenderduty alarm for an Alertmanager alert. Alertmanager repeats
+// firing alerts, those are only sent once, and tenderduty's own reminders apply. Returns false when nothing was sent.
+func forwardAlertmanagerAlert(chain string, a alertmanagerAlert) bool {
+	td.chainsMux.RLock()
+	cc := td.Chains[chain]
+	td.chainsMux.RUnlock()
+	if cc.isPaused() {
+		return false
+	}
+
+	id := alertmanagerAlertID(a)
+	message := a.Annotations["summary"]
+	if message == "" {
+		message = a.Annotations["description"]
+	}
+	if message == "" {
+		message = a.Labels["alertname"]
+	}
+	message = fmt.Sprintf("%s (from alertmanager): %s", a.Labels["alertname"], message)
+	severity, ok := normalizeSeverity(a.Labels["severity"])
+	if !ok || severity == "" {
+		severity = "warning"
+	}
+
+	switch {
+	case a.Status == "resolved" && alarms.exist(chain, id):
+		td.alert(chain, message, severity, true, &id)
+	case a.Status == "firing" && !alarms.exist(chain, id):
+		td.alert(chain, message, severity, false, &id)
+	default:
+		return false
+	}
+	return true
+}
+
+// alertmanagerAlertID is the unique ID of an Alertmanager alert, from its fingerprint, or a hash of its labels for
+// senders that leave it out.
+func alertmanagerAlertID(a alertmanagerAlert) string {
+	fingerprint := a.Fingerprint
+	if fingerprint == "" {
+		keys := make([]string, 0, len(a.Labels))
+		for k := range a.Labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var b strings.Builder
+		for _, k := range keys {
+			b.WriteString(k + "=" + a.Labels[k] + "\n")
+		}
+		sum := sha256.Sum256([]byte(b.String()))
+		fingerprint = hex.EncodeToString(sum[:8])
+	}
+	return fmt.Sprintf("Alertmanager_%s_%s", a.Labels["alertname"], fingerprint)
+}
@@ -0,0 +1,155 @@
+package tenderduty
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func alertmanagerTestPayload(status string) string {
+	return `{
+  "version": "4",
+  "groupKey": "{}:{alertname=\"NodeDiskFull\"}",
+  "status": "` + status + `",
+  "receiver": "tenderduty",
+  "groupLabels": {"alertname": "NodeDiskFull"},
+  "commonLabels": {"alertname": "NodeDiskFull"},
+  "commonAnnotations": {},
+  "externalURL": "http://alertmanager:9093",
+  "alerts": [
+    {
+      "status": "` + status + `",
+      "labels": {"alertname": "NodeDiskFull", "chain_id": "test-chain-1", "severity": "critical", "instance": "node1:9100"},
+      "annotations": {"summary": "disk of node1 is 95% full"},
+      "startsAt": "2024-01-02T15:04:05Z",
+      "endsAt": "0001-01-01T00:00:00Z",
+      "generatorURL": "http://prometheus:9090/graph",
+      "fingerprint": "c9d8e7f6a5b4c3d2"
+    },
+    {
+      "status": "` + status + `",
+      "labels": {"alertname": "HighLatency", "chain": "unknown-chain"},
+      "annotations": {},
+      "fingerprint": "0123456789abcdef"
+    }
+  ]
+}`
+}
+
+func TestAlertmanagerHandler(t *testing.T) {
+	originalAlarms := alarms
+	alarms = &alarmCache{AllAlarms: make(map[string]map[string]alertMsgCache), notifyMux: sync.RWMutex{}}
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	td.APIToken = testAPIToken
+	defer func() { td = originalTd }()
+
+	const alertID = "Alertmanager_NodeDiskFull_c9d8e7f6a5b4c3d2"
+
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		expectedStatus int
+		forwarded      int
+		firing         bool
+		resolved       bool
+	}{
+		{
+			name:           "firing alert is forwarded to the chain",
+			method:         http.MethodPost,
+			body:           alertmanagerTestPayload("firing"),
+			expectedStatus: http.StatusOK,
+			forwarded:      1,
+			firing:         true,
+		},
+		{
+			name:           "repeated firing alert is not sent again",
+			method:         http.MethodPost,
+			body:           alertmanagerTestPayload("firing"),
+			expectedStatus: http.StatusOK,
+			firing:         true,
+		},
+		{
+			name:           "resolved alert resolves the alarm",
+			method:         http.MethodPost,
+			body:           alertmanagerTestPayload("resolved"),
+			expectedStatus: http.StatusOK,
+			forwarded:      1,
+			resolved:       true,
+		},
+		{
+			name:           "invalid payload",
+			method:         http.MethodPost,
+			body:           `{"alerts": "nope"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "wrong method",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			alertmanagerHandler(rec, apiRequest(tt.method, apiAlertmanagerPath, strings.NewReader(tt.body)))
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+			var resp alertmanagerResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Forwarded != tt.forwarded || len(resp.Skipped) != 1 || resp.Skipped[0] != "HighLatency" {
+				t.Errorf("expected %d forwarded and HighLatency skipped, got %+v", tt.forwarded, resp)
+			}
+			if firing := alarms.exist("test-chain", alertID); firing != tt.firing {
+				t.Errorf("expected firing %v, got %v", tt.firing, firing)
+			}
+			if len(td.alertChan) != tt.forwarded {
+				t.Fatalf("expected %d notifications, got %d", tt.forwarded, len(td.alertChan))
+			}
+			if tt.forwarded > 0 {
+				msg := <-td.alertChan
+				if msg.uniqueId != alertID || msg.severity != "critical" || msg.resolved != tt.resolved {
+					t.Errorf("unexpected notification %+v", msg)
+				}
+				if msg.message != "NodeDiskFull (from alertmanager): disk of node1 is 95% full" {
+					t.Errorf("unexpected message %q", msg.message)
+				}
+			}
+		})
+	}
+
+	t.Run("needs the api token", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		alertmanagerHandler(rec, httptest.NewRequest(http.MethodPost, apiAlertmanagerPath, strings.NewReader(`{"version":"4","alerts":[]}`)))
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("expected status %d, got %d", http.StatusUnauthorized, rec.Code)
+		}
+	})
+}
+
+func TestAlertmanagerAlertID(t *testing.T) {
+	labels := map[string]string{"alertname": "NodeDiskFull", "instance": "node1:9100"}
+	withoutFingerprint := alertmanagerAlertID(alertmanagerAlert{Labels: labels})
+	if withoutFingerprint != alertmanagerAlertID(alertmanagerAlert{Labels: map[string]string{"instance": "node1:9100", "alertname": "NodeDiskFull"}}) {
+		t.Error("the ID of an alert without a fingerprint should only depend on its labels")
+	}
+	if !strings.HasPrefix(withoutFingerprint, "Alertmanager_NodeDiskFull_") {
+		t.Errorf("unexpected ID %s", withoutFingerprint)
+	}
+	if got := alertmanagerAlertID(alertmanagerAlert{Labels: labels, Fingerprint: "abc"}); got != "Alertmanager_NodeDiskFull_abc" {
+		t.Errorf("expected the fingerprint to be used, got %s", got)
+	}
+}
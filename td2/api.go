@@ -0,0 +1,203 @@
+package tenderduty
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	apiChainsPrefix = "/api/v1/chains/"
+	apiMuteAllPath  = "/api/v1/mute-all"
+)
+
+// registerApi adds the API routes and health probes to the default mux, which is also served by the dashboard. Without
+// the dashboard the probes are served by the prometheus exporter or on their own, see serveProbes.
+func registerApi() {
+	http.HandleFunc(apiChainsPrefix, apiChainsHandler)
+	http.HandleFunc(apiMuteAllPath, muteAllHandler)
+	// the webhook can send alerts to every destination, it is only served with a token to check
+	if td.APIToken != "" {
+		http.HandleFunc(apiAlertmanagerPath, alertmanagerHandler)
+	}
+	registerProbes(http.DefaultServeMux)
+}
+
+// apiChainsHandler routes requests under /api/v1/chains/. Unique IDs can contain a node's URL, so path segments are
+// split before they are unescaped, clients should escape the slashes in an ID as %2F.
+func apiChainsHandler(writer http.ResponseWriter, request *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	parts := strings.Split(strings.TrimPrefix(request.URL.EscapedPath(), apiChainsPrefix), "/")
+	for i := range parts {
+		p, err := url.PathUnescape(parts[i])
+		if err != nil {
+			apiError(writer, http.StatusBadRequest, "invalid path")
+			return
+		}
+		parts[i] = p
+	}
+
+	switch {
+	// POST /api/v1/chains/{name}/alarms/{uniqueId}/snooze?minutes=N
+	case len(parts) == 4 && parts[1] == "alarms" && parts[3] == "snooze":
+		snoozeHandler(writer, request, parts[0], parts[2])
+	// GET /api/v1/chains/{name}/history?since=RFC3339
+	case len(parts) == 2 && parts[1] == "history":
+		historyHandler(writer, request, parts[0])
+	// POST /api/v1/chains/{name}/pause and /api/v1/chains/{name}/resume
+	case len(parts) == 2 && (parts[1] == "pause" || parts[1] == "resume"):
+		pauseHandler(writer, request, parts[0], parts[1] == "pause")
+	default:
+		apiError(writer, http.StatusNotFound, "not found")
+	}
+}
+
+// requireAPIToken checks that a request to a write route carries api_token as a bearer token, and answers it when not.
+// The dashboard port may be public, so the write routes are disabled until a token is configured.
+func requireAPIToken(writer http.ResponseWriter, request *http.Request) bool {
+	if td.APIToken == "" {
+		apiError(writer, http.StatusForbidden, "set api_token in the config to enable this route")
+		return false
+	}
+	auth := request.Header.Get("Authorization")
+	token := strings.TrimPrefix(auth, "Bearer ")
+	if token == auth || subtle.ConstantTimeCompare([]byte(token), []byte(td.APIToken)) != 1 {
+		writer.Header().Set("WWW-Authenticate", "Bearer")
+		apiError(writer, http.StatusUnauthorized, "missing or invalid bearer token")
+		return false
+	}
+	return true
+}
+
+func snoozeHandler(writer http.ResponseWriter, request *http.Request, chain string, alertID string) {
+	if !requireAPIToken(writer, request) {
+		return
+	}
+	if request.Method != http.MethodPost {
+		apiError(writer, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	minutes, err := strconv.Atoi(request.URL.Query().Get("minutes"))
+	if err != nil || minutes <= 0 {
+		apiError(writer, http.StatusBadRequest, "minutes must be a positive integer")
+		return
+	}
+	until := time.Now().Add(time.Duration(minutes) * time.Minute)
+	if err = alarms.snooze(chain, alertID, until); err != nil {
+		apiError(writer, http.StatusNotFound, err.Error())
+		return
+	}
+	l("😴 snoozed alarm", alertID, "on", chain, "until", until.UTC().Format(time.RFC3339))
+	j, _ := json.Marshal(map[string]string{"chain": chain, "alarm": alertID, "snoozed_until": until.UTC().Format(time.RFC3339)})
+	_, _ = writer.Write(j)
+}
+
+// pauseHandler stops or restarts the alert evaluations of a chain, pausing resolves its active alarms.
+func pauseHandler(writer http.ResponseWriter, request *http.Request, chain string, pause bool) {
+	if !requireAPIToken(writer, request) {
+		return
+	}
+	if request.Method != http.MethodPost {
+		apiError(writer, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	td.chainsMux.RLock()
+	cc := td.Chains[chain]
+	td.chainsMux.RUnlock()
+	if cc == nil {
+		apiError(writer, http.StatusNotFound, "unknown chain "+chain)
+		return
+	}
+	if cc.setPaused(pause) {
+		if pause {
+			alarms.resolveAll(chain)
+			l("⏸️ paused monitoring of", chain)
+		} else {
+			l("▶️ resumed monitoring of", chain)
+		}
+	}
+	j, _ := json.Marshal(map[string]any{"chain": chain, "paused": pause})
+	_, _ = writer.Write(j)
+}
+
+// muteAllHandler mutes new alerts on every chain for the given minutes, 0 unmutes. Resolves are still sent.
+func muteAllHandler(writer http.ResponseWriter, request *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	if !requireAPIToken(writer, request) {
+		return
+	}
+	if request.Method != http.MethodPost {
+		apiError(writer, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	minutes, err := strconv.Atoi(request.URL.Query().Get("minutes"))
+	if err != nil || minutes < 0 {
+		apiError(writer, http.StatusBadRequest, "minutes must be a positive integer, or 0 to unmute")
+		return
+	}
+	until := td.muteAll(time.Duration(minutes) * time.Minute)
+	resp := map[string]any{"muted": minutes > 0}
+	if minutes > 0 {
+		l("🔇 notifications muted on all chains until", until.UTC().Format(time.RFC3339))
+		resp["muted_until"] = until.UTC().Format(time.RFC3339)
+	} else {
+		l("🔔 notifications unmuted")
+	}
+	j, _ := json.Marshal(resp)
+	_, _ = writer.Write(j)
+}
+
+// historyResponse is the stable schema of the history API, Enabled is false when alert_history_size is 0 and Alerts
+// is then always empty.
+type historyResponse struct {
+	Chain   string       `json:"chain"`
+	Enabled bool         `json:"enabled"`
+	Since   time.Time    `json:"since"`
+	Alerts  []alertEvent `json:"alerts"`
+}
+
+// historyHandler returns the alarms that fired or resolved on a chain since a time, the whole history without one.
+func historyHandler(writer http.ResponseWriter, request *http.Request, chain string) {
+	if request.Method != http.MethodGet {
+		apiError(writer, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var since time.Time
+	if s := request.URL.Query().Get("since"); s != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339, s); err != nil {
+			apiError(writer, http.StatusBadRequest, "since must be an RFC3339 time like 2024-01-02T15:04:05Z")
+			return
+		}
+	}
+	td.chainsMux.RLock()
+	_, known := td.Chains[chain]
+	td.chainsMux.RUnlock()
+	if !known {
+		apiError(writer, http.StatusNotFound, "unknown chain "+chain)
+		return
+	}
+	resp := historyResponse{Chain: chain, Since: since.UTC(), Alerts: make([]alertEvent, 0)}
+	if td.history != nil {
+		resp.Enabled = true
+		resp.Alerts = td.history.since(chain, since)
+	}
+	if td.HideLogs {
+		// the messages carry the validator and node details that hide_logs keeps off the public dashboard
+		for i := range resp.Alerts {
+			resp.Alerts[i].Message = ""
+		}
+	}
+	j, _ := json.Marshal(resp)
+	_, _ = writer.Write(j)
+}
+
+func apiError(writer http.ResponseWriter, status int, msg string) {
+	writer.WriteHeader(status)
+	j, _ := json.Marshal(map[string]string{"error": msg})
+	_, _ = writer.Write(j)
+}
@@ -0,0 +1,440 @@
+package tenderduty
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testAPIToken is the api_token of the API tests, apiRequest sends it.
+const testAPIToken = "test-api-token"
+
+func apiRequest(method, path string, body io.Reader) *http.Request {
+	request := httptest.NewRequest(method, path, body)
+	request.Header.Set("Authorization", "Bearer "+testAPIToken)
+	return request
+}
+
+func TestRequireAPIToken(t *testing.T) {
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name           string
+		configured     string
+		authorization  string
+		expectedOk     bool
+		expectedStatus int
+	}{
+		{name: "disabled without api_token", authorization: "Bearer ", expectedStatus: http.StatusForbidden},
+		{name: "missing token", configured: testAPIToken, expectedStatus: http.StatusUnauthorized},
+		{name: "wrong token", configured: testAPIToken, authorization: "Bearer wrong", expectedStatus: http.StatusUnauthorized},
+		{name: "token without the bearer scheme", configured: testAPIToken, authorization: testAPIToken, expectedStatus: http.StatusUnauthorized},
+		{name: "valid token", configured: testAPIToken, authorization: "Bearer " + testAPIToken, expectedOk: true, expectedStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td.APIToken = tt.configured
+			request := httptest.NewRequest(http.MethodPost, apiMuteAllPath, nil)
+			if tt.authorization != "" {
+				request.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			if ok := requireAPIToken(rec, request); ok != tt.expectedOk {
+				t.Errorf("expected %v, got %v", tt.expectedOk, ok)
+			}
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestApiSnooze(t *testing.T) {
+	testAlarms := &alarmCache{
+		AllAlarms: map[string]map[string]alertMsgCache{
+			"test-chain": {
+				"RPCNodeDown_testval123_http://node1.example.com": {Message: "test alert", SentTime: time.Now()},
+			},
+		},
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	td.APIToken = testAPIToken
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{
+			name:           "snoozes an active alarm with an escaped id",
+			method:         http.MethodPost,
+			path:           "/api/v1/chains/test-chain/alarms/RPCNodeDown_testval123_http:%2F%2Fnode1.example.com/snooze?minutes=30",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown alarm",
+			method:         http.MethodPost,
+			path:           "/api/v1/chains/test-chain/alarms/ChainStalled_testval123/snooze?minutes=30",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid minutes",
+			method:         http.MethodPost,
+			path:           "/api/v1/chains/test-chain/alarms/ChainStalled_testval123/snooze?minutes=abc",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "wrong method",
+			method:         http.MethodGet,
+			path:           "/api/v1/chains/test-chain/alarms/ChainStalled_testval123/snooze?minutes=30",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "unknown route",
+			method:         http.MethodPost,
+			path:           "/api/v1/chains/test-chain/unknown",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			apiChainsHandler(rec, apiRequest(tt.method, tt.path, nil))
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	testAlarms.notifyMux.Lock()
+	snoozed := testAlarms.isSnoozed("test-chain", "RPCNodeDown_testval123_http://node1.example.com")
+	testAlarms.notifyMux.Unlock()
+	if !snoozed {
+		t.Error("expected the alarm to be snoozed")
+	}
+}
+
+func TestApiPauseResume(t *testing.T) {
+	testAlarms := &alarmCache{
+		AllAlarms: map[string]map[string]alertMsgCache{
+			"test-chain": {
+				"ChainStalled_testval123": {Message: "test alert", SentTime: time.Now(), Severity: "warning"},
+			},
+		},
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	td.APIToken = testAPIToken
+	defer func() { td = originalTd }()
+	cc := td.Chains["test-chain"]
+
+	steps := []struct {
+		name           string
+		method         string
+		path           string
+		noToken        bool
+		expectedStatus int
+		expectedPaused bool
+	}{
+		{
+			name:           "pausing needs the api token",
+			method:         http.MethodPost,
+			path:           "/api/v1/chains/test-chain/pause",
+			noToken:        true,
+			expectedStatus: http.StatusUnauthorized,
+			expectedPaused: false,
+		},
+		{
+			name:           "pause",
+			method:         http.MethodPost,
+			path:           "/api/v1/chains/test-chain/pause",
+			expectedStatus: http.StatusOK,
+			expectedPaused: true,
+		},
+		{
+			name:           "pausing again is a no-op",
+			method:         http.MethodPost,
+			path:           "/api/v1/chains/test-chain/pause",
+			expectedStatus: http.StatusOK,
+			expectedPaused: true,
+		},
+		{
+			name:           "wrong method",
+			method:         http.MethodGet,
+			path:           "/api/v1/chains/test-chain/resume",
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedPaused: true,
+		},
+		{
+			name:           "resume",
+			method:         http.MethodPost,
+			path:           "/api/v1/chains/test-chain/resume",
+			expectedStatus: http.StatusOK,
+			expectedPaused: false,
+		},
+		{
+			name:           "unknown chain",
+			method:         http.MethodPost,
+			path:           "/api/v1/chains/other-chain/pause",
+			expectedStatus: http.StatusNotFound,
+			expectedPaused: false,
+		},
+	}
+
+	for _, step := range steps {
+		rec := httptest.NewRecorder()
+		request := apiRequest(step.method, step.path, nil)
+		if step.noToken {
+			request.Header.Del("Authorization")
+		}
+		apiChainsHandler(rec, request)
+		if rec.Code != step.expectedStatus {
+			t.Errorf("%s: expected status %d, got %d: %s", step.name, step.expectedStatus, rec.Code, rec.Body.String())
+		}
+		if cc.isPaused() != step.expectedPaused {
+			t.Errorf("%s: expected paused %v, got %v", step.name, step.expectedPaused, cc.isPaused())
+		}
+	}
+
+	if count := testAlarms.getCount("test-chain"); count != 0 {
+		t.Errorf("expected pausing to resolve the active alarms, %d still active", count)
+	}
+	if len(td.alertChan) != 1 {
+		t.Fatalf("expected one resolve to be queued, got %d", len(td.alertChan))
+	}
+	if msg := <-td.alertChan; !msg.resolved || msg.severity != "warning" {
+		t.Errorf("expected a warning resolve, got resolved %v with severity %q", msg.resolved, msg.severity)
+	}
+}
+
+func TestApiMuteAll(t *testing.T) {
+	originalAlarms := alarms
+	alarms = &alarmCache{AllAlarms: make(map[string]map[string]alertMsgCache), notifyMux: sync.RWMutex{}}
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	td.APIToken = testAPIToken
+	defer func() { td = originalTd }()
+
+	id := "ChainStalled_testval123"
+	steps := []struct {
+		name           string
+		method         string
+		path           string
+		resolved       bool
+		expectedStatus int
+		expectedMuted  bool
+		expectedSent   int
+	}{
+		{
+			name:           "mute holds back alerts",
+			method:         http.MethodPost,
+			path:           "/api/v1/mute-all?minutes=30",
+			expectedStatus: http.StatusOK,
+			expectedMuted:  true,
+		},
+		{
+			name:           "resolves still go out while muted",
+			method:         http.MethodPost,
+			path:           "/api/v1/mute-all?minutes=30",
+			resolved:       true,
+			expectedStatus: http.StatusOK,
+			expectedMuted:  true,
+			expectedSent:   1,
+		},
+		{
+			name:           "wrong method",
+			method:         http.MethodGet,
+			path:           "/api/v1/mute-all?minutes=30",
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedMuted:  true,
+		},
+		{
+			name:           "invalid minutes",
+			method:         http.MethodPost,
+			path:           "/api/v1/mute-all?minutes=-5",
+			expectedStatus: http.StatusBadRequest,
+			expectedMuted:  true,
+		},
+		{
+			name:           "zero minutes unmutes",
+			method:         http.MethodPost,
+			path:           "/api/v1/mute-all?minutes=0",
+			expectedStatus: http.StatusOK,
+			expectedSent:   1,
+		},
+	}
+
+	for _, step := range steps {
+		rec := httptest.NewRecorder()
+		muteAllHandler(rec, apiRequest(step.method, step.path, nil))
+		if rec.Code != step.expectedStatus {
+			t.Errorf("%s: expected status %d, got %d: %s", step.name, step.expectedStatus, rec.Code, rec.Body.String())
+		}
+		if muted := td.muted(time.Now()); muted != step.expectedMuted {
+			t.Errorf("%s: expected muted %v, got %v", step.name, step.expectedMuted, muted)
+		}
+		td.alert("test-chain", "stalled", "critical", step.resolved, &id)
+		if len(td.alertChan) != step.expectedSent {
+			t.Errorf("%s: expected %d notifications, got %d", step.name, step.expectedSent, len(td.alertChan))
+		}
+		for len(td.alertChan) > 0 {
+			<-td.alertChan
+		}
+	}
+
+	td.muteAll(30 * time.Minute)
+	if td.muted(time.Now().Add(time.Hour)) {
+		t.Error("expected the mute to expire")
+	}
+	td.toggleMute()
+	if td.muted(time.Now()) {
+		t.Error("expected toggling to unmute")
+	}
+	td.toggleMute()
+	if !td.muted(time.Now()) || td.muted(time.Now().Add(signalMuteDuration)) {
+		t.Errorf("expected toggling to mute for %s", signalMuteDuration)
+	}
+}
+
+func TestApiHistory(t *testing.T) {
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	// disabled history is an empty set, not an error
+	rec := httptest.NewRecorder()
+	apiChainsHandler(rec, httptest.NewRequest(http.MethodGet, "/api/v1/chains/test-chain/history", nil))
+	var resp historyResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("unexpected response %d: %s", rec.Code, rec.Body.String())
+	}
+	if resp.Enabled || len(resp.Alerts) != 0 {
+		t.Errorf("expected a disabled and empty history, got %+v", resp)
+	}
+
+	td.history = newMemoryAlertHistory(10)
+	alertID := "ChainStalled_testval123"
+	td.alert("test-chain", "stalled", "critical", false, &alertID)
+	td.alert("test-chain", "stalled", "critical", true, &alertID)
+	for len(td.alertChan) > 0 {
+		<-td.alertChan
+	}
+	afterFirst := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	td.alert("test-chain", "stalled again", "critical", false, &alertID)
+	<-td.alertChan
+
+	tests := []struct {
+		name             string
+		method           string
+		path             string
+		hideLogs         bool
+		expectedStatus   int
+		expectedMessages []string
+	}{
+		{
+			name:             "whole history",
+			method:           http.MethodGet,
+			path:             "/api/v1/chains/test-chain/history",
+			expectedStatus:   http.StatusOK,
+			expectedMessages: []string{"stalled", "stalled", "stalled again"},
+		},
+		{
+			name:             "since a time",
+			method:           http.MethodGet,
+			path:             "/api/v1/chains/test-chain/history?since=" + url.QueryEscape(afterFirst.Format(time.RFC3339Nano)),
+			expectedStatus:   http.StatusOK,
+			expectedMessages: []string{"stalled again"},
+		},
+		{
+			name:             "messages hidden with hide_logs",
+			method:           http.MethodGet,
+			path:             "/api/v1/chains/test-chain/history",
+			hideLogs:         true,
+			expectedStatus:   http.StatusOK,
+			expectedMessages: []string{"", "", ""},
+		},
+		{
+			name:           "invalid since",
+			method:         http.MethodGet,
+			path:           "/api/v1/chains/test-chain/history?since=yesterday",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown chain",
+			method:         http.MethodGet,
+			path:           "/api/v1/chains/other-chain/history",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "wrong method",
+			method:         http.MethodPost,
+			path:           "/api/v1/chains/test-chain/history",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td.HideLogs = tt.hideLogs
+			rec := httptest.NewRecorder()
+			apiChainsHandler(rec, httptest.NewRequest(tt.method, tt.path, nil))
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			if tt.hideLogs && strings.Contains(rec.Body.String(), `"message"`) {
+				t.Errorf("expected no messages with hide_logs, got %s", rec.Body.String())
+			}
+			var resp historyResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if !resp.Enabled {
+				t.Error("expected the history to be enabled")
+			}
+			messages := make([]string, 0)
+			for _, e := range resp.Alerts {
+				messages = append(messages, e.Message)
+			}
+			if !reflect.DeepEqual(messages, tt.expectedMessages) {
+				t.Errorf("expected %v, got %v", tt.expectedMessages, messages)
+			}
+		})
+	}
+}
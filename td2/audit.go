@@ -0,0 +1,135 @@
+package tenderduty
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultAuditLogMaxMB is the size the audit log is rotated at when audit_log_max_mb is not set.
+	defaultAuditLogMaxMB = 100
+	// auditFlushInterval is how often the buffered audit entries are written to the file.
+	auditFlushInterval = 5 * time.Second
+)
+
+// auditEntry is one line of the audit log, an alarm firing or resolving.
+type auditEntry struct {
+	Time     time.Time `json:"time"`
+	Chain    string    `json:"chain"`
+	UniqueID string    `json:"unique_id"`
+	Severity string    `json:"severity"`
+	Resolved bool      `json:"resolved"`
+	Message  string    `json:"message"`
+}
+
+// auditLog appends every alert to a JSON lines file. When the file reaches maxBytes it is renamed with a .1 suffix,
+// replacing the previous one, and a new file is started.
+type auditLog struct {
+	mux      sync.Mutex
+	path     string
+	maxBytes int64
+	f        *os.File
+	w        *bufio.Writer
+	size     int64
+}
+
+func openAuditLog(path string, maxBytes int64) (*auditLog, error) {
+	a := &auditLog{path: path, maxBytes: maxBytes}
+	if err := a.open(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *auditLog) open() error {
+	//#nosec -- path is from the config file
+	f, err := os.OpenFile(a.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	a.f, a.w, a.size = f, bufio.NewWriter(f), info.Size()
+	return nil
+}
+
+// record buffers an entry, rotating the file first if the entry would take it over maxBytes.
+func (a *auditLog) record(entry auditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	if a.maxBytes > 0 && a.size > 0 && a.size+int64(len(line)) > a.maxBytes {
+		if err = a.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := a.w.Write(line)
+	a.size += int64(n)
+	return err
+}
+
+// rotate must be called while holding mux.
+func (a *auditLog) rotate() error {
+	if err := a.w.Flush(); err != nil {
+		return err
+	}
+	if err := a.f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(a.path, a.path+".1"); err != nil {
+		return err
+	}
+	return a.open()
+}
+
+// flush writes the buffered entries to the file, it does nothing without an audit log.
+func (a *auditLog) flush() error {
+	if a == nil {
+		return nil
+	}
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	return a.w.Flush()
+}
+
+// flushEvery flushes the buffered entries every interval until ctx is done, it does nothing without an audit log.
+func (a *auditLog) flushEvery(ctx context.Context, interval time.Duration) {
+	if a == nil {
+		return
+	}
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-tick.C:
+			if err := a.flush(); err != nil {
+				lError("could not write the audit log:", err)
+			}
+		case <-ctx.Done():
+			_ = a.flush()
+			return
+		}
+	}
+}
+
+func (c *Config) recordAudit(chainName, message, severity string, resolved bool, id string) {
+	if c.audit == nil {
+		return
+	}
+	entry := auditEntry{Time: time.Now().UTC(), Chain: chainName, UniqueID: id, Severity: severity, Resolved: resolved, Message: message}
+	if err := c.audit.record(entry); err != nil {
+		lError("could not write the audit log:", err)
+	}
+}
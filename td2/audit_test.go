@@ -0,0 +1,94 @@
+package tenderduty
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func readAuditLines(t *testing.T, path string) []auditEntry {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	entries := make([]auditEntry, 0)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry auditEntry
+		if err = json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid audit line %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestAuditLog(t *testing.T) {
+	originalAlarms := alarms
+	alarms = &alarmCache{AllAlarms: make(map[string]map[string]alertMsgCache), notifyMux: sync.RWMutex{}}
+	defer func() { alarms = originalAlarms }()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	// an existing file is appended to
+	if err := os.WriteFile(path, []byte(`{"chain":"earlier"}`+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c := createTestConfig()
+	var err error
+	if c.audit, err = openAuditLog(path, 1<<20); err != nil {
+		t.Fatal(err)
+	}
+	id := "ChainStalled_testval123"
+	c.alert("test-chain", "stalled: have not seen a new block", "critical", false, &id)
+	c.alert("test-chain", "stalled: have not seen a new block", "critical", true, &id)
+
+	if entries := readAuditLines(t, path); len(entries) != 1 {
+		t.Errorf("expected the entries to be buffered until flushed, got %d lines", len(entries))
+	}
+	if err = c.audit.flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := readAuditLines(t, path)
+	if len(entries) != 3 || entries[0].Chain != "earlier" {
+		t.Fatalf("expected two entries appended to the existing one, got %+v", entries)
+	}
+	for i, resolved := range []bool{false, true} {
+		e := entries[i+1]
+		if e.Chain != "test-chain" || e.UniqueID != id || e.Severity != "critical" || e.Resolved != resolved ||
+			e.Message != "stalled: have not seen a new block" || e.Time.IsZero() {
+			t.Errorf("unexpected entry %+v", e)
+		}
+	}
+}
+
+func TestAuditLogRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	audit, err := openAuditLog(path, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err = audit.record(auditEntry{Chain: "test-chain", UniqueID: "ChainStalled_testval123", Message: "stalled"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = audit.flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	rotated := readAuditLines(t, path+".1")
+	current := readAuditLines(t, path)
+	if len(rotated)+len(current) != 3 || len(rotated) == 0 || len(current) == 0 {
+		t.Errorf("expected the entries split over the rotated and the new file, got %d and %d", len(rotated), len(current))
+	}
+	if info, err := os.Stat(path + ".1"); err != nil || info.Size() > 300 {
+		t.Errorf("expected the rotated file to stay under the limit, got %v %v", info, err)
+	}
+}
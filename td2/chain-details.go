@@ -3,6 +3,7 @@ package tenderduty
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
@@ -122,7 +123,7 @@ type registryResults struct {
 
 // refreshRegistry updates the path map for public RPC endpoints for @eco_stake's public RPC proxy
 func refreshRegistry() error {
-	res, err := http.Get(registryJson)
+	res, err := newHTTPClient(0).Get(registryJson)
 	if err != nil {
 		return err
 	}
@@ -155,3 +156,69 @@ func getRegistryUrl(chainid string) (url string, ok bool) {
 	defer pathMux.Unlock()
 	return publicRpcUrl + cosmosPaths[chainid], cosmosPaths[chainid] != ""
 }
+
+// maxRegistryNodes is how many of the registry's public RPC endpoints are added for a chain using from_registry
+const maxRegistryNodes = 5
+
+// registryChain is a trimmed down version of a chain from the cosmos directory, only holding the info we need
+type registryChain struct {
+	Chain struct {
+		ChainId string `json:"chain_id"`
+		Apis    struct {
+			Rpc []struct {
+				Address  string `json:"address"`
+				Provider string `json:"provider"`
+			} `json:"rpc"`
+		} `json:"apis"`
+	} `json:"chain"`
+}
+
+// resolveFromRegistry looks up the chain by its name in the chain registry and fills in the chain-id and, when none
+// are configured, a few public RPC nodes. Public nodes are not alerted on when they go down.
+func (cc *ChainConfig) resolveFromRegistry(registryUrl string) error {
+	path := strings.ToLower(strings.TrimSpace(cc.name))
+	res, err := newHTTPClient(0).Get(registryUrl + path)
+	if err != nil {
+		return err
+	}
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("chain registry returned %d for %s", res.StatusCode, path)
+	}
+	chain := &registryChain{}
+	err = json.Unmarshal(body, chain)
+	if err != nil {
+		return err
+	}
+	if chain.Chain.ChainId == "" {
+		return fmt.Errorf("chain registry has no chain-id for %s", path)
+	}
+
+	switch cc.ChainId {
+	case "":
+		cc.ChainId = chain.Chain.ChainId
+	case chain.Chain.ChainId:
+	default:
+		return fmt.Errorf("configured chain-id %s does not match %s from the chain registry", cc.ChainId, chain.Chain.ChainId)
+	}
+	if len(cc.Nodes) > 0 {
+		return nil
+	}
+	for _, rpc := range chain.Chain.Apis.Rpc {
+		if len(cc.Nodes) == maxRegistryNodes {
+			break
+		}
+		if rpc.Address == "" {
+			continue
+		}
+		cc.Nodes = append(cc.Nodes, &NodeConfig{Url: strings.TrimSuffix(rpc.Address, "/")})
+	}
+	if len(cc.Nodes) == 0 {
+		return fmt.Errorf("chain registry has no RPC endpoints for %s", path)
+	}
+	return nil
+}
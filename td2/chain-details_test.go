@@ -0,0 +1,96 @@
+package tenderduty
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const registryFixture = `{
+  "repository": {"url": "https://github.com/cosmos/chain-registry"},
+  "chain": {
+    "name": "osmosis",
+    "chain_id": "osmosis-1",
+    "apis": {
+      "rpc": [
+        {"address": "https://rpc.osmosis.example.com/", "provider": "one"},
+        {"address": "", "provider": "empty"},
+        {"address": "https://rpc2.osmosis.example.com", "provider": "two"}
+      ]
+    }
+  }
+}`
+
+func TestResolveFromRegistry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/osmosis" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(registryFixture))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name            string
+		chainName       string
+		chainId         string
+		nodes           []*NodeConfig
+		expectErr       bool
+		expectedChainId string
+		expectedNodes   []string
+	}{
+		{
+			name:            "fills in the chain-id and public nodes",
+			chainName:       "Osmosis",
+			expectedChainId: "osmosis-1",
+			expectedNodes:   []string{"https://rpc.osmosis.example.com", "https://rpc2.osmosis.example.com"},
+		},
+		{
+			name:            "keeps configured nodes",
+			chainName:       "osmosis",
+			chainId:         "osmosis-1",
+			nodes:           []*NodeConfig{{Url: "http://localhost:26657", AlertIfDown: true}},
+			expectedChainId: "osmosis-1",
+			expectedNodes:   []string{"http://localhost:26657"},
+		},
+		{
+			name:      "rejects a mismatching chain-id",
+			chainName: "osmosis",
+			chainId:   "osmo-test-5",
+			expectErr: true,
+		},
+		{
+			name:      "unknown chain",
+			chainName: "nonexistent",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := &ChainConfig{name: tt.chainName, ChainId: tt.chainId, Nodes: tt.nodes}
+			err := cc.resolveFromRegistry(server.URL + "/")
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cc.ChainId != tt.expectedChainId {
+				t.Errorf("expected chain-id %s, got %s", tt.expectedChainId, cc.ChainId)
+			}
+			if len(cc.Nodes) != len(tt.expectedNodes) {
+				t.Fatalf("expected %d nodes, got %d", len(tt.expectedNodes), len(cc.Nodes))
+			}
+			for i, node := range cc.Nodes {
+				if node.Url != tt.expectedNodes[i] {
+					t.Errorf("expected node %s, got %s", tt.expectedNodes[i], node.Url)
+				}
+			}
+		})
+	}
+}
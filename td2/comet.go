@@ -0,0 +1,259 @@
+package tenderduty
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cometVersion is the RPC dialect a node speaks. The shape of /block_results changed twice: Tendermint v0.34
+// base64 encodes event attributes, CometBFT v0.37 sends them as plain strings, and CometBFT v0.38 replaced the begin
+// and end block events with finalize block events.
+type cometVersion int
+
+const (
+	cometUnknown cometVersion = iota
+	comet34
+	comet37
+	comet38
+)
+
+func (v cometVersion) String() string {
+	switch v {
+	case comet34:
+		return "0.34"
+	case comet37:
+		return "0.37"
+	case comet38:
+		return "0.38"
+	}
+	return "unknown"
+}
+
+// parseCometVersion maps a version string, either the comet_version hint or the node_info.version from /status, to
+// the RPC dialect. v0.35 and v0.36 never saw wide use and are treated as v0.37, CometBFT v1 responds like v0.38.
+func parseCometVersion(s string) cometVersion {
+	parts := strings.SplitN(strings.TrimPrefix(strings.TrimSpace(s), "v"), ".", 3)
+	if len(parts) < 2 {
+		return cometUnknown
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return cometUnknown
+	}
+	minor, err := strconv.Atoi(strings.SplitN(parts[1], "-", 2)[0])
+	if err != nil {
+		return cometUnknown
+	}
+	switch {
+	case major >= 1:
+		return comet38
+	case minor >= 38:
+		return comet38
+	case minor >= 35:
+		return comet37
+	case minor == 34:
+		return comet34
+	}
+	return cometUnknown
+}
+
+// rpcVersion returns the RPC dialect to use for the chain, the comet_version setting wins over what /status reported.
+func (cc *ChainConfig) rpcVersion() cometVersion {
+	if v := parseCometVersion(cc.CometVersion); v != cometUnknown {
+		return v
+	}
+	cc.cometMux.RLock()
+	defer cc.cometMux.RUnlock()
+	return cc.detectedComet
+}
+
+func (cc *ChainConfig) setDetectedComet(version string) {
+	v := parseCometVersion(version)
+	if v == cometUnknown {
+		return
+	}
+	cc.cometMux.Lock()
+	defer cc.cometMux.Unlock()
+	cc.detectedComet = v
+}
+
+// decode returns the attribute's key and value as text. Tendermint v0.34 sends both base64 encoded while later
+// versions send plain strings. When the version is not known yet a key that decodes as base64 is assumed to be encoded,
+// none of the keys we look for are valid base64.
+func (a abciAttribute) decode(v cometVersion) (key, value string) {
+	if v == comet37 || v == comet38 {
+		return a.Key, a.Value
+	}
+	k, err := base64.StdEncoding.DecodeString(a.Key)
+	if err != nil {
+		return a.Key, a.Value
+	}
+	val, _ := base64.StdEncoding.DecodeString(a.Value)
+	return string(k), string(val)
+}
+
+// blockResults holds the events from /block_results with the attributes already decoded.
+type blockResults struct {
+	Height      int64
+	BlockEvents []abciEvent // begin and end block events, or finalize block events from v0.38
+	TxResults   []txResult
+}
+
+type txResult struct {
+	Code   uint32
+	Events []abciEvent
+}
+
+// cometValidator is an entry of the /validators response. The public key is kept as the type name and base64 value
+// so that key types the tendermint library doesn't know about don't fail the whole response.
+type cometValidator struct {
+	Address          string
+	PubKeyType       string
+	PubKey           string
+	VotingPower      int64
+	ProposerPriority int64
+}
+
+// rpcGet fetches a path from the node the client is connected to and unmarshals the json-rpc result into v.
+func (cc *ChainConfig) rpcGet(ctx context.Context, path string, v any) error {
+	if cc.client == nil {
+		return errors.New("no rpc client")
+	}
+	u, err := url.Parse(cc.client.Remote())
+	if err != nil {
+		return err
+	}
+	switch u.Scheme {
+	case "tcp":
+		u.Scheme = "http"
+	case "http", "https":
+	default:
+		return fmt.Errorf("%s is not supported for raw rpc queries", u.Scheme)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(u.String(), "/")+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := newNodeHTTPClient(cc.nodeByUrl(cc.client.Remote()), 10*time.Second).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %d", path, resp.StatusCode)
+	}
+	reply := struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+			Data    string `json:"data"`
+		} `json:"error"`
+	}{}
+	if err = json.Unmarshal(b, &reply); err != nil {
+		return err
+	}
+	if reply.Error != nil {
+		return fmt.Errorf("%s: %s %s", path, reply.Error.Message, reply.Error.Data)
+	}
+	return json.Unmarshal(reply.Result, v)
+}
+
+// getBlockResults fetches the events for a height, parsing the response according to the node's version.
+func (cc *ChainConfig) getBlockResults(ctx context.Context, height int64) (*blockResults, error) {
+	raw := struct {
+		Height           stringInt64   `json:"height"`
+		BeginBlockEvents []abciEvent   `json:"begin_block_events"`
+		EndBlockEvents   []abciEvent   `json:"end_block_events"`
+		FinalizeEvents   []abciEvent   `json:"finalize_block_events"`
+		TxsResults       []rawTxResult `json:"txs_results"`
+	}{}
+	if err := cc.rpcGet(ctx, fmt.Sprintf("/block_results?height=%d", height), &raw); err != nil {
+		return nil, err
+	}
+	v := cc.rpcVersion()
+	res := &blockResults{Height: raw.Height.val()}
+	if v == comet38 || (v == cometUnknown && raw.FinalizeEvents != nil) {
+		res.BlockEvents = decodeEvents(raw.FinalizeEvents, v)
+	} else {
+		res.BlockEvents = decodeEvents(append(raw.BeginBlockEvents, raw.EndBlockEvents...), v)
+	}
+	for _, tx := range raw.TxsResults {
+		res.TxResults = append(res.TxResults, txResult{Code: tx.Code, Events: decodeEvents(tx.Events, v)})
+	}
+	return res, nil
+}
+
+type rawTxResult struct {
+	Code   uint32      `json:"code"`
+	Events []abciEvent `json:"events"`
+}
+
+func decodeEvents(events []abciEvent, v cometVersion) []abciEvent {
+	decoded := make([]abciEvent, 0, len(events))
+	for _, e := range events {
+		attrs := make([]abciAttribute, 0, len(e.Attributes))
+		for _, a := range e.Attributes {
+			k, val := a.decode(v)
+			attrs = append(attrs, abciAttribute{Key: k, Value: val})
+		}
+		decoded = append(decoded, abciEvent{Type: e.Type, Attributes: attrs})
+	}
+	return decoded
+}
+
+// getValidatorSet fetches the full validator set at a height, a height of 0 means the latest block. It also returns the
+// height the set was read at, the later pages are read at the height of the first so they can't come from a newer block.
+func (cc *ChainConfig) getValidatorSet(ctx context.Context, height int64) ([]cometValidator, int64, error) {
+	const perPage = 100
+	vals := make([]cometValidator, 0)
+	for page := 1; ; page++ {
+		path := fmt.Sprintf("/validators?page=%d&per_page=%d", page, perPage)
+		if height > 0 {
+			path += fmt.Sprintf("&height=%d", height)
+		}
+		raw := struct {
+			Validators []struct {
+				Address string `json:"address"`
+				PubKey  struct {
+					Type  string `json:"type"`
+					Value string `json:"value"`
+				} `json:"pub_key"`
+				VotingPower      stringInt64 `json:"voting_power"`
+				ProposerPriority stringInt64 `json:"proposer_priority"`
+			} `json:"validators"`
+			BlockHeight stringInt64 `json:"block_height"`
+			Total       stringInt64 `json:"total"`
+		}{}
+		if err := cc.rpcGet(ctx, path, &raw); err != nil {
+			return nil, 0, err
+		}
+		if height == 0 {
+			height = raw.BlockHeight.val()
+		}
+		for _, v := range raw.Validators {
+			vals = append(vals, cometValidator{
+				Address:          v.Address,
+				PubKeyType:       v.PubKey.Type,
+				PubKey:           v.PubKey.Value,
+				VotingPower:      v.VotingPower.val(),
+				ProposerPriority: v.ProposerPriority.val(),
+			})
+		}
+		if len(raw.Validators) == 0 || int64(len(vals)) >= raw.Total.val() {
+			return vals, height, nil
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package tenderduty
+
+import (
+	"time"
+
+	dash "github.com/firstset/tenderduty/v2/td2/dashboard"
+)
+
+// sendDashUpdate sends a chain's status to the dashboard, at most once every dashboard_update_seconds. Updates in
+// between are held back and only the newest one is sent when the interval ends. A change of the bonded, jailed or
+// tombstoned state or of the number of active alerts is sent right away.
+func (cc *ChainConfig) sendDashUpdate(status *dash.ChainStatus) {
+	interval := time.Duration(td.DashboardUpdateSeconds) * time.Second
+	cc.dashMux.Lock()
+	defer cc.dashMux.Unlock()
+	if interval <= 0 || dashStatusChanged(cc.dashSent, status) || time.Since(cc.dashSentAt) >= interval {
+		cc.dashPending = nil
+		cc.sendDashLocked(status)
+		return
+	}
+	cc.dashPending = status
+	if cc.dashTimer == nil {
+		cc.dashTimer = time.AfterFunc(interval-time.Since(cc.dashSentAt), cc.flushDashUpdate)
+	}
+}
+
+// flushDashUpdate sends the newest update held back by sendDashUpdate, if it wasn't superseded in the meantime.
+func (cc *ChainConfig) flushDashUpdate() {
+	cc.dashMux.Lock()
+	defer cc.dashMux.Unlock()
+	cc.dashTimer = nil
+	if cc.dashPending != nil {
+		cc.sendDashLocked(cc.dashPending)
+		cc.dashPending = nil
+	}
+}
+
+// sendDashLocked must be called while holding dashMux.
+func (cc *ChainConfig) sendDashLocked(status *dash.ChainStatus) {
+	cc.dashSent = status
+	cc.dashSentAt = time.Now()
+	td.updateChan <- status
+}
+
+// dashStatusChanged reports whether an update changes something that should not wait for the next interval.
+func dashStatusChanged(sent, status *dash.ChainStatus) bool {
+	return sent == nil ||
+		sent.Bonded != status.Bonded ||
+		sent.Jailed != status.Jailed ||
+		sent.Tombstoned != status.Tombstoned ||
+		sent.ActiveAlerts != status.ActiveAlerts ||
+		sent.ActiveSeverity != status.ActiveSeverity
+}
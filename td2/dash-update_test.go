@@ -0,0 +1,78 @@
+package tenderduty
+
+import (
+	"testing"
+
+	dash "github.com/firstset/tenderduty/v2/td2/dashboard"
+)
+
+func TestSendDashUpdateVolume(t *testing.T) {
+	tests := []struct {
+		name          string
+		interval      int
+		jailedAt      int64
+		expectedSends int
+	}{
+		{
+			name:          "should send every update without an interval",
+			expectedSends: 100,
+		},
+		{
+			name:          "should send one update per interval",
+			interval:      3600,
+			expectedSends: 1,
+		},
+		{
+			name:          "should send a status change right away",
+			interval:      3600,
+			jailedAt:      50,
+			expectedSends: 2,
+		},
+	}
+
+	originalTd := td
+	defer func() { td = originalTd }()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td = &Config{updateChan: make(chan *dash.ChainStatus, 200), DashboardUpdateSeconds: tt.interval}
+			cc := &ChainConfig{name: "test-chain"}
+			for height := int64(1); height <= 100; height++ {
+				cc.sendDashUpdate(&dash.ChainStatus{Name: "test-chain", Height: height, Jailed: tt.jailedAt > 0 && height >= tt.jailedAt})
+			}
+			if cc.dashTimer != nil {
+				cc.dashTimer.Stop()
+			}
+			if len(td.updateChan) != tt.expectedSends {
+				t.Errorf("expected %d dashboard updates, got %d", tt.expectedSends, len(td.updateChan))
+			}
+		})
+	}
+}
+
+func TestFlushDashUpdate(t *testing.T) {
+	originalTd := td
+	td = &Config{updateChan: make(chan *dash.ChainStatus, 10), DashboardUpdateSeconds: 3600}
+	defer func() { td = originalTd }()
+
+	cc := &ChainConfig{name: "test-chain"}
+	for height := int64(1); height <= 10; height++ {
+		cc.sendDashUpdate(&dash.ChainStatus{Name: "test-chain", Height: height})
+	}
+	if cc.dashTimer == nil {
+		t.Fatal("expected a flush to be scheduled")
+	}
+	cc.dashTimer.Stop()
+	cc.flushDashUpdate()
+
+	if len(td.updateChan) != 2 {
+		t.Fatalf("expected 2 dashboard updates, got %d", len(td.updateChan))
+	}
+	<-td.updateChan
+	if latest := <-td.updateChan; latest.Height != 10 {
+		t.Errorf("expected the held back update to be the newest, got height %d", latest.Height)
+	}
+	cc.flushDashUpdate()
+	if len(td.updateChan) != 0 {
+		t.Errorf("expected nothing left to flush, got %d updates", len(td.updateChan))
+	}
+}
@@ -0,0 +1,118 @@
+package tenderduty
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/go-yaml/yaml"
+)
+
+// redacted replaces secrets in the output of DumpConfig.
+const redacted = "<redacted>"
+
+// effectiveChain is the part of a chain's config that decides which alerts fire, after the defaults are merged.
+type effectiveChain struct {
+	ChainId    string      `yaml:"chain_id"`
+	ValAddress string      `yaml:"valoper_address"`
+	Alerts     AlertConfig `yaml:"alerts"`
+}
+
+// DumpConfig writes the alert settings each chain ends up with once default_alert_config is merged in, as yaml or
+// json. Webhooks and API keys are redacted.
+func DumpConfig(configFiles []string, chainConfigDirectory string, password *string, format string, w io.Writer) error {
+	c, err := readConfig(configFiles, chainConfigDirectory, password)
+	if err != nil {
+		return err
+	}
+	return dumpEffectiveConfig(c, format, w)
+}
+
+func dumpEffectiveConfig(c *Config, format string, w io.Writer) error {
+	names := make([]string, 0, len(c.Chains))
+	for name := range c.Chains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	chains := make(yaml.MapSlice, 0, len(names))
+	for _, name := range names {
+		cc := c.Chains[name]
+		applyAlertDefaults(&cc.Alerts, &c.DefaultAlertConfig)
+		alerts := cc.Alerts
+		redactAlertConfig(&alerts)
+		chains = append(chains, yaml.MapItem{Key: name, Value: effectiveChain{ChainId: cc.ChainId, ValAddress: cc.ValAddress, Alerts: alerts}})
+	}
+
+	b, err := yaml.Marshal(yaml.MapSlice{{Key: "chains", Value: chains}})
+	if err != nil {
+		return err
+	}
+	switch format {
+	case "", "yaml":
+	case "json":
+		// round trip through yaml so the keys match the config file rather than the go field names
+		var doc yaml.MapSlice
+		if err = yaml.Unmarshal(b, &doc); err != nil {
+			return err
+		}
+		if b, err = json.MarshalIndent(jsonValue(doc), "", "  "); err != nil {
+			return err
+		}
+		b = append(b, '\n')
+	default:
+		return fmt.Errorf("unknown format %s, valid choices are yaml and json", format)
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// redactAlertConfig hides the credentials of the notification destinations.
+func redactAlertConfig(a *AlertConfig) {
+	for _, secret := range alertSecrets(a) {
+		if *secret != "" {
+			*secret = redacted
+		}
+	}
+}
+
+// jsonValue converts the ordered maps from the yaml decoder into something encoding/json can marshal, keeping order.
+func jsonValue(v any) any {
+	switch t := v.(type) {
+	case yaml.MapSlice:
+		m := make(orderedJSON, 0, len(t))
+		for _, item := range t {
+			m = append(m, yaml.MapItem{Key: fmt.Sprint(item.Key), Value: jsonValue(item.Value)})
+		}
+		return m
+	case []any:
+		for i := range t {
+			t[i] = jsonValue(t[i])
+		}
+		return t
+	}
+	return v
+}
+
+// orderedJSON marshals as a json object with the keys in order.
+type orderedJSON []yaml.MapItem
+
+func (o orderedJSON) MarshalJSON() ([]byte, error) {
+	buf := []byte{'{'}
+	for i, item := range o {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		k, err := json.Marshal(item.Key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(item.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(append(append(buf, k...), ':'), v...)
+	}
+	return append(buf, '}'), nil
+}
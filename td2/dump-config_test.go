@@ -0,0 +1,112 @@
+package tenderduty
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-yaml/yaml"
+)
+
+const dumpTestConfig = `
+default_alert_config:
+  stalled_enabled: yes
+  stalled_minutes: 10
+  discord:
+    enabled: yes
+    webhook: https://discord.com/api/webhooks/secret
+chains:
+  "Chain A":
+    chain_id: chain-a-1
+    valoper_address: valoper1a
+    alerts:
+      stalled_minutes: 20
+  "Chain B":
+    chain_id: chain-b-1
+    valoper_address: valoper1b
+    alerts:
+      stalled_enabled: no
+`
+
+func TestDumpConfig(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config.yml")
+	if err := os.WriteFile(configFile, []byte(dumpTestConfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+	password := ""
+
+	type dumpedChain struct {
+		ChainId string `yaml:"chain_id" json:"chain_id"`
+		Alerts  struct {
+			Stalled       *int  `yaml:"stalled_minutes" json:"stalled_minutes"`
+			StalledAlerts *bool `yaml:"stalled_enabled" json:"stalled_enabled"`
+			Discord       struct {
+				Enabled *bool  `yaml:"enabled" json:"enabled"`
+				Webhook string `yaml:"webhook" json:"webhook"`
+			} `yaml:"discord" json:"discord"`
+		} `yaml:"alerts" json:"alerts"`
+	}
+	type dump struct {
+		Chains map[string]dumpedChain `yaml:"chains" json:"chains"`
+	}
+
+	for _, format := range []string{"yaml", "json"} {
+		t.Run(format, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := DumpConfig([]string{configFile}, filepath.Join(dir, "chains.d"), &password, format, &out); err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(out.String(), "secret") {
+				t.Errorf("the discord webhook was not redacted:\n%s", out.String())
+			}
+
+			d := dump{}
+			var err error
+			if format == "json" {
+				err = json.Unmarshal(out.Bytes(), &d)
+			} else {
+				err = yaml.Unmarshal(out.Bytes(), &d)
+			}
+			if err != nil {
+				t.Fatalf("could not parse the dump: %v\n%s", err, out.String())
+			}
+
+			tests := []struct {
+				chain         string
+				stalled       int
+				stalledAlerts bool
+			}{
+				// the chain's own value wins over the default
+				{chain: "Chain A", stalled: 20, stalledAlerts: true},
+				// the default fills in what the chain left out
+				{chain: "Chain B", stalled: 10, stalledAlerts: false},
+			}
+			for _, tt := range tests {
+				c, ok := d.Chains[tt.chain]
+				if !ok {
+					t.Fatalf("%s missing from the dump:\n%s", tt.chain, out.String())
+				}
+				if c.Alerts.Stalled == nil || *c.Alerts.Stalled != tt.stalled {
+					t.Errorf("%s: expected stalled_minutes %d, got %v", tt.chain, tt.stalled, c.Alerts.Stalled)
+				}
+				if c.Alerts.StalledAlerts == nil || *c.Alerts.StalledAlerts != tt.stalledAlerts {
+					t.Errorf("%s: expected stalled_enabled %v, got %v", tt.chain, tt.stalledAlerts, c.Alerts.StalledAlerts)
+				}
+				if c.Alerts.Discord.Enabled == nil || !*c.Alerts.Discord.Enabled || c.Alerts.Discord.Webhook != redacted {
+					t.Errorf("%s: expected the default discord settings with a redacted webhook, got %+v", tt.chain, c.Alerts.Discord)
+				}
+			}
+		})
+	}
+}
+
+func TestDumpConfigUnknownFormat(t *testing.T) {
+	c := &Config{Chains: map[string]*ChainConfig{"test-chain": {ChainId: "test-chain-1"}}}
+	if err := dumpEffectiveConfig(c, "toml", &bytes.Buffer{}); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
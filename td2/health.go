@@ -0,0 +1,84 @@
+package tenderduty
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// alertWorkerHeartbeat is how often the alert worker records that it is alive while there are no alerts to send,
+// /healthz fails once it has missed a few.
+const alertWorkerHeartbeat = 10 * time.Second
+
+// alertWorkerSeen is the unix nano time the alert worker last went round its loop, 0 before it started.
+var alertWorkerSeen int64
+
+func markAlertWorker() {
+	atomic.StoreInt64(&alertWorkerSeen, time.Now().UnixNano())
+}
+
+func alertWorkerAlive() bool {
+	seen := atomic.LoadInt64(&alertWorkerSeen)
+	return seen != 0 && time.Since(time.Unix(0, seen)) < 3*alertWorkerHeartbeat
+}
+
+// healthzHandler is a liveness probe for the tenderduty process, it says nothing about the chains.
+func healthzHandler(writer http.ResponseWriter, _ *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	if !alertWorkerAlive() {
+		apiError(writer, http.StatusServiceUnavailable, "alert worker is not running")
+		return
+	}
+	j, _ := json.Marshal(map[string]string{"status": "ok"})
+	_, _ = writer.Write(j)
+}
+
+// readyzHandler is a readiness probe, tenderduty is ready once it can monitor at least one chain.
+func readyzHandler(writer http.ResponseWriter, _ *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	ready := 0
+	td.chainsMux.RLock()
+	for _, cc := range td.Chains {
+		if cc.monitoringUp() {
+			ready++
+		}
+	}
+	total := len(td.Chains)
+	td.chainsMux.RUnlock()
+	if ready == 0 {
+		apiError(writer, http.StatusServiceUnavailable, "no chain is connected yet")
+		return
+	}
+	j, _ := json.Marshal(map[string]int{"chains": total, "connected": ready})
+	_, _ = writer.Write(j)
+}
+
+// registerProbes adds /healthz and /readyz to mux.
+func registerProbes(mux *http.ServeMux) {
+	mux.HandleFunc("/healthz", healthzHandler)
+	mux.HandleFunc("/readyz", readyzHandler)
+}
+
+// newProbeMux returns a mux with only the health probes.
+func newProbeMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	registerProbes(mux)
+	return mux
+}
+
+// serveProbes serves the health probes on listen_port when neither the dashboard nor the prometheus exporter is
+// enabled, so a headless deployment still has them.
+func serveProbes(port string) {
+	l("serving the health probes on", port)
+	srv := &http.Server{
+		Addr:              ":" + port,
+		Handler:           newProbeMux(),
+		ReadTimeout:       20 * time.Second,
+		WriteTimeout:      20 * time.Second,
+		IdleTimeout:       120 * time.Second,
+		ReadHeaderTimeout: 20 * time.Second,
+	}
+	log.Fatal(srv.ListenAndServe())
+}
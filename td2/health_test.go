@@ -0,0 +1,100 @@
+package tenderduty
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHealthz(t *testing.T) {
+	original := atomic.LoadInt64(&alertWorkerSeen)
+	defer atomic.StoreInt64(&alertWorkerSeen, original)
+
+	tests := []struct {
+		name           string
+		seen           int64
+		expectedStatus int
+	}{
+		{name: "worker not started", seen: 0, expectedStatus: http.StatusServiceUnavailable},
+		{name: "worker recently seen", seen: time.Now().UnixNano(), expectedStatus: http.StatusOK},
+		{name: "worker stopped", seen: time.Now().Add(-time.Hour).UnixNano(), expectedStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt64(&alertWorkerSeen, tt.seen)
+			rec := httptest.NewRecorder()
+			healthzHandler(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestReadyz(t *testing.T) {
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+	cc := td.Chains["test-chain"]
+
+	tests := []struct {
+		name           string
+		noNodes        bool
+		updated        time.Time
+		expectedStatus int
+	}{
+		{name: "not connected yet", expectedStatus: http.StatusServiceUnavailable},
+		{name: "connected", updated: time.Now(), expectedStatus: http.StatusOK},
+		{name: "all nodes down", noNodes: true, updated: time.Now(), expectedStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc.noNodes = tt.noNodes
+			cc.valInfoUpdated = tt.updated
+			rec := httptest.NewRecorder()
+			readyzHandler(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestProbesWithoutDashboard(t *testing.T) {
+	original := atomic.LoadInt64(&alertWorkerSeen)
+	defer atomic.StoreInt64(&alertWorkerSeen, original)
+	atomic.StoreInt64(&alertWorkerSeen, time.Now().UnixNano())
+
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name       string
+		enableDash bool
+		mux        func() *http.ServeMux
+		served     bool
+	}{
+		{name: "prometheus exporter serves the probes without the dashboard", mux: newPromMux, served: true},
+		{name: "prometheus exporter leaves the probes to the dashboard", enableDash: true, mux: newPromMux},
+		{name: "probe listener serves the probes", mux: newProbeMux, served: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td.EnableDash = tt.enableDash
+			mux := tt.mux()
+			for _, path := range []string{"/healthz", "/readyz"} {
+				rec := httptest.NewRecorder()
+				mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+				if served := rec.Code != http.StatusNotFound; served != tt.served {
+					t.Errorf("expected %s served %v, got status %d", path, tt.served, rec.Code)
+				}
+			}
+		})
+	}
+}
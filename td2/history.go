@@ -0,0 +1,55 @@
+package tenderduty
+
+import (
+	"sync"
+	"time"
+)
+
+// alertEvent is one alarm firing or resolving, it is also the JSON schema of the history API.
+type alertEvent struct {
+	Time     time.Time `json:"time"`
+	ID       string    `json:"id"`
+	Message  string    `json:"message,omitempty"`
+	Severity string    `json:"severity"`
+	Resolved bool      `json:"resolved"`
+}
+
+// alertHistoryStore keeps the alert events of each chain.
+type alertHistoryStore interface {
+	record(chain string, event alertEvent)
+	// since returns the chain's events at or after t, oldest first
+	since(chain string, t time.Time) []alertEvent
+}
+
+// memoryAlertHistory keeps the newest size events of each chain, they are lost on restart.
+type memoryAlertHistory struct {
+	mux    sync.RWMutex
+	size   int
+	events map[string][]alertEvent
+}
+
+func newMemoryAlertHistory(size int) *memoryAlertHistory {
+	return &memoryAlertHistory{size: size, events: make(map[string][]alertEvent)}
+}
+
+func (m *memoryAlertHistory) record(chain string, event alertEvent) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	events := append(m.events[chain], event)
+	if len(events) > m.size {
+		events = events[len(events)-m.size:]
+	}
+	m.events[chain] = events
+}
+
+func (m *memoryAlertHistory) since(chain string, t time.Time) []alertEvent {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	result := make([]alertEvent, 0)
+	for _, e := range m.events[chain] {
+		if !e.Time.Before(t) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
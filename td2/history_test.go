@@ -0,0 +1,26 @@
+package tenderduty
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryAlertHistory(t *testing.T) {
+	h := newMemoryAlertHistory(3)
+	start := time.Now()
+	for i, id := range []string{"a", "b", "c", "d"} {
+		h.record("test-chain", alertEvent{Time: start.Add(time.Duration(i) * time.Minute), ID: id})
+	}
+	h.record("other-chain", alertEvent{Time: start, ID: "x"})
+
+	events := h.since("test-chain", time.Time{})
+	if len(events) != 3 || events[0].ID != "b" || events[2].ID != "d" {
+		t.Errorf("expected the newest 3 events b..d, got %+v", events)
+	}
+	if events = h.since("test-chain", start.Add(2*time.Minute)); len(events) != 2 || events[0].ID != "c" {
+		t.Errorf("expected c and d since +2m, got %+v", events)
+	}
+	if events = h.since("unknown-chain", time.Time{}); events == nil || len(events) != 0 {
+		t.Errorf("expected an empty, non-nil result for an unknown chain, got %#v", events)
+	}
+}
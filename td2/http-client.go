@@ -0,0 +1,243 @@
+package tenderduty
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+)
+
+// newDialer returns a dialer honoring the dns_server setting, used for both http and websocket connections.
+func newDialer() *net.Dialer {
+	d := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if td != nil && td.DNSServer != "" {
+		server := td.DNSServer
+		d.Resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				return (&net.Dialer{Timeout: 5 * time.Second}).DialContext(ctx, network, server)
+			},
+		}
+	}
+	return d
+}
+
+// dialContext forces tcp4 or tcp6 when dial_network is set. Settings are read on each dial so that clients created
+// while the config is still loading pick them up.
+func dialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	if td != nil && td.DialNetwork != "" && strings.HasPrefix(network, "tcp") {
+		network = td.DialNetwork
+	}
+	return newDialer().DialContext(ctx, network, addr)
+}
+
+// proxyFunc uses proxy_url when configured and falls back to the HTTP_PROXY/HTTPS_PROXY environment variables.
+func proxyFunc(req *http.Request) (*url.URL, error) {
+	if td != nil && td.proxyURL != nil {
+		return td.proxyURL, nil
+	}
+	return http.ProxyFromEnvironment(req)
+}
+
+// loadCACerts adds the certificates in a PEM file to the system pool, or to an empty pool where the system one isn't
+// available.
+func loadCACerts(path string) (*x509.CertPool, error) {
+	//#nosec -- variable specified in the config file
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("no PEM certificates found")
+	}
+	return pool, nil
+}
+
+// tlsConfig applies tls_skip_verify and ca_cert_file.
+func tlsConfig(c *Config) *tls.Config {
+	if c == nil {
+		return &tls.Config{}
+	}
+	//#nosec G402 -- configurable option
+	return &tls.Config{InsecureSkipVerify: c.TLSSkipVerify, RootCAs: c.rootCAs}
+}
+
+// newHTTPTransport builds the transport for c's outgoing requests, validateConfig builds the one they all share.
+func newHTTPTransport(c *Config) *http.Transport {
+	return &http.Transport{
+		Proxy:                 proxyFunc,
+		DialContext:           dialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+		TLSClientConfig:       tlsConfig(c),
+	}
+}
+
+// httpTransport returns the shared transport, so that connections to the same host are reused across requests.
+// Requests made while the config is still loading get a transport of their own.
+func httpTransport() *http.Transport {
+	if td != nil && td.transport != nil {
+		return td.transport
+	}
+	return newHTTPTransport(td)
+}
+
+// newHTTPClient returns a client using the proxy and dialer settings, a zero timeout means no timeout.
+func newHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{Transport: httpTransport(), Timeout: timeout}
+}
+
+// authHeader returns the headers and basic auth configured for the node, nil when there are none or node is nil.
+func (n *NodeConfig) authHeader() http.Header {
+	if n == nil || (len(n.Headers) == 0 && n.BasicAuthUser == "") {
+		return nil
+	}
+	header := make(http.Header)
+	for k, v := range n.Headers {
+		header.Set(k, v)
+	}
+	if n.BasicAuthUser != "" {
+		header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(n.BasicAuthUser+":"+n.BasicAuthPassword)))
+	}
+	return header
+}
+
+// headerTransport adds a node's headers to every request.
+type headerTransport struct {
+	base   http.RoundTripper
+	header http.Header
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// a RoundTripper must not modify the request it is given
+	req = req.Clone(req.Context())
+	for k, v := range t.header {
+		req.Header[k] = v
+	}
+	return t.base.RoundTrip(req)
+}
+
+// nodeClientKey identifies a cached node client, nodes with the same headers share one.
+type nodeClientKey struct {
+	transport *http.Transport
+	header    string
+	timeout   time.Duration
+}
+
+var (
+	nodeClients    = make(map[nodeClientKey]*http.Client)
+	nodeClientsMux sync.Mutex
+)
+
+// newNodeHTTPClient is newHTTPClient for requests to an rpc node, they carry the node's headers and basic auth. The
+// client is created on first use and reused for the node's later requests.
+func newNodeHTTPClient(node *NodeConfig, timeout time.Duration) *http.Client {
+	header := node.authHeader()
+	if header == nil {
+		return newHTTPClient(timeout)
+	}
+	key := nodeClientKey{transport: httpTransport(), timeout: timeout}
+	var b strings.Builder
+	_ = header.Write(&b) // writes the headers sorted by key
+	key.header = b.String()
+
+	nodeClientsMux.Lock()
+	defer nodeClientsMux.Unlock()
+	if client := nodeClients[key]; client != nil {
+		return client
+	}
+	client := &http.Client{Transport: &headerTransport{base: key.transport, header: header}, Timeout: timeout}
+	nodeClients[key] = client
+	return client
+}
+
+// newWebsocketDialer returns a websocket dialer using the proxy and dialer settings.
+func newWebsocketDialer() *websocket.Dialer {
+	return &websocket.Dialer{
+		Proxy:            proxyFunc,
+		NetDialContext:   dialContext,
+		HandshakeTimeout: 45 * time.Second,
+		TLSClientConfig:  tlsConfig(td),
+	}
+}
+
+// newRPCClient creates a tendermint rpc client for node, which is nil for the public fallback. Unix sockets are left to
+// the library's own client.
+func newRPCClient(remote string, node *NodeConfig) (*rpchttp.HTTP, error) {
+	if strings.HasPrefix(remote, "unix://") {
+		return rpchttp.New(remote, "/websocket")
+	}
+	return rpchttp.NewWithClient(remote, "/websocket", newNodeHTTPClient(node, 0))
+}
+
+// rpcClientKey identifies a shared rpc client, chains only share a client when they reach the same endpoint for the
+// same chain with the same headers.
+type rpcClientKey struct {
+	remote  string
+	chainId string
+	header  string
+}
+
+var (
+	rpcClients    = make(map[rpcClientKey]*rpchttp.HTTP)
+	rpcClientsMux sync.Mutex
+)
+
+// sharedRPCClient returns the rpc client for remote serving chainId, creating it on first use. Several chains served
+// by one node behind a proxy reuse the same client and its connections instead of opening their own.
+func sharedRPCClient(remote, chainId string, node *NodeConfig) (*rpchttp.HTTP, error) {
+	key := rpcClientKey{remote: remote, chainId: chainId}
+	if header := node.authHeader(); header != nil {
+		var b strings.Builder
+		_ = header.Write(&b) // writes the headers sorted by key
+		key.header = b.String()
+	}
+
+	rpcClientsMux.Lock()
+	defer rpcClientsMux.Unlock()
+	if client := rpcClients[key]; client != nil {
+		return client, nil
+	}
+	client, err := newRPCClient(remote, node)
+	if err != nil {
+		return nil, err
+	}
+	rpcClients[key] = client
+	return client, nil
+}
+
+// nodeByUrl returns the configured node with the url, nil for the public fallback node.
+func (cc *ChainConfig) nodeByUrl(u string) *NodeConfig {
+	for _, node := range cc.Nodes {
+		if node.Url == u {
+			return node
+		}
+	}
+	return nil
+}
+
+// servedChainId is the chain-id node is expected to serve for the chain, nil is the public fallback.
+func (cc *ChainConfig) servedChainId(node *NodeConfig) string {
+	if node != nil && node.ChainId != "" {
+		return node.ChainId
+	}
+	return cc.ChainId
+}
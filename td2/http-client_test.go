@@ -0,0 +1,338 @@
+package tenderduty
+
+import (
+	"context"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+)
+
+func TestNewHTTPClientProxy(t *testing.T) {
+	origTd := td
+	defer func() { td = origTd }()
+
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// a proxied request carries the absolute url of the target
+		proxied = r.URL.String()
+		_, _ = w.Write([]byte("via proxy"))
+	}))
+	defer proxy.Close()
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("direct"))
+	}))
+	defer target.Close()
+
+	tests := []struct {
+		name        string
+		proxyURL    string
+		dialNetwork string
+		expected    string
+	}{
+		{name: "configured proxy is used", proxyURL: proxy.URL, expected: "via proxy"},
+		{name: "configured proxy with tcp4", proxyURL: proxy.URL, dialNetwork: "tcp4", expected: "via proxy"},
+		{name: "no proxy connects directly", expected: "direct"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxied = ""
+			td = createTestConfig()
+			td.ProxyURL = tt.proxyURL
+			td.DialNetwork = tt.dialNetwork
+			if fatal, problems := validateConfig(td); fatal {
+				t.Fatalf("unexpected config problems: %v", problems)
+			}
+
+			// the target is on loopback, which ProxyFromEnvironment never proxies, so the no proxy case stays direct
+			resp, err := newHTTPClient(5 * time.Second).Get(target.URL + "/status")
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			if string(body) != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, string(body))
+			}
+			if tt.proxyURL != "" {
+				if u, _ := url.Parse(proxied); u == nil || u.Host != mustHost(t, target.URL) {
+					t.Errorf("proxy did not receive the request for %s, got %q", target.URL, proxied)
+				}
+			}
+		})
+	}
+}
+
+func TestNewHTTPClientCACertFile(t *testing.T) {
+	origTd := td
+	defer func() { td = origTd }()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	// the test server's certificate is self-signed, so it acts as its own CA
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	badFile := filepath.Join(t.TempDir(), "bad.pem")
+	if err := os.WriteFile(badFile, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		caCertFile  string
+		fatal       bool
+		expectError bool
+	}{
+		{name: "private CA is trusted", caCertFile: caFile},
+		{name: "unknown CA is rejected", expectError: true},
+		{name: "file without certificates", caCertFile: badFile, fatal: true},
+		{name: "missing file", caCertFile: filepath.Join(t.TempDir(), "missing.pem"), fatal: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td = createTestConfig()
+			td.CACertFile = tt.caCertFile
+			fatal, problems := validateConfig(td)
+			if fatal != tt.fatal {
+				t.Fatalf("expected fatal=%v, got %v (%v)", tt.fatal, fatal, problems)
+			}
+			if fatal {
+				return
+			}
+
+			resp, err := newHTTPClient(5 * time.Second).Get(server.URL)
+			if tt.expectError {
+				if err == nil {
+					resp.Body.Close()
+					t.Error("expected the certificate to be rejected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			resp.Body.Close()
+		})
+	}
+}
+
+func TestValidateConfigNetworkSettings(t *testing.T) {
+	tests := []struct {
+		name        string
+		proxyURL    string
+		dialNetwork string
+		dnsServer   string
+		fatal       bool
+	}{
+		{name: "defaults", fatal: false},
+		{name: "valid settings", proxyURL: "http://proxy.local:3128", dialNetwork: "tcp6", dnsServer: "1.1.1.1:53", fatal: false},
+		{name: "proxy without scheme", proxyURL: "proxy.local:3128", fatal: true},
+		{name: "unknown network", dialNetwork: "udp", fatal: true},
+		{name: "dns server without port", dnsServer: "1.1.1.1", fatal: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := createTestConfig()
+			c.ProxyURL = tt.proxyURL
+			c.DialNetwork = tt.dialNetwork
+			c.DNSServer = tt.dnsServer
+			fatal, problems := validateConfig(c)
+			if fatal != tt.fatal {
+				t.Errorf("expected fatal=%v, got %v (%v)", tt.fatal, fatal, problems)
+			}
+		})
+	}
+}
+
+func mustHost(t *testing.T, raw string) string {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u.Host
+}
+
+func TestNodeAuthHeaders(t *testing.T) {
+	origTd := td
+	td = &Config{}
+	defer func() { td = origTd }()
+
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		node          *NodeConfig
+		authorization string
+		apiKey        string
+	}{
+		{
+			name:          "should send the configured headers",
+			node:          &NodeConfig{Url: server.URL, Headers: map[string]string{"Authorization": "Bearer token", "X-Api-Key": "key"}},
+			authorization: "Bearer token",
+			apiKey:        "key",
+		},
+		{
+			name:          "should send basic auth",
+			node:          &NodeConfig{Url: server.URL, BasicAuthUser: "user", BasicAuthPassword: "pass"},
+			authorization: "Basic dXNlcjpwYXNz",
+		},
+		{
+			name: "should send nothing extra without auth",
+			node: &NodeConfig{Url: server.URL},
+		},
+		{
+			name: "should send nothing extra to the public fallback",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := func(via string) {
+				t.Helper()
+				if got := received.Get("Authorization"); got != tt.authorization {
+					t.Errorf("%s: expected Authorization %q, got %q", via, tt.authorization, got)
+				}
+				if got := received.Get("X-Api-Key"); got != tt.apiKey {
+					t.Errorf("%s: expected X-Api-Key %q, got %q", via, tt.apiKey, got)
+				}
+			}
+
+			received = nil
+			client, err := newRPCClient(server.URL, tt.node)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, _ = client.Status(context.Background())
+			check("rpc client")
+
+			received = nil
+			_, _, _, _ = getStatusWithEndpoint(context.Background(), server.URL, tt.node)
+			check("status request")
+		})
+	}
+}
+
+func TestSharedRPCClient(t *testing.T) {
+	origTd, origClients := td, rpcClients
+	td = &Config{}
+	rpcClients = make(map[rpcClientKey]*rpchttp.HTTP)
+	defer func() { td, rpcClients = origTd, origClients }()
+
+	const remote = "http://127.0.0.1:26657"
+	cc := &ChainConfig{ChainId: "chain-a-1"}
+	node := &NodeConfig{Url: remote}
+
+	first, err := sharedRPCClient(remote, cc.servedChainId(node), node)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		remote  string
+		chainId string
+		node    *NodeConfig
+		shared  bool
+	}{
+		{name: "should share the client for the same endpoint and chain", remote: remote, chainId: "chain-a-1", node: &NodeConfig{Url: remote}, shared: true},
+		{name: "should not share the client with another chain", remote: remote, chainId: "chain-b-1", node: &NodeConfig{Url: remote, ChainId: "chain-b-1"}},
+		{name: "should not share the client with another endpoint", remote: "http://127.0.0.1:26658", chainId: "chain-a-1", node: &NodeConfig{Url: "http://127.0.0.1:26658"}},
+		{name: "should not share the client when the headers differ", remote: remote, chainId: "chain-a-1", node: &NodeConfig{Url: remote, Headers: map[string]string{"X-Chain": "b"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := sharedRPCClient(tt.remote, tt.chainId, tt.node)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if shared := client == first; shared != tt.shared {
+				t.Errorf("expected shared %v, got %v", tt.shared, shared)
+			}
+			again, err := sharedRPCClient(tt.remote, tt.chainId, tt.node)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if again != client {
+				t.Error("expected the same client on the second call")
+			}
+		})
+	}
+
+	if got := cc.servedChainId(&NodeConfig{ChainId: "chain-b-1"}); got != "chain-b-1" {
+		t.Errorf("expected the node's chain_id, got %s", got)
+	}
+	if got := cc.servedChainId(nil); got != "chain-a-1" {
+		t.Errorf("expected the chain's chain_id for the public fallback, got %s", got)
+	}
+}
+
+func TestSharedHTTPTransport(t *testing.T) {
+	origTd, origClients := td, nodeClients
+	td = createTestConfig()
+	nodeClients = make(map[nodeClientKey]*http.Client)
+	defer func() { td, nodeClients = origTd, origClients }()
+
+	if fatal, problems := validateConfig(td); fatal {
+		t.Fatalf("unexpected config problems: %v", problems)
+	}
+	if td.transport == nil {
+		t.Fatal("expected validateConfig to build the shared transport")
+	}
+
+	node := &NodeConfig{Url: "http://127.0.0.1:26657", Headers: map[string]string{"X-Api-Key": "key"}}
+	tests := []struct {
+		name   string
+		client *http.Client
+		reused *http.Client
+	}{
+		{name: "should reuse the transport", client: newHTTPClient(5 * time.Second), reused: newHTTPClient(10 * time.Second)},
+		{name: "should reuse the transport without node headers", client: newNodeHTTPClient(&NodeConfig{}, 5*time.Second), reused: newHTTPClient(5 * time.Second)},
+		{name: "should reuse the node client", client: newNodeHTTPClient(node, 5*time.Second), reused: newNodeHTTPClient(&NodeConfig{Headers: map[string]string{"X-Api-Key": "key"}}, 5*time.Second)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.client.Transport != tt.reused.Transport {
+				t.Error("expected the clients to share a transport")
+			}
+			base := tt.client.Transport
+			if ht, ok := base.(*headerTransport); ok {
+				base = ht.base
+				if tt.client != tt.reused {
+					t.Error("expected the same client for the same node headers")
+				}
+			}
+			if base != td.transport {
+				t.Error("expected the shared transport")
+			}
+		})
+	}
+
+	if other := newNodeHTTPClient(&NodeConfig{Headers: map[string]string{"X-Api-Key": "other"}}, 5*time.Second); other.Transport == newNodeHTTPClient(node, 5*time.Second).Transport {
+		t.Error("expected another client for different node headers")
+	}
+}
@@ -0,0 +1,135 @@
+package tenderduty
+
+import (
+	"errors"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// IBCClientStatus is what is known about a watched IBC light client. ExpiresAt is the end of the trusting period after
+// the client's latest consensus state, it stays zero for clients that are not tendermint light clients.
+type IBCClientStatus struct {
+	ClientID  string    `json:"client_id"`
+	Status    string    `json:"status"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// the ibc-go types are not a dependency, the few messages needed are encoded and decoded by hand
+const (
+	ibcTendermintClientState    = "/ibc.lightclients.tendermint.v1.ClientState"
+	ibcTendermintConsensusState = "/ibc.lightclients.tendermint.v1.ConsensusState"
+)
+
+// ibcClientRequest encodes QueryClientStatusRequest and QueryClientStateRequest, both only carry the client ID.
+func ibcClientRequest(clientID string) []byte {
+	b := protowire.AppendTag(nil, 1, protowire.BytesType)
+	return protowire.AppendString(b, clientID)
+}
+
+// ibcLatestConsensusStateRequest encodes a QueryConsensusStateRequest for the client's latest height.
+func ibcLatestConsensusStateRequest(clientID string) []byte {
+	b := ibcClientRequest(clientID)
+	b = protowire.AppendTag(b, 4, protowire.VarintType)
+	return protowire.AppendVarint(b, protowire.EncodeBool(true))
+}
+
+// protoField returns the last occurrence of field num in a protobuf message, bytes fields in raw and varints in value.
+func protoField(b []byte, num protowire.Number) (raw []byte, value uint64, found bool, err error) {
+	for len(b) > 0 {
+		n, typ, l := protowire.ConsumeTag(b)
+		if l < 0 {
+			return nil, 0, false, protowire.ParseError(l)
+		}
+		b = b[l:]
+		switch typ {
+		case protowire.BytesType:
+			v, l := protowire.ConsumeBytes(b)
+			if l < 0 {
+				return nil, 0, false, protowire.ParseError(l)
+			}
+			if n == num {
+				raw, found = v, true
+			}
+			b = b[l:]
+		case protowire.VarintType:
+			v, l := protowire.ConsumeVarint(b)
+			if l < 0 {
+				return nil, 0, false, protowire.ParseError(l)
+			}
+			if n == num {
+				value, found = v, true
+			}
+			b = b[l:]
+		default:
+			l := protowire.ConsumeFieldValue(n, typ, b)
+			if l < 0 {
+				return nil, 0, false, protowire.ParseError(l)
+			}
+			b = b[l:]
+		}
+	}
+	return raw, value, found, nil
+}
+
+// unmarshalIBCClientStatus decodes a QueryClientStatusResponse.
+func unmarshalIBCClientStatus(b []byte) (string, error) {
+	status, _, _, err := protoField(b, 1)
+	return string(status), err
+}
+
+// unmarshalIBCAny returns the first field of a response, a google.protobuf.Any, if it holds typeURL.
+func unmarshalIBCAny(b []byte, typeURL string) (value []byte, ok bool, err error) {
+	anyMsg, _, found, err := protoField(b, 1)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	url, _, _, err := protoField(anyMsg, 1)
+	if err != nil || string(url) != typeURL {
+		return nil, false, err
+	}
+	value, _, _, err = protoField(anyMsg, 2)
+	return value, err == nil, err
+}
+
+// unmarshalProtoTime decodes a google.protobuf.Duration or Timestamp, they share the seconds and nanos layout.
+func unmarshalProtoTime(b []byte) (seconds int64, nanos int64, err error) {
+	_, s, _, err := protoField(b, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	_, n, _, err := protoField(b, 2)
+	return int64(s), int64(int32(n)), err
+}
+
+// ibcTrustingPeriod decodes the trusting period of a tendermint ClientState, field 3.
+func ibcTrustingPeriod(clientState []byte) (time.Duration, error) {
+	raw, _, found, err := protoField(clientState, 3)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, errors.New("client state has no trusting period")
+	}
+	seconds, nanos, err := unmarshalProtoTime(raw)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds)*time.Second + time.Duration(nanos), nil
+}
+
+// ibcConsensusTime decodes the timestamp of a tendermint ConsensusState, field 1.
+func ibcConsensusTime(consensusState []byte) (time.Time, error) {
+	raw, _, found, err := protoField(consensusState, 1)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !found {
+		return time.Time{}, errors.New("consensus state has no timestamp")
+	}
+	seconds, nanos, err := unmarshalProtoTime(raw)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(seconds, nanos).UTC(), nil
+}
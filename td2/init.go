@@ -2,6 +2,7 @@ package tenderduty
 
 import (
 	"embed"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
@@ -22,22 +23,115 @@ func init() {
 	// use a channel for logging, two reasons: several logs could hit at once (formatting,) and to broadcast
 	// messages to the monitoring dashboard
 	go func() {
-		for msg := range logs {
-			msg = strings.TrimRight(strings.TrimLeft(fmt.Sprint(msg), "["), "]")
-			log.Println("tenderduty | ", msg)
+		for entry := range logs {
+			msg := entry.text()
+			if td.LogFormat == "json" {
+				j, e := json.Marshal(jsonLog{
+					Level: entry.level.String(),
+					Time:  time.Now().UTC().Format(time.RFC3339),
+					Chain: entry.chain,
+					Msg:   formatLog(entry.msg),
+				})
+				if e == nil {
+					_, _ = os.Stderr.Write(append(j, '\n'))
+				}
+			} else {
+				log.Println("tenderduty | ", msg)
+			}
 			if td.EnableDash && !td.HideLogs && td.logChan != nil {
 				td.logChan <- dash.LogMessage{
 					MsgType: "log",
 					Ts:      time.Now().UTC().Unix(),
-					Msg:     msg.(string),
+					Msg:     msg,
 				}
 			}
 		}
 	}()
 }
 
-var logs = make(chan any)
+type logLevel uint8
+
+const (
+	levelDebug logLevel = iota
+	levelInfo
+	levelWarn
+	levelError
+)
+
+var logLevelNames = map[string]logLevel{
+	"debug": levelDebug,
+	"info":  levelInfo,
+	"warn":  levelWarn,
+	"error": levelError,
+}
+
+func (lvl logLevel) String() string {
+	for k, v := range logLevelNames {
+		if v == lvl {
+			return k
+		}
+	}
+	return "info"
+}
+
+// logEntry is sent to the logging goroutine, chain is optional and is a separate field in json logs.
+type logEntry struct {
+	level logLevel
+	chain string
+	msg   []any
+}
+
+// text is the message as it's shown in the text logs and on the dashboard.
+func (e logEntry) text() string {
+	if e.chain == "" {
+		return formatLog(e.msg)
+	}
+	return formatLog(append([]any{e.chain}, e.msg...))
+}
+
+func formatLog(v []any) string {
+	return strings.TrimRight(strings.TrimLeft(fmt.Sprint(v), "["), "]")
+}
+
+// jsonLog is a single line written when log_format is json
+type jsonLog struct {
+	Level string `json:"level"`
+	Time  string `json:"time"`
+	Chain string `json:"chain,omitempty"`
+	Msg   string `json:"msg"`
+}
+
+var logs = make(chan logEntry)
+
+// send drops anything below the configured log_level before it reaches the channel
+func send(entry logEntry) {
+	if entry.level < td.logLevel {
+		return
+	}
+	logs <- entry
+}
 
 func l(v ...any) {
-	logs <- v
+	send(logEntry{level: levelInfo, msg: v})
+}
+
+func lDebug(v ...any) {
+	send(logEntry{level: levelDebug, msg: v})
+}
+
+func lWarn(v ...any) {
+	send(logEntry{level: levelWarn, msg: v})
+}
+
+func lError(v ...any) {
+	send(logEntry{level: levelError, msg: v})
+}
+
+// lChain logs a message about a chain, the text format is the same as l(chain, v...)
+func lChain(chain string, v ...any) {
+	send(logEntry{level: levelInfo, chain: chain, msg: v})
+}
+
+func lChainError(chain string, v ...any) {
+	send(logEntry{level: levelError, chain: chain, msg: v})
 }
@@ -0,0 +1,40 @@
+package tenderduty
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+)
+
+const (
+	// keyringPrefix marks a secret in the config that is stored in the OS keyring, e.g. `api_key: keyring:pd-key`.
+	keyringPrefix = "keyring:"
+	// keyringService is the service the entries are looked up under.
+	keyringService = "tenderduty"
+)
+
+// alertSecrets returns the credentials of the notification destinations.
+func alertSecrets(a *AlertConfig) []*string {
+	return []*string{&a.Pagerduty.ApiKey, &a.Pagerduty.ApiToken, &a.Discord.Webhook, &a.Discord.ResolvedWebhook, &a.Telegram.ApiKey, &a.Slack.Webhook, &a.Slack.ResolvedWebhook}
+}
+
+// resolveKeyringSecrets replaces `keyring:<entry>` references in the config with the secret stored in the keyring.
+func resolveKeyringSecrets(c *Config) error {
+	secrets := append(alertSecrets(&c.DefaultAlertConfig), &c.CoinMarketCapAPIToken, &c.APIToken)
+	for _, cc := range c.Chains {
+		secrets = append(secrets, alertSecrets(&cc.Alerts)...)
+	}
+	for _, secret := range secrets {
+		if !strings.HasPrefix(*secret, keyringPrefix) {
+			continue
+		}
+		entry := strings.TrimPrefix(*secret, keyringPrefix)
+		value, err := keyring.Get(keyringService, entry)
+		if err != nil {
+			return fmt.Errorf("could not read %q from the %s keyring: %w", entry, keyringService, err)
+		}
+		*secret = value
+	}
+	return nil
+}
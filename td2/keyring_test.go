@@ -0,0 +1,73 @@
+package tenderduty
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+func TestResolveKeyringSecrets(t *testing.T) {
+	keyring.MockInit()
+	if err := keyring.Set(keyringService, "pd-key", "pd-secret"); err != nil {
+		t.Fatal(err)
+	}
+	if err := keyring.Set(keyringService, "tg-key", "tg-secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		pdKey         string
+		chainTgKey    string
+		expectedPd    string
+		expectedTg    string
+		expectedError string
+	}{
+		{
+			name:       "should resolve keyring references",
+			pdKey:      "keyring:pd-key",
+			chainTgKey: "keyring:tg-key",
+			expectedPd: "pd-secret",
+			expectedTg: "tg-secret",
+		},
+		{
+			name:       "should leave plain values alone",
+			pdKey:      "plain-key",
+			expectedPd: "plain-key",
+		},
+		{
+			name:          "should fail on a missing entry",
+			pdKey:         "keyring:missing",
+			expectedError: `could not read "missing" from the tenderduty keyring`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{
+				DefaultAlertConfig: AlertConfig{Pagerduty: PDConfig{ApiKey: tt.pdKey}},
+				Chains: map[string]*ChainConfig{
+					"test-chain": {Alerts: AlertConfig{Telegram: TeleConfig{ApiKey: tt.chainTgKey}}},
+				},
+			}
+
+			err := resolveKeyringSecrets(c)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("expected error containing %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.DefaultAlertConfig.Pagerduty.ApiKey != tt.expectedPd {
+				t.Errorf("expected pagerduty key %q, got %q", tt.expectedPd, c.DefaultAlertConfig.Pagerduty.ApiKey)
+			}
+			if c.Chains["test-chain"].Alerts.Telegram.ApiKey != tt.expectedTg {
+				t.Errorf("expected telegram key %q, got %q", tt.expectedTg, c.Chains["test-chain"].Alerts.Telegram.ApiKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package tenderduty
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// signalMuteDuration is how long SIGUSR1 mutes notifications for.
+const signalMuteDuration = time.Hour
+
+// muteAll holds back every new alert on all chains until the returned time, resolves still go out. A zero duration
+// unmutes.
+func (c *Config) muteAll(d time.Duration) time.Time {
+	c.muteMux.Lock()
+	defer c.muteMux.Unlock()
+	c.mutedUntil = time.Time{}
+	if d > 0 {
+		c.mutedUntil = time.Now().Add(d)
+	}
+	return c.mutedUntil
+}
+
+// muted reports whether notifications are muted at now, the mute expires on its own.
+func (c *Config) muted(now time.Time) bool {
+	c.muteMux.RLock()
+	defer c.muteMux.RUnlock()
+	return now.Before(c.mutedUntil)
+}
+
+// toggleMute mutes notifications for signalMuteDuration, or unmutes them if they already are.
+func (c *Config) toggleMute() {
+	if c.muted(time.Now()) {
+		c.muteAll(0)
+		l("🔔 notifications unmuted")
+		return
+	}
+	until := c.muteAll(signalMuteDuration)
+	l("🔇 notifications muted on all chains until", until.UTC().Format(time.RFC3339))
+}
+
+// muteOnSignal toggles the global mute every time tenderduty receives SIGUSR1.
+func (c *Config) muteOnSignal() {
+	toggle := make(chan os.Signal, 1)
+	signal.Notify(toggle, syscall.SIGUSR1)
+	defer signal.Stop(toggle)
+	for {
+		select {
+		case <-toggle:
+			c.toggleMute()
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
@@ -244,6 +244,17 @@ type NamadaVotingPowerResponse struct {
 	TotalVotingPower string `json:"totalVotingPower"`
 }
 
+// NamadaChainParametersResponse holds the fields of the indexer's chain parameters used for the APR.
+type NamadaChainParametersResponse struct {
+	Apr                string `json:"apr"`
+	NativeTokenAddress string `json:"nativeTokenAddress"`
+}
+
+type NamadaTokenSupplyResponse struct {
+	Address     string `json:"address"`
+	TotalSupply string `json:"totalSupply"`
+}
+
 type Validator struct {
 	ValidatorID   string `json:"validatorId"`
 	Rank          int    `json:"rank"`
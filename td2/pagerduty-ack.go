@@ -0,0 +1,101 @@
+package tenderduty
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// pagerdutyAckInterval is how often acknowledged incidents are read back from PagerDuty.
+var pagerdutyAckInterval = time.Minute
+
+// pagerdutyAPIEndpoint is the REST API, as opposed to the events API alerts are sent to.
+var pagerdutyAPIEndpoint = "https://api.pagerduty.com"
+
+// syncPagerdutyAcks polls PagerDuty for acknowledged incidents, chains without a pagerduty api_token are skipped.
+func syncPagerdutyAcks(ctx context.Context) {
+	ticker := time.NewTicker(pagerdutyAckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			updatePagerdutyAcks(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// updatePagerdutyAcks records which of the active alarms have been acknowledged in PagerDuty, each api_token is
+// only queried once no matter how many chains use it.
+func updatePagerdutyAcks(ctx context.Context) {
+	byToken := make(map[string][]*ChainConfig)
+	td.chainsMux.RLock()
+	for _, cc := range td.Chains {
+		if !boolVal(td.DefaultAlertConfig.Pagerduty.Enabled) || !boolVal(cc.Alerts.Pagerduty.Enabled) || cc.Alerts.Pagerduty.ApiToken == "" {
+			continue
+		}
+		byToken[cc.Alerts.Pagerduty.ApiToken] = append(byToken[cc.Alerts.Pagerduty.ApiToken], cc)
+	}
+	td.chainsMux.RUnlock()
+
+	for token, chains := range byToken {
+		keys, err := acknowledgedIncidents(ctx, token)
+		if err != nil {
+			lError("could not read acknowledged incidents from PagerDuty:", err)
+			continue
+		}
+		for _, cc := range chains {
+			alarms.setAcknowledged(cc, keys)
+		}
+	}
+}
+
+// acknowledgedIncidents returns the incident keys, which are our dedup keys, of all acknowledged incidents.
+func acknowledgedIncidents(ctx context.Context, token string) (map[string]bool, error) {
+	client := pagerduty.NewClient(token, pagerduty.WithAPIEndpoint(pagerdutyAPIEndpoint))
+	client.HTTPClient = newHTTPClient(30 * time.Second)
+	keys := make(map[string]bool)
+	opts := pagerduty.ListIncidentsOptions{Statuses: []string{"acknowledged"}, Limit: 100}
+	for {
+		resp, err := client.ListIncidentsWithContext(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, incident := range resp.Incidents {
+			keys[incident.IncidentKey] = true
+		}
+		if !resp.More || len(resp.Incidents) == 0 {
+			return keys, nil
+		}
+		opts.Offset += uint(len(resp.Incidents))
+	}
+}
+
+// setAcknowledged replaces the chain's acknowledged alarms with the active alarms whose incident is acknowledged.
+func (a *alarmCache) setAcknowledged(cc *ChainConfig, keys map[string]bool) {
+	a.notifyMux.Lock()
+	defer a.notifyMux.Unlock()
+	if a.acknowledged == nil {
+		a.acknowledged = make(map[string]map[string]bool)
+	}
+	acked := make(map[string]bool)
+	for id := range a.AllAlarms[cc.name] {
+		if !keys[pagerdutyDedupKey(&alertMsg{chainId: cc.ChainId, uniqueId: id, pdDedupKey: a.sentDedupKey(id)})] {
+			continue
+		}
+		if !a.acknowledged[cc.name][id] {
+			l(fmt.Sprintf("👀 Acknowledged alarm on %s (%s) in PagerDuty - no more reminders until it resolves", cc.name, id))
+		}
+		acked[id] = true
+	}
+	a.acknowledged[cc.name] = acked
+}
+
+// isAcknowledged must be called while holding notifyMux, alarms acknowledged in PagerDuty or with a Telegram button
+// count.
+func (a *alarmCache) isAcknowledged(chain string, alertID string) bool {
+	return a.acknowledged[chain][alertID] || a.tgAcknowledged[chain][alertID]
+}
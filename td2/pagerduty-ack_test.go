@@ -0,0 +1,108 @@
+package tenderduty
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUpdatePagerdutyAcks(t *testing.T) {
+	proposal := "UnvotedGovernanceProposal_testval123_7"
+	stalled := "ChainStalled_testval123"
+
+	var gotToken string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotToken = r.Header.Get("Authorization")
+		if r.URL.Path != "/incidents" || r.URL.Query().Get("statuses[]") != "acknowledged" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// the acknowledged incidents come back over two pages
+		if r.URL.Query().Get("offset") == "" {
+			_, _ = w.Write([]byte(`{"incidents": [{"id": "P1", "incident_key": "other-chain-1_` + stalled + `", "status": "acknowledged"}],
+				"limit": 100, "offset": 0, "more": true}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"incidents": [{"id": "P2", "incident_key": "test-chain-1_` + proposal + `", "status": "acknowledged"}],
+			"limit": 100, "offset": 1, "more": false}`))
+	}))
+	defer server.Close()
+
+	originalEndpoint := pagerdutyAPIEndpoint
+	pagerdutyAPIEndpoint = server.URL
+	defer func() { pagerdutyAPIEndpoint = originalEndpoint }()
+
+	originalAlarms := alarms
+	testAlarms := &alarmCache{
+		SentTgAlarms:   make(map[string]alertMsgCache),
+		SentPdAlarms:   make(map[string]alertMsgCache),
+		AllAlarms:      map[string]map[string]alertMsgCache{"test-chain": {}},
+		flappingAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux:      sync.RWMutex{},
+	}
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	td.GovernanceAlertsReminderInterval = 6
+	defer func() { td = originalTd }()
+	trueBool := true
+	td.DefaultAlertConfig.Pagerduty.Enabled = &trueBool
+	td.Chains["test-chain"].Alerts.Pagerduty = PDConfig{Enabled: &trueBool, ApiToken: "read-token"}
+
+	// both alarms were sent a while ago, so a reminder for the proposal would be due
+	sent := alertMsgCache{Message: "test alert", SentTime: time.Now().Add(-7 * time.Hour)}
+	for _, id := range []string{proposal, stalled} {
+		testAlarms.AllAlarms["test-chain"][id] = sent
+		testAlarms.SentTgAlarms[id] = sent
+		testAlarms.SentPdAlarms[id] = sent
+	}
+
+	updatePagerdutyAcks(context.Background())
+	if gotToken != "Token token=read-token" {
+		t.Errorf("expected the api_token to be used, got %q", gotToken)
+	}
+	if !testAlarms.isAcknowledged("test-chain", proposal) {
+		t.Error("expected the proposal alarm to be acknowledged")
+	}
+	if testAlarms.isAcknowledged("test-chain", stalled) {
+		t.Error("an incident with the same id on another chain should not acknowledge the alarm")
+	}
+
+	tests := []struct {
+		name     string
+		id       string
+		dest     notifyDest
+		expected bool
+	}{
+		{name: "acknowledged reminder is not re-sent to telegram", id: proposal, dest: tg, expected: false},
+		{name: "acknowledged reminder still goes to pagerduty", id: proposal, dest: pd, expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := &alertMsg{
+				chainName: "test-chain",
+				uniqueId:  tt.id,
+				severity:  "critical",
+				alertConfig: &AlertConfig{
+					Telegram:  TeleConfig{SeverityThreshold: "info"},
+					Pagerduty: PDConfig{SeverityThreshold: "info"},
+				},
+			}
+			if result := shouldNotify(msg, tt.dest); result != tt.expected {
+				t.Errorf("shouldNotify() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+
+	// resolving the alarm forgets the acknowledgement, so it doesn't carry over if the alarm fires again
+	td.alert("test-chain", "test alert", "critical", true, &proposal)
+	<-td.alertChan
+	if testAlarms.isAcknowledged("test-chain", proposal) {
+		t.Error("expected the acknowledgement to be cleared when the alarm resolves")
+	}
+}
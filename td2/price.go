@@ -0,0 +1,93 @@
+package tenderduty
+
+import (
+	"fmt"
+	"time"
+)
+
+// priceRetryInterval is how often watchPriceConversion tries to fetch the prices again after they failed at startup
+var priceRetryInterval = 10 * time.Minute
+
+// priceFailureLimit is how many validator info refreshes in a row a chain's price can fail before alerting
+const priceFailureLimit = 3
+
+const (
+	priceDisabledAlertID = "PriceConversionDisabled"
+	priceFailingAlertID  = "PriceConversionFailing"
+)
+
+// watchPriceConversion alerts on the chains with a slug when price conversion was turned off because the prices could
+// not be fetched at startup, and turns it back on once they can. The unclaimed rewards alerts depend on it.
+func (c *Config) watchPriceConversion() {
+	if !c.priceConversionDown {
+		return
+	}
+	c.alertPriceConversion(false)
+	for {
+		time.Sleep(priceRetryInterval)
+		if c.retryPriceConversion() {
+			return
+		}
+	}
+}
+
+// retryPriceConversion turns price conversion back on and resolves the alerts if the prices can be fetched again.
+func (c *Config) retryPriceConversion() bool {
+	if _, err := c.coinMarketCapClient.GetPrices(c.ctx); err != nil {
+		lDebug("price conversion is still unavailable:", err)
+		return false
+	}
+	c.priceConversionDown = false
+	c.PriceConversion.Enabled = true
+	l("💸 price conversion enabled")
+	c.alertPriceConversion(true)
+	return true
+}
+
+func (c *Config) alertPriceConversion(resolved bool) {
+	c.chainsMux.RLock()
+	names := make([]string, 0, len(c.Chains))
+	for name, cc := range c.Chains {
+		if cc.Slug != "" {
+			names = append(names, name)
+		}
+	}
+	c.chainsMux.RUnlock()
+
+	id := priceDisabledAlertID
+	for _, name := range names {
+		if resolved != alarms.exist(name, id) {
+			continue
+		}
+		c.alert(
+			name,
+			"price conversion is disabled because the prices could not be fetched, unclaimed rewards alerts are not sent",
+			"warning",
+			resolved,
+			&id,
+		)
+	}
+}
+
+// countPriceFailure alerts when the chain's price could not be fetched priceFailureLimit times in a row, and resolves
+// the alert once it is fetched again.
+func (cc *ChainConfig) countPriceFailure(err error) {
+	id := priceFailingAlertID
+	if err == nil {
+		cc.priceFailures = 0
+		if alarms.exist(cc.name, id) {
+			td.alert(cc.name, fmt.Sprintf("could not fetch the %s price for %s", cc.Slug, cc.ChainId), "warning", true, &id)
+		}
+		return
+	}
+	cc.priceFailures++
+	if cc.priceFailures >= priceFailureLimit && !alarms.exist(cc.name, id) {
+		td.alert(
+			cc.name,
+			fmt.Sprintf("could not fetch the %s price for %s %d times in a row: %s", cc.Slug, cc.ChainId, cc.priceFailures, err),
+			"warning",
+			false,
+			&id,
+		)
+	}
+}
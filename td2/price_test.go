@@ -0,0 +1,105 @@
+package tenderduty
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/firstset/tenderduty/v2/td2/utils"
+)
+
+func TestWatchPriceConversion(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the startup fetch and the first retry fail, the second retry succeeds
+		if atomic.AddInt32(&requests, 1) <= 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":{"error_code":0},"data":{"1":{"id":1,"name":"Osmosis","symbol":"OSMO","slug":"osmosis","quote":{"USD":{"price":0.5,"last_updated":"2024-01-01T00:00:00.000Z"}}}}}`))
+	}))
+	defer server.Close()
+
+	originalAlarms := alarms
+	alarms = &alarmCache{AllAlarms: make(map[string]map[string]alertMsgCache), notifyMux: sync.RWMutex{}}
+	defer func() { alarms = originalAlarms }()
+
+	originalInterval := priceRetryInterval
+	priceRetryInterval = 10 * time.Millisecond
+	defer func() { priceRetryInterval = originalInterval }()
+
+	c := createTestConfig()
+	c.ctx = context.Background()
+	c.Chains["test-chain"].Slug = "osmosis"
+	c.coinMarketCapClient = utils.NewCoinMarketCapClient("test-key", "USD", utils.NewCache(), 8, []string{"osmosis"})
+	utils.WithEndpoint(server.URL)(c.coinMarketCapClient)
+
+	// what loadConfig does when the startup fetch fails
+	if _, err := c.coinMarketCapClient.GetPrices(c.ctx); err == nil {
+		t.Fatal("expected the startup fetch to fail")
+	}
+	c.priceConversionDown = true
+
+	c.watchPriceConversion()
+
+	if !c.PriceConversion.Enabled || c.priceConversionDown {
+		t.Error("expected price conversion to be enabled again")
+	}
+	if got := atomic.LoadInt32(&requests); got != 3 {
+		t.Errorf("expected 3 price requests, got %d", got)
+	}
+	if len(c.alertChan) != 2 {
+		t.Fatalf("expected an alert and its resolve, got %d notifications", len(c.alertChan))
+	}
+	if alert := <-c.alertChan; alert.resolved || alert.uniqueId != priceDisabledAlertID || alert.severity != "warning" {
+		t.Errorf("expected a warning for the disabled price conversion, got %+v", alert)
+	}
+	if resolve := <-c.alertChan; !resolve.resolved || resolve.uniqueId != priceDisabledAlertID {
+		t.Errorf("expected the alert to be resolved, got %+v", resolve)
+	}
+}
+
+func TestCountPriceFailure(t *testing.T) {
+	originalAlarms := alarms
+	alarms = &alarmCache{AllAlarms: make(map[string]map[string]alertMsgCache), notifyMux: sync.RWMutex{}}
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	cc := td.Chains["test-chain"]
+	cc.Slug = "osmosis"
+	failure := errors.New("slug 'osmosis' not found")
+
+	steps := []struct {
+		name     string
+		err      error
+		firing   bool
+		notified int
+	}{
+		{name: "first failure is not alerted", err: failure},
+		{name: "second failure is not alerted", err: failure},
+		{name: "third failure in a row alerts", err: failure, firing: true, notified: 1},
+		{name: "further failures are not repeated", err: failure, firing: true},
+		{name: "a fetched price resolves the alert", notified: 1},
+		{name: "the count starts over", err: failure},
+	}
+	for _, step := range steps {
+		cc.countPriceFailure(step.err)
+		if firing := alarms.exist(cc.name, priceFailingAlertID); firing != step.firing {
+			t.Errorf("%s: expected firing %v, got %v", step.name, step.firing, firing)
+		}
+		if len(td.alertChan) != step.notified {
+			t.Errorf("%s: expected %d notifications, got %d", step.name, step.notified, len(td.alertChan))
+		}
+		for len(td.alertChan) > 0 {
+			<-td.alertChan
+		}
+	}
+}
@@ -2,10 +2,14 @@ package tenderduty
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -15,6 +19,82 @@ import (
 
 var promMux sync.RWMutex
 
+// droppedStatUpdates counts the updates discarded because statsChan was full
+var droppedStatUpdates uint64
+
+// providerQueryErrors is incremented directly by the queries rather than through statsChan, it is registered when
+// the exporter starts.
+var providerQueryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "tenderduty_provider_query_errors_total",
+	Help: "count of failed validator and chain queries since tenderduty was started, by query and a coarse error class",
+}, []string{"name", "chain_id", "query", "class"})
+
+// chainUp is a package variable so that it can be registered with a test registry, the exporter registers it on start.
+var chainUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "tenderduty_chain_up",
+	Help: "1 when the chain has a healthy rpc node and recently refreshed validator info, 0 when tenderduty can't monitor it",
+}, []string{"name", "chain_id", "moniker"})
+
+// notificationsSent and notificationsFailed count every delivery attempt, a notification that succeeds on a retry is
+// counted once in each. Registered when the exporter starts.
+var (
+	notificationsSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "tenderduty_notifications_sent_total",
+		Help: "count of alerts and resolutions delivered since tenderduty was started, by destination",
+	}, []string{"destination", "name", "chain_id"})
+	notificationsFailed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "tenderduty_notifications_failed_total",
+		Help: "count of failed attempts to deliver alerts and resolutions since tenderduty was started, by destination",
+	}, []string{"destination", "name", "chain_id"})
+)
+
+// notificationsSuppressed counts the notifications that shouldNotify held back, registered when the exporter starts.
+var notificationsSuppressed = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "tenderduty_notifications_suppressed_total",
+	Help: "count of alerts and resolutions not sent since tenderduty was started, by destination and reason: threshold, snooze, acknowledged, dedup or flap",
+}, []string{"destination", "reason", "name", "chain_id"})
+
+// countSuppressed records a notification to dest that was not sent for reason.
+func countSuppressed(dest notifyDest, reason string, msg *alertMsg) {
+	notificationsSuppressed.WithLabelValues(dest.String(), reason, msg.chainName, msg.chainId).Inc()
+}
+
+// countNotification records the outcome of a delivery to dest and passes the error through.
+func countNotification(dest string, msg *alertMsg, err error) error {
+	if err != nil {
+		notificationsFailed.WithLabelValues(dest, msg.chainName, msg.chainId).Inc()
+	} else {
+		notificationsSent.WithLabelValues(dest, msg.chainName, msg.chainId).Inc()
+	}
+	return err
+}
+
+// countQueryError records a failed query, a nil error is ignored.
+func (cc *ChainConfig) countQueryError(query string, err error) {
+	if err == nil {
+		return
+	}
+	providerQueryErrors.WithLabelValues(cc.name, cc.ChainId, query, queryErrorClass(err)).Inc()
+}
+
+// queryErrorClass sorts an error into timeout, connection, decode or other, enough to tell flaky nodes from
+// queries a chain doesn't support.
+func queryErrorClass(err error) string {
+	var netErr net.Error
+	var opErr *net.OpError
+	msg := strings.ToLower(err.Error())
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout(), strings.Contains(msg, "timeout"):
+		return "timeout"
+	case errors.As(err, &opErr), strings.Contains(msg, "connection refused"), strings.Contains(msg, "no such host"), strings.Contains(msg, "eof"):
+		return "connection"
+	case strings.Contains(msg, "unmarshal"), strings.Contains(msg, "decode"):
+		return "decode"
+	default:
+		return "other"
+	}
+}
+
 type metricType uint8
 
 const (
@@ -30,6 +110,9 @@ const (
 	metricWindowSize
 	metricLastBlockSeconds
 	metricLastBlockSecondsNotFinal
+	metricWebsocketLastBlock
+	metricBlockTimeEMA
+	metricLastBlockHeight
 
 	metricTotalNodes
 	metricUnealthyNodes
@@ -37,6 +120,14 @@ const (
 	metricNodeDownSeconds
 
 	metricUnvotedProposals
+
+	metricCryptoPrice
+	metricDelegatedTokens
+	metricValidatorAPR
+	metricSelfDelegationRewards
+	metricCommission
+
+	metricChainUp
 )
 
 type promUpdate struct {
@@ -46,6 +137,7 @@ type promUpdate struct {
 	chainId  string
 	moniker  string
 	endpoint string
+	currency string
 }
 
 type metrics map[metricType]*prometheus.GaugeVec
@@ -61,13 +153,29 @@ func (m metrics) setStat(update *promUpdate) {
 	if update.metric == metricNodeLagSeconds || update.metric == metricNodeDownSeconds {
 		lbls["endpoint"] = update.endpoint
 	}
+	if update.metric == metricCryptoPrice {
+		lbls["currency"] = update.currency
+	}
 	m[update.metric].With(lbls).Set(update.counter)
 }
 
+// sendStat queues a prometheus update without blocking, if the exporter has stalled and the channel is full the update
+// is dropped so that monitoring can carry on.
+func (c *Config) sendStat(update *promUpdate) {
+	select {
+	case c.statsChan <- update:
+	default:
+		if atomic.AddUint64(&droppedStatUpdates, 1) == 1 {
+			lWarn("⚠️ prometheus stats channel is full, dropping updates")
+		}
+	}
+}
+
 func prometheusExporter(ctx context.Context, updates chan *promUpdate) {
 	// attributes used to uniquely identify each chain
 	chainLabels := []string{"name", "chain_id", "moniker"}
 	hostLabels := []string{"name", "chain_id", "moniker", "endpoint"}
+	priceLabels := []string{"name", "chain_id", "moniker", "currency"}
 
 	// setup our signing gauges
 	signed := promauto.NewGaugeVec(prometheus.GaugeOpts{
@@ -118,6 +226,18 @@ func prometheusExporter(ctx context.Context, updates chan *promUpdate) {
 		Name: "tenderduty_time_since_last_block_unfinalized",
 		Help: "how many seconds since the previous block was finalized, set regardless of finalization, useful for stall detection, not helpful for figuring average time",
 	}, chainLabels)
+	websocketLastBlock := promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tenderduty_websocket_last_block_timestamp",
+		Help: "unix timestamp of the last block received over the websocket (or of the first connect if none arrived yet), compare with time() to detect a broken websocket while the chain is still producing blocks",
+	}, chainLabels)
+	blockTimeEMA := promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tenderduty_block_time_average_seconds",
+		Help: "exponential moving average of the seconds between finalized blocks, weighted towards about the last 20 blocks",
+	}, chainLabels)
+	lastBlockHeight := promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tenderduty_last_block_height",
+		Help: "height of the last finalized block received over the websocket",
+	}, chainLabels)
 
 	// setup node health gauges:
 	nodesMonitored := promauto.NewGaugeVec(prometheus.GaugeOpts{
@@ -133,6 +253,30 @@ func prometheusExporter(ctx context.Context, updates chan *promUpdate) {
 		Help: "the count of the unvoted governance proposals that are in the voting period",
 	}, chainLabels)
 
+	// price conversion, only set when convert_to_fiat is enabled
+	cryptoPrice := promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tenderduty_crypto_price",
+		Help: "the latest price of the chain's token in the configured fiat currency",
+	}, priceLabels)
+
+	// validator economics, token amounts are in display units when the chain's denom metadata is known
+	delegatedTokens := promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tenderduty_delegated_tokens",
+		Help: "tokens delegated to the validator",
+	}, chainLabels)
+	validatorAPR := promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tenderduty_validator_apr",
+		Help: "the staking APR for delegators after the validator's commission, 0.1 is 10%",
+	}, chainLabels)
+	selfDelegationRewards := promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tenderduty_self_delegation_rewards",
+		Help: "unclaimed rewards from the validator's self delegation",
+	}, chainLabels)
+	commission := promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tenderduty_commission",
+		Help: "unclaimed validator commission",
+	}, chainLabels)
+
 	// extra labels for individual node stats
 	nodeLagSec := promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "tenderduty_endpoint_syncing_seconds_behind",
@@ -143,6 +287,16 @@ func prometheusExporter(ctx context.Context, updates chan *promUpdate) {
 		Help: "how many seconds a node has been marked as unhealthy",
 	}, hostLabels)
 
+	prometheus.MustRegister(providerQueryErrors, chainUp, notificationsSent, notificationsFailed, notificationsSuppressed)
+
+	// not a gauge like the others, only counts since startup and has no chain labels
+	promauto.NewCounterFunc(prometheus.CounterOpts{
+		Name: "tenderduty_dropped_stat_updates_total",
+		Help: "count of prometheus updates dropped because the exporter could not keep up",
+	}, func() float64 {
+		return float64(atomic.LoadUint64(&droppedStatUpdates))
+	})
+
 	m := metrics{
 		metricSigned:                   signed,
 		metricProposed:                 proposed,
@@ -156,11 +310,20 @@ func prometheusExporter(ctx context.Context, updates chan *promUpdate) {
 		metricWindowSize:               windowSize,
 		metricLastBlockSeconds:         lastBlockSec,
 		metricLastBlockSecondsNotFinal: lastBlockSecUnfinalized,
+		metricWebsocketLastBlock:       websocketLastBlock,
+		metricBlockTimeEMA:             blockTimeEMA,
+		metricLastBlockHeight:          lastBlockHeight,
 		metricTotalNodes:               nodesMonitored,
 		metricUnealthyNodes:            nodesUnhealthy,
 		metricNodeLagSeconds:           nodeLagSec,  // todo
 		metricNodeDownSeconds:          nodeDownSec, // todo
 		metricUnvotedProposals:         unvotedProposals,
+		metricCryptoPrice:              cryptoPrice,
+		metricDelegatedTokens:          delegatedTokens,
+		metricValidatorAPR:             validatorAPR,
+		metricSelfDelegationRewards:    selfDelegationRewards,
+		metricCommission:               commission,
+		metricChainUp:                  chainUp,
 	}
 
 	go func() {
@@ -174,13 +337,10 @@ func prometheusExporter(ctx context.Context, updates chan *promUpdate) {
 		}
 	}()
 
-	promMux := http.NewServeMux()
-
 	l(fmt.Sprintf("📊 Serving prometheus metrics at 0.0.0.0:%d/metrics", td.PrometheusListenPort))
-	promMux.Handle("/metrics", promhttp.Handler())
 	promSrv := &http.Server{
 		Addr:              fmt.Sprintf(":%d", td.PrometheusListenPort),
-		Handler:           promMux,
+		Handler:           newPromMux(),
 		ReadTimeout:       20 * time.Second,
 		WriteTimeout:      20 * time.Second,
 		IdleTimeout:       120 * time.Second,
@@ -188,3 +348,13 @@ func prometheusExporter(ctx context.Context, updates chan *promUpdate) {
 	}
 	log.Fatal(promSrv.ListenAndServe())
 }
+
+// newPromMux returns the mux of the prometheus exporter, it also serves the health probes when the dashboard is off.
+func newPromMux() *http.ServeMux {
+	promMux := http.NewServeMux()
+	promMux.Handle("/metrics", promhttp.Handler())
+	if !td.EnableDash {
+		registerProbes(promMux)
+	}
+	return promMux
+}
@@ -0,0 +1,319 @@
+package tenderduty
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSendStatDoesNotBlock(t *testing.T) {
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+	td.statsChan = make(chan *promUpdate, 1)
+
+	before := atomic.LoadUint64(&droppedStatUpdates)
+	td.sendStat(&promUpdate{metric: metricSigned, counter: 1})
+	// the exporter isn't reading, so this one must be dropped instead of blocking
+	td.sendStat(&promUpdate{metric: metricSigned, counter: 2})
+
+	if dropped := atomic.LoadUint64(&droppedStatUpdates) - before; dropped != 1 {
+		t.Errorf("expected 1 dropped update, got %d", dropped)
+	}
+	if u := <-td.statsChan; u.counter != 1 {
+		t.Errorf("expected the first update to be queued, got counter %v", u.counter)
+	}
+}
+
+func TestQueryErrorClass(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{err: fmt.Errorf("query signing info: %w", context.DeadlineExceeded), expected: "timeout"},
+		{err: errors.New("post failed: Post \"http://127.0.0.1:26657\": net/http: request canceled (Client.Timeout exceeded)"), expected: "timeout"},
+		{err: fmt.Errorf("query validator: %w", &net.OpError{Op: "dial", Err: errors.New("connection refused")}), expected: "connection"},
+		{err: errors.New("unmarshal signing info response: unexpected EOF"), expected: "connection"},
+		{err: errors.New("unmarshal slashing params: proto: illegal wireType 7"), expected: "decode"},
+		{err: errors.New("could not find validator cosmosvaloper1xyz"), expected: "other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := queryErrorClass(tt.err); got != tt.expected {
+				t.Errorf("queryErrorClass(%q) = %s, want %s", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCountQueryError(t *testing.T) {
+	cc := &ChainConfig{name: "test-chain", ChainId: "test-chain-1"}
+	counter := providerQueryErrors.WithLabelValues("test-chain", "test-chain-1", "signing_info", "timeout")
+	before := testutil.ToFloat64(counter)
+
+	cc.countQueryError("signing_info", nil)
+	cc.countQueryError("signing_info", context.DeadlineExceeded)
+	cc.countQueryError("signing_info", context.DeadlineExceeded)
+
+	if counted := testutil.ToFloat64(counter) - before; counted != 2 {
+		t.Errorf("expected 2 errors to be counted, got %v", counted)
+	}
+}
+
+func TestChainUpMetric(t *testing.T) {
+	tests := []struct {
+		name     string
+		noNodes  bool
+		updated  time.Time
+		expected float64
+	}{
+		{name: "healthy node and fresh validator info", updated: time.Now().Add(-time.Minute), expected: 1},
+		{name: "no healthy nodes", noNodes: true, updated: time.Now(), expected: 0},
+		{name: "stale validator info", updated: time.Now().Add(-valInfoStaleAfter - time.Minute), expected: 0},
+		{name: "validator info never fetched", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chainUp.Reset()
+			cc := &ChainConfig{name: "test-chain", ChainId: "test-chain-1", noNodes: tt.noNodes, valInfoUpdated: tt.updated,
+				valInfo: &ValInfo{Moniker: "test-moniker"}}
+			up := 0.0
+			if cc.monitoringUp() {
+				up = 1
+			}
+			metrics{metricChainUp: chainUp}.setStat(cc.mkUpdate(metricChainUp, up, ""))
+
+			reg := prometheus.NewPedanticRegistry()
+			reg.MustRegister(chainUp)
+			expected := fmt.Sprintf(`# HELP tenderduty_chain_up 1 when the chain has a healthy rpc node and recently refreshed validator info, 0 when tenderduty can't monitor it
+# TYPE tenderduty_chain_up gauge
+tenderduty_chain_up{chain_id="test-chain-1",moniker="test-moniker",name="test-chain"} %v
+`, tt.expected)
+			if err := testutil.GatherAndCompare(reg, strings.NewReader(expected), "tenderduty_chain_up"); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestNotificationMetrics(t *testing.T) {
+	testAlarms := &alarmCache{
+		SentSlkAlarms:  make(map[string]alertMsgCache),
+		AllAlarms:      make(map[string]map[string]alertMsgCache),
+		flappingAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux:      sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	msg := &alertMsg{slk: true, chain: "test-chain (test-chain-1)", chainName: "test-chain", chainId: "test-chain-1",
+		message: "test message", severity: "critical", slkHook: server.URL, alertConfig: &AlertConfig{}}
+	sent := notificationsSent.WithLabelValues("slack", "test-chain", "test-chain-1")
+	failed := notificationsFailed.WithLabelValues("slack", "test-chain", "test-chain-1")
+	sentBefore, failedBefore := testutil.ToFloat64(sent), testutil.ToFloat64(failed)
+
+	// every notification is a new alarm, so none of them is deduplicated
+	notify := func(id string) {
+		msg.uniqueId = id
+		_ = notifySlack(msg)
+	}
+	notify("test_alert_1")
+	status = http.StatusInternalServerError
+	notify("test_alert_2")
+	notify("test_alert_3")
+	// a disabled destination is neither sent nor failed
+	msg.slk = false
+	notify("test_alert_4")
+
+	if counted := testutil.ToFloat64(sent) - sentBefore; counted != 1 {
+		t.Errorf("expected 1 sent notification, got %v", counted)
+	}
+	if counted := testutil.ToFloat64(failed) - failedBefore; counted != 2 {
+		t.Errorf("expected 2 failed notifications, got %v", counted)
+	}
+}
+
+func TestNotificationsSuppressedMetric(t *testing.T) {
+	testAlarms := &alarmCache{notifyMux: sync.RWMutex{}}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	cooldown := 5
+	td.ResolveCooldownMinutes = &cooldown
+	defer func() { td = originalTd }()
+
+	tests := []struct {
+		name     string
+		dest     notifyDest
+		uniqueId string
+		severity string
+		resolved bool
+		setup    func()
+		reason   string
+	}{
+		{
+			name:     "severity below the threshold",
+			dest:     pd,
+			uniqueId: "test_alert",
+			severity: "info",
+			reason:   "threshold",
+		},
+		{
+			name:     "snoozed alarm",
+			dest:     tg,
+			uniqueId: "test_alert",
+			severity: "critical",
+			setup: func() {
+				_ = testAlarms.snooze("test-chain", "test_alert", time.Now().Add(time.Hour))
+			},
+			reason: "snooze",
+		},
+		{
+			name:     "proposal reminder for an acknowledged incident",
+			dest:     tg,
+			uniqueId: "UnvotedGovernanceProposal_1",
+			severity: "critical",
+			setup: func() {
+				testAlarms.SentTgAlarms["UnvotedGovernanceProposal_1"] = alertMsgCache{SentTime: time.Now().Add(-time.Hour)}
+				testAlarms.acknowledged = map[string]map[string]bool{"test-chain": {"UnvotedGovernanceProposal_1": true}}
+			},
+			reason: "acknowledged",
+		},
+		{
+			name:     "alert that was already sent",
+			dest:     tg,
+			uniqueId: "test_alert",
+			severity: "critical",
+			setup: func() {
+				testAlarms.SentTgAlarms["test_alert"] = alertMsgCache{SentTime: time.Now()}
+			},
+			reason: "dedup",
+		},
+		{
+			name:     "resolve without an alert",
+			dest:     tg,
+			uniqueId: "test_alert",
+			severity: "critical",
+			resolved: true,
+			reason:   "dedup",
+		},
+		{
+			name:     "pagerduty alert sent again within five minutes",
+			dest:     pd,
+			uniqueId: "test_alert",
+			severity: "critical",
+			setup: func() {
+				testAlarms.flappingAlarms["test-chain (test-chain-1)"] = map[string]alertMsgCache{"test_alert": {SentTime: time.Now()}}
+			},
+			reason: "flap",
+		},
+		{
+			name:     "resolve within the resolve cooldown",
+			dest:     tg,
+			uniqueId: "test_alert",
+			severity: "critical",
+			resolved: true,
+			setup: func() {
+				testAlarms.SentTgAlarms["test_alert"] = alertMsgCache{SentTime: time.Now()}
+				// the held back resolve is only scheduled once, so the timer never fires during the test
+				testAlarms.flappingAlarms["test-chain (test-chain-1)"] = map[string]alertMsgCache{
+					fmt.Sprintf("resolve_%d_test_alert", tg): {SentTime: time.Now()},
+					fmt.Sprintf("held_%d_test_alert", tg):    {SentTime: time.Now()},
+				}
+			},
+			reason: "flap",
+		},
+		{
+			name:     "slack alert that was already sent",
+			dest:     slk,
+			uniqueId: "test_alert",
+			severity: "critical",
+			setup: func() {
+				testAlarms.SentSlkAlarms["test_alert"] = alertMsgCache{SentTime: time.Now()}
+			},
+			reason: "dedup",
+		},
+		{
+			name:     "slack resolve within the resolve cooldown",
+			dest:     slk,
+			uniqueId: "test_alert",
+			severity: "critical",
+			resolved: true,
+			setup: func() {
+				testAlarms.SentSlkAlarms["test_alert"] = alertMsgCache{SentTime: time.Now()}
+				testAlarms.flappingAlarms["test-chain (test-chain-1)"] = map[string]alertMsgCache{
+					fmt.Sprintf("resolve_%d_test_alert", slk): {SentTime: time.Now()},
+					fmt.Sprintf("held_%d_test_alert", slk):    {SentTime: time.Now()},
+				}
+			},
+			reason: "flap",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAlarms.SentPdAlarms = make(map[string]alertMsgCache)
+			testAlarms.SentTgAlarms = make(map[string]alertMsgCache)
+			testAlarms.SentSlkAlarms = make(map[string]alertMsgCache)
+			testAlarms.flappingAlarms = make(map[string]map[string]alertMsgCache)
+			testAlarms.snoozedAlarms = nil
+			testAlarms.acknowledged = nil
+			if tt.setup != nil {
+				tt.setup()
+			}
+
+			msg := &alertMsg{
+				pd:        true,
+				chain:     "test-chain (test-chain-1)",
+				chainName: "test-chain",
+				chainId:   "test-chain-1",
+				message:   "test message",
+				uniqueId:  tt.uniqueId,
+				severity:  tt.severity,
+				resolved:  tt.resolved,
+				alertConfig: &AlertConfig{
+					Pagerduty: PDConfig{SeverityThreshold: "critical"},
+					Telegram:  TeleConfig{SeverityThreshold: "info"},
+					Slack:     SlackConfig{SeverityThreshold: "info"},
+				},
+			}
+			counter := notificationsSuppressed.WithLabelValues(tt.dest.String(), tt.reason, "test-chain", "test-chain-1")
+			before := testutil.ToFloat64(counter)
+			if tt.dest == slk {
+				// slack is suppressed through notifySlack like a real notification
+				msg.slk = true
+				if err := notifySlack(msg); err != nil {
+					t.Fatal(err)
+				}
+			} else if shouldNotify(msg, tt.dest) {
+				t.Fatal("expected the notification to be suppressed")
+			}
+			if counted := testutil.ToFloat64(counter) - before; counted != 1 {
+				t.Errorf("expected 1 %s suppression, got %v", tt.reason, counted)
+			}
+		})
+	}
+}
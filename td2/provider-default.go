@@ -2,7 +2,6 @@ package tenderduty
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
 	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
@@ -21,8 +21,27 @@ import (
 	mint "github.com/cosmos/cosmos-sdk/x/mint/types"
 	slashing "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
+	upgrade "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	"golang.org/x/crypto/sha3"
 )
 
+// errNoSlashingModule is returned by the slashing queries when the chain does not route them, e.g. a sovereign rollup
+var errNoSlashingModule = errors.New("the chain has no slashing module")
+
+// errValidatorNotFound is returned by QueryValidatorInfo when the chain answers that the validator does not exist, as
+// opposed to the query failing.
+var errValidatorNotFound = errors.New("could not find validator")
+
+// errUnknownGovQuery is returned by queryVotingPeriodProposals when the chain does not route that gov version's query.
+var errUnknownGovQuery = errors.New("🛑 the chain does not support this gov version")
+
+// unknownQueryPath reports whether a failed ABCI query was rejected because nothing handles its path.
+func unknownQueryPath(code uint32, log string) bool {
+	return code != 0 && (strings.Contains(log, "unknown query path") || strings.Contains(log, "unknown service"))
+}
+
+// ConvertValopertToAccAddress returns the account address of a validator operator. The bytes are kept, only the prefix
+// changes, so this also holds on Ethermint chains where the account's 0x address is the same bytes in hex.
 func ConvertValopertToAccAddress(valoperAddr string) (string, error) {
 	// Check if it's a valoper address
 	if !strings.Contains(valoperAddr, "valoper") {
@@ -47,6 +66,52 @@ func ConvertValopertToAccAddress(valoperAddr string) (string, error) {
 	return accAddress, nil
 }
 
+// ethSecp256k1PubKeys are the type URLs of the eth_secp256k1 keys used by EVM chains built on Ethermint, e.g. Evmos and
+// Injective. They are encoded the same as a secp256k1 key, a compressed point.
+var ethSecp256k1PubKeys = map[string]bool{
+	"/ethermint.crypto.v1.ethsecp256k1.PubKey":      true,
+	"/injective.crypto.v1beta1.ethsecp256k1.PubKey": true,
+	"/cosmos.evm.crypto.v1.ethsecp256k1.PubKey":     true,
+}
+
+// consensusAddress returns the consensus address of a validator's consensus pubkey, the address it signs blocks with.
+// An empty address means the key type is not known.
+func consensusAddress(typeUrl string, value []byte) ([]byte, error) {
+	switch {
+	case typeUrl == "/cosmos.crypto.ed25519.PubKey":
+		pk := ed25519.PubKey{}
+		if err := pk.Unmarshal(value); err != nil {
+			return nil, err
+		}
+		return pk.Address().Bytes(), nil
+	case typeUrl == "/cosmos.crypto.secp256k1.PubKey":
+		pk := secp256k1.PubKey{}
+		if err := pk.Unmarshal(value); err != nil {
+			return nil, err
+		}
+		return pk.Address().Bytes(), nil
+	case ethSecp256k1PubKeys[typeUrl]:
+		pk := secp256k1.PubKey{}
+		if err := pk.Unmarshal(value); err != nil {
+			return nil, err
+		}
+		return ethAddress(pk.Key)
+	}
+	return nil, nil
+}
+
+// ethAddress returns the Ethereum style address of a compressed secp256k1 key: the last 20 bytes of the keccak256 hash
+// of the uncompressed point. The account's 0x address and its bech32 address hold these same bytes.
+func ethAddress(compressed []byte) ([]byte, error) {
+	pub, err := btcec.ParsePubKey(compressed, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("invalid eth_secp256k1 pubkey: %w", err)
+	}
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(pub.SerializeUncompressed()[1:])
+	return hash.Sum(nil)[12:], nil
+}
+
 type DefaultProvider struct {
 	ChainConfig *ChainConfig
 }
@@ -59,16 +124,6 @@ func (d *DefaultProvider) CheckIfValidatorVoted(ctx context.Context, proposalID
 	params.Add("page", "1")
 	params.Add("per_page", "1")
 
-	// Create a reusable HTTP client with timeout
-	tr := &http.Transport{
-		//#nosec G402 -- configurable option
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: td.TLSSkipVerify},
-	}
-	client := &http.Client{
-		Transport: tr,
-		Timeout:   5 * time.Second, // Add reasonable timeout
-	}
-
 	// Store the last error to return if all nodes fail
 	var lastErr error
 
@@ -83,7 +138,7 @@ func (d *DefaultProvider) CheckIfValidatorVoted(ctx context.Context, proposalID
 			continue // Try next node
 		}
 
-		resp, err := client.Do(req)
+		resp, err := newNodeHTTPClient(node, 5*time.Second).Do(req)
 		if err != nil {
 			lastErr = err
 			continue // Try next node
@@ -127,50 +182,107 @@ func (d *DefaultProvider) CheckIfValidatorVoted(ctx context.Context, proposalID
 	return false, nil
 }
 
-func (d *DefaultProvider) QueryUnvotedOpenProposals(ctx context.Context) ([]gov.Proposal, error) {
+func (d *DefaultProvider) QueryUnvotedOpenProposals(ctx context.Context) (unvoted []gov.Proposal, err error) {
+	defer func() { d.ChainConfig.countQueryError("proposals", err) }()
+	proposals, err := d.queryOpenProposals(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Filter out proposals the validator has already voted on
+	var unvotedProposals []gov.Proposal
+
+	for _, proposal := range proposals {
+		// For each proposal, check if the validator has voted
+		accAddress, err := ConvertValopertToAccAddress(d.ChainConfig.ValAddress)
+		if err != nil {
+			l(fmt.Sprintf("⚠️ Cannot convert valoper to account address: %v", err))
+			continue
+		}
+
+		hasVoted, err := d.CheckIfValidatorVoted(ctx, proposal.ProposalId, accAddress)
+		if err != nil {
+			l(fmt.Sprintf("⚠️ Error checking if validator voted: %v", err))
+		}
+
+		if !hasVoted {
+			unvotedProposals = append(unvotedProposals, proposal)
+		}
+	}
+
+	return unvotedProposals, nil
+}
+
+// the proposals queries of the gov versions, see queryOpenProposals
+var govProposalsPaths = map[string]string{
+	"v1":      "/cosmos.gov.v1.Query/Proposals",
+	"v1beta1": "/cosmos.gov.v1beta1.Query/Proposals",
+}
+
+// queryOpenProposals gets the proposals in voting period with the gov version the chain supports. The first query
+// probes v1 and falls back to v1beta1, the version that answered is kept so chains before cosmos-sdk v0.46, which only
+// have the v1beta1 queries, don't get a rejected v1 query on every refresh.
+func (d *DefaultProvider) queryOpenProposals(ctx context.Context) ([]gov.Proposal, error) {
+	cc := d.ChainConfig
+	if cc.govVersion != "" {
+		proposals, err := d.queryVotingPeriodProposals(ctx, govProposalsPaths[cc.govVersion])
+		if errors.Is(err, errUnknownGovQuery) {
+			// the node was replaced by one running another version, probe again on the next refresh
+			cc.govVersion = ""
+		}
+		return proposals, err
+	}
+
+	proposals, err := d.queryVotingPeriodProposals(ctx, govProposalsPaths["v1"])
+	if err == nil {
+		cc.govVersion = "v1"
+		return proposals, nil
+	}
+	proposals, errBeta := d.queryVotingPeriodProposals(ctx, govProposalsPaths["v1beta1"])
+	if errBeta != nil {
+		return nil, fmt.Errorf("%w, v1beta1 fallback: %v", err, errBeta)
+	}
+	// a v1 query that failed for another reason, e.g. a timeout, says nothing about the version
+	if errors.Is(err, errUnknownGovQuery) {
+		cc.govVersion = "v1beta1"
+		lDebug(fmt.Sprintf("ℹ️ %s only supports gov v1beta1, using it for the proposals", cc.ChainId))
+	}
+	return proposals, nil
+}
+
+// queryVotingPeriodProposals gets the proposals in voting period from either the v1 or v1beta1 gov query. The gov types
+// are v1beta1, v1 proposals decode into them since the fields used by tenderduty have the same numbers in both.
+func (d *DefaultProvider) queryVotingPeriodProposals(ctx context.Context, path string) ([]gov.Proposal, error) {
 	// get all proposals in voting period
 	qProposal := gov.QueryProposalsRequest{
 		// Filter for only proposals in voting period
 		ProposalStatus: gov.StatusVotingPeriod,
 	}
 	b, err := qProposal.Marshal()
-	if err == nil {
-		resp, err := d.ChainConfig.client.ABCIQuery(ctx, "/cosmos.gov.v1.Query/Proposals", b)
-		if resp == nil || resp.Response.Value == nil {
-			return nil, fmt.Errorf("🛑 failed to query proposals for %s, error: %v", d.ChainConfig.name, err)
-		} else {
-			proposals := &gov.QueryProposalsResponse{}
-			err = proposals.Unmarshal(resp.Response.Value)
-			if err == nil {
-				// Step 2: Filter out proposals the validator has already voted on
-				var unvotedProposals []gov.Proposal
-
-				for _, proposal := range proposals.Proposals {
-					// For each proposal, check if the validator has voted
-					accAddress, err := ConvertValopertToAccAddress(d.ChainConfig.ValAddress)
-					if err != nil {
-						l(fmt.Sprintf("⚠️ Cannot convert valoper to account address: %v", err))
-						continue
-					}
-
-					hasVoted, err := d.CheckIfValidatorVoted(ctx, proposal.ProposalId, accAddress)
-					if err != nil {
-						l(fmt.Sprintf("⚠️ Error checking if validator voted: %v", err))
-					}
-
-					if !hasVoted {
-						unvotedProposals = append(unvotedProposals, proposal)
-					}
-				}
-
-				return unvotedProposals, nil
-			}
-		}
+	if err != nil {
+		return nil, err
+	}
+	resp, err := d.ChainConfig.client.ABCIQuery(ctx, path, b)
+	if err != nil {
+		return nil, fmt.Errorf("🛑 failed to query proposals for %s, error: %v", d.ChainConfig.name, err)
+	}
+	// an empty value is valid when there are no proposals in voting period, so check the code instead
+	if unknownQueryPath(resp.Response.Code, resp.Response.Log) {
+		return nil, fmt.Errorf("%w for %s: %s", errUnknownGovQuery, d.ChainConfig.name, resp.Response.Log)
+	}
+	if resp.Response.Code != 0 {
+		return nil, fmt.Errorf("🛑 failed to query proposals for %s, error: %s", d.ChainConfig.name, resp.Response.Log)
+	}
+	proposals := &gov.QueryProposalsResponse{}
+	err = proposals.Unmarshal(resp.Response.Value)
+	if err != nil {
+		return nil, err
 	}
-	return nil, err
+	return proposals.Proposals, nil
 }
 
 func (d *DefaultProvider) QueryDenomMetadata(ctx context.Context, denom string) (medatada *bank.Metadata, err error) {
+	defer func() { d.ChainConfig.countQueryError("denom_metadata", err) }()
 	queryParams := bank.QueryDenomMetadataRequest{
 		Denom: denom,
 	}
@@ -194,6 +306,7 @@ func (d *DefaultProvider) QueryDenomMetadata(ctx context.Context, denom string)
 }
 
 func (d *DefaultProvider) QueryValidatorSelfDelegationRewardsAndCommission(ctx context.Context) (rewards *github_com_cosmos_cosmos_sdk_types.DecCoins, commission *github_com_cosmos_cosmos_sdk_types.DecCoins, err error) {
+	defer func() { d.ChainConfig.countQueryError("rewards", err) }()
 	accAddress, err := ConvertValopertToAccAddress(d.ChainConfig.ValAddress)
 	if err != nil {
 		return nil, nil, fmt.Errorf("🛑 failed to decode valoper address: %w", err)
@@ -243,6 +356,7 @@ func (d *DefaultProvider) QueryValidatorSelfDelegationRewardsAndCommission(ctx c
 }
 
 func (d *DefaultProvider) QueryValidatorVotingPool(ctx context.Context) (votingPool *staking.Pool, err error) {
+	defer func() { d.ChainConfig.countQueryError("voting_pool", err) }()
 	queryParams := staking.QueryPoolRequest{}
 	b, err := queryParams.Marshal()
 	if err != nil {
@@ -264,6 +378,7 @@ func (d *DefaultProvider) QueryValidatorVotingPool(ctx context.Context) (votingP
 }
 
 func (d *DefaultProvider) QueryValidatorInfo(ctx context.Context) (pub []byte, moniker string, jailed bool, bonded bool, delegatedTokens float64, commissionRate float64, err error) {
+	defer func() { d.ChainConfig.countQueryError("validator", err) }()
 	if strings.Contains(d.ChainConfig.ValAddress, "valcons") {
 		_, bz, err := bech32.DecodeAndConvert(d.ChainConfig.ValAddress)
 		if err != nil {
@@ -285,8 +400,11 @@ func (d *DefaultProvider) QueryValidatorInfo(ctx context.Context) (pub []byte, m
 	if err != nil {
 		return
 	}
+	if resp.Response.Code != 0 && !strings.Contains(strings.ToLower(resp.Response.Log), "not found") {
+		return nil, "", false, false, 0, 0, fmt.Errorf("validator query failed: %s", resp.Response.Log)
+	}
 	if resp.Response.Value == nil {
-		return nil, "", false, false, 0, 0, errors.New("could not find validator " + d.ChainConfig.ValAddress)
+		return nil, "", false, false, 0, 0, fmt.Errorf("%w %s", errValidatorNotFound, d.ChainConfig.ValAddress)
 	}
 	val := &staking.QueryValidatorResponse{}
 	err = val.Unmarshal(resp.Response.Value)
@@ -297,22 +415,9 @@ func (d *DefaultProvider) QueryValidatorInfo(ctx context.Context) (pub []byte, m
 		return nil, "", false, false, 0, 0, errors.New("got invalid consensus pubkey for " + d.ChainConfig.ValAddress)
 	}
 
-	pubBytes := make([]byte, 0)
-	switch val.Validator.ConsensusPubkey.TypeUrl {
-	case "/cosmos.crypto.ed25519.PubKey":
-		pk := ed25519.PubKey{}
-		err = pk.Unmarshal(val.Validator.ConsensusPubkey.Value)
-		if err != nil {
-			return
-		}
-		pubBytes = pk.Address().Bytes()
-	case "/cosmos.crypto.secp256k1.PubKey":
-		pk := secp256k1.PubKey{}
-		err = pk.Unmarshal(val.Validator.ConsensusPubkey.Value)
-		if err != nil {
-			return
-		}
-		pubBytes = pk.Address().Bytes()
+	pubBytes, err := consensusAddress(val.Validator.ConsensusPubkey.TypeUrl, val.Validator.ConsensusPubkey.Value)
+	if err != nil {
+		return nil, "", false, false, 0, 0, err
 	}
 	if len(pubBytes) == 0 {
 		return nil, "", false, false, 0, 0, errors.New("could not get pubkey for" + d.ChainConfig.ValAddress)
@@ -321,7 +426,160 @@ func (d *DefaultProvider) QueryValidatorInfo(ctx context.Context) (pub []byte, m
 	return pubBytes, val.Validator.GetMoniker(), val.Validator.Jailed, val.Validator.Status == 3, val.Validator.Tokens.ToDec().MustFloat64(), val.Validator.Commission.Rate.MustFloat64(), nil
 }
 
-func (d *DefaultProvider) QuerySigningInfo(ctx context.Context) (*slashing.ValidatorSigningInfo, error) {
+// QueryUnbondingDelegations returns the unbonding entries of the operator account's self-delegation.
+func (d *DefaultProvider) QueryUnbondingDelegations(ctx context.Context) (entries []staking.UnbondingDelegationEntry, err error) {
+	defer func() { d.ChainConfig.countQueryError("unbonding", err) }()
+	if !strings.Contains(d.ChainConfig.ValAddress, "valoper") {
+		return nil, errors.New("querying unbonding delegations requires a valoper address, got " + d.ChainConfig.ValAddress)
+	}
+	accAddress, err := ConvertValopertToAccAddress(d.ChainConfig.ValAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	q := staking.QueryUnbondingDelegationRequest{
+		DelegatorAddr: accAddress,
+		ValidatorAddr: d.ChainConfig.ValAddress,
+	}
+	b, err := q.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("marshal unbonding delegation request: %w", err)
+	}
+	resp, err := d.ChainConfig.client.ABCIQuery(ctx, "/cosmos.staking.v1beta1.Query/UnbondingDelegation", b)
+	if err != nil {
+		return nil, fmt.Errorf("query unbonding delegation: %w", err)
+	}
+	if resp.Response.Value == nil {
+		// the staking module answers with not found when nothing is unbonding
+		if strings.Contains(resp.Response.Log, "not found") {
+			return []staking.UnbondingDelegationEntry{}, nil
+		}
+		return nil, errors.New("could not query unbonding delegations for validator " + d.ChainConfig.ValAddress + ": " + resp.Response.Log)
+	}
+	ubd := &staking.QueryUnbondingDelegationResponse{}
+	err = ubd.Unmarshal(resp.Response.Value)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal unbonding delegation response: %w", err)
+	}
+	return ubd.Unbond.Entries, nil
+}
+
+// QueryAccountBalance returns the operator account's balance of denom in base units, the gas it pays fees with.
+func (d *DefaultProvider) QueryAccountBalance(ctx context.Context, denom string) (balance float64, err error) {
+	defer func() { d.ChainConfig.countQueryError("account_balance", err) }()
+	if !strings.Contains(d.ChainConfig.ValAddress, "valoper") {
+		return 0, errors.New("querying the account balance requires a valoper address, got " + d.ChainConfig.ValAddress)
+	}
+	accAddress, err := ConvertValopertToAccAddress(d.ChainConfig.ValAddress)
+	if err != nil {
+		return 0, err
+	}
+
+	q := bank.QueryBalanceRequest{
+		Address: accAddress,
+		Denom:   denom,
+	}
+	b, err := q.Marshal()
+	if err != nil {
+		return 0, fmt.Errorf("marshal balance request: %w", err)
+	}
+	resp, err := d.ChainConfig.client.ABCIQuery(ctx, "/cosmos.bank.v1beta1.Query/Balance", b)
+	if err != nil {
+		return 0, fmt.Errorf("query balance: %w", err)
+	}
+	if resp.Response.Code != 0 {
+		return 0, errors.New("could not query the balance of " + accAddress + ": " + resp.Response.Log)
+	}
+	val := &bank.QueryBalanceResponse{}
+	if err = val.Unmarshal(resp.Response.Value); err != nil {
+		return 0, fmt.Errorf("unmarshal balance response: %w", err)
+	}
+	// an account that never held the denom has no balance
+	if val.Balance == nil {
+		return 0, nil
+	}
+	return val.Balance.Amount.ToDec().MustFloat64(), nil
+}
+
+// QueryUpgradePlan returns the scheduled software upgrade, nil when there is none or the chain has no upgrade module.
+func (d *DefaultProvider) QueryUpgradePlan(ctx context.Context) (plan *upgrade.Plan, err error) {
+	defer func() { d.ChainConfig.countQueryError("upgrade_plan", err) }()
+	q := upgrade.QueryCurrentPlanRequest{}
+	b, err := q.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("marshal upgrade plan request: %w", err)
+	}
+	resp, err := d.ChainConfig.client.ABCIQuery(ctx, "/cosmos.upgrade.v1beta1.Query/CurrentPlan", b)
+	if err != nil {
+		return nil, fmt.Errorf("query upgrade plan: %w", err)
+	}
+	if unknownQueryPath(resp.Response.Code, resp.Response.Log) {
+		return nil, nil
+	}
+	if resp.Response.Code != 0 {
+		return nil, errors.New("could not query the upgrade plan: " + resp.Response.Log)
+	}
+	val := &upgrade.QueryCurrentPlanResponse{}
+	if err = val.Unmarshal(resp.Response.Value); err != nil {
+		return nil, fmt.Errorf("unmarshal upgrade plan response: %w", err)
+	}
+	return val.Plan, nil
+}
+
+// QueryIBCClientStatus returns the status of an IBC light client, and for tendermint clients when it expires: the
+// trusting period after the consensus state at its latest height.
+func (d *DefaultProvider) QueryIBCClientStatus(ctx context.Context, clientID string) (status *IBCClientStatus, err error) {
+	defer func() { d.ChainConfig.countQueryError("ibc_client", err) }()
+	status = &IBCClientStatus{ClientID: clientID}
+	resp, err := d.ChainConfig.client.ABCIQuery(ctx, "/ibc.core.client.v1.Query/ClientStatus", ibcClientRequest(clientID))
+	if err != nil {
+		return nil, fmt.Errorf("query ibc client status: %w", err)
+	}
+	if resp.Response.Value == nil {
+		return nil, errors.New("could not query the status of ibc client " + clientID + ": " + resp.Response.Log)
+	}
+	if status.Status, err = unmarshalIBCClientStatus(resp.Response.Value); err != nil {
+		return nil, fmt.Errorf("unmarshal ibc client status response: %w", err)
+	}
+
+	resp, err = d.ChainConfig.client.ABCIQuery(ctx, "/ibc.core.client.v1.Query/ClientState", ibcClientRequest(clientID))
+	if err != nil {
+		return nil, fmt.Errorf("query ibc client state: %w", err)
+	}
+	clientState, ok, err := unmarshalIBCAny(resp.Response.Value, ibcTendermintClientState)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal ibc client state response: %w", err)
+	}
+	if !ok {
+		// only tendermint light clients expire after a trusting period
+		return status, nil
+	}
+	trustingPeriod, err := ibcTrustingPeriod(clientState)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal ibc client state: %w", err)
+	}
+
+	resp, err = d.ChainConfig.client.ABCIQuery(ctx, "/ibc.core.client.v1.Query/ConsensusState", ibcLatestConsensusStateRequest(clientID))
+	if err != nil {
+		return nil, fmt.Errorf("query ibc consensus state: %w", err)
+	}
+	consensusState, ok, err := unmarshalIBCAny(resp.Response.Value, ibcTendermintConsensusState)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal ibc consensus state response: %w", err)
+	}
+	if !ok {
+		return nil, errors.New("could not query the consensus state of ibc client " + clientID + ": " + resp.Response.Log)
+	}
+	updated, err := ibcConsensusTime(consensusState)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal ibc consensus state: %w", err)
+	}
+	status.ExpiresAt = updated.Add(trustingPeriod)
+	return status, nil
+}
+
+func (d *DefaultProvider) QuerySigningInfo(ctx context.Context) (signing *slashing.ValidatorSigningInfo, err error) {
+	defer func() { d.ChainConfig.countQueryError("signing_info", err) }()
 	// get current signing information (tombstoned, missed block count)
 	qSigning := slashing.QuerySigningInfoRequest{ConsAddress: d.ChainConfig.valInfo.Valcons}
 	b, err := qSigning.Marshal()
@@ -329,9 +587,15 @@ func (d *DefaultProvider) QuerySigningInfo(ctx context.Context) (*slashing.Valid
 		return nil, fmt.Errorf("marshal signing info request: %w", err)
 	}
 	resp, err := d.ChainConfig.client.ABCIQuery(ctx, "/cosmos.slashing.v1beta1.Query/SigningInfo", b)
-	if resp == nil || resp.Response.Value == nil {
+	if err != nil {
 		return nil, fmt.Errorf("query signing info: %w", err)
 	}
+	if unknownQueryPath(resp.Response.Code, resp.Response.Log) {
+		return nil, errNoSlashingModule
+	}
+	if resp.Response.Value == nil {
+		return nil, errors.New("could not query signing info for validator " + d.ChainConfig.ValAddress + ": " + resp.Response.Log)
+	}
 	info := &slashing.QuerySigningInfoResponse{}
 	err = info.Unmarshal(resp.Response.Value)
 	if err != nil {
@@ -341,7 +605,8 @@ func (d *DefaultProvider) QuerySigningInfo(ctx context.Context) (*slashing.Valid
 	return &info.ValSigningInfo, nil
 }
 
-func (d *DefaultProvider) QuerySlashingParams(ctx context.Context) (*slashing.Params, error) {
+func (d *DefaultProvider) QuerySlashingParams(ctx context.Context) (slashingParams *slashing.Params, err error) {
+	defer func() { d.ChainConfig.countQueryError("slashing_params", err) }()
 	qParams := &slashing.QueryParamsRequest{}
 	b, err := qParams.Marshal()
 	if err != nil {
@@ -351,6 +616,9 @@ func (d *DefaultProvider) QuerySlashingParams(ctx context.Context) (*slashing.Pa
 	if err != nil {
 		return nil, fmt.Errorf("query slashing params: %w", err)
 	}
+	if unknownQueryPath(resp.Response.Code, resp.Response.Log) {
+		return nil, errNoSlashingModule
+	}
 	if resp.Response.Value == nil {
 		return nil, errors.New("🛑 could not query slashing params, got empty response")
 	}
@@ -363,6 +631,7 @@ func (d *DefaultProvider) QuerySlashingParams(ctx context.Context) (*slashing.Pa
 }
 
 func (d *DefaultProvider) QueryChainInfo(ctx context.Context) (totalSupply float64, communityTax float64, inflationRate float64, err error) {
+	defer func() { d.ChainConfig.countQueryError("chain_info", err) }()
 	// Query total supply using bank module
 	supplyQueryParams := bank.QuerySupplyOfRequest{
 		Denom: d.ChainConfig.denomMetadata.Base,
@@ -0,0 +1,323 @@
+package tenderduty
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	gov "github.com/cosmos/cosmos-sdk/x/gov/types"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// newAbciTestClient returns an rpc client for a server that answers abci_query requests with respond.
+func newAbciTestClient(t *testing.T, respond func(path string) (code uint32, value []byte)) *rpchttp.HTTP {
+	return newAbciLogTestClient(t, func(path string) (uint32, string, []byte) {
+		code, value := respond(path)
+		return code, "unknown query path", value
+	})
+}
+
+// newAbciLogTestClient is newAbciTestClient for tests that also need to choose the response's log.
+func newAbciLogTestClient(t *testing.T, respond func(path string) (code uint32, log string, value []byte)) *rpchttp.HTTP {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params struct {
+				Path string `json:"path"`
+			} `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Method != "abci_query" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		code, log, value := respond(req.Params.Path)
+		resp := map[string]any{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result": map[string]any{
+				"response": map[string]any{
+					"code":   code,
+					"log":    log,
+					"value":  value,
+					"height": "1",
+				},
+			},
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := rpchttp.New(server.URL, "/websocket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestQueryUnvotedOpenProposalsFallback(t *testing.T) {
+	valoper, err := bech32.ConvertAndEncode("cosmosvaloper", make([]byte, 20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	proposals, err := (&gov.QueryProposalsResponse{Proposals: []gov.Proposal{{ProposalId: 7, Status: gov.StatusVotingPeriod}}}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		v1Supported   bool
+		expectedPaths []string
+	}{
+		{
+			name:          "uses gov v1 when available",
+			v1Supported:   true,
+			expectedPaths: []string{"/cosmos.gov.v1.Query/Proposals"},
+		},
+		{
+			name:          "falls back to gov v1beta1",
+			expectedPaths: []string{"/cosmos.gov.v1.Query/Proposals", "/cosmos.gov.v1beta1.Query/Proposals"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			client := newAbciTestClient(t, func(path string) (uint32, []byte) {
+				paths = append(paths, path)
+				if path == "/cosmos.gov.v1.Query/Proposals" && !tt.v1Supported {
+					return 6, nil
+				}
+				return 0, proposals
+			})
+			provider := &DefaultProvider{ChainConfig: &ChainConfig{
+				name:       "test-chain",
+				ValAddress: valoper,
+				client:     client,
+			}}
+
+			unvoted, err := provider.QueryUnvotedOpenProposals(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(unvoted) != 1 || unvoted[0].ProposalId != 7 {
+				t.Errorf("expected proposal 7 to be unvoted, got %+v", unvoted)
+			}
+			if len(paths) != len(tt.expectedPaths) {
+				t.Fatalf("expected queries %v, got %v", tt.expectedPaths, paths)
+			}
+			for i := range paths {
+				if paths[i] != tt.expectedPaths[i] {
+					t.Errorf("expected queries %v, got %v", tt.expectedPaths, paths)
+				}
+			}
+		})
+	}
+}
+
+func TestQueryUnvotedOpenProposalsGovVersion(t *testing.T) {
+	valoper, err := bech32.ConvertAndEncode("cosmosvaloper", make([]byte, 20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	proposals, err := (&gov.QueryProposalsResponse{}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	const v1, v1beta1 = "/cosmos.gov.v1.Query/Proposals", "/cosmos.gov.v1beta1.Query/Proposals"
+
+	tests := []struct {
+		name            string
+		v1Log           string   // the log of a rejected v1 query, v1 is answered when empty
+		expectedVersion string   // the cached version after the first refresh
+		expectedPaths   []string // the queries of the second refresh
+	}{
+		{
+			name:            "gov v1 is kept",
+			expectedVersion: "v1",
+			expectedPaths:   []string{v1},
+		},
+		{
+			name:            "v1beta1 only chains don't query v1 again",
+			v1Log:           "unknown query path",
+			expectedVersion: "v1beta1",
+			expectedPaths:   []string{v1beta1},
+		},
+		{
+			name:          "other v1 failures are probed again",
+			v1Log:         "timed out waiting for the query",
+			expectedPaths: []string{v1, v1beta1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			client := newAbciLogTestClient(t, func(path string) (uint32, string, []byte) {
+				paths = append(paths, path)
+				if path == v1 && tt.v1Log != "" {
+					return 6, tt.v1Log, nil
+				}
+				return 0, "", proposals
+			})
+			cc := &ChainConfig{name: "test-chain", ValAddress: valoper, client: client}
+			provider := &DefaultProvider{ChainConfig: cc}
+
+			if _, err = provider.QueryUnvotedOpenProposals(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if cc.govVersion != tt.expectedVersion {
+				t.Errorf("expected gov version %q to be cached, got %q", tt.expectedVersion, cc.govVersion)
+			}
+
+			paths = nil
+			if _, err = provider.QueryUnvotedOpenProposals(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(paths) != fmt.Sprint(tt.expectedPaths) {
+				t.Errorf("expected queries %v on the next refresh, got %v", tt.expectedPaths, paths)
+			}
+		})
+	}
+
+	t.Run("a node without the cached version is probed again", func(t *testing.T) {
+		client := newAbciTestClient(t, func(path string) (uint32, []byte) {
+			if path == v1 {
+				return 6, nil
+			}
+			return 0, proposals
+		})
+		cc := &ChainConfig{name: "test-chain", ValAddress: valoper, client: client, govVersion: "v1"}
+		if _, err := (&DefaultProvider{ChainConfig: cc}).QueryUnvotedOpenProposals(context.Background()); err == nil {
+			t.Error("expected the rejected query to fail")
+		}
+		if cc.govVersion != "" {
+			t.Errorf("expected the cached version to be dropped, got %q", cc.govVersion)
+		}
+	})
+}
+
+// ibcTestResponse encodes a query response holding value as a google.protobuf.Any of typeURL.
+func ibcTestResponse(typeURL string, value []byte) []byte {
+	anyMsg := protowire.AppendTag(nil, 1, protowire.BytesType)
+	anyMsg = protowire.AppendString(anyMsg, typeURL)
+	anyMsg = protowire.AppendTag(anyMsg, 2, protowire.BytesType)
+	anyMsg = protowire.AppendBytes(anyMsg, value)
+	b := protowire.AppendTag(nil, 1, protowire.BytesType)
+	return protowire.AppendBytes(b, anyMsg)
+}
+
+// ibcTestSeconds encodes a google.protobuf.Duration or Timestamp as field num of a message.
+func ibcTestSeconds(num protowire.Number, seconds int64) []byte {
+	inner := protowire.AppendTag(nil, 1, protowire.VarintType)
+	inner = protowire.AppendVarint(inner, uint64(seconds))
+	b := protowire.AppendTag(nil, num, protowire.BytesType)
+	return protowire.AppendBytes(b, inner)
+}
+
+func TestQueryIBCClientStatus(t *testing.T) {
+	updated := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	status := protowire.AppendString(protowire.AppendTag(nil, 1, protowire.BytesType), "Active")
+	tests := []struct {
+		name            string
+		clientStateType string
+		expectedExpiry  time.Time
+	}{
+		{
+			name:            "tendermint client expires after the trusting period",
+			clientStateType: ibcTendermintClientState,
+			expectedExpiry:  updated.Add(14 * 24 * time.Hour),
+		},
+		{
+			name:            "other clients have no known expiry",
+			clientStateType: "/ibc.lightclients.solomachine.v2.ClientState",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newAbciTestClient(t, func(path string) (uint32, []byte) {
+				switch path {
+				case "/ibc.core.client.v1.Query/ClientStatus":
+					return 0, status
+				case "/ibc.core.client.v1.Query/ClientState":
+					return 0, ibcTestResponse(tt.clientStateType, ibcTestSeconds(3, 14*24*3600))
+				case "/ibc.core.client.v1.Query/ConsensusState":
+					return 0, ibcTestResponse(ibcTendermintConsensusState, ibcTestSeconds(1, updated.Unix()))
+				}
+				return 6, nil
+			})
+			provider := &DefaultProvider{ChainConfig: &ChainConfig{name: "test-chain", client: client}}
+
+			got, err := provider.QueryIBCClientStatus(context.Background(), "07-tendermint-0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.ClientID != "07-tendermint-0" || got.Status != "Active" {
+				t.Errorf("expected 07-tendermint-0 to be Active, got %+v", got)
+			}
+			if !got.ExpiresAt.Equal(tt.expectedExpiry) {
+				t.Errorf("expected expiry %v, got %v", tt.expectedExpiry, got.ExpiresAt)
+			}
+		})
+	}
+}
+
+func TestConsensusAddress(t *testing.T) {
+	// the secp256k1 generator point, the public key of private key 1
+	compressed, _ := hex.DecodeString("0279BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798")
+	key := protowire.AppendBytes(protowire.AppendTag(nil, 1, protowire.BytesType), compressed)
+
+	tests := []struct {
+		name     string
+		typeUrl  string
+		value    []byte
+		expected string
+		wantErr  bool
+	}{
+		{"ethermint eth_secp256k1", "/ethermint.crypto.v1.ethsecp256k1.PubKey", key, "7E5F4552091A69125D5DFCB7B8C2659029395BDF", false},
+		{"injective eth_secp256k1", "/injective.crypto.v1beta1.ethsecp256k1.PubKey", key, "7E5F4552091A69125D5DFCB7B8C2659029395BDF", false},
+		{"secp256k1 hashes the key differently", "/cosmos.crypto.secp256k1.PubKey", key, "751E76E8199196D454941C45D1B3A323F1433BD6", false},
+		{"invalid eth_secp256k1 key", "/ethermint.crypto.v1.ethsecp256k1.PubKey", protowire.AppendBytes(protowire.AppendTag(nil, 1, protowire.BytesType), []byte{2, 1}), "", true},
+		{"unknown key type", "/cosmos.crypto.sr25519.PubKey", key, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := consensusAddress(tt.typeUrl, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if fmt.Sprintf("%X", got) != tt.expected {
+				t.Errorf("expected %s, got %X", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestConvertValoperToAccAddressEthermint(t *testing.T) {
+	addr, _ := hex.DecodeString("7E5F4552091A69125D5DFCB7B8C2659029395BDF")
+	valoper, err := bech32.ConvertAndEncode("evmosvaloper", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acc, err := ConvertValopertToAccAddress(valoper)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefix, bz, err := bech32.DecodeAndConvert(acc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the account's 0x address is the same bytes
+	if prefix != "evmos" || "0x"+hex.EncodeToString(bz) != "0x7e5f4552091a69125d5dfcb7b8c2659029395bdf" {
+		t.Errorf("expected the evmos account of 0x7e5f4552091a69125d5dfcb7b8c2659029395bdf, got %s", acc)
+	}
+}
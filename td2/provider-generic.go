@@ -0,0 +1,450 @@
+package tenderduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+	gov "github.com/cosmos/cosmos-sdk/x/gov/types"
+	slashing "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
+	upgrade "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+)
+
+// GenericHTTPProvider queries chains that are not cosmos-sdk based over plain HTTP. Each query has a section in
+// the provider configs with a `url` template and the JSON paths of the fields to extract, for example:
+//
+//	provider:
+//	  name: generic
+//	  configs:
+//	    validator_info:
+//	      url: https://api.example.com/validators/{{ .ValAddress }}
+//	      address: $.validator.consensus_address
+//	      moniker: $.validator.name
+//
+// The url templates can use .ValAddress, .Valcons, .ChainId and .Denom.
+type GenericHTTPProvider struct {
+	ChainConfig *ChainConfig
+}
+
+// genericTemplateData is what the url templates of the generic provider are rendered with.
+type genericTemplateData struct {
+	ValAddress string
+	Valcons    string
+	ChainId    string
+	Denom      string
+}
+
+// section returns the config for one query, the yaml decoder produces map[interface{}]interface{} for nested maps.
+func (d *GenericHTTPProvider) section(name string) (map[string]string, bool) {
+	raw, ok := d.ChainConfig.Provider.Configs[name]
+	if !ok {
+		return nil, false
+	}
+	section := make(map[string]string)
+	switch m := raw.(type) {
+	case map[any]any:
+		for k, v := range m {
+			section[fmt.Sprint(k)] = fmt.Sprint(v)
+		}
+	case map[string]any:
+		for k, v := range m {
+			section[k] = fmt.Sprint(v)
+		}
+	default:
+		return nil, false
+	}
+	return section, true
+}
+
+// configString returns a top level string option of the provider configs.
+func (d *GenericHTTPProvider) configString(name string) string {
+	if v, ok := d.ChainConfig.Provider.Configs[name]; ok {
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
+// fetch renders the url of the named section, requests it and returns the section and the decoded JSON body.
+func (d *GenericHTTPProvider) fetch(ctx context.Context, name string) (map[string]string, any, error) {
+	section, ok := d.section(name)
+	if !ok || section["url"] == "" {
+		return nil, nil, fmt.Errorf("%s is not configured for the generic provider on %s", name, d.ChainConfig.name)
+	}
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(section["url"])
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid %s url template: %w", name, err)
+	}
+	data := genericTemplateData{
+		ValAddress: d.ChainConfig.ValAddress,
+		ChainId:    d.ChainConfig.ChainId,
+		Denom:      d.configString("denom"),
+	}
+	if d.ChainConfig.valInfo != nil {
+		data.Valcons = d.ChainConfig.valInfo.Valcons
+	}
+	var reqURL bytes.Buffer
+	if err = tmpl.Execute(&reqURL, data); err != nil {
+		return nil, nil, fmt.Errorf("render %s url template: %w", name, err)
+	}
+
+	var body any
+	if err = getIndexerJson(ctx, newHTTPClient(10*time.Second), reqURL.String(), &body); err != nil {
+		return nil, nil, err
+	}
+	return section, body, nil
+}
+
+// jsonPath extracts a value from decoded JSON, paths are dot separated keys and array indexes with an optional
+// leading `$`, for example `$.result.validators[0].address` or `result.validators.0.address`.
+func jsonPath(doc any, path string) (any, error) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	path = strings.ReplaceAll(strings.ReplaceAll(path, "[", "."), "]", "")
+	cur := doc
+	if path == "" {
+		return cur, nil
+	}
+	for _, key := range strings.Split(path, ".") {
+		switch node := cur.(type) {
+		case map[string]any:
+			v, ok := node[key]
+			if !ok {
+				return nil, fmt.Errorf("key %q not found in %s", key, path)
+			}
+			cur = v
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, fmt.Errorf("invalid index %q in %s", key, path)
+			}
+			cur = node[i]
+		default:
+			return nil, fmt.Errorf("cannot descend into %q in %s", key, path)
+		}
+	}
+	return cur, nil
+}
+
+// float extracts a number, JSON strings holding a number are accepted since many APIs return amounts as strings.
+func (d *GenericHTTPProvider) float(section map[string]string, doc any, field string) (float64, error) {
+	v, err := d.value(section, doc, field)
+	if err != nil {
+		return 0, err
+	}
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %q is not a number", field, n)
+		}
+		return f, nil
+	}
+	return 0, fmt.Errorf("%s: unexpected type %T", field, v)
+}
+
+// str extracts a value as a string.
+func (d *GenericHTTPProvider) str(section map[string]string, doc any, field string) (string, error) {
+	v, err := d.value(section, doc, field)
+	if err != nil {
+		return "", err
+	}
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	return fmt.Sprint(v), nil
+}
+
+// boolean extracts a bool, strings are compared against `<field>_true_values`, a comma separated list defaulting
+// to true.
+func (d *GenericHTTPProvider) boolean(section map[string]string, doc any, field string) (bool, error) {
+	v, err := d.value(section, doc, field)
+	if err != nil {
+		return false, err
+	}
+	if b, ok := v.(bool); ok {
+		return b, nil
+	}
+	trueValues := "true"
+	if tv := section[field+"_true_values"]; tv != "" {
+		trueValues = tv
+	}
+	s := fmt.Sprint(v)
+	for _, t := range strings.Split(trueValues, ",") {
+		if strings.EqualFold(strings.TrimSpace(t), s) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// value looks up the configured path of field in doc.
+func (d *GenericHTTPProvider) value(section map[string]string, doc any, field string) (any, error) {
+	path, ok := section[field]
+	if !ok {
+		return nil, fmt.Errorf("no path configured for %s", field)
+	}
+	return jsonPath(doc, path)
+}
+
+func (d *GenericHTTPProvider) QueryValidatorInfo(ctx context.Context) (pub []byte, moniker string, jailed bool, bonded bool, delegatedTokens float64, commissionRate float64, err error) {
+	defer func() { d.ChainConfig.countQueryError("validator", err) }()
+	section, doc, err := d.fetch(ctx, "validator_info")
+	if err != nil {
+		return
+	}
+
+	if strings.Contains(d.ChainConfig.ValAddress, "valcons") {
+		_, pub, err = bech32.DecodeAndConvert(d.ChainConfig.ValAddress)
+		if err != nil {
+			return nil, "", false, false, 0, 0, errors.New("could not decode and convert your address " + d.ChainConfig.ValAddress)
+		}
+	} else {
+		var address string
+		if address, err = d.str(section, doc, "address"); err != nil {
+			return
+		}
+		// the consensus address is usually hex as in block signatures, base64 is accepted too
+		if pub, err = hex.DecodeString(address); err != nil {
+			if pub, err = base64.StdEncoding.DecodeString(address); err != nil {
+				return nil, "", false, false, 0, 0, fmt.Errorf("consensus address %q is neither hex nor base64", address)
+			}
+		}
+		if len(pub) < 20 {
+			return nil, "", false, false, 0, 0, fmt.Errorf("consensus address %q is too short", address)
+		}
+	}
+
+	moniker = d.ChainConfig.ValAddress
+	if _, ok := section["moniker"]; ok {
+		if moniker, err = d.str(section, doc, "moniker"); err != nil {
+			return
+		}
+	}
+	bonded = true
+	if _, ok := section["bonded"]; ok {
+		if bonded, err = d.boolean(section, doc, "bonded"); err != nil {
+			return
+		}
+	}
+	if _, ok := section["jailed"]; ok {
+		if jailed, err = d.boolean(section, doc, "jailed"); err != nil {
+			return
+		}
+	}
+	if _, ok := section["delegated_tokens"]; ok {
+		if delegatedTokens, err = d.float(section, doc, "delegated_tokens"); err != nil {
+			return
+		}
+	}
+	if _, ok := section["commission_rate"]; ok {
+		if commissionRate, err = d.float(section, doc, "commission_rate"); err != nil {
+			return
+		}
+	}
+	return pub, moniker, jailed, bonded, delegatedTokens, commissionRate, nil
+}
+
+func (d *GenericHTTPProvider) QuerySigningInfo(ctx context.Context) (signing *slashing.ValidatorSigningInfo, err error) {
+	defer func() { d.ChainConfig.countQueryError("signing_info", err) }()
+	section, doc, err := d.fetch(ctx, "signing_info")
+	if err != nil {
+		return nil, err
+	}
+	missed, err := d.float(section, doc, "missed_blocks")
+	if err != nil {
+		return nil, err
+	}
+	signing = &slashing.ValidatorSigningInfo{MissedBlocksCounter: int64(missed)}
+	if _, ok := section["tombstoned"]; ok {
+		if signing.Tombstoned, err = d.boolean(section, doc, "tombstoned"); err != nil {
+			return nil, err
+		}
+	}
+	return signing, nil
+}
+
+func (d *GenericHTTPProvider) QuerySlashingParams(ctx context.Context) (slashingParams *slashing.Params, err error) {
+	defer func() { d.ChainConfig.countQueryError("slashing_params", err) }()
+	section, doc, err := d.fetch(ctx, "slashing_params")
+	if err != nil {
+		return nil, err
+	}
+	window, err := d.float(section, doc, "signed_blocks_window")
+	if err != nil {
+		return nil, err
+	}
+	minSigned, err := d.str(section, doc, "min_signed_per_window")
+	if err != nil {
+		return nil, err
+	}
+	minSignedDec, err := github_com_cosmos_cosmos_sdk_types.NewDecFromStr(minSigned)
+	if err != nil {
+		return nil, fmt.Errorf("min_signed_per_window: %q is not a decimal", minSigned)
+	}
+	return &slashing.Params{SignedBlocksWindow: int64(window), MinSignedPerWindow: minSignedDec}, nil
+}
+
+func (d *GenericHTTPProvider) QueryValidatorVotingPool(ctx context.Context) (votingPool *staking.Pool, err error) {
+	defer func() { d.ChainConfig.countQueryError("voting_pool", err) }()
+	section, doc, err := d.fetch(ctx, "voting_pool")
+	if err != nil {
+		return nil, err
+	}
+	bondedTokens, err := d.float(section, doc, "bonded_tokens")
+	if err != nil {
+		return nil, err
+	}
+	pool := staking.Pool{
+		BondedTokens:    floatToInt(bondedTokens),
+		NotBondedTokens: github_com_cosmos_cosmos_sdk_types.ZeroInt(),
+	}
+	if _, ok := section["not_bonded_tokens"]; ok {
+		notBonded, err := d.float(section, doc, "not_bonded_tokens")
+		if err != nil {
+			return nil, err
+		}
+		pool.NotBondedTokens = floatToInt(notBonded)
+	}
+	return &pool, nil
+}
+
+// floatToInt converts a token amount, which may not fit an int64 for tokens with 18 decimals.
+func floatToInt(f float64) github_com_cosmos_cosmos_sdk_types.Int {
+	i, _ := new(big.Float).SetFloat64(f).Int(nil)
+	return github_com_cosmos_cosmos_sdk_types.NewIntFromBigInt(i)
+}
+
+func (d *GenericHTTPProvider) QueryValidatorSelfDelegationRewardsAndCommission(ctx context.Context) (rewards *github_com_cosmos_cosmos_sdk_types.DecCoins, commission *github_com_cosmos_cosmos_sdk_types.DecCoins, err error) {
+	defer func() { d.ChainConfig.countQueryError("rewards", err) }()
+	denom := d.configString("denom")
+	if denom == "" {
+		return nil, nil, errors.New("denom is not configured for the generic provider on " + d.ChainConfig.name)
+	}
+	section, doc, err := d.fetch(ctx, "rewards")
+	if err != nil {
+		return nil, nil, err
+	}
+	coins := func(field string) (*github_com_cosmos_cosmos_sdk_types.DecCoins, error) {
+		result := github_com_cosmos_cosmos_sdk_types.DecCoins{}
+		if _, ok := section[field]; !ok {
+			return &result, nil
+		}
+		amount, err := d.str(section, doc, field)
+		if err != nil {
+			return nil, err
+		}
+		dec, err := github_com_cosmos_cosmos_sdk_types.NewDecFromStr(amount)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %q is not a decimal", field, amount)
+		}
+		result = append(result, github_com_cosmos_cosmos_sdk_types.NewDecCoinFromDec(denom, dec))
+		return &result, nil
+	}
+	if rewards, err = coins("rewards"); err != nil {
+		return nil, nil, err
+	}
+	if commission, err = coins("commission"); err != nil {
+		return nil, nil, err
+	}
+	return rewards, commission, nil
+}
+
+func (d *GenericHTTPProvider) QueryChainInfo(ctx context.Context) (totalSupply float64, communityTax float64, inflationRate float64, err error) {
+	defer func() { d.ChainConfig.countQueryError("chain_info", err) }()
+	section, doc, err := d.fetch(ctx, "chain_info")
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if totalSupply, err = d.float(section, doc, "total_supply"); err != nil {
+		return 0, 0, 0, err
+	}
+	if inflationRate, err = d.float(section, doc, "inflation_rate"); err != nil {
+		return 0, 0, 0, err
+	}
+	if _, ok := section["community_tax"]; ok {
+		if communityTax, err = d.float(section, doc, "community_tax"); err != nil {
+			return 0, 0, 0, err
+		}
+	}
+	return totalSupply, communityTax, inflationRate, nil
+}
+
+// QueryUnvotedOpenProposals returns the proposal ids found at the `ids` path, governance is not monitored when the
+// section is missing.
+func (d *GenericHTTPProvider) QueryUnvotedOpenProposals(ctx context.Context) (unvoted []gov.Proposal, err error) {
+	if _, ok := d.section("unvoted_proposals"); !ok {
+		return nil, nil
+	}
+	defer func() { d.ChainConfig.countQueryError("unvoted_proposals", err) }()
+	section, doc, err := d.fetch(ctx, "unvoted_proposals")
+	if err != nil {
+		return nil, err
+	}
+	v, err := d.value(section, doc, "ids")
+	if err != nil {
+		return nil, err
+	}
+	ids, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("ids: expected a list, got %T", v)
+	}
+	unvoted = make([]gov.Proposal, 0, len(ids))
+	for _, id := range ids {
+		n, err := strconv.ParseUint(fmt.Sprint(id), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("ids: %v is not a proposal id", id)
+		}
+		unvoted = append(unvoted, gov.Proposal{ProposalId: n})
+	}
+	return unvoted, nil
+}
+
+// QueryDenomMetadata is built from the `denom`, `display_denom` and `exponent` options instead of being queried.
+func (d *GenericHTTPProvider) QueryDenomMetadata(ctx context.Context, denom string) (medatada *bank.Metadata, err error) {
+	display := d.configString("display_denom")
+	if denom != d.configString("denom") || display == "" {
+		return nil, fmt.Errorf("no denom metadata for %s on %s", denom, d.ChainConfig.name)
+	}
+	exponent, err := strconv.ParseUint(d.configString("exponent"), 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent for %s: %w", display, err)
+	}
+	return &bank.Metadata{
+		DenomUnits: []*bank.DenomUnit{
+			{Denom: denom, Exponent: 0},
+			{Denom: display, Exponent: uint32(exponent)},
+		},
+		Base:    denom,
+		Display: display,
+		Symbol:  strings.ToUpper(display),
+	}, nil
+}
+
+func (d *GenericHTTPProvider) QueryUnbondingDelegations(ctx context.Context) ([]staking.UnbondingDelegationEntry, error) {
+	return nil, errors.New("QueryUnbondingDelegations not implemented for the generic provider")
+}
+
+func (d *GenericHTTPProvider) QueryAccountBalance(ctx context.Context, denom string) (float64, error) {
+	return 0, errors.New("QueryAccountBalance not implemented for the generic provider")
+}
+
+func (d *GenericHTTPProvider) QueryIBCClientStatus(ctx context.Context, clientID string) (*IBCClientStatus, error) {
+	return nil, errors.New("QueryIBCClientStatus not implemented for the generic provider")
+}
+
+func (d *GenericHTTPProvider) QueryUpgradePlan(ctx context.Context) (*upgrade.Plan, error) {
+	return nil, errors.New("QueryUpgradePlan not implemented for the generic provider")
+}
@@ -0,0 +1,246 @@
+package tenderduty
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-yaml/yaml"
+)
+
+func newGenericTestProvider(t *testing.T) *GenericHTTPProvider {
+	fixtures := map[string]string{
+		"/validators/val1": `{"validator": {"consensus_address": "0102030405060708090A0B0C0D0E0F1011121314", "name": "my validator",
+			"status": "ACTIVE", "jailed": false, "stake": "1500000", "commission": 0.05}}`,
+		"/validators/val1/liveness": `{"data": [{"missed": 12, "tombstoned": "no"}]}`,
+		"/params":                   `{"liveness": {"window": "10000", "min_signed": "0.05"}}`,
+		"/staking":                  `{"total_bonded": 30000000}`,
+		"/validators/val1/rewards":  `{"rewards": "12.5", "commission": "3.25"}`,
+		"/economics":                `{"supply": "100000000", "inflation": 0.07}`,
+		"/governance/unvoted/val1":  `{"proposals": [4, "9"]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := fixtures[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	config := `
+name: generic
+configs:
+  denom: uexample
+  display_denom: example
+  exponent: 6
+  validator_info:
+    url: "BASE/validators/{{ .ValAddress }}"
+    address: $.validator.consensus_address
+    moniker: $.validator.name
+    bonded: $.validator.status
+    bonded_true_values: "active, bonded"
+    jailed: $.validator.jailed
+    delegated_tokens: $.validator.stake
+    commission_rate: $.validator.commission
+  signing_info:
+    url: "BASE/validators/{{ .ValAddress }}/liveness"
+    missed_blocks: $.data[0].missed
+    tombstoned: data.0.tombstoned
+  slashing_params:
+    url: BASE/params
+    signed_blocks_window: $.liveness.window
+    min_signed_per_window: $.liveness.min_signed
+  voting_pool:
+    url: BASE/staking
+    bonded_tokens: $.total_bonded
+  rewards:
+    url: "BASE/validators/{{ .ValAddress }}/rewards"
+    rewards: $.rewards
+    commission: $.commission
+  chain_info:
+    url: BASE/economics
+    total_supply: $.supply
+    inflation_rate: $.inflation
+  unvoted_proposals:
+    url: "BASE/governance/unvoted/{{ .ValAddress }}"
+    ids: $.proposals
+`
+	pc := ProviderConfig{}
+	if err := yaml.Unmarshal([]byte(strings.ReplaceAll(config, "BASE", server.URL)), &pc); err != nil {
+		t.Fatal(err)
+	}
+	return &GenericHTTPProvider{ChainConfig: &ChainConfig{name: "generic-chain", ValAddress: "val1", Provider: pc}}
+}
+
+func TestGenericHTTPProvider(t *testing.T) {
+	provider := newGenericTestProvider(t)
+	ctx := context.Background()
+
+	pub, moniker, jailed, bonded, delegated, commissionRate, err := provider.QueryValidatorInfo(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(pub) != "0102030405060708090a0b0c0d0e0f1011121314" || moniker != "my validator" || jailed || !bonded ||
+		delegated != 1500000 || commissionRate != 0.05 {
+		t.Errorf("unexpected validator info: %x %q jailed=%v bonded=%v %v %v", pub, moniker, jailed, bonded, delegated, commissionRate)
+	}
+
+	signing, err := provider.QuerySigningInfo(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signing.MissedBlocksCounter != 12 || signing.Tombstoned {
+		t.Errorf("unexpected signing info: %+v", signing)
+	}
+
+	params, err := provider.QuerySlashingParams(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params.SignedBlocksWindow != 10000 || params.MinSignedPerWindow.String() != "0.050000000000000000" {
+		t.Errorf("unexpected slashing params: %+v", params)
+	}
+
+	pool, err := provider.QueryValidatorVotingPool(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool.BondedTokens.Int64() != 30000000 {
+		t.Errorf("expected 30000000 bonded tokens, got %s", pool.BondedTokens)
+	}
+
+	rewards, commission, err := provider.QueryValidatorSelfDelegationRewardsAndCommission(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rewards.String() != "12.500000000000000000uexample" || commission.String() != "3.250000000000000000uexample" {
+		t.Errorf("unexpected rewards %s and commission %s", rewards, commission)
+	}
+
+	supply, tax, inflation, err := provider.QueryChainInfo(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if supply != 100000000 || tax != 0 || inflation != 0.07 {
+		t.Errorf("unexpected chain info: %v %v %v", supply, tax, inflation)
+	}
+
+	unvoted, err := provider.QueryUnvotedOpenProposals(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make([]uint64, 0)
+	for _, p := range unvoted {
+		ids = append(ids, p.ProposalId)
+	}
+	if !reflect.DeepEqual(ids, []uint64{4, 9}) {
+		t.Errorf("expected proposals [4 9], got %v", ids)
+	}
+
+	meta, err := provider.QueryDenomMetadata(ctx, "uexample")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Display != "example" || meta.DenomUnits[1].Exponent != 6 {
+		t.Errorf("unexpected denom metadata: %+v", meta)
+	}
+}
+
+func TestGenericHTTPProviderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(p *GenericHTTPProvider)
+		query   func(p *GenericHTTPProvider) error
+		wantErr string
+	}{
+		{
+			name:   "section not configured",
+			modify: func(p *GenericHTTPProvider) { delete(p.ChainConfig.Provider.Configs, "signing_info") },
+			query: func(p *GenericHTTPProvider) error {
+				_, err := p.QuerySigningInfo(context.Background())
+				return err
+			},
+			wantErr: "signing_info is not configured",
+		},
+		{
+			name: "path not found",
+			modify: func(p *GenericHTTPProvider) {
+				p.ChainConfig.Provider.Configs["voting_pool"].(map[any]any)["bonded_tokens"] = "$.missing"
+			},
+			query: func(p *GenericHTTPProvider) error {
+				_, err := p.QueryValidatorVotingPool(context.Background())
+				return err
+			},
+			wantErr: `key "missing" not found`,
+		},
+		{
+			name: "http error",
+			modify: func(p *GenericHTTPProvider) {
+				section := p.ChainConfig.Provider.Configs["chain_info"].(map[any]any)
+				section["url"] = section["url"].(string) + "/nope"
+			},
+			query: func(p *GenericHTTPProvider) error {
+				_, _, _, err := p.QueryChainInfo(context.Background())
+				return err
+			},
+			wantErr: "returned 404",
+		},
+		{
+			name:   "missing proposals section disables governance",
+			modify: func(p *GenericHTTPProvider) { delete(p.ChainConfig.Provider.Configs, "unvoted_proposals") },
+			query: func(p *GenericHTTPProvider) error {
+				_, err := p.QueryUnvotedOpenProposals(context.Background())
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newGenericTestProvider(t)
+			tt.modify(provider)
+			err := tt.query(provider)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestJsonPath(t *testing.T) {
+	doc := map[string]any{"a": map[string]any{"b": []any{"x", map[string]any{"c": 1.0}}}}
+	tests := []struct {
+		path     string
+		expected any
+		wantErr  bool
+	}{
+		{path: "$.a.b[1].c", expected: 1.0},
+		{path: "a.b.0", expected: "x"},
+		{path: "$", expected: doc},
+		{path: "a.b[2]", wantErr: true},
+		{path: "a.b.0.c", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			v, err := jsonPath(doc, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(v, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, v)
+			}
+		})
+	}
+}
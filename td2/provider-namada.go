@@ -3,7 +3,6 @@ package tenderduty
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -22,6 +21,7 @@ import (
 	gov "github.com/cosmos/cosmos-sdk/x/gov/types"
 	slashing "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
+	upgrade "github.com/cosmos/cosmos-sdk/x/upgrade/types"
 	namada "github.com/firstset/tenderduty/v2/td2/namada"
 	"github.com/near/borsh-go"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
@@ -93,7 +93,8 @@ func getVotingPeriodProposals(httpClient *http.Client, indexers []string) ([]gov
 	return votingPeriodProposals, lastErr
 }
 
-func (d *NamadaProvider) QueryUnvotedOpenProposals(ctx context.Context) ([]gov.Proposal, error) {
+func (d *NamadaProvider) QueryUnvotedOpenProposals(ctx context.Context) (unvoted []gov.Proposal, err error) {
+	defer func() { d.ChainConfig.countQueryError("proposals", err) }()
 	// Store the last error to return if all indexer endpoints fail
 	var lastErr error
 	var unVotedProposals []gov.Proposal
@@ -102,14 +103,7 @@ func (d *NamadaProvider) QueryUnvotedOpenProposals(ctx context.Context) ([]gov.P
 	validatorAddress, ok2 := d.ChainConfig.Provider.Configs["validator_address"].(string)
 	if ok1 && ok2 {
 		// Create a reusable HTTP client with timeout
-		tr := &http.Transport{
-			//#nosec G402 -- configurable option
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: td.TLSSkipVerify},
-		}
-		httpClient := &http.Client{
-			Transport: tr,
-			Timeout:   5 * time.Second, // Add reasonable timeout
-		}
+		httpClient := newHTTPClient(5 * time.Second)
 
 		urls := make([]string, len(indexers))
 		for i, v := range indexers {
@@ -173,6 +167,7 @@ func (d *NamadaProvider) QueryUnvotedOpenProposals(ctx context.Context) ([]gov.P
 }
 
 func (d *NamadaProvider) QueryValidatorInfo(ctx context.Context) (pub []byte, moniker string, jailed bool, bonded bool, delegatedTokens float64, commissionRate float64, err error) {
+	defer func() { d.ChainConfig.countQueryError("validator", err) }()
 	hexAddress := ""
 	if strings.Contains(d.ChainConfig.ValAddress, "valcons") {
 		_, bz, err := bech32.DecodeAndConvert(d.ChainConfig.ValAddress)
@@ -224,8 +219,8 @@ func (d *NamadaProvider) QueryValidatorInfo(ctx context.Context) (pub []byte, mo
 		if stake != nil {
 			delegatedTokensFloat, err := strconv.ParseFloat(stake.Raw.String(), 64)
 			if err == nil {
-				// not sure about the rationale behind yet but the value is uint and it needs to be divivded by 1e6 to get the correct precision
-				info.DelegatedTokens = delegatedTokensFloat / 1000000
+				// the stake is in unam, it's converted to NAM with the denom metadata like on cosmos chains
+				info.DelegatedTokens = delegatedTokensFloat
 			}
 		}
 
@@ -265,7 +260,8 @@ func getLivenessInfo(ctx context.Context, client *rpchttp.HTTP) (*namada.Livenes
 	return &livenessInfo, nil
 }
 
-func (d *NamadaProvider) QuerySigningInfo(ctx context.Context) (*slashing.ValidatorSigningInfo, error) {
+func (d *NamadaProvider) QuerySigningInfo(ctx context.Context) (signing *slashing.ValidatorSigningInfo, err error) {
+	defer func() { d.ChainConfig.countQueryError("signing_info", err) }()
 	livenessInfo, err := getLivenessInfo(ctx, d.ChainConfig.client)
 	if err != nil {
 		return nil, err
@@ -286,7 +282,8 @@ func (d *NamadaProvider) QuerySigningInfo(ctx context.Context) (*slashing.Valida
 	return &signingInfo, nil
 }
 
-func (d *NamadaProvider) QuerySlashingParams(ctx context.Context) (*slashing.Params, error) {
+func (d *NamadaProvider) QuerySlashingParams(ctx context.Context) (slashingParams *slashing.Params, err error) {
+	defer func() { d.ChainConfig.countQueryError("slashing_params", err) }()
 	livenessInfo, err := getLivenessInfo(ctx, d.ChainConfig.client)
 	if err != nil {
 		return nil, err
@@ -300,11 +297,46 @@ func (d *NamadaProvider) QuerySlashingParams(ctx context.Context) (*slashing.Par
 	return &slashing.Params{SignedBlocksWindow: int64(livenessInfo.LivenessWindowLen), MinSignedPerWindow: cosmos_sdk_types.MustNewDecFromStr(livenessInfo.LivenessThreshold.String())}, nil
 }
 
+// namadaDenomMetadata describes NAM, Namada has no bank module to query it from. Amounts from the chain and the
+// indexer are in unam.
+var namadaDenomMetadata = bank.Metadata{
+	Description: "The native token of Namada",
+	DenomUnits: []*bank.DenomUnit{
+		{Denom: "unam", Exponent: 0},
+		{Denom: "NAM", Exponent: 6},
+	},
+	Base:    "unam",
+	Display: "NAM",
+	Name:    "Namada",
+	Symbol:  "NAM",
+}
+
 func (d *NamadaProvider) QueryDenomMetadata(ctx context.Context, denom string) (medatada *bank.Metadata, err error) {
-	return nil, errors.New("QueryDenomMetadata with ABCIQuery not implemented for Namada")
+	if denom != namadaDenomMetadata.Base {
+		return nil, fmt.Errorf("no denom metadata for %s on Namada", denom)
+	}
+	meta := namadaDenomMetadata
+	return &meta, nil
+}
+
+func (d *NamadaProvider) QueryUnbondingDelegations(ctx context.Context) ([]staking.UnbondingDelegationEntry, error) {
+	return nil, errors.New("QueryUnbondingDelegations not implemented for Namada")
+}
+
+func (d *NamadaProvider) QueryAccountBalance(ctx context.Context, denom string) (float64, error) {
+	return 0, errors.New("QueryAccountBalance not implemented for Namada")
+}
+
+func (d *NamadaProvider) QueryIBCClientStatus(ctx context.Context, clientID string) (*IBCClientStatus, error) {
+	return nil, errors.New("QueryIBCClientStatus not implemented for Namada")
+}
+
+func (d *NamadaProvider) QueryUpgradePlan(ctx context.Context) (*upgrade.Plan, error) {
+	return nil, errors.New("QueryUpgradePlan not implemented for Namada")
 }
 
 func (d *NamadaProvider) QueryValidatorSelfDelegationRewardsAndCommission(ctx context.Context) (rewards *github_com_cosmos_cosmos_sdk_types.DecCoins, commission *github_com_cosmos_cosmos_sdk_types.DecCoins, err error) {
+	defer func() { d.ChainConfig.countQueryError("rewards", err) }()
 	// Store the last error to return if all indexer endpoints fail
 	var lastErr error
 	// In Namada we don't query self-delegation rewards, this field will be kept as 0
@@ -319,14 +351,7 @@ func (d *NamadaProvider) QueryValidatorSelfDelegationRewardsAndCommission(ctx co
 	validatorAddress, ok2 := d.ChainConfig.Provider.Configs["validator_address"].(string)
 	if ok1 && ok2 {
 		// Create a reusable HTTP client with timeout
-		tr := &http.Transport{
-			//#nosec G402 -- configurable option
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: td.TLSSkipVerify},
-		}
-		httpClient := &http.Client{
-			Transport: tr,
-			Timeout:   5 * time.Second, // Add reasonable timeout
-		}
+		httpClient := newHTTPClient(5 * time.Second)
 		// Try each indexer in the list
 		for _, indexer := range indexers {
 			reqURL := fmt.Sprintf("%s/api/v1/pos/reward/%s", indexer, validatorAddress)
@@ -371,6 +396,7 @@ func (d *NamadaProvider) QueryValidatorSelfDelegationRewardsAndCommission(ctx co
 }
 
 func (d *NamadaProvider) QueryValidatorVotingPool(ctx context.Context) (votingPool *staking.Pool, err error) {
+	defer func() { d.ChainConfig.countQueryError("voting_pool", err) }()
 	// Store the last error to return if all indexer endpoints fail
 	var lastErr error
 	var result *staking.Pool
@@ -378,14 +404,7 @@ func (d *NamadaProvider) QueryValidatorVotingPool(ctx context.Context) (votingPo
 
 	if ok {
 		// Create a reusable HTTP client with timeout
-		tr := &http.Transport{
-			//#nosec G402 -- configurable option
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: td.TLSSkipVerify},
-		}
-		httpClient := &http.Client{
-			Transport: tr,
-			Timeout:   5 * time.Second, // Add reasonable timeout
-		}
+		httpClient := newHTTPClient(5 * time.Second)
 		// Try each indexer in the list
 		for _, indexer := range indexers {
 			reqURL := fmt.Sprintf("%s/api/v1/pos/voting-power", indexer)
@@ -416,7 +435,8 @@ func (d *NamadaProvider) QueryValidatorVotingPool(ctx context.Context) (votingPo
 				if ok {
 					result = &staking.Pool{
 						NotBondedTokens: github_com_cosmos_cosmos_sdk_types.ZeroInt(), // we ommit this field in Namada
-						BondedTokens:    bondedTokens,
+						// the voting power is in NAM, the validator's stake is in unam
+						BondedTokens: bondedTokens.MulRaw(1000000),
 					}
 				}
 			}()
@@ -429,8 +449,67 @@ func (d *NamadaProvider) QueryValidatorVotingPool(ctx context.Context) (votingPo
 	return nil, lastErr
 }
 
+// QueryChainInfo gets the total supply and the staking APR from the indexer. Namada's PoS inflation is adjusted by a
+// controller towards a target staked ratio, see https://specs.namada.net/modules/proof-of-stake/inflation-system#proof-of-stake-rewards,
+// so instead of reading its parameters the inflation rate is derived from the APR the indexer reports, which makes
+// baseAPR = inflationRate * totalSupply / totalBondedTokens equal to it. Namada has no community tax.
 func (d *NamadaProvider) QueryChainInfo(ctx context.Context) (totalSupply float64, communityTax float64, inflationRate float64, err error) {
-	// TODO: leave it here for now, Namada has a quite different way of calculating the inflation rate
-	// see more details here https://specs.namada.net/modules/proof-of-stake/inflation-system#proof-of-stake-rewards
-	return 0, 0, 0, errors.New("CalculateAPR not implemented for Namada")
+	defer func() { d.ChainConfig.countQueryError("chain_info", err) }()
+	indexers, ok := d.ChainConfig.Provider.Configs["indexers"].([]any)
+	if !ok {
+		return 0, 0, 0, errors.New("no indexers configured for Namada")
+	}
+	if d.ChainConfig.totalBondedTokens == 0 {
+		return 0, 0, 0, errors.New("the total bonded tokens are not known yet")
+	}
+
+	// Create a reusable HTTP client with timeout
+	httpClient := newHTTPClient(5 * time.Second)
+
+	// Store the last error to return if all indexer endpoints fail
+	var lastErr error
+	for _, indexer := range indexers {
+		params := namada.NamadaChainParametersResponse{}
+		if err = getIndexerJson(ctx, httpClient, fmt.Sprintf("%s/api/v1/chain/parameters", indexer), &params); err != nil {
+			lastErr = err
+			continue // Try next indexer
+		}
+		apr, err := strconv.ParseFloat(params.Apr, 64)
+		if err != nil {
+			lastErr = fmt.Errorf("parse apr %q: %w", params.Apr, err)
+			continue
+		}
+
+		supply := namada.NamadaTokenSupplyResponse{}
+		if err = getIndexerJson(ctx, httpClient, fmt.Sprintf("%s/api/v1/chain/token-supply?address=%s", indexer, url.QueryEscape(params.NativeTokenAddress)), &supply); err != nil {
+			lastErr = err
+			continue
+		}
+		// the supply is in unam like the bonded tokens
+		totalSupply, err = strconv.ParseFloat(supply.TotalSupply, 64)
+		if err != nil || totalSupply == 0 {
+			lastErr = fmt.Errorf("invalid total supply %q", supply.TotalSupply)
+			continue
+		}
+
+		return totalSupply, 0, apr * d.ChainConfig.totalBondedTokens / totalSupply, nil
+	}
+	return 0, 0, 0, lastErr
+}
+
+// getIndexerJson decodes the JSON response from a Namada indexer into v.
+func getIndexerJson(ctx context.Context, httpClient *http.Client, reqURL string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %d", reqURL, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
 }
@@ -0,0 +1,128 @@
+package tenderduty
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// renderSamples build an example message and its severity for the alert types RenderAlert knows, the validator is
+// named by its address since the moniker isn't known offline.
+var renderSamples = map[string]func(c *Config, cc *ChainConfig) (message, severity string){
+	"ChainStalled": func(c *Config, cc *ChainConfig) (string, string) {
+		return fmt.Sprintf("stalled: have not seen a new block on %s in %d minutes", cc.ChainId, intVal(cc.Alerts.Stalled)), "critical"
+	},
+	"NoRPCEndpoints": func(c *Config, cc *ChainConfig) (string, string) {
+		return fmt.Sprintf("no RPC endpoints are working for %s", cc.ChainId), priorityOrCritical(cc.Alerts.NoServersPriority)
+	},
+	"ConsecutiveBlocksMissed": func(c *Config, cc *ChainConfig) (string, string) {
+		return fmt.Sprintf("%s has missed %d blocks on %s", cc.ValAddress, intVal(cc.Alerts.ConsecutiveMissed), cc.ChainId), cc.Alerts.ConsecutivePriority
+	},
+	"PercentageBlocksMissed": func(c *Config, cc *ChainConfig) (string, string) {
+		percent, severity := 10, cc.Alerts.PercentagePriority
+		if len(cc.Alerts.Window) > 0 {
+			percent = cc.Alerts.Window[0].Percent
+			if cc.Alerts.Window[0].Severity != "" {
+				severity = cc.Alerts.Window[0].Severity
+			}
+		}
+		return fmt.Sprintf("%s has missed > %d%% of the slashing window's blocks on %s", cc.ValAddress, percent, cc.ChainId), severity
+	},
+	"ValidatorInactive": func(c *Config, cc *ChainConfig) (string, string) {
+		return fmt.Sprintf("%s is no longer active: validator %s is jailed for chainid %s", cc.ValAddress, cc.ValAddress, cc.ChainId), "critical"
+	},
+	"RPCNodeDown": func(c *Config, cc *ChainConfig) (string, string) {
+		node := "http://127.0.0.1:26657"
+		if len(cc.Nodes) > 0 {
+			node = cc.Nodes[0].Url
+		}
+		severity := priorityOrCritical(c.NodeDownSeverity)
+		return fmt.Sprintf("Severity: %s\nRPC node %s has been down for > %d minutes on %s", severity, node, c.NodeDownMin, cc.ChainId), severity
+	},
+}
+
+// RenderAlert prints an example alert of alertType on chain, firing and resolved, as each of the chain's enabled
+// destinations would receive it. Nothing is sent, and webhooks and API keys are not shown.
+func RenderAlert(configFiles []string, chainConfigDirectory string, password *string, chain, alertType string, w io.Writer) error {
+	c, err := readConfig(configFiles, chainConfigDirectory, password)
+	if err != nil {
+		return err
+	}
+	return renderAlert(c, chain, alertType, w)
+}
+
+func renderAlert(c *Config, chain, alertType string, w io.Writer) error {
+	cc, ok := c.Chains[chain]
+	if !ok {
+		return fmt.Errorf("chain %s is not in the config", chain)
+	}
+	sample, ok := renderSamples[alertType]
+	if !ok {
+		types := make([]string, 0, len(renderSamples))
+		for t := range renderSamples {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+		return fmt.Errorf("unknown alert type %s, valid choices are %s", alertType, strings.Join(types, ", "))
+	}
+	applyAlertDefaults(&cc.Alerts, &c.DefaultAlertConfig)
+
+	message, severity := sample(c, cc)
+	id := alertType + "_" + cc.ValAddress
+	rendered := 0
+	for _, resolved := range []bool{false, true} {
+		msg := c.newAlertMsg(chain, message, severity, resolved, id, 5*time.Minute)
+		state := "alert"
+		if resolved {
+			state = "resolved"
+		}
+		for _, dest := range []notifyDest{pd, tg, di, slk, sns, exe} {
+			out, enabled, err := renderDest(msg, dest)
+			if err != nil {
+				return err
+			}
+			if !enabled {
+				continue
+			}
+			rendered++
+			if _, err = fmt.Fprintf(w, "=== %s (%s) ===\n%s\n\n", dest, state, out); err != nil {
+				return err
+			}
+		}
+	}
+	if rendered == 0 {
+		_, err := fmt.Fprintf(w, "no destinations are enabled for %s\n", chain)
+		return err
+	}
+	return nil
+}
+
+// renderDest returns what dest would be sent for msg, and whether it is enabled for the chain.
+func renderDest(msg *alertMsg, dest notifyDest) (out string, enabled bool, err error) {
+	var v any
+	switch dest {
+	case pd:
+		event := buildPagerdutyEvent(msg)
+		event.RoutingKey = redacted
+		v, enabled = event, msg.pd
+	case tg:
+		return buildTgMessage(msg), msg.tg, nil
+	case di:
+		v, enabled = buildDiscordMessage(msg), msg.disc
+	case slk:
+		v, enabled = buildSlackMessage(msg), msg.slk
+		if msg.slkBlocks {
+			v = buildSlackBlockMessage(msg)
+		}
+	case sns:
+		input := buildSNSPublishInput(msg)
+		return fmt.Sprintf("Subject: %s\n\n%s", *input.Subject, *input.Message), msg.sns, nil
+	case exe:
+		return strings.Join(append([]string{msg.exeCommand}, msg.exeArgs...), " ") + "\n" + strings.Join(execEnv(msg), "\n"), msg.exe, nil
+	}
+	b, err := json.MarshalIndent(v, "", "  ")
+	return string(b), enabled, err
+}
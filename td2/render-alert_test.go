@@ -0,0 +1,107 @@
+package tenderduty
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const renderTestConfig = `
+default_alert_config:
+  consecutive_missed: 5
+  consecutive_priority: critical
+  pagerduty:
+    enabled: yes
+    api_key: pd-secret
+  discord:
+    enabled: yes
+    webhook: https://discord.com/api/webhooks/secret
+  telegram:
+    enabled: yes
+    api_key: tg-secret
+    channel: "-123"
+  slack:
+    enabled: yes
+    webhook: https://hooks.slack.com/services/secret
+  sns:
+    enabled: yes
+    topic_arn: arn:aws:sns:us-east-1:123456789012:alerts
+  exec:
+    enabled: yes
+    command: /usr/local/bin/notify
+chains:
+  "Chain A":
+    chain_id: chain-a-1
+    valoper_address: valoper1a
+  "Chain B":
+    chain_id: chain-b-1
+    valoper_address: valoper1b
+    alerts:
+      pagerduty:
+        enabled: no
+      discord:
+        enabled: no
+      telegram:
+        enabled: no
+      slack:
+        enabled: no
+      sns:
+        enabled: no
+      exec:
+        enabled: no
+`
+
+func TestRenderAlert(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config.yml")
+	if err := os.WriteFile(configFile, []byte(renderTestConfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+	password := ""
+	render := func(chain, alertType string) (string, error) {
+		var out bytes.Buffer
+		err := RenderAlert([]string{configFile}, filepath.Join(dir, "chains.d"), &password, chain, alertType, &out)
+		return out.String(), err
+	}
+
+	out, err := render("Chain A", "ConsecutiveBlocksMissed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sections := strings.Split(strings.TrimSpace(out), "=== ")[1:]
+	rendered := make(map[string]string)
+	for _, section := range sections {
+		header, body, _ := strings.Cut(section, " ===\n")
+		rendered[header] = strings.TrimSpace(body)
+	}
+	for _, dest := range []notifyDest{pd, tg, di, slk, sns, exe} {
+		for _, state := range []string{"alert", "resolved"} {
+			header := dest.String() + " (" + state + ")"
+			body, ok := rendered[header]
+			if !ok || body == "" {
+				t.Errorf("expected a rendered %s message", header)
+				continue
+			}
+			if state == "alert" && !strings.Contains(body, "valoper1a has missed 5 blocks on chain-a-1") {
+				t.Errorf("expected the %s message to contain the alert, got %s", header, body)
+			}
+		}
+	}
+	for _, secret := range []string{"pd-secret", "tg-secret", "webhooks/secret", "services/secret"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("expected %s to be left out of the output", secret)
+		}
+	}
+
+	if out, err = render("Chain B", "ChainStalled"); err != nil || !strings.Contains(out, "no destinations are enabled") {
+		t.Errorf("expected no destinations to be rendered, got %q, %v", out, err)
+	}
+	if _, err = render("Chain C", "ChainStalled"); err == nil {
+		t.Error("expected an error for an unknown chain")
+	}
+	if _, err = render("Chain A", "Unknown"); err == nil {
+		t.Error("expected an error for an unknown alert type")
+	}
+}
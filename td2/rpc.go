@@ -2,7 +2,6 @@ package tenderduty
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,7 +12,6 @@ import (
 	"time"
 
 	dash "github.com/firstset/tenderduty/v2/td2/dashboard"
-	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
 )
 
 // newRpc sets up the rpc client used for monitoring. It will try nodes in order until a working node is found.
@@ -27,7 +25,7 @@ func (cc *ChainConfig) newRpc() error {
 	}
 
 	// grab the first working endpoint
-	tryUrl := func(u string) (msg string, down, syncing bool) {
+	tryUrl := func(u string, node *NodeConfig) (msg string, down, syncing bool, network string) {
 		_, err := url.Parse(u)
 		if err != nil {
 			msg = fmt.Sprintf("❌ could not parse url %s: (%s) %s", cc.name, u, err)
@@ -35,18 +33,17 @@ func (cc *ChainConfig) newRpc() error {
 			down = true
 			return
 		}
-		cc.client, err = rpchttp.New(u, "/websocket")
+		cc.client, err = sharedRPCClient(u, cc.servedChainId(node), node)
 		if err != nil {
 			msg = fmt.Sprintf("❌ could not connect client for %s: (%s) %s", cc.name, u, err)
 			l(msg)
 			down = true
 			return
 		}
-		var network string
 		var catching_up bool
 		status, err := cc.client.Status(ctx)
 		if err != nil {
-			n, c, err := getStatusWithEndpoint(ctx, u)
+			n, c, v, err := getStatusWithEndpoint(ctx, u, node)
 			if err != nil {
 				msg = fmt.Sprintf("❌ could not get status for %s: (%s) %s", cc.name, u, err)
 				down = true
@@ -54,8 +51,10 @@ func (cc *ChainConfig) newRpc() error {
 				return
 			}
 			network, catching_up = n, c
+			cc.setDetectedComet(v)
 		} else {
 			network, catching_up = status.NodeInfo.Network, status.SyncInfo.CatchingUp
+			cc.setDetectedComet(status.NodeInfo.Version)
 		}
 		if network != cc.ChainId {
 			msg = fmt.Sprintf("chain id %s on %s does not match, expected %s, skipping", network, u, cc.ChainId)
@@ -74,17 +73,18 @@ func (cc *ChainConfig) newRpc() error {
 		return
 	}
 	down := func(endpoint *NodeConfig, msg string) {
-		if !endpoint.down {
-			endpoint.down = true
-			endpoint.downSince = time.Now()
-		}
+		endpoint.markFailed(intVal(td.NodeDownGraceChecks))
 		endpoint.lastMsg = msg
 	}
 	for _, endpoint := range cc.Nodes {
 		if anyWorking && endpoint.down {
 			continue
 		}
-		if msg, failed, syncing := tryUrl(endpoint.Url); failed {
+		msg, failed, syncing, network := tryUrl(endpoint.Url, endpoint)
+		if network != "" {
+			endpoint.setNetwork(network)
+		}
+		if failed {
 			endpoint.syncing = syncing
 			down(endpoint, msg)
 			continue
@@ -94,9 +94,9 @@ func (cc *ChainConfig) newRpc() error {
 	if cc.PublicFallback {
 		if u, ok := getRegistryUrl(cc.ChainId); ok {
 			node := guessPublicEndpoint(u)
-			l(cc.ChainId, "⛑ attemtping to use public fallback node", node)
-			if _, kk, _ := tryUrl(node); !kk {
-				l(cc.ChainId, "⛑ connected to public endpoint", node)
+			lChain(cc.ChainId, "⛑ attemtping to use public fallback node", node)
+			if _, kk, _, _ := tryUrl(node, nil); !kk {
+				lChain(cc.ChainId, "⛑ connected to public endpoint", node)
 				return nil
 			}
 		} else {
@@ -107,7 +107,7 @@ func (cc *ChainConfig) newRpc() error {
 	alarms.clearAll(cc.name)
 	cc.lastError = "no usable RPC endpoints available for " + cc.ChainId
 	if td.EnableDash {
-		td.updateChan <- &dash.ChainStatus{
+		cc.sendDashUpdate(&dash.ChainStatus{
 			MsgType:                 "status",
 			Name:                    cc.name,
 			ChainId:                 cc.ChainId,
@@ -121,6 +121,7 @@ func (cc *ChainConfig) newRpc() error {
 			Nodes:                   len(cc.Nodes),
 			HealthyNodes:            0,
 			ActiveAlerts:            1,
+			ActiveSeverity:          priorityOrCritical(cc.Alerts.NoServersPriority),
 			Height:                  0,
 			LastError:               cc.lastError,
 			Blocks:                  cc.blocksResults,
@@ -139,7 +140,7 @@ func (cc *ChainConfig) newRpc() error {
 			CryptoPrice:             cc.cryptoPrice,
 			DenomMetadata:           cc.denomMetadata,
 			Projected30DRewards:     cc.valInfo.Projected30DRewards,
-		}
+		})
 	}
 	return errors.New("no usable endpoints available for " + cc.ChainId)
 }
@@ -161,23 +162,19 @@ func (cc *ChainConfig) monitorHealth(ctx context.Context, chainName string) {
 				go func(node *NodeConfig) {
 					alert := func(msg string) {
 						node.lastMsg = fmt.Sprintf("%-12s node %s is %s", chainName, node.Url, msg)
-						if !node.AlertIfDown {
-							// even if we aren't alerting, we want to display the status in the dashboard.
-							node.down = true
+						// even if we aren't alerting, we want to display the status in the dashboard.
+						if !node.markFailed(intVal(td.NodeDownGraceChecks)) || !node.AlertIfDown {
 							return
 						}
-						if !node.down {
-							node.down = true
-							node.downSince = time.Now()
-						}
 						if td.Prom {
-							td.statsChan <- cc.mkUpdate(metricNodeDownSeconds, time.Since(node.downSince).Seconds(), node.Url)
+							td.sendStat(cc.mkUpdate(metricNodeDownSeconds, time.Since(node.downSince).Seconds(), node.Url))
 						}
-						l("⚠️ " + node.lastMsg)
+						lWarn("⚠️ " + node.lastMsg)
 					}
-					c, e := rpchttp.New(node.Url, "/websocket")
+					c, e := sharedRPCClient(node.Url, cc.servedChainId(node), node)
 					if e != nil {
 						alert(e.Error())
+						return
 					}
 					cwt, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 					status, e := c.Status(cwt)
@@ -186,23 +183,39 @@ func (cc *ChainConfig) monitorHealth(ctx context.Context, chainName string) {
 						alert("down")
 						return
 					}
+					node.setNetwork(status.NodeInfo.Network)
 					if status.NodeInfo.Network != cc.ChainId {
 						alert("on the wrong network")
 						return
 					}
+					node.setCatchingUp(status.SyncInfo.CatchingUp)
 					if status.SyncInfo.CatchingUp {
 						alert("not synced")
 						node.syncing = true
 						return
 					}
 
+					// the websocket lag alarm uses this to tell a broken websocket apart from a stalled chain
+					cc.setPolledHeight(status.SyncInfo.LatestBlockHeight)
+
+					// peer count for the low peers alarm, a failure here isn't treated as the node being down
+					cwt, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+					netInfo, e := c.NetInfo(cwt)
+					cancel()
+					if e != nil {
+						lWarn(fmt.Sprintf("⚠️ %-12s could not get net_info from %s: %s", chainName, node.Url, e))
+					} else {
+						node.setPeers(netInfo.NPeers)
+					}
+
 					// node's OK, clear the note
 					if node.down {
 						node.lastMsg = ""
 						node.wasDown = true
 					}
-					td.statsChan <- cc.mkUpdate(metricNodeDownSeconds, 0, node.Url)
+					td.sendStat(cc.mkUpdate(metricNodeDownSeconds, 0, node.Url))
 					node.down = false
+					node.failures = 0
 					node.syncing = false
 					node.downSince = time.Unix(0, 0)
 					cc.noNodes = false
@@ -216,6 +229,8 @@ func (cc *ChainConfig) monitorHealth(ctx context.Context, chainName string) {
 					l("💥", cc.ChainId, e)
 				}
 			}
+			// the node checks above run in the background, so this sees the heights from the previous refresh
+			cc.checkHeightProgress()
 			if cc.valInfo != nil {
 				cc.lastValInfo = &ValInfo{
 					Moniker:               cc.valInfo.Moniker,
@@ -250,11 +265,12 @@ func (c *Config) pingHealthcheck() {
 
 	go func() {
 		for range ticker.C {
-			_, err := http.Get(c.Healthcheck.PingURL)
+			resp, err := newHTTPClient(30 * time.Second).Get(c.Healthcheck.PingURL)
 			if err != nil {
-				l(fmt.Sprintf("❌ Failed to ping healthcheck URL: %s", err.Error()))
+				lWarn(fmt.Sprintf("❌ Failed to ping healthcheck URL: %s", err.Error()))
 			} else {
-				l(fmt.Sprintf("🏓 Successfully pinged healthcheck URL: %s", c.Healthcheck.PingURL))
+				_ = resp.Body.Close()
+				lDebug(fmt.Sprintf("🏓 Successfully pinged healthcheck URL: %s", c.Healthcheck.PingURL))
 			}
 		}
 	}()
@@ -267,7 +283,7 @@ var endpointRex = regexp.MustCompile(`//([^/:]+)(:\d+)?`)
 // The cosmos.directory requires them. This is a workaround to get the actual URL for the server behind their proxy.
 // The RPC base URL will return links endpoints, and we can parse this to guess the original URL.
 func guessPublicEndpoint(u string) string {
-	resp, err := http.Get(u + "/")
+	resp, err := newHTTPClient(10 * time.Second).Get(u + "/")
 	if err != nil {
 		return u
 	}
@@ -291,11 +307,11 @@ func guessPublicEndpoint(u string) string {
 	return proto + matches[1] + port
 }
 
-func getStatusWithEndpoint(ctx context.Context, u string) (string, bool, error) {
+func getStatusWithEndpoint(ctx context.Context, u string, node *NodeConfig) (network string, catchingUp bool, version string, err error) {
 	// Parse the URL
 	parsedURL, err := url.Parse(u)
 	if err != nil {
-		return "", false, err
+		return "", false, "", err
 	}
 
 	// Check if the scheme is 'tcp' and modify to 'http'
@@ -306,23 +322,18 @@ func getStatusWithEndpoint(ctx context.Context, u string) (string, bool, error)
 	queryPath := fmt.Sprintf("%s/status", parsedURL.String())
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, queryPath, nil)
 	if err != nil {
-		return "", false, err
+		return "", false, "", err
 	}
 
-	tr := &http.Transport{
-		//#nosec G402 -- configurable option
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: td.TLSSkipVerify},
-	}
-	client := &http.Client{Transport: tr}
-	resp, err := client.Do(req)
+	resp, err := newNodeHTTPClient(node, 0).Do(req)
 	if err != nil {
-		return "", false, err
+		return "", false, "", err
 	}
 	defer resp.Body.Close()
 
 	b, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", false, err
+		return "", false, "", err
 	}
 
 	type tendermintStatus struct {
@@ -331,6 +342,7 @@ func getStatusWithEndpoint(ctx context.Context, u string) (string, bool, error)
 		Result  struct {
 			NodeInfo struct {
 				Network string `json:"network"`
+				Version string `json:"version"`
 			} `json:"node_info"`
 			SyncInfo struct {
 				CatchingUp bool `json:"catching_up"`
@@ -338,8 +350,8 @@ func getStatusWithEndpoint(ctx context.Context, u string) (string, bool, error)
 		} `json:"result"`
 	}
 	var status tendermintStatus
-	if err := json.Unmarshal(b, &status); err != nil {
-		return "", false, err
+	if err = json.Unmarshal(b, &status); err != nil {
+		return "", false, "", err
 	}
-	return status.Result.NodeInfo.Network, status.Result.SyncInfo.CatchingUp, nil
+	return status.Result.NodeInfo.Network, status.Result.SyncInfo.CatchingUp, status.Result.NodeInfo.Version, nil
 }
@@ -0,0 +1,49 @@
+package tenderduty
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewRpcRecordsWrongChainId(t *testing.T) {
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result": map[string]any{
+				"node_info": map[string]any{"network": "other-chain-1"},
+				"sync_info": map[string]any{"catching_up": false},
+			},
+		})
+	}))
+	defer server.Close()
+
+	node := &NodeConfig{Url: server.URL, AlertIfDown: true}
+	cc := &ChainConfig{
+		name:       "test-chain",
+		ChainId:    "test-chain-1",
+		ValAddress: "testval123",
+		valInfo:    &ValInfo{Moniker: "test-validator"},
+		Nodes:      []*NodeConfig{node},
+	}
+
+	if err := cc.newRpc(); err == nil {
+		t.Fatal("expected no usable endpoints")
+	}
+	if !node.down {
+		t.Error("expected the node on the wrong network to be marked down")
+	}
+	if network := node.getNetwork(); network != "other-chain-1" {
+		t.Errorf("expected the reported chain-id to be recorded, got %q", network)
+	}
+}
@@ -14,9 +14,9 @@ import (
 
 var td = &Config{}
 
-func Run(configFile, stateFile, chainConfigDirectory string, password *string, devMode bool) error {
+func Run(configFiles []string, stateFile, chainConfigDirectory string, password *string, devMode bool) error {
 	var err error
-	td, err = loadConfig(configFile, stateFile, chainConfigDirectory, password)
+	td, err = loadConfig(configFiles, stateFile, chainConfigDirectory, password)
 	if err != nil {
 		return err
 	}
@@ -32,35 +32,36 @@ func Run(configFile, stateFile, chainConfigDirectory string, password *string, d
 	defer td.cancel()
 
 	go func() {
+		heartbeat := time.NewTicker(alertWorkerHeartbeat)
+		defer heartbeat.Stop()
+		markAlertWorker()
 		for {
 			select {
 			case alert := <-td.alertChan:
-				go func(msg *alertMsg) {
-					var e error
-					e = notifyPagerduty(msg)
-					if e != nil {
-						l(msg.chain, "error sending alert to pagerduty", e.Error())
-					}
-					e = notifyDiscord(msg)
-					if e != nil {
-						l(msg.chain, "error sending alert to discord", e.Error())
-					}
-					e = notifyTg(msg)
-					if e != nil {
-						l(msg.chain, "error sending alert to telegram", e.Error())
-					}
-					e = notifySlack(msg)
-					if e != nil {
-						l(msg.chain, "error sending alert to slack", e.Error())
-					}
-				}(alert)
+				markAlertWorker()
+				go sendNotifications(alert, intVal(td.NotifyMaxRetries))
+			case <-heartbeat.C:
+				markAlertWorker()
 			case <-td.ctx.Done():
 				return
 			}
 		}
 	}()
 
+	// only does anything for chains with a pagerduty api_token
+	go syncPagerdutyAcks(td.ctx)
+	// only does anything for telegram bots with inline_buttons
+	listenTgCallbacks(td.ctx)
+	// only does anything when the prices could not be fetched at startup
+	go td.watchPriceConversion()
+	// only does anything when summary_report is enabled
+	go td.watchSummaryReport()
+	go td.muteOnSignal()
+	// only does anything when audit_log_path is set
+	go td.audit.flushEvery(td.ctx, auditFlushInterval)
+
 	if td.EnableDash {
+		registerApi()
 		go dash.Serve(td.Listen, td.updateChan, td.logChan, td.HideLogs, devMode)
 		l("starting dashboard on", td.Listen)
 	} else {
@@ -70,6 +71,10 @@ func Run(configFile, stateFile, chainConfigDirectory string, password *string, d
 			}
 		}()
 	}
+	// a headless deployment without a prometheus port still gets the health probes, on listen_port
+	if !td.EnableDash && !td.Prom && td.Listen != "" {
+		go serveProbes(td.Listen)
+	}
 	if td.Prom {
 		go prometheusExporter(td.ctx, td.statsChan)
 	} else {
@@ -103,7 +108,7 @@ func Run(configFile, stateFile, chainConfigDirectory string, password *string, d
 			for {
 				e := cc.newRpc()
 				if e != nil {
-					l(cc.ChainId, e)
+					lChain(cc.ChainId, e)
 					time.Sleep(5 * time.Second)
 					continue
 				}
@@ -118,7 +123,7 @@ func Run(configFile, stateFile, chainConfigDirectory string, password *string, d
 					l("🛑", cc.ChainId, e)
 				}
 				cc.WsRun()
-				l(cc.ChainId, "🌀 websocket exited! Restarting monitoring")
+				lChain(cc.ChainId, "🌀 websocket exited! Restarting monitoring")
 				time.Sleep(5 * time.Second)
 			}
 		}(cc, k)
@@ -141,6 +146,9 @@ func saveOnExit(stateFile string, saved chan any) {
 	saveState := func() {
 		defer close(saved)
 		log.Println("saving state...")
+		if e := td.audit.flush(); e != nil {
+			log.Println(e)
+		}
 		//#nosec -- variable specified on command line
 		f, e := os.OpenFile(stateFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
 		if e != nil {
@@ -156,6 +164,10 @@ func saveOnExit(stateFile string, saved chan any) {
 				blocks[k] = v.blocksResults
 			}
 		}
+		stats := make(map[string]chainStats)
+		for k, v := range td.Chains {
+			stats[k] = v.stats()
+		}
 		nodesDown := make(map[string]map[string]time.Time)
 		for k, v := range td.Chains {
 			for _, node := range v.Nodes {
@@ -171,6 +183,7 @@ func saveOnExit(stateFile string, saved chan any) {
 			Alarms:    alarms,
 			Blocks:    blocks,
 			NodesDown: nodesDown,
+			Stats:     stats,
 		})
 		if e != nil {
 			log.Println(e)
@@ -0,0 +1,141 @@
+package tenderduty
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-yaml/yaml"
+)
+
+// schemaDraft is the JSON Schema version PrintSchema writes.
+const schemaDraft = "https://json-schema.org/draft-07/schema#"
+
+var (
+	durationType     = reflect.TypeOf(time.Duration(0))
+	windowLadderType = reflect.TypeOf(WindowLadder{})
+	alertConfigType  = reflect.TypeOf(AlertConfig{})
+)
+
+// PrintSchema writes a JSON Schema of the config file, built from the yaml tags of Config and the structs under it, so
+// editors can validate and complete a config.yml. The defaults are the values set in defaultConfig, the example
+// config, with default_alert_config also used for each chain's alerts. The notifier settings get no defaults.
+func PrintSchema(defaultConfig []byte, w io.Writer) error {
+	var defaults Config
+	if err := yaml.Unmarshal(defaultConfig, &defaults); err != nil {
+		return err
+	}
+	// the example's secret is a placeholder, not a default
+	defaults.CoinMarketCapAPIToken = ""
+	b := &schemaBuilder{alertDefaults: reflect.ValueOf(defaults.DefaultAlertConfig)}
+	schema := b.build(reflect.TypeOf(Config{}), reflect.ValueOf(defaults))
+	schema["$schema"] = schemaDraft
+	schema["title"] = "tenderduty config"
+
+	out, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(out, '\n'))
+	return err
+}
+
+type schemaBuilder struct {
+	alertDefaults reflect.Value
+}
+
+// build returns the schema of t, def holds its default value and is invalid when there is none.
+func (b *schemaBuilder) build(t reflect.Type, def reflect.Value) map[string]any {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		if def.IsValid() {
+			if def.IsNil() {
+				def = reflect.Value{}
+			} else {
+				def = def.Elem()
+			}
+		}
+	}
+	// a chain's alerts fall back to default_alert_config
+	if t == alertConfigType && !def.IsValid() {
+		def = b.alertDefaults
+	}
+
+	switch t {
+	case durationType:
+		// yaml accepts a duration string such as 1m, or nanoseconds
+		schema := map[string]any{"type": []string{"string", "integer"}}
+		if def.IsValid() && !def.IsZero() {
+			schema["default"] = time.Duration(def.Int()).String()
+		}
+		return schema
+	case windowLadderType:
+		// see WindowLadder.UnmarshalYAML
+		return map[string]any{"oneOf": []any{
+			map[string]any{"type": "integer"},
+			b.build(reflect.TypeOf([]WindowThreshold{}), reflect.Value{}),
+		}}
+	}
+
+	schema := make(map[string]any)
+	switch t.Kind() {
+	case reflect.Struct:
+		properties := make(map[string]any)
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name := yamlName(f)
+			if name == "" {
+				continue
+			}
+			var fieldDef reflect.Value
+			// the example's notifier settings are placeholders, such as the webhooks, rather than defaults
+			notifier := t == alertConfigType && f.Type.Kind() == reflect.Struct
+			if def.IsValid() && !notifier {
+				fieldDef = def.Field(i)
+			}
+			properties[name] = b.build(f.Type, fieldDef)
+		}
+		schema["type"] = "object"
+		schema["properties"] = properties
+		// unknown keys are ignored when the config is read, flag them since they are likely typos
+		schema["additionalProperties"] = false
+		return schema
+	case reflect.Map:
+		schema["type"] = "object"
+		if t.Elem().Kind() != reflect.Interface {
+			schema["additionalProperties"] = b.build(t.Elem(), reflect.Value{})
+		}
+		return schema
+	case reflect.Slice, reflect.Array:
+		schema["type"] = "array"
+		schema["items"] = b.build(t.Elem(), reflect.Value{})
+		return schema
+	case reflect.Bool:
+		schema["type"] = "boolean"
+	case reflect.String:
+		schema["type"] = "string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		schema["type"] = "integer"
+	case reflect.Float32, reflect.Float64:
+		schema["type"] = "number"
+	}
+	if def.IsValid() && !def.IsZero() {
+		schema["default"] = def.Interface()
+	}
+	return schema
+}
+
+// yamlName returns the key of a config field, empty for fields that aren't read from the config.
+func yamlName(f reflect.StructField) string {
+	if !f.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
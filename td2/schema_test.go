@@ -0,0 +1,86 @@
+package tenderduty
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+const schemaTestConfig = `
+node_down_alert_minutes: 3
+coin_market_cap_api_token: xxxxxx
+healthcheck:
+  ping_rate: 1m
+default_alert_config:
+  consecutive_missed: 5
+  discord:
+    enabled: yes
+    webhook: https://discord.com/api/webhooks/secret
+`
+
+func TestPrintSchema(t *testing.T) {
+	var out bytes.Buffer
+	if err := PrintSchema([]byte(schemaTestConfig), &out); err != nil {
+		t.Fatal(err)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(out.Bytes(), &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+
+	// property walks the properties of the nested objects, chains descends into the schema of each chain
+	property := func(path ...string) map[string]any {
+		t.Helper()
+		node := schema
+		for _, p := range path {
+			if p == "*" {
+				node, _ = node["additionalProperties"].(map[string]any)
+			} else {
+				properties, _ := node["properties"].(map[string]any)
+				node, _ = properties[p].(map[string]any)
+			}
+			if node == nil {
+				t.Fatalf("schema has no %v", path)
+			}
+		}
+		return node
+	}
+
+	tests := []struct {
+		name        string
+		path        []string
+		wantType    any
+		wantDefault any
+	}{
+		{"top level int", []string{"node_down_alert_minutes"}, "integer", float64(3)},
+		{"top level bool", []string{"enable_dashboard"}, "boolean", nil},
+		{"placeholder secret", []string{"coin_market_cap_api_token"}, "string", nil},
+		{"duration", []string{"healthcheck", "ping_rate"}, []any{"string", "integer"}, "1m0s"},
+		{"default alert", []string{"default_alert_config", "consecutive_missed"}, "integer", float64(5)},
+		{"chain alert uses the default alert config", []string{"chains", "*", "alerts", "consecutive_missed"}, "integer", float64(5)},
+		{"notifier placeholder", []string{"default_alert_config", "discord", "webhook"}, "string", nil},
+		{"float", []string{"chains", "*", "alerts", "min_gas_balance"}, "number", nil},
+		{"list", []string{"chains", "*", "nodes"}, "array", nil},
+		{"struct", []string{"chains", "*", "provider"}, "object", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := property(tt.path...)
+			gotType, _ := json.Marshal(p["type"])
+			wantType, _ := json.Marshal(tt.wantType)
+			if !bytes.Equal(gotType, wantType) {
+				t.Errorf("type = %s, want %s", gotType, wantType)
+			}
+			if p["default"] != tt.wantDefault {
+				t.Errorf("default = %v, want %v", p["default"], tt.wantDefault)
+			}
+		})
+	}
+
+	if property("chains", "*")["additionalProperties"] != false {
+		t.Error("unknown chain keys should not be allowed")
+	}
+	if _, ok := property("chains", "*", "alerts", "percentage_missed")["oneOf"]; !ok {
+		t.Error("percentage_missed should accept a percentage or a list of thresholds")
+	}
+}
@@ -0,0 +1,91 @@
+package tenderduty
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// severityAliases maps the priority names accepted in the config to the three severities tenderduty uses, so the
+// severity thresholds and quiet hours can compare them.
+var severityAliases = map[string]string{
+	"critical": "critical",
+	"crit":     "critical",
+	"high":     "critical",
+	"error":    "critical",
+	"warning":  "warning",
+	"warn":     "warning",
+	"medium":   "warning",
+	"info":     "info",
+	"low":      "info",
+}
+
+// normalizeSeverity returns the tenderduty severity for a configured priority, false when it is unknown. An empty
+// priority is kept, the alerts fall back to their own default for it.
+func normalizeSeverity(priority string) (string, bool) {
+	if priority == "" {
+		return "", true
+	}
+	severity, ok := severityAliases[strings.ToLower(strings.TrimSpace(priority))]
+	return severity, ok
+}
+
+// alertSeverities returns the configurable priorities of an alert config by their yaml key.
+func alertSeverities(a *AlertConfig) map[string]*string {
+	severities := map[string]*string{
+		"stalled_priority":           &a.StalledPriority,
+		"consecutive_priority":       &a.ConsecutivePriority,
+		"percentage_priority":        &a.PercentagePriority,
+		"consecutive_empty_priority": &a.ConsecutiveEmptyPriority,
+		"empty_percentage_priority":  &a.EmptyPercentagePriority,
+		"no_servers_priority":        &a.NoServersPriority,
+	}
+	for i := range a.Window {
+		severities[fmt.Sprintf("percentage_missed[%d].severity", i)] = &a.Window[i].Severity
+	}
+	return severities
+}
+
+// normalizeSeverities rewrites the priorities of an alert config to tenderduty's severities, and reports the ones that
+// are unknown. where names the config section in the problems.
+func normalizeSeverities(a *AlertConfig, where string) (problems []string) {
+	severities := alertSeverities(a)
+	keys := make([]string, 0, len(severities))
+	for key := range severities {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		priority := severities[key]
+		severity, ok := normalizeSeverity(*priority)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("error: %s in %s must be critical, warning or info, got %s", key, where, *priority))
+			continue
+		}
+		*priority = severity
+	}
+	return problems
+}
+
+// severityRank orders the severities from info to critical, unknown and empty severities rank lowest.
+func severityRank(severity string) int {
+	switch severity {
+	case "critical":
+		return 3
+	case "warning":
+		return 2
+	case "info":
+		return 1
+	}
+	return 0
+}
+
+// pagerdutySeverity maps a tenderduty severity to one the PagerDuty events API accepts, which rejects events with any
+// other value. An unset priority is sent as critical.
+func pagerdutySeverity(severity string) string {
+	switch severity {
+	case "critical", "warning", "info":
+		return severity
+	}
+	return "critical"
+}
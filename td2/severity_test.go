@@ -0,0 +1,66 @@
+package tenderduty
+
+import (
+	"testing"
+)
+
+func TestNormalizeSeverity(t *testing.T) {
+	tests := []struct {
+		priority string
+		expected string
+		ok       bool
+	}{
+		{"critical", "critical", true},
+		{"High", "critical", true},
+		{"error", "critical", true},
+		{" warn ", "warning", true},
+		{"medium", "warning", true},
+		{"low", "info", true},
+		{"", "", true},
+		{"urgent", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.priority, func(t *testing.T) {
+			got, ok := normalizeSeverity(tt.priority)
+			if got != tt.expected || ok != tt.ok {
+				t.Errorf("normalizeSeverity(%q) = %q, %v, want %q, %v", tt.priority, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestNormalizeSeverities(t *testing.T) {
+	alerts := AlertConfig{
+		StalledPriority:     "HIGH",
+		ConsecutivePriority: "urgent",
+		PercentagePriority:  "warn",
+		Window:              WindowLadder{{Percent: 5, Severity: "low"}, {Percent: 10}},
+	}
+	problems := normalizeSeverities(&alerts, "test-chain")
+	if len(problems) != 1 || problems[0] != "error: consecutive_priority in test-chain must be critical, warning or info, got urgent" {
+		t.Errorf("expected only consecutive_priority to be reported, got %v", problems)
+	}
+	if alerts.StalledPriority != "critical" || alerts.PercentagePriority != "warning" {
+		t.Errorf("expected the aliases to be normalized, got %q and %q", alerts.StalledPriority, alerts.PercentagePriority)
+	}
+	if alerts.Window[0].Severity != "info" || alerts.Window[1].Severity != "" {
+		t.Errorf("expected the thresholds to be normalized, got %+v", alerts.Window)
+	}
+	if alerts.NoServersPriority != "" {
+		t.Errorf("an unset priority should stay unset, got %q", alerts.NoServersPriority)
+	}
+}
+
+func TestPagerdutySeverity(t *testing.T) {
+	tests := map[string]string{
+		"critical": "critical",
+		"warning":  "warning",
+		"info":     "info",
+		"":         "critical",
+	}
+	for severity, expected := range tests {
+		if got := pagerdutySeverity(severity); got != expected {
+			t.Errorf("pagerdutySeverity(%q) = %q, want %q", severity, got, expected)
+		}
+	}
+}
@@ -47,9 +47,16 @@ export class TableRenderer {
     }
 
     if (status.active_alerts > 0) {
-      statusClass = "status-indicator-yellow";
+      // color by the worst active alert: red for critical, orange for warning
+      if (status.active_severity === "critical") {
+        statusClass = "status-indicator-red";
+      } else if (status.active_severity === "warning") {
+        statusClass = "status-indicator-orange";
+      } else {
+        statusClass = "status-indicator-yellow";
+      }
       statusText = `${_.escape(status.active_alerts)} active issues`;
-      // Make yellow indicator clickable only if there's an error modal to show
+      // Make the indicator clickable only if there's an error modal to show
       if (status.last_error !== "") {
         toggleAttribute = `uk-toggle="target: #${modalId}"`;
       }
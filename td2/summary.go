@@ -0,0 +1,133 @@
+package tenderduty
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	dash "github.com/firstset/tenderduty/v2/td2/dashboard"
+	"github.com/firstset/tenderduty/v2/td2/utils"
+)
+
+// defaultSummaryInterval is how many hours apart summary reports are sent when interval_hours is not set
+const defaultSummaryInterval = 24
+
+const summaryPrefix = "📊 Summary: "
+
+// summaryDestinations are the notifiers a summary can be sent to, pagerduty is left out since it would open an incident.
+var summaryDestinations = []string{"discord", "telegram", "slack", "sns", "exec"}
+
+func summaryDestination(name string) bool {
+	return slices.Contains(summaryDestinations, name)
+}
+
+// watchSummaryReport sends every chain's summary to the configured destinations each interval.
+func (c *Config) watchSummaryReport() {
+	if !c.SummaryReport.Enabled {
+		return
+	}
+	ticker := time.NewTicker(time.Duration(c.SummaryReport.IntervalHours) * time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.sendSummaries()
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *Config) sendSummaries() {
+	c.chainsMux.RLock()
+	msgs := make([]*alertMsg, 0, len(c.Chains))
+	for name, cc := range c.Chains {
+		if cc.valInfo == nil {
+			lDebug("not sending a summary for", name, "the validator has not been queried yet")
+			continue
+		}
+		healthyNodes := 0
+		for _, node := range cc.Nodes {
+			if !node.down {
+				healthyNodes++
+			}
+		}
+		msg := c.newAlertMsg(name, buildSummary(cc.dashStatus(cc.lastBlockNum, healthyNodes, "")), "info", false, "SummaryReport_"+cc.ValAddress, 0)
+		msg.report = true
+		msgs = append(msgs, msg)
+	}
+	c.chainsMux.RUnlock()
+
+	for _, msg := range msgs {
+		for _, n := range notifiers {
+			if !slices.Contains(c.SummaryReport.Destinations, n.name) || !summaryDestination(n.name) || !summaryEnabled(msg, n.name) {
+				continue
+			}
+			// summaries are not alarms, so they skip the de-duplication in notify and are not retried
+			if err := countNotification(n.name, msg, n.send(msg)); err != nil {
+				lChainError(msg.chain, "error sending summary to "+n.name, err.Error())
+			}
+		}
+	}
+}
+
+// summaryEnabled reports whether the destination is enabled for the chain the summary is for.
+func summaryEnabled(msg *alertMsg, name string) bool {
+	switch name {
+	case "discord":
+		return msg.disc
+	case "telegram":
+		return msg.tg
+	case "slack":
+		return msg.slk
+	case "sns":
+		return msg.sns
+	case "exec":
+		return msg.exe
+	}
+	return false
+}
+
+// buildSummary is the body of a chain's summary report, built from the status the dashboard is sent.
+func buildSummary(status *dash.ChainStatus) string {
+	lines := make([]string, 0, 7)
+	if status.Moniker != "" {
+		lines = append(lines, "validator: "+status.Moniker)
+	}
+
+	state := "active"
+	switch {
+	case status.Tombstoned:
+		state = "tombstoned"
+	case status.Jailed:
+		state = "jailed"
+	case !status.Bonded:
+		state = "inactive"
+	}
+	lines = append(lines, "status: "+state, fmt.Sprintf("height: %d", status.Height))
+
+	if status.Window > 0 {
+		lines = append(lines, fmt.Sprintf("missed blocks: %d of %d in the window (%.2f%%)", status.Missed, status.Window, 100*float64(status.Missed)/float64(status.Window)))
+	} else {
+		lines = append(lines, "missed blocks: unknown")
+	}
+	lines = append(lines, fmt.Sprintf("active alerts: %d", status.ActiveAlerts))
+
+	if status.ValidatorAPR > 0 {
+		lines = append(lines, fmt.Sprintf("validator APR: %.2f%%", 100*status.ValidatorAPR))
+	}
+	if status.DelegatedTokens > 0 {
+		delegated := fmt.Sprintf("delegated tokens: %.0f", status.DelegatedTokens)
+		if status.DenomMetadata != nil {
+			if value, unit, err := utils.ConvertFloatInBaseUnitToDisplayUnit(status.DelegatedTokens, *status.DenomMetadata); err == nil {
+				delegated = fmt.Sprintf("delegated tokens: %.2f %s", value, unit)
+			}
+		}
+		lines = append(lines, delegated)
+	}
+	if status.Nodes > 0 {
+		lines = append(lines, fmt.Sprintf("healthy nodes: %d of %d", status.HealthyNodes, status.Nodes))
+	}
+	return strings.Join(lines, "\n")
+}
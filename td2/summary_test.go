@@ -0,0 +1,122 @@
+package tenderduty
+
+import (
+	"strings"
+	"testing"
+
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+	dash "github.com/firstset/tenderduty/v2/td2/dashboard"
+)
+
+func TestBuildSummary(t *testing.T) {
+	metadata := &bank.Metadata{
+		Base:    "uosmo",
+		Display: "osmo",
+		DenomUnits: []*bank.DenomUnit{
+			{Denom: "uosmo", Exponent: 0},
+			{Denom: "osmo", Exponent: 6},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		status           *dash.ChainStatus
+		expectedLines    []string
+		unexpectedPrefix []string
+	}{
+		{
+			name: "should include the chain's numbers in display units",
+			status: &dash.ChainStatus{
+				Moniker:         "validator-1",
+				Bonded:          true,
+				Height:          123456,
+				Missed:          25,
+				Window:          10000,
+				ActiveAlerts:    2,
+				ValidatorAPR:    0.1234,
+				DelegatedTokens: 1500000000,
+				DenomMetadata:   metadata,
+				Nodes:           3,
+				HealthyNodes:    2,
+			},
+			expectedLines: []string{
+				"validator: validator-1",
+				"status: active",
+				"height: 123456",
+				"missed blocks: 25 of 10000 in the window (0.25%)",
+				"active alerts: 2",
+				"validator APR: 12.34%",
+				"delegated tokens: 1500.00 osmo",
+				"healthy nodes: 2 of 3",
+			},
+		},
+		{
+			name: "should fall back to base units without denom metadata",
+			status: &dash.ChainStatus{
+				Bonded:          true,
+				Height:          10,
+				Window:          100,
+				DelegatedTokens: 1500000,
+			},
+			expectedLines:    []string{"delegated tokens: 1500000"},
+			unexpectedPrefix: []string{"validator:", "validator APR:", "healthy nodes:"},
+		},
+		{
+			name:          "should show a jailed validator without a signing window",
+			status:        &dash.ChainStatus{Jailed: true, Height: 10},
+			expectedLines: []string{"status: jailed", "missed blocks: unknown", "active alerts: 0"},
+		},
+		{
+			name:          "should show a tombstoned validator over jailed",
+			status:        &dash.ChainStatus{Jailed: true, Tombstoned: true},
+			expectedLines: []string{"status: tombstoned"},
+		},
+		{
+			name:          "should show an unbonded validator as inactive",
+			status:        &dash.ChainStatus{},
+			expectedLines: []string{"status: inactive"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := strings.Split(buildSummary(tt.status), "\n")
+			for _, expected := range tt.expectedLines {
+				found := false
+				for _, line := range lines {
+					if line == expected {
+						found = true
+						break
+					}
+				}
+				if !found {
+					t.Errorf("expected line %q in the summary, got %q", expected, lines)
+				}
+			}
+			for _, prefix := range tt.unexpectedPrefix {
+				for _, line := range lines {
+					if strings.HasPrefix(line, prefix) {
+						t.Errorf("expected no %q line in the summary, got %q", prefix, line)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSummaryMessages(t *testing.T) {
+	msg := &alertMsg{chain: "test-chain (test-1)", message: "height: 10", severity: "info", report: true}
+
+	if text := buildTgMessage(msg); !strings.Contains(text, summaryPrefix) || strings.Contains(text, "ALERT") {
+		t.Errorf("expected a summary telegram message, got %s", text)
+	}
+	if discord := buildDiscordMessage(msg); !strings.HasPrefix(discord.Content, summaryPrefix) {
+		t.Errorf("expected a summary discord message, got %s", discord.Content)
+	}
+	if slack := buildSlackMessage(msg); !strings.Contains(slack.Attachments[0].Title, summaryPrefix) || slack.Text != "height: 10" {
+		t.Errorf("expected a summary slack message, got %+v", slack)
+	}
+	if input := buildSNSPublishInput(msg); !strings.HasPrefix(*input.Subject, "TenderDuty SUMMARY") {
+		t.Errorf("expected a summary sns subject, got %s", *input.Subject)
+	}
+}
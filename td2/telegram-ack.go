@@ -0,0 +1,153 @@
+package tenderduty
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// tgSnoozeDuration is how long the Snooze button snoozes an alarm for.
+const tgSnoozeDuration = time.Hour
+
+const (
+	tgAckAction    = "ack"
+	tgSnoozeAction = "snooze"
+)
+
+// tgAlarmRef identifies an alarm in callback data, which Telegram limits to 64 bytes, too short for a chain name and
+// unique ID. The alarm is looked up among the active ones instead.
+func tgAlarmRef(chain string, alertID string) string {
+	sum := sha256.Sum256([]byte(chain + "/" + alertID))
+	return hex.EncodeToString(sum[:8])
+}
+
+// tgCallbackData is the data sent back when a button is pressed, the action and the alarm reference.
+func tgCallbackData(action, chain, alertID string) string {
+	return action + ":" + tgAlarmRef(chain, alertID)
+}
+
+// buildTgKeyboard returns the Ack and Snooze buttons for a critical alert, nil when the message gets none.
+func buildTgKeyboard(msg *alertMsg) *tgbotapi.InlineKeyboardMarkup {
+	if !msg.tgButtons || msg.resolved || msg.report || msg.oneShot || msg.severity != "critical" {
+		return nil
+	}
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("Ack", tgCallbackData(tgAckAction, msg.chainName, msg.uniqueId)),
+		tgbotapi.NewInlineKeyboardButtonData("Snooze 1h", tgCallbackData(tgSnoozeAction, msg.chainName, msg.uniqueId)),
+	))
+	return &keyboard
+}
+
+// findByRef returns the active alarm a callback refers to.
+func (a *alarmCache) findByRef(ref string) (chain string, alertID string, found bool) {
+	a.notifyMux.RLock()
+	defer a.notifyMux.RUnlock()
+	for chain, active := range a.AllAlarms {
+		for id := range active {
+			if tgAlarmRef(chain, id) == ref {
+				return chain, id, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// acknowledgeInTg marks an active alarm as acknowledged, the same as an acknowledged PagerDuty incident, until it
+// resolves.
+func (a *alarmCache) acknowledgeInTg(chain string, alertID string) error {
+	a.notifyMux.Lock()
+	defer a.notifyMux.Unlock()
+	if _, ok := a.AllAlarms[chain][alertID]; !ok {
+		return fmt.Errorf("no active alarm %s on %s", alertID, chain)
+	}
+	if a.tgAcknowledged == nil {
+		a.tgAcknowledged = make(map[string]map[string]bool)
+	}
+	if a.tgAcknowledged[chain] == nil {
+		a.tgAcknowledged[chain] = make(map[string]bool)
+	}
+	a.tgAcknowledged[chain][alertID] = true
+	return nil
+}
+
+// handleTgCallback acknowledges or snoozes the alarm a button was pressed for, and returns the text shown to the user
+// who pressed it.
+func handleTgCallback(data string, from string) string {
+	action, ref, ok := strings.Cut(data, ":")
+	if !ok {
+		return "unknown button"
+	}
+	chain, alertID, found := alarms.findByRef(ref)
+	if !found {
+		return "the alarm is no longer active"
+	}
+	switch action {
+	case tgAckAction:
+		if err := alarms.acknowledgeInTg(chain, alertID); err != nil {
+			return "the alarm is no longer active"
+		}
+		l(fmt.Sprintf("👀 Acknowledged alarm on %s (%s) in Telegram by %s - no more reminders until it resolves", chain, alertID, from))
+		return "acknowledged"
+	case tgSnoozeAction:
+		until := time.Now().Add(tgSnoozeDuration)
+		if err := alarms.snooze(chain, alertID, until); err != nil {
+			return "the alarm is no longer active"
+		}
+		l(fmt.Sprintf("💤 Snoozed alarm on %s (%s) in Telegram by %s until %s", chain, alertID, from, until.UTC().Format(time.RFC3339)))
+		return "snoozed until " + until.UTC().Format("15:04 UTC")
+	}
+	return "unknown button"
+}
+
+// listenTgCallbacks receives the button presses for every bot that sends inline buttons. The updates are long polled,
+// so the bot can't have a webhook set or be polled by another program.
+func listenTgCallbacks(ctx context.Context) {
+	tokens := make(map[string]bool)
+	td.chainsMux.RLock()
+	for _, cc := range td.Chains {
+		if boolVal(td.DefaultAlertConfig.Telegram.Enabled) && boolVal(cc.Alerts.Telegram.Enabled) && boolVal(cc.Alerts.Telegram.InlineButtons) && cc.Alerts.Telegram.ApiKey != "" {
+			tokens[cc.Alerts.Telegram.ApiKey] = true
+		}
+	}
+	td.chainsMux.RUnlock()
+
+	for token := range tokens {
+		go listenTgBot(ctx, token)
+	}
+}
+
+func listenTgBot(ctx context.Context, token string) {
+	bot, err := getTgBot(token)
+	if err != nil {
+		lError("could not receive Telegram button presses:", err)
+		return
+	}
+	u := tgbotapi.NewUpdate(0)
+	u.Timeout = 60
+	u.AllowedUpdates = []string{"callback_query"}
+	updates := bot.GetUpdatesChan(u)
+	defer bot.StopReceivingUpdates()
+	for {
+		select {
+		case update := <-updates:
+			if update.CallbackQuery == nil {
+				continue
+			}
+			from := "unknown user"
+			if update.CallbackQuery.From != nil {
+				from = update.CallbackQuery.From.String()
+			}
+			reply := handleTgCallback(update.CallbackQuery.Data, from)
+			if _, err := bot.Request(tgbotapi.NewCallback(update.CallbackQuery.ID, reply)); err != nil {
+				lWarn("telegram callback answer:", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
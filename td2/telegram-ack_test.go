@@ -0,0 +1,110 @@
+package tenderduty
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHandleTgCallback(t *testing.T) {
+	proposal := "UnvotedGovernanceProposal_testval123_7"
+	stalled := "ChainStalled_testval123"
+
+	originalAlarms := alarms
+	defer func() { alarms = originalAlarms }()
+
+	tests := []struct {
+		name            string
+		data            string
+		expectedReply   string
+		expectedAcked   bool
+		expectedSnoozed bool
+	}{
+		{
+			name:          "should acknowledge the alarm",
+			data:          tgCallbackData(tgAckAction, "test-chain", proposal),
+			expectedReply: "acknowledged",
+			expectedAcked: true,
+		},
+		{
+			name:            "should snooze the alarm",
+			data:            tgCallbackData(tgSnoozeAction, "test-chain", proposal),
+			expectedReply:   "snoozed until",
+			expectedSnoozed: true,
+		},
+		{
+			name:          "should not act on an alarm that resolved",
+			data:          tgCallbackData(tgAckAction, "test-chain", "ChainStalled_other"),
+			expectedReply: "the alarm is no longer active",
+		},
+		{
+			name:          "should not act on an unknown action",
+			data:          tgCallbackData("delete", "test-chain", proposal),
+			expectedReply: "unknown button",
+		},
+		{
+			name:          "should not act on malformed data",
+			data:          "garbage",
+			expectedReply: "unknown button",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alarms = &alarmCache{
+				AllAlarms: map[string]map[string]alertMsgCache{"test-chain": {
+					proposal: {Message: "test alert", SentTime: time.Now()},
+					stalled:  {Message: "test alert", SentTime: time.Now()},
+				}},
+				notifyMux: sync.RWMutex{},
+			}
+
+			if reply := handleTgCallback(tt.data, "operator"); !strings.HasPrefix(reply, tt.expectedReply) {
+				t.Errorf("expected reply %q, got %q", tt.expectedReply, reply)
+			}
+			if acked := alarms.isAcknowledged("test-chain", proposal); acked != tt.expectedAcked {
+				t.Errorf("expected acknowledged %v, got %v", tt.expectedAcked, acked)
+			}
+			if snoozed := alarms.isSnoozed("test-chain", proposal); snoozed != tt.expectedSnoozed {
+				t.Errorf("expected snoozed %v, got %v", tt.expectedSnoozed, snoozed)
+			}
+			// only the alarm the button belongs to is changed
+			if alarms.isAcknowledged("test-chain", stalled) || alarms.isSnoozed("test-chain", stalled) {
+				t.Error("expected the other alarm to be left alone")
+			}
+		})
+	}
+}
+
+func TestBuildTgKeyboard(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      *alertMsg
+		expected bool
+	}{
+		{name: "should add buttons to a critical alert", msg: &alertMsg{tgButtons: true, severity: "critical"}, expected: true},
+		{name: "should not add buttons to a warning", msg: &alertMsg{tgButtons: true, severity: "warning"}},
+		{name: "should not add buttons to a resolve", msg: &alertMsg{tgButtons: true, severity: "critical", resolved: true}},
+		{name: "should not add buttons when disabled", msg: &alertMsg{severity: "critical"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.msg.chainName, tt.msg.uniqueId = "a-chain-with-a-rather-long-name", "UnvotedGovernanceProposal_cosmosvaloper1qwertyuiopasdfghjklzxcvbnm0123456789ab_123"
+			keyboard := buildTgKeyboard(tt.msg)
+			if (keyboard != nil) != tt.expected {
+				t.Fatalf("expected buttons %v, got %+v", tt.expected, keyboard)
+			}
+			if keyboard == nil {
+				return
+			}
+			for _, button := range keyboard.InlineKeyboard[0] {
+				// telegram rejects callback data over 64 bytes
+				if button.CallbackData == nil || len(*button.CallbackData) > 64 {
+					t.Errorf("expected callback data of at most 64 bytes on %s, got %v", button.Text, button.CallbackData)
+				}
+			}
+		})
+	}
+}
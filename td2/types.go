@@ -2,16 +2,19 @@ package tenderduty
 
 import (
 	"context"
+	"crypto/x509"
 	_ "embed"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"slices"
@@ -24,6 +27,7 @@ import (
 	gov "github.com/cosmos/cosmos-sdk/x/gov/types"
 	slashing "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
+	upgrade "github.com/cosmos/cosmos-sdk/x/upgrade/types"
 	dash "github.com/firstset/tenderduty/v2/td2/dashboard"
 	utils "github.com/firstset/tenderduty/v2/td2/utils"
 	"github.com/go-yaml/yaml"
@@ -31,8 +35,11 @@ import (
 )
 
 const (
-	showBLocks = 512
-	staleHours = 24
+	// showBLocks is the default block history size, BlockHistorySize is kept between minBlockHistory and maxBlockHistory
+	showBLocks      = 512
+	minBlockHistory = 50
+	maxBlockHistory = 10000
+	staleHours      = 24
 )
 
 func SeverityThresholdToSeverities(threhold string) []string {
@@ -115,8 +122,14 @@ type Config struct {
 	cancel              context.CancelFunc
 	alarms              *alarmCache
 	coinMarketCapClient *utils.CoinMarketCapClient
+	priceConversionDown bool                   // price conversion was turned off because the prices failed at startup
+	logLevel            logLevel               // parsed from LogLevel by validateConfig
 	tenderdutyCache     *utils.TenderdutyCache // used for caching different kinds of data in memory, such as bank metadata quried from our GitHub repo
 
+	// mutedUntil holds back new alerts on all chains, set through the API or SIGUSR1
+	muteMux    sync.RWMutex
+	mutedUntil time.Time
+
 	// EnableDash enables the web dashboard
 	EnableDash bool `yaml:"enable_dashboard"`
 	// Listen is the URL for the dashboard to listen on, must be a valid/parsable URL
@@ -124,15 +137,71 @@ type Config struct {
 	// HideLogs controls whether logs are sent to the dashboard. It will also suppress many alarm details.
 	// This is useful if the dashboard will be public.
 	HideLogs bool `yaml:"hide_logs"`
+	// APIToken is the bearer token the API's write routes require, they are disabled without one.
+	APIToken string `yaml:"api_token"`
+	// LogFormat is either "text", the default, or "json" for one JSON object per line.
+	LogFormat string `yaml:"log_format"`
+	// LogLevel is the lowest level that is logged: debug, info (the default), warn or error.
+	LogLevel string `yaml:"log_level"`
 
 	// NodeDownMin controls how long we wait before sending an alert that a node is not responding or has
 	// fallen behind.
 	NodeDownMin int `yaml:"node_down_alert_minutes"`
 	// NodeDownSeverity controls the Pagerduty severity when notifying if a node is down.
 	NodeDownSeverity string `yaml:"node_down_alert_severity"`
+	// NodeDownBatchThreshold sends one alert listing the down nodes instead of one per node when at least this many of a
+	// chain's nodes are down at once, 0 disables it.
+	NodeDownBatchThreshold int `yaml:"node_down_batch_threshold"`
+	// NodeDownGraceChecks is how many checks in a row a node has to fail before it is considered down and the
+	// node_down_alert_minutes countdown starts, 1 by default.
+	NodeDownGraceChecks *int `yaml:"node_down_grace_checks"`
+
+	// BlockHistorySize is how many recent blocks are kept for each chain and shown on the dashboard, 512 by default.
+	BlockHistorySize int `yaml:"block_history_size"`
+	// DashboardUpdateSeconds sends each chain's status to the dashboard at most this often, 0 sends every update.
+	DashboardUpdateSeconds int `yaml:"dashboard_update_seconds"`
+	// AlertHistorySize is how many alert events are kept in memory for each chain and served by the history API, 0
+	// disables the history.
+	AlertHistorySize int `yaml:"alert_history_size"`
+	history          alertHistoryStore
+	// AuditLogPath appends every alert that fires or resolves to this file as a JSON line, independent of the
+	// notification destinations. AuditLogMaxMB is the size it is rotated at, 100 by default.
+	AuditLogPath  string `yaml:"audit_log_path"`
+	AuditLogMaxMB int    `yaml:"audit_log_max_mb"`
+	audit         *auditLog
+	// ResetStatsOnRestart starts the signed, proposed and missed block counters from zero on every start, instead of
+	// continuing from the state file.
+	ResetStatsOnRestart bool `yaml:"reset_stats_on_restart"`
+
+	// NotifyMaxRetries is how many times a failed notification is retried before it is dropped, 3 by default.
+	NotifyMaxRetries *int `yaml:"notify_max_retries"`
+	// ResolveCooldownMinutes holds back a resolve sent within this many minutes of the previous resolve of the same
+	// alarm, until the cooldown ends. 5 by default, 0 disables it.
+	ResolveCooldownMinutes *int `yaml:"resolve_cooldown_minutes"`
+
+	// ValidatorSetCacheMinutes is how long a chain's validator set is reused for the rank alert before it is queried
+	// again, 10 by default.
+	ValidatorSetCacheMinutes int `yaml:"validator_set_cache_minutes"`
+
+	// QuietHours holds back alerts below critical during a daily window, e.g. overnight.
+	QuietHours QuietHoursConfig `yaml:"quiet_hours"`
+	// DisplayTimezone is the IANA timezone times in alert messages are shown in, UTC if blank.
+	DisplayTimezone string `yaml:"display_timezone"`
 
 	// whether skip the TLS verification
 	TLSSkipVerify bool `yaml:"tls_skip_verify"`
+	// CACertFile is a PEM bundle of extra CAs trusted for RPC and API endpoints, on top of the system ones.
+	CACertFile string `yaml:"ca_cert_file"`
+	rootCAs    *x509.CertPool
+	// transport is shared by all outgoing HTTP requests, it is built once the TLS settings are loaded.
+	transport *http.Transport
+	// ProxyURL sends outgoing HTTP requests through this proxy, HTTP_PROXY/HTTPS_PROXY are used when empty.
+	ProxyURL string `yaml:"proxy_url"`
+	// DialNetwork forces tcp4 or tcp6 for outgoing connections, the default tcp uses either.
+	DialNetwork string `yaml:"dial_network"`
+	// DNSServer is an optional host:port resolver used instead of the system one.
+	DNSServer string `yaml:"dns_server"`
+	proxyURL  *url.URL
 
 	// Prom controls if the prometheus exporter is enabled.
 	Prom bool `yaml:"prometheus_enabled"`
@@ -142,8 +211,13 @@ type Config struct {
 	// DefaultAlertConfig defines the default alert settings which can be
 	// overridden on a per chain basis in the `alerts` section.
 	DefaultAlertConfig AlertConfig `yaml:"default_alert_config"`
+	// Runbooks maps an alert type, e.g. ChainStalled, to a URL that is linked from its Slack, Discord and PagerDuty
+	// notifications.
+	Runbooks map[string]string `yaml:"runbooks"`
 	// Healthcheck information
 	Healthcheck HealthcheckConfig `yaml:"healthcheck"`
+	// SummaryReport periodically sends a digest of every chain's status, even when nothing is wrong.
+	SummaryReport SummaryReportConfig `yaml:"summary_report"`
 
 	// When GovernanceAlerts is true, GovernanceAlertsReminderInterval defines how often to remind the user about unvoted proposals, every 6 hours by default
 	GovernanceAlertsReminderInterval int `yaml:"governance_alerts_reminder_interval"`
@@ -162,6 +236,49 @@ type savedState struct {
 	Alarms    *alarmCache                     `json:"alarms"`
 	Blocks    map[string][]int                `json:"blocks"`
 	NodesDown map[string]map[string]time.Time `json:"nodes_down"`
+	Stats     map[string]chainStats           `json:"stats"`
+}
+
+// chainStats are the cumulative block counters of a chain, kept across restarts unless reset_stats_on_restart is set.
+// The consecutive counters always start over.
+type chainStats struct {
+	TotalSigns      float64 `json:"total_signs"`
+	TotalProps      float64 `json:"total_props"`
+	TotalPropsEmpty float64 `json:"total_props_empty"`
+	TotalMiss       float64 `json:"total_miss"`
+	PrevoteMiss     float64 `json:"prevote_miss"`
+	PrecommitMiss   float64 `json:"precommit_miss"`
+}
+
+// stats returns the counters to save in the state file.
+func (cc *ChainConfig) stats() chainStats {
+	return chainStats{
+		TotalSigns:      cc.statTotalSigns,
+		TotalProps:      cc.statTotalProps,
+		TotalPropsEmpty: cc.statTotalPropsEmpty,
+		TotalMiss:       cc.statTotalMiss,
+		PrevoteMiss:     cc.statPrevoteMiss,
+		PrecommitMiss:   cc.statPrecommitMiss,
+	}
+}
+
+// restoreStats loads the counters saved by the previous run, unless reset_stats_on_restart is set.
+func (c *Config) restoreStats(saved map[string]chainStats) {
+	if c.ResetStatsOnRestart {
+		return
+	}
+	for k, v := range saved {
+		cc := c.Chains[k]
+		if cc == nil {
+			continue
+		}
+		cc.statTotalSigns = v.TotalSigns
+		cc.statTotalProps = v.TotalProps
+		cc.statTotalPropsEmpty = v.TotalPropsEmpty
+		cc.statTotalMiss = v.TotalMiss
+		cc.statPrevoteMiss = v.PrevoteMiss
+		cc.statPrecommitMiss = v.PrecommitMiss
+	}
 }
 
 type ProviderConfig struct {
@@ -185,8 +302,18 @@ type ChainConfig struct {
 	baseAPR           float64            // the base APR of a chain
 	denomMetadata     *bank.Metadata     // chain denom metadata
 	cryptoPrice       *utils.CryptoPrice // coin price in a fiat currency
+	priceFailures     int                // price lookups that failed in a row, see countPriceFailure
+	rewardsFailures   int                // rewards and commission queries that failed in a row, see countRewardsFailure
+
+	commissionLast      float64   // the commission seen on the previous evaluation, see evaluateCommissionStaleAlert
+	commissionWithdrawn time.Time // when the commission was last seen to drop, or first seen
+
+	percentWindow int64 // the slashing window size on the previous percentage evaluation, see evaluatePercentageBlocksMissedAlert
 
 	minSignedPerWindow      float64 // instantly see the validator risk level
+	slashingMissing         bool    // the slashing queries were rejected, set by checkSlashingModule
+	govVersion              string  // the gov version the proposals are queried with, empty until probed, see queryOpenProposals
+	validatorMissing        bool    // the chain answered that it has no such validator, set by GetValInfo
 	blocksResults           []int
 	lastError               string
 	lastBlockTime           time.Time
@@ -194,6 +321,64 @@ type ChainConfig struct {
 	lastBlockNum            int64
 	activeAlerts            int
 	unvotedOpenGovProposals []gov.Proposal // the open proposals that the validator has not voted on
+	upgradeHeight           int64          // height of the scheduled software upgrade, 0 when none, see setUpgradePlan
+	upgradeName             string         // name of the scheduled software upgrade
+	watchStart              time.Time      // when watch() started, for the startup grace period
+	monikerAlerted          string         // the last moniker a change alert was sent for
+	consKeyAlerted          string         // the last consensus address a change alert was sent for, as hex
+	firstSignPending        bool           // the validator was seen outside the active set, waiting for its first signed block
+	paramsSeen              bool           // whether lastCommunityTax and lastInflationRate hold a previous refresh
+	lastCommunityTax        float64        // community tax at the previous check, for the chain param change alert
+	lastInflationRate       float64        // inflation rate at the previous check, for the chain param change alert
+
+	// the websocket lag fields are written by the websocket and the per-node health check goroutines, and read by
+	// watch(). Heights are compared rather than block times so that a node's clock skew can't affect the result.
+	wsLagMux     sync.RWMutex
+	wsHeight     int64     // newest final height delivered over the websocket
+	wsSeen       time.Time // local time wsHeight last advanced, seeded when the websocket connects
+	polledHeight int64     // highest height reported by a healthy node's /status
+	polledSeen   time.Time // local time polledHeight last advanced
+
+	// stuckHeight is the best known height at the last health check refresh, stuckRefreshes how many refreshes in a row
+	// it has not advanced. Also guarded by wsLagMux.
+	stuckHeight    int64
+	stuckRefreshes int
+
+	// recent prevote/precommit misses are counted by the websocket goroutine and read by watch()
+	consensusMissMux    sync.RWMutex
+	recentBlocks        int
+	recentPrevoteMiss   int
+	recentPrecommitMiss int
+	recentSignedStreak  int // how many of the newest blocks in a row were signed
+	recentUptimeSigned  int // signed blocks among the newest uptime_window_blocks
+	recentUptimeBlocks  int // blocks among the newest uptime_window_blocks with a known outcome
+	recentProposed      int // blocks in the history proposed by the validator
+	recentKnown         int // blocks in the history with a known outcome
+
+	// the block time average is updated by the websocket goroutine and read by watch()
+	blockTimeMux sync.RWMutex
+	blockTimeEMA float64 // exponential moving average of the seconds between finalized blocks, 0 until the second block
+
+	valInfoUpdated time.Time // when the validator info was last refreshed successfully, for tenderduty_chain_up
+
+	// the RPC dialect reported by the node's /status, written when connecting and read by the rpc queries
+	cometMux      sync.RWMutex
+	detectedComet cometVersion
+
+	// slash events seen by the websocket goroutine, waiting for watch() to alert on them
+	slashMux       sync.Mutex
+	pendingSlashes []*slashEvent
+
+	// dashboard updates held back by sendDashUpdate, at most one per dashboard_update_seconds is sent
+	dashMux     sync.Mutex
+	dashSent    *dash.ChainStatus
+	dashSentAt  time.Time
+	dashPending *dash.ChainStatus
+	dashTimer   *time.Timer
+
+	// paused is set through the API while a chain is down for maintenance, watch() skips its evaluations
+	pauseMux sync.RWMutex
+	paused   bool
 
 	statTotalSigns       float64
 	statTotalProps       float64
@@ -204,6 +389,10 @@ type ChainConfig struct {
 	statTotalPropsEmpty  float64
 	statConsecutiveEmpty float64
 
+	statConsecutivePrevoteMiss   int
+	statConsecutivePrecommitMiss int
+	statConsecutiveSigned        int
+
 	// ChainId is used to ensure any endpoints contacted claim to be on the correct chain. This is a weak verification,
 	// no light client validation is performed, so caution is advised when using public endpoints.
 	ChainId string `yaml:"chain_id"`
@@ -212,26 +401,309 @@ type ChainConfig struct {
 	ValAddress string `yaml:"valoper_address"`
 	// ValconsOverride allows skipping the lookup of the consensus public key and setting it directly.
 	ValconsOverride string `yaml:"valcons_override"`
+	// Bech32Prefix is the consensus address prefix (e.g. "cosmosvalcons"), only needed when it can't be derived from
+	// the valoper address.
+	Bech32Prefix string `yaml:"bech32_prefix"`
 	// ExtraInfo will be appended to the alert data. This is useful for pagerduty because multiple tenderduty instances
 	// can be pointed at pagerduty and duplicate alerts will be filtered by using a key. The first alert will win, this
 	// can be useful for knowing what tenderduty instance sent the alert.
-	ExtraInfo string `yaml:"extra_info"` // FIXME not used yet!
+	ExtraInfo string `yaml:"extra_info"`
 	// Alerts defines the types of alerts to send for this chain.
 	Alerts AlertConfig `yaml:"alerts"`
 	// PublicFallback determines if tenderduty should attempt to use public RPC endpoints in the situation that not
 	// explicitly defined RPC servers are available. Not recommended.
 	PublicFallback bool `yaml:"public_fallback"`
+	// FromRegistry looks the chain up by its name in the cosmos chain registry, filling in the chain-id and, if none
+	// are configured, public RPC nodes.
+	FromRegistry bool `yaml:"from_registry"`
 	// Nodes defines what RPC servers to connect to.
 	Nodes []*NodeConfig `yaml:"nodes"`
+	// DisplayTimezone overrides the global display_timezone for this chain's alerts.
+	DisplayTimezone string `yaml:"display_timezone"`
+	displayLocation *time.Location
+	// CometVersion selects how block results and validator sets are parsed: "0.34" for Tendermint, "0.37" or "0.38"
+	// for CometBFT. When empty it is detected from the node's /status.
+	CometVersion string `yaml:"comet_version"`
+	// NodesFile is a YAML file, or a glob matching several, with a list of nodes that are added to Nodes at load time.
+	NodesFile string `yaml:"nodes_file"`
 	// Provider defines what implementation should be used for checking a chain's status
 	// currently it supports two values: `default` or `namada`
 	Provider ProviderConfig `yaml:"provider"`
+	// DenomExponentOverride and DisplayDenomOverride are used instead of the chain's denom metadata, e.g. 6 and "atom"
+	// for uatom. Amounts stay in the base denom when the chain and the GitHub fallback don't know the metadata.
+	DenomExponentOverride *int   `yaml:"denom_exponent_override"`
+	DisplayDenomOverride  string `yaml:"display_denom_override"`
+	// EvalIntervalSeconds is how many seconds to wait between alert evaluations, default 2. Chains with long block
+	// times don't need to be checked as often.
+	EvalIntervalSeconds *int `yaml:"eval_interval_seconds"`
+	// NoSlashingModule skips the signing info and slashing params queries, and the alerts based on the signed
+	// blocks window, for chains without a slashing module such as sovereign rollups. It is detected when not set.
+	NoSlashingModule bool `yaml:"no_slashing_module"`
+	// IBCClients are the IBC light client IDs on this chain to watch for expiry, e.g. the clients a relayer updates
+	IBCClients []string `yaml:"ibc_clients"`
 	// The name/slug of this chain, used by CoinMarketCap API to convert the price
 	Slug string `yaml:"slug"`
+	// PriceCacheExpirationMinutes overrides convert_to_fiat.cache_expiration for this chain's price
+	PriceCacheExpirationMinutes int `yaml:"price_cache_expiration_minutes"`
 	// The inflation rate of the chain, if specified the value overrides the query result
 	InflationRateOverriding float64 `yaml:"inflationRate"`
 }
 
+// wsConnected starts the websocket lag timer, so a websocket that never delivers a block is still detected. It is
+// only seeded once, a reconnect that never delivers should not reset the timer.
+func (cc *ChainConfig) wsConnected() {
+	cc.wsLagMux.Lock()
+	defer cc.wsLagMux.Unlock()
+	if cc.wsSeen.IsZero() {
+		cc.wsSeen = time.Now()
+	}
+}
+
+// setWsHeight records a block delivered over the websocket.
+func (cc *ChainConfig) setWsHeight(height int64) {
+	cc.wsLagMux.Lock()
+	defer cc.wsLagMux.Unlock()
+	if height > cc.wsHeight {
+		cc.wsHeight = height
+		cc.wsSeen = time.Now()
+	}
+}
+
+// setPolledHeight records the latest height reported by a node, it never moves backwards if nodes disagree.
+func (cc *ChainConfig) setPolledHeight(height int64) {
+	cc.wsLagMux.Lock()
+	defer cc.wsLagMux.Unlock()
+	if height > cc.polledHeight {
+		cc.polledHeight = height
+		cc.polledSeen = time.Now()
+	}
+}
+
+// polledAdvancedSince reports if any node has seen a new height after t.
+func (cc *ChainConfig) polledAdvancedSince(t time.Time) bool {
+	cc.wsLagMux.RLock()
+	defer cc.wsLagMux.RUnlock()
+	return cc.polledSeen.After(t)
+}
+
+// wsLagging reports if the websocket hasn't delivered a block since before t while the nodes are ahead of it.
+func (cc *ChainConfig) wsLagging(t time.Time) bool {
+	cc.wsLagMux.RLock()
+	defer cc.wsLagMux.RUnlock()
+	return !cc.wsSeen.IsZero() && cc.wsSeen.Before(t) && cc.polledHeight > cc.wsHeight
+}
+
+// wsLastSeen returns when the websocket last delivered a block, or when it connected if none have arrived since.
+func (cc *ChainConfig) wsLastSeen() time.Time {
+	cc.wsLagMux.RLock()
+	defer cc.wsLagMux.RUnlock()
+	return cc.wsSeen
+}
+
+// checkHeightProgress is called on every health check refresh, it counts the refreshes in a row where neither the
+// websocket nor the nodes have reported a new height.
+func (cc *ChainConfig) checkHeightProgress() {
+	cc.wsLagMux.Lock()
+	defer cc.wsLagMux.Unlock()
+	height := cc.wsHeight
+	if cc.polledHeight > height {
+		height = cc.polledHeight
+	}
+	if height == 0 || height > cc.stuckHeight {
+		cc.stuckHeight = height
+		cc.stuckRefreshes = 0
+		return
+	}
+	cc.stuckRefreshes++
+}
+
+// heightStuck returns the last known height and how many refreshes it has been stuck for.
+func (cc *ChainConfig) heightStuck() (height int64, refreshes int) {
+	cc.wsLagMux.RLock()
+	defer cc.wsLagMux.RUnlock()
+	return cc.stuckHeight, cc.stuckRefreshes
+}
+
+// inStartupGrace reports if watch() started less than StartupGraceMinutes ago.
+func (cc *ChainConfig) inStartupGrace() bool {
+	return !cc.watchStart.IsZero() && time.Since(cc.watchStart) < time.Duration(intVal(cc.Alerts.StartupGraceMinutes))*time.Minute
+}
+
+// setConsensusMisses counts the blocks in the history that were missed even though the validator's prevote or
+// precommit was seen.
+func (cc *ChainConfig) setConsensusMisses(blocks []int) {
+	prevote, precommit, streak := 0, 0, 0
+	for streak < len(blocks) && StatusType(blocks[streak]) >= StatusSigned {
+		streak++
+	}
+	for _, status := range blocks {
+		switch StatusType(status) {
+		case StatusPrevote:
+			prevote++
+		case StatusPrecommit:
+			precommit++
+		}
+	}
+	window := intVal(cc.Alerts.UptimeWindowBlocks)
+	if window <= 0 || window > len(blocks) {
+		window = len(blocks)
+	}
+	proposed, observed := 0, 0
+	for _, status := range blocks {
+		if status < 0 {
+			continue
+		}
+		observed++
+		if StatusType(status) == StatusProposed || StatusType(status) == StatusProposedEmpty {
+			proposed++
+		}
+	}
+	signed, known := 0, 0
+	for _, status := range blocks[:window] {
+		// blocks from before tenderduty started are -1
+		if status < 0 {
+			continue
+		}
+		known++
+		if StatusType(status) >= StatusSigned {
+			signed++
+		}
+	}
+	cc.consensusMissMux.Lock()
+	defer cc.consensusMissMux.Unlock()
+	cc.recentBlocks, cc.recentPrevoteMiss, cc.recentPrecommitMiss = len(blocks), prevote, precommit
+	cc.recentSignedStreak = streak
+	cc.recentUptimeSigned, cc.recentUptimeBlocks = signed, known
+	cc.recentProposed, cc.recentKnown = proposed, observed
+}
+
+// proposals returns how many blocks in the history the validator proposed, how many have a known outcome, and the size
+// of the history, as counted by setConsensusMisses.
+func (cc *ChainConfig) proposals() (proposed int, known int, blocks int) {
+	cc.consensusMissMux.RLock()
+	defer cc.consensusMissMux.RUnlock()
+	return cc.recentProposed, cc.recentKnown, cc.recentBlocks
+}
+
+// uptime returns how many of the newest uptime_window_blocks were signed, how many of them have a known outcome, and
+// the size of the window, as counted by setConsensusMisses.
+func (cc *ChainConfig) uptime() (signed int, known int, window int) {
+	cc.consensusMissMux.RLock()
+	defer cc.consensusMissMux.RUnlock()
+	window = intVal(cc.Alerts.UptimeWindowBlocks)
+	if window <= 0 || window > cc.recentBlocks {
+		window = cc.recentBlocks
+	}
+	return cc.recentUptimeSigned, cc.recentUptimeBlocks, window
+}
+
+// signedStreak returns how many of the newest blocks in a row were signed, as counted by setConsensusMisses.
+func (cc *ChainConfig) signedStreak() int {
+	cc.consensusMissMux.RLock()
+	defer cc.consensusMissMux.RUnlock()
+	return cc.recentSignedStreak
+}
+
+// consensusMisses returns the counts from setConsensusMisses, and how many blocks they were counted over.
+func (cc *ChainConfig) consensusMisses() (prevote int, precommit int, blocks int) {
+	cc.consensusMissMux.RLock()
+	defer cc.consensusMissMux.RUnlock()
+	return cc.recentPrevoteMiss, cc.recentPrecommitMiss, cc.recentBlocks
+}
+
+// resolveDue reports if an alarm's condition has been clear for the chain's resolve_delay_seconds, so a briefly
+// recovering condition doesn't send an all-clear that is followed by a new alert.
+func (cc *ChainConfig) resolveDue(alertID string) bool {
+	return alarms.clearedFor(cc.name, alertID, time.Duration(intVal(cc.Alerts.ResolveDelaySeconds))*time.Second)
+}
+
+// blockTimeEMAAlpha is the weight of the newest interval in the block time average, about the last 20 blocks count
+const blockTimeEMAAlpha = 0.1
+
+// updateBlockTime adds the interval between two finalized blocks to the block time average.
+func (cc *ChainConfig) updateBlockTime(interval time.Duration) {
+	cc.blockTimeMux.Lock()
+	defer cc.blockTimeMux.Unlock()
+	if cc.blockTimeEMA == 0 {
+		cc.blockTimeEMA = interval.Seconds()
+		return
+	}
+	cc.blockTimeEMA = blockTimeEMAAlpha*interval.Seconds() + (1-blockTimeEMAAlpha)*cc.blockTimeEMA
+}
+
+// averageBlockTime returns the block time average in seconds, 0 if not enough blocks were seen yet.
+func (cc *ChainConfig) averageBlockTime() float64 {
+	cc.blockTimeMux.RLock()
+	defer cc.blockTimeMux.RUnlock()
+	return cc.blockTimeEMA
+}
+
+// recordSlash queues a slash event seen in a block until watch() alerts on it.
+func (cc *ChainConfig) recordSlash(slash *slashEvent) {
+	cc.slashMux.Lock()
+	defer cc.slashMux.Unlock()
+	cc.pendingSlashes = append(cc.pendingSlashes, slash)
+}
+
+// takeSlashes returns the queued slash events and empties the queue.
+func (cc *ChainConfig) takeSlashes() []*slashEvent {
+	cc.slashMux.Lock()
+	defer cc.slashMux.Unlock()
+	slashes := cc.pendingSlashes
+	cc.pendingSlashes = nil
+	return slashes
+}
+
+// recordVoteStreak tracks how many blocks in a row were missed with the validator's prevote or precommit seen, any
+// other status ends the streak.
+func (cc *ChainConfig) recordVoteStreak(status StatusType) {
+	if status == StatusPrevote {
+		cc.statConsecutivePrevoteMiss++
+	} else {
+		cc.statConsecutivePrevoteMiss = 0
+	}
+	if status == StatusPrecommit {
+		cc.statConsecutivePrecommitMiss++
+	} else {
+		cc.statConsecutivePrecommitMiss = 0
+	}
+}
+
+// strictResetSigned is how many blocks in a row have to be signed to reset the consecutive missed counter when
+// consecutive_reset_strict is set.
+const strictResetSigned = 2
+
+// countConsecutiveMiss updates the consecutive missed counter. Any signed or proposed block resets it, unless
+// ConsecutiveResetStrict is set, then only strictResetSigned signed blocks in a row do, so a validator that signs
+// an odd block in between misses keeps counting towards the alert.
+func (cc *ChainConfig) countConsecutiveMiss(status StatusType) {
+	switch status {
+	case Statusmissed, StatusPrevote, StatusPrecommit:
+		cc.statConsecutiveMiss += 1
+		cc.statConsecutiveSigned = 0
+	case StatusSigned, StatusProposed, StatusProposedEmpty:
+		cc.statConsecutiveSigned += 1
+		if !boolVal(cc.Alerts.ConsecutiveResetStrict) || cc.statConsecutiveSigned >= strictResetSigned {
+			cc.statConsecutiveMiss = 0
+		}
+	}
+}
+
+// setPaused reports whether the paused state changed.
+func (cc *ChainConfig) setPaused(paused bool) bool {
+	cc.pauseMux.Lock()
+	defer cc.pauseMux.Unlock()
+	changed := cc.paused != paused
+	cc.paused = paused
+	return changed
+}
+
+func (cc *ChainConfig) isPaused() bool {
+	cc.pauseMux.RLock()
+	defer cc.pauseMux.RUnlock()
+	return cc.paused
+}
+
 // mkUpdate returns the info needed by prometheus for a gauge.
 func (cc *ChainConfig) mkUpdate(t metricType, v float64, node string) *promUpdate {
 	return &promUpdate{
@@ -250,6 +722,68 @@ type AlertConfig struct {
 	Stalled *int `yaml:"stalled_minutes"`
 	// Whether to alert when no new blocks are seen
 	StalledAlerts *bool `yaml:"stalled_enabled"`
+	// Severity of the stalled chain alert, critical if unset
+	StalledPriority string `yaml:"stalled_priority"`
+
+	// How many minutes the websocket can go without a NewBlock event, while polling shows the chain advancing
+	WebsocketLag *int `yaml:"websocket_lag_minutes"`
+	// Whether to alert when the websocket stops delivering blocks but the chain is not stalled
+	WebsocketLagAlerts *bool `yaml:"websocket_lag_enabled"`
+
+	// MaxBlockTimeSeconds is the average block time in seconds above which block production is considered slow
+	MaxBlockTimeSeconds *float64 `yaml:"max_block_time_seconds"`
+	// Whether to alert when the average block time goes above MaxBlockTimeSeconds
+	BlockTimeSlowdownAlerts *bool `yaml:"block_time_slowdown_enabled"`
+	// HeightStuckChecks is how many health check refreshes, one a minute, the height can stay the same before alarming
+	HeightStuckChecks *int `yaml:"height_stuck_checks"`
+	// Whether to alert when the height does not advance for HeightStuckChecks refreshes
+	HeightStuckAlerts *bool `yaml:"height_stuck_enabled"`
+
+	// StartupGraceMinutes is how long after starting to hold back stall and missed-block alerts while connections are
+	// established
+	StartupGraceMinutes *int `yaml:"startup_grace_minutes"`
+	// ResolveDelaySeconds is how long an alarm's condition has to stay clear before it resolves
+	ResolveDelaySeconds *int `yaml:"resolve_delay_seconds"`
+
+	// MinPeers is the lowest peer count a node can report from net_info before alerting
+	MinPeers *int `yaml:"min_peers"`
+	// Whether to alert when a node's peer count drops below MinPeers
+	PeerAlerts *bool `yaml:"peer_alerts"`
+
+	// SyncingMaxMinutes is how long a node can report catching_up before alerting
+	SyncingMaxMinutes *int `yaml:"syncing_max_minutes"`
+	// Whether to alert when a node has been catching up for longer than SyncingMaxMinutes
+	NodeSyncingAlerts *bool `yaml:"node_syncing_alerts"`
+
+	// Whether to alert when a node reports a different chain-id than the one configured
+	WrongChainIdAlerts *bool `yaml:"wrong_chain_id_alerts"`
+
+	// PrevoteMissThreshold is how many of the recent blocks can be missed with the validator's prevote seen before alerting
+	PrevoteMissThreshold *int `yaml:"prevote_miss_threshold"`
+	// PrecommitMissThreshold is how many of the recent blocks can be missed with the validator's precommit seen before alerting
+	PrecommitMissThreshold *int `yaml:"precommit_miss_threshold"`
+	// Whether to alert when the validator takes part in consensus rounds but its signature is missing from the blocks
+	ConsensusParticipationAlerts *bool `yaml:"consensus_participation_enabled"`
+
+	// Whether to alert when the signed share of the newest UptimeWindowBlocks observed blocks drops below MinUptimePercent
+	UptimeSLAAlerts *bool `yaml:"uptime_sla_alerts"`
+	// MinUptimePercent is the signed percentage to stay above, e.g. 99.5
+	MinUptimePercent *float64 `yaml:"min_uptime_percent"`
+	// UptimeWindowBlocks is how many of the newest blocks the uptime is counted over, all the blocks in the dashboard's
+	// history by default
+	UptimeWindowBlocks *int `yaml:"uptime_window_blocks"`
+
+	// Whether to alert when the validator proposes far more or fewer of the observed blocks than its voting power predicts
+	ProposerAnomalyAlerts *bool `yaml:"proposer_anomaly_alerts"`
+	// ProposerAnomalyPercent is how far, in percent of the expected count, the proposed blocks can be off before alerting
+	ProposerAnomalyPercent *float64 `yaml:"proposer_anomaly_percent"`
+
+	// ConsecutivePrevoteMissed is how many blocks in a row can be missed with the validator's prevote seen before alerting
+	ConsecutivePrevoteMissed *int `yaml:"consecutive_prevote_missed"`
+	// ConsecutivePrecommitMissed is how many blocks in a row can be missed with the validator's precommit seen before alerting
+	ConsecutivePrecommitMissed *int `yaml:"consecutive_precommit_missed"`
+	// Whether to alert on streaks of blocks missed with the validator's prevote or precommit seen
+	ConsecutiveVoteMissAlerts *bool `yaml:"consecutive_vote_miss_enabled"`
 
 	// How many missed blocks are acceptable before alerting
 	ConsecutiveMissed *int `yaml:"consecutive_missed"`
@@ -257,13 +791,22 @@ type AlertConfig struct {
 	ConsecutivePriority string `yaml:"consecutive_priority"`
 	// Whether to alert on consecutive missed blocks
 	ConsecutiveAlerts *bool `yaml:"consecutive_enabled"`
+	// ConsecutiveResetStrict only resets the consecutive missed count after two signed blocks in a row, by default
+	// any signed block resets it.
+	ConsecutiveResetStrict *bool `yaml:"consecutive_reset_strict"`
 
-	// Window is how many blocks missed as a percentage of the slashing window to trigger an alert
-	Window *int `yaml:"percentage_missed"`
+	// Window is how many blocks missed as a percentage of the slashing window to trigger an alert, it can be a single
+	// percentage or a ladder of thresholds that each alert with their own severity.
+	Window WindowLadder `yaml:"percentage_missed"`
 	// PercentagePriority is a tag for pagerduty to route on priority
 	PercentagePriority string `yaml:"percentage_priority"`
 	// PercentageAlerts is whether to alert on percentage based misses
 	PercentageAlerts *bool `yaml:"percentage_enabled"`
+	// PercentageResolveOnRecovery resolves the percentage alert once the last PercentageRecoveryBlocks blocks were all
+	// signed, rather than waiting for the missed blocks to leave the slashing window.
+	PercentageResolveOnRecovery *bool `yaml:"percentage_resolve_on_recovery"`
+	// PercentageRecoveryBlocks is how many blocks in a row have to be signed to count as recovered, 100 if unset.
+	PercentageRecoveryBlocks *int `yaml:"percentage_recovery_blocks"`
 
 	// How many consecutive empty blocks are acceptable before alerting
 	ConsecutiveEmpty *int `yaml:"consecutive_empty"`
@@ -283,6 +826,8 @@ type AlertConfig struct {
 	AlertIfInactive *bool `yaml:"alert_if_inactive"`
 	// AlertIfNoServers: should an alert be sent if no servers are reachable?
 	AlertIfNoServers *bool `yaml:"alert_if_no_servers"`
+	// Severity of the no RPC endpoints alert, critical if unset
+	NoServersPriority string `yaml:"no_servers_priority"`
 
 	// Whether to alert on unvoted governance proposals
 	GovernanceAlerts *bool `yaml:"governance_alerts"`
@@ -292,9 +837,73 @@ type AlertConfig struct {
 	StakeChangeDropThreshold     *float64 `yaml:"stake_change_drop_threshold"`
 	StakeChangeIncreaseThreshold *float64 `yaml:"stake_change_increase_threshold"`
 
+	// Whether to alert when the validator's share of the bonded voting power drops below MinVotingPowerPercent
+	VotingPowerAlerts *bool `yaml:"voting_power_alerts"`
+	// MinVotingPowerPercent is a percentage of the total bonded tokens, e.g. 0.5 for 0.5%
+	MinVotingPowerPercent *float64 `yaml:"min_voting_power_percent"`
+
+	// Whether to alert when the commission rate is further than CommissionRateTolerance from ExpectedCommissionRate
+	CommissionRateAlerts *bool `yaml:"commission_rate_alerts"`
+	// ExpectedCommissionRate is the intended commission as a fraction like the on-chain rate, e.g. 0.05 for 5%
+	ExpectedCommissionRate *float64 `yaml:"expected_commission_rate"`
+	// CommissionRateTolerance is how far the rate may be from ExpectedCommissionRate, also a fraction
+	CommissionRateTolerance *float64 `yaml:"commission_rate_tolerance"`
+
+	// Whether to alert when the validator's rank by voting power is worse than MaxValidatorRank, e.g. close to dropping
+	// out of the active set
+	ValidatorRankAlerts *bool `yaml:"validator_rank_alerts"`
+	MaxValidatorRank    *int  `yaml:"max_validator_rank"`
+
+	// Whether to alert when the delegated tokens are below MinDelegatedTokens or above MaxDelegatedTokens
+	DelegatedTokensAlerts *bool `yaml:"delegated_tokens_alerts"`
+	// MinDelegatedTokens and MaxDelegatedTokens are in display units, e.g. ATOM rather than uatom, unset or 0 disables a bound
+	MinDelegatedTokens *float64 `yaml:"min_delegated_tokens"`
+	MaxDelegatedTokens *float64 `yaml:"max_delegated_tokens"`
+
 	// Whether to alert when a validator has more than the threhold value of unclaimed rewards
 	UnclaimedRewardsAlerts    *bool    `yaml:"unclaimed_rewards_alerts"`
 	UnclaimedRewardsThreshold *float64 `yaml:"unclaimed_rewards_threshold_in_fiat_currency"`
+	// RewardsQueryFailThreshold is how many validator info refreshes in a row the rewards and commission query can fail
+	// before a warning is sent, the unclaimed rewards alerts can't fire meanwhile. Unset or 0 disables it.
+	RewardsQueryFailThreshold *int `yaml:"rewards_query_fail_threshold"`
+
+	// Whether to alert when the commission has not been withdrawn for CommissionStaleHours, 48 by default
+	CommissionStaleAlerts *bool `yaml:"commission_stale_alerts"`
+	CommissionStaleHours  *int  `yaml:"commission_stale_hours"`
+
+	// Whether to alert when the operator account starts unbonding its self-delegation
+	UnbondingAlerts *bool `yaml:"unbonding_alerts"`
+
+	// Whether to alert when the operator account's balance of the fee denom is below MinGasBalance, in display units
+	GasBalanceAlerts *bool    `yaml:"gas_balance_alerts"`
+	MinGasBalance    *float64 `yaml:"min_gas_balance"`
+
+	// Whether to alert when one of the chain's ibc_clients is close to expiring, or has expired or been frozen
+	IBCClientExpiryAlerts *bool `yaml:"ibc_client_expiry_alerts"`
+	// IBCClientExpiryHours is how many hours before a client expires to send the warning
+	IBCClientExpiryHours *int `yaml:"ibc_client_expiry_hours"`
+
+	// Whether to skip the stall and missed block alerts while the chain is halted for a scheduled software upgrade
+	SuppressDuringUpgrade *bool `yaml:"suppress_during_upgrade"`
+	// Whether to alert when the chain is within UpgradeWarningBlocks of a scheduled software upgrade
+	UpcomingUpgradeAlerts *bool `yaml:"upcoming_upgrade_alerts"`
+	// UpgradeWarningBlocks is how many blocks before the upgrade height to send the warning
+	UpgradeWarningBlocks *int `yaml:"upgrade_warning_blocks"`
+
+	// Whether to send an info alert when the validator's moniker changes
+	MonikerChangeAlerts *bool `yaml:"moniker_change_alerts"`
+
+	// Whether to send a critical alert when the validator's consensus key changes
+	ConsKeyChangeAlerts *bool `yaml:"cons_key_change_alerts"`
+
+	// Whether to send an info alert when a validator that was not bonded joins the active set and signs its first block
+	FirstSignAlert *bool `yaml:"first_sign_alert"`
+
+	// Whether to send a critical alert as soon as a slash event for the validator is seen in a block
+	SlashEventAlerts *bool `yaml:"slash_event_alerts"`
+
+	// Whether to send an info alert when the community tax or the inflation rate changes
+	ChainParamAlerts *bool `yaml:"chain_param_alerts"`
 
 	// chain specific overrides for alert destinations.
 	// Pagerduty configuration values
@@ -305,18 +914,124 @@ type AlertConfig struct {
 	Telegram TeleConfig `yaml:"telegram"`
 	// Slack webhook information
 	Slack SlackConfig `yaml:"slack"`
+	// AWS SNS topic information
+	SNS SNSConfig `yaml:"sns"`
+	// Exec runs a local command for each alert
+	Exec ExecConfig `yaml:"exec"`
+}
+
+// WindowThreshold is one step of a WindowLadder, an empty Severity uses the chain's percentage_priority.
+type WindowThreshold struct {
+	Percent  int    `yaml:"percent"`
+	Severity string `yaml:"severity"`
+}
+
+// WindowLadder holds the missed block percentages that trigger an alert.
+type WindowLadder []WindowThreshold
+
+// UnmarshalYAML accepts either a single percentage, the original format, or a list of thresholds.
+func (w *WindowLadder) UnmarshalYAML(unmarshal func(any) error) error {
+	var percent int
+	if err := unmarshal(&percent); err == nil {
+		*w = WindowLadder{{Percent: percent}}
+		return nil
+	}
+	var thresholds []WindowThreshold
+	if err := unmarshal(&thresholds); err != nil {
+		return err
+	}
+	*w = thresholds
+	return nil
 }
 
 // NodeConfig holds the basic information for a node to connect to.
 type NodeConfig struct {
 	Url         string `yaml:"url"`
 	AlertIfDown bool   `yaml:"alert_if_down"`
+	// Headers are added to every HTTP and websocket request to the node, e.g. an Authorization bearer token
+	Headers map[string]string `yaml:"headers"`
+	// BasicAuthUser and BasicAuthPassword authenticate the requests to the node with HTTP basic auth
+	BasicAuthUser     string `yaml:"basic_auth_user"`
+	BasicAuthPassword string `yaml:"basic_auth_password"`
+	// ChainId is the chain the node serves when one endpoint is shared by several chains, e.g. behind a proxy that
+	// routes on a header. Chains sharing an endpoint also share its rpc client, it defaults to the chain's chain_id.
+	ChainId string `yaml:"chain_id"`
 
 	down      bool
 	wasDown   bool
 	syncing   bool
 	lastMsg   string
 	downSince time.Time
+	failures  int // checks failed in a row, see markFailed
+
+	peersMux   sync.RWMutex // peers is written by the health check and read by watch()
+	peers      int          // peer count from the last successful net_info query
+	peersKnown bool
+
+	networkMux sync.RWMutex // network is written when connecting and by the health check, and read by watch()
+	network    string       // chain-id reported by the node's /status, empty until it answered
+
+	syncingMux   sync.RWMutex // syncingSince is written by the health check and read by watch()
+	syncingSince time.Time    // when the node started reporting catching_up, zero while it is caught up
+}
+
+// markFailed counts a failed check and marks the node down once grace checks in a row have failed, the down time starts
+// then. Returns whether the node is down.
+func (n *NodeConfig) markFailed(grace int) bool {
+	n.failures++
+	if !n.down && n.failures >= grace {
+		n.down = true
+		n.downSince = time.Now()
+	}
+	return n.down
+}
+
+// setPeers records the peer count returned by net_info.
+func (n *NodeConfig) setPeers(peers int) {
+	n.peersMux.Lock()
+	defer n.peersMux.Unlock()
+	n.peers = peers
+	n.peersKnown = true
+}
+
+// getPeers returns the last known peer count, and false if net_info hasn't succeeded yet.
+func (n *NodeConfig) getPeers() (int, bool) {
+	n.peersMux.RLock()
+	defer n.peersMux.RUnlock()
+	return n.peers, n.peersKnown
+}
+
+// setCatchingUp records the node's catching_up flag, keeping the time it was first seen.
+func (n *NodeConfig) setCatchingUp(catchingUp bool) {
+	n.syncingMux.Lock()
+	defer n.syncingMux.Unlock()
+	switch {
+	case !catchingUp:
+		n.syncingSince = time.Time{}
+	case n.syncingSince.IsZero():
+		n.syncingSince = time.Now()
+	}
+}
+
+// catchingUpSince returns when the node started catching up, zero if it is caught up.
+func (n *NodeConfig) catchingUpSince() time.Time {
+	n.syncingMux.RLock()
+	defer n.syncingMux.RUnlock()
+	return n.syncingSince
+}
+
+// setNetwork records the chain-id reported by the node.
+func (n *NodeConfig) setNetwork(network string) {
+	n.networkMux.Lock()
+	defer n.networkMux.Unlock()
+	n.network = network
+}
+
+// getNetwork returns the chain-id the node last reported, empty if it never answered.
+func (n *NodeConfig) getNetwork() string {
+	n.networkMux.RLock()
+	defer n.networkMux.RUnlock()
+	return n.network
 }
 
 // PDConfig is the information required to send alerts to PagerDuty
@@ -325,6 +1040,17 @@ type PDConfig struct {
 	ApiKey            string `yaml:"api_key"`
 	DefaultSeverity   string `yaml:"default_severity"`
 	SeverityThreshold string `yaml:"severity_threshold"`
+	// ApiToken is a read-only REST API token, distinct from the events api_key. When set, incidents acknowledged in
+	// PagerDuty stop the reminders to the other channels until the alarm resolves.
+	ApiToken string `yaml:"api_token"`
+	// TitlePrefix is prepended to the summary and sent as the group, Footer is sent as the component.
+	TitlePrefix string `yaml:"title_prefix"`
+	Footer      string `yaml:"footer"`
+	// FlapWindowMinutes suppresses an alert sent again this soon after the last one, 5 by default, 0 disables it.
+	FlapWindowMinutes *int `yaml:"flap_window_minutes"`
+	// MinResolveMinutes holds back a resolve until the alert has been open this long, so a blip doesn't open and close
+	// an incident right away. 0, the default, sends it immediately.
+	MinResolveMinutes *int `yaml:"min_resolve_minutes"`
 }
 
 // DiscordConfig holds the information needed to publish to a Discord webhook for sending alerts
@@ -333,6 +1059,11 @@ type DiscordConfig struct {
 	Webhook           string   `yaml:"webhook"`
 	Mentions          []string `yaml:"mentions"`
 	SeverityThreshold string   `yaml:"severity_threshold"`
+	// TitlePrefix and Footer tag the messages, e.g. with the instance or environment sending them
+	TitlePrefix string `yaml:"title_prefix"`
+	Footer      string `yaml:"footer"`
+	// ResolvedWebhook receives the resolve messages instead of Webhook, e.g. a quieter log channel
+	ResolvedWebhook string `yaml:"resolved_webhook"`
 }
 
 // TeleConfig holds the information needed to publish to a Telegram webhook for sending alerts
@@ -342,6 +1073,13 @@ type TeleConfig struct {
 	Channel           string   `yaml:"channel"`
 	Mentions          []string `yaml:"mentions"`
 	SeverityThreshold string   `yaml:"severity_threshold"`
+	// TitlePrefix and Footer tag the messages, e.g. with the instance or environment sending them
+	TitlePrefix string `yaml:"title_prefix"`
+	Footer      string `yaml:"footer"`
+	// InlineButtons adds Ack and Snooze buttons to critical alerts, the bot then long polls for the button presses
+	InlineButtons *bool `yaml:"inline_buttons"`
+	// ResolvedChannel receives the resolve messages instead of Channel, e.g. a quieter log chat
+	ResolvedChannel string `yaml:"resolved_channel"`
 }
 
 // SlackConfig holds the information needed to publish to a Slack webhook for sending alerts
@@ -350,6 +1088,104 @@ type SlackConfig struct {
 	Webhook           string   `yaml:"webhook"`
 	Mentions          []string `yaml:"mentions"`
 	SeverityThreshold string   `yaml:"severity_threshold"`
+	// TitlePrefix and Footer tag the messages, e.g. with the instance or environment sending them
+	TitlePrefix string `yaml:"title_prefix"`
+	Footer      string `yaml:"footer"`
+	// UseBlocks sends Block Kit messages instead of the legacy attachments
+	UseBlocks *bool `yaml:"use_blocks"`
+	// ResolvedWebhook receives the resolve messages instead of Webhook, e.g. a quieter log channel
+	ResolvedWebhook string `yaml:"resolved_webhook"`
+}
+
+// SNSConfig holds the information needed to publish alerts to an AWS SNS topic. Credentials are not configured here,
+// they are resolved by the default AWS chain: environment variables, the shared config files or an IAM role.
+type SNSConfig struct {
+	Enabled           *bool  `yaml:"enabled"`
+	Region            string `yaml:"region"`
+	TopicARN          string `yaml:"topic_arn"`
+	SeverityThreshold string `yaml:"severity_threshold"`
+	// TitlePrefix is prepended to the subject and Footer appended to the message
+	TitlePrefix string `yaml:"title_prefix"`
+	Footer      string `yaml:"footer"`
+}
+
+// ExecConfig runs Command with Args for every alert, the alert is passed in the TD_CHAIN, TD_SEVERITY, TD_MESSAGE,
+// TD_RESOLVED and TD_UNIQUE_ID environment variables. It has to be enabled explicitly, in the default alert config
+// and for the chain, since it runs whatever is configured with tenderduty's privileges.
+type ExecConfig struct {
+	Enabled           *bool    `yaml:"enabled"`
+	Command           string   `yaml:"command"`
+	Args              []string `yaml:"args"`
+	SeverityThreshold string   `yaml:"severity_threshold"`
+}
+
+// QuietHoursConfig is a daily window, in Timezone, during which only critical alerts are sent. Start and End use the
+// 24-hour "15:04" format, a window where End is before Start crosses midnight.
+type QuietHoursConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	Timezone string `yaml:"timezone"`
+	Start    string `yaml:"start"`
+	End      string `yaml:"end"`
+
+	location *time.Location // parsed by validateConfig, along with start and end as minutes after midnight
+	start    int
+	end      int
+}
+
+// loadDisplayLocation returns the zone alert times are shown in, the chain's timezone wins over the global one. It
+// falls back to UTC when neither is set or the name is unknown.
+func loadDisplayLocation(global, chain string) (*time.Location, error) {
+	name := chain
+	if name == "" {
+		name = global
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC, err
+	}
+	return loc, nil
+}
+
+// formatTime formats a time for an alert message in the chain's display timezone.
+func (cc *ChainConfig) formatTime(t time.Time) string {
+	loc := cc.displayLocation
+	if loc == nil {
+		loc = time.UTC
+	}
+	return t.In(loc).Format("2006-01-02 15:04 MST")
+}
+
+// parse checks the settings and fills in the location and the start and end minutes, an empty timezone is UTC.
+func (q *QuietHoursConfig) parse() error {
+	loc, err := time.LoadLocation(q.Timezone)
+	if err != nil {
+		return err
+	}
+	start, err := time.Parse("15:04", q.Start)
+	if err != nil {
+		return fmt.Errorf("invalid start %q, expected HH:MM", q.Start)
+	}
+	end, err := time.Parse("15:04", q.End)
+	if err != nil {
+		return fmt.Errorf("invalid end %q, expected HH:MM", q.End)
+	}
+	q.location = loc
+	q.start = start.Hour()*60 + start.Minute()
+	q.end = end.Hour()*60 + end.Minute()
+	return nil
+}
+
+// active reports whether now falls inside the quiet window.
+func (q *QuietHoursConfig) active(now time.Time) bool {
+	if !q.Enabled || q.location == nil || q.start == q.end {
+		return false
+	}
+	local := now.In(q.location)
+	minute := local.Hour()*60 + local.Minute()
+	if q.start < q.end {
+		return minute >= q.start && minute < q.end
+	}
+	return minute >= q.start || minute < q.end
 }
 
 // HealthcheckConfig holds the information needed to send pings to a healthcheck endpoint
@@ -359,12 +1195,34 @@ type HealthcheckConfig struct {
 	PingRate time.Duration `yaml:"ping_rate"`
 }
 
+// SummaryReportConfig controls the periodic chain summary. Destinations are notifier names, e.g. discord or telegram,
+// and a chain's report only goes to those that are enabled for it.
+type SummaryReportConfig struct {
+	Enabled       bool     `yaml:"enabled"`
+	IntervalHours int      `yaml:"interval_hours"`
+	Destinations  []string `yaml:"destinations"`
+}
+
 type PriceConversionConfig struct {
 	Enabled         bool   `yaml:"enabled"`
 	Currency        string `yaml:"currency"`
 	CacheExpiration int    `yaml:"cache_expiration"`
 }
 
+// resizeBlockHistory returns blocks with the given size. The newest block is first, so the oldest blocks are dropped
+// when it shrinks and it is padded with -1, no data, when it grows.
+func resizeBlockHistory(blocks []int, size int) []int {
+	if len(blocks) == size {
+		return blocks
+	}
+	resized := make([]int, size)
+	n := copy(resized, blocks)
+	for i := n; i < size; i++ {
+		resized[i] = -1
+	}
+	return resized
+}
+
 // validateConfig is a non-exhaustive check for common problems with the configuration. Needs love.
 func validateConfig(c *Config) (fatal bool, problems []string) {
 	problems = make([]string, 0)
@@ -386,33 +1244,191 @@ func validateConfig(c *Config) (fatal bool, problems []string) {
 		}
 	}
 
+	switch c.LogFormat {
+	case "", "text", "json":
+	default:
+		fatal = true
+		problems = append(problems, fmt.Sprintf("error: log_format must be text or json, got %s", c.LogFormat))
+	}
+
+	if c.LogLevel == "" {
+		c.logLevel = levelInfo
+	} else if lvl, ok := logLevelNames[c.LogLevel]; ok {
+		c.logLevel = lvl
+	} else {
+		fatal = true
+		problems = append(problems, fmt.Sprintf("error: log_level must be debug, info, warn or error, got %s", c.LogLevel))
+	}
+
+	if severity, ok := normalizeSeverity(c.NodeDownSeverity); ok {
+		c.NodeDownSeverity = severity
+	} else {
+		fatal = true
+		problems = append(problems, fmt.Sprintf("error: node_down_alert_severity must be critical, warning or info, got %s", c.NodeDownSeverity))
+	}
+	if severityProblems := normalizeSeverities(&c.DefaultAlertConfig, "default_alert_config"); len(severityProblems) > 0 {
+		fatal = true
+		problems = append(problems, severityProblems...)
+	}
+
 	if c.NodeDownMin < 3 {
 		problems = append(problems, "warning: setting 'node_down_alert_minutes' to less than three minutes might result in false alarms")
 	}
 
+	if c.ProxyURL != "" {
+		c.proxyURL, err = url.Parse(c.ProxyURL)
+		if err != nil || c.proxyURL.Scheme == "" || c.proxyURL.Host == "" {
+			fatal = true
+			problems = append(problems, fmt.Sprintf("error: proxy_url %s does not appear to be valid", c.ProxyURL))
+		}
+	}
+
+	if c.CACertFile != "" {
+		c.rootCAs, err = loadCACerts(c.CACertFile)
+		if err != nil {
+			fatal = true
+			problems = append(problems, fmt.Sprintf("error: ca_cert_file %s could not be loaded: %s", c.CACertFile, err))
+		}
+	}
+
+	switch c.DialNetwork {
+	case "", "tcp", "tcp4", "tcp6":
+	default:
+		fatal = true
+		problems = append(problems, fmt.Sprintf("error: dial_network must be tcp, tcp4 or tcp6, got %s", c.DialNetwork))
+	}
+
+	if c.DNSServer != "" {
+		if _, _, err = net.SplitHostPort(c.DNSServer); err != nil {
+			fatal = true
+			problems = append(problems, fmt.Sprintf("error: dns_server must be host:port, got %s", c.DNSServer))
+		}
+	}
+	// proxy and dial settings are read on each request, only the TLS settings are fixed when the transport is built
+	c.transport = newHTTPTransport(c)
+
+	if c.QuietHours.Enabled {
+		if err = c.QuietHours.parse(); err != nil {
+			fatal = true
+			problems = append(problems, fmt.Sprintf("error: quiet_hours: %s", err))
+		}
+	}
+
+	if c.SummaryReport.Enabled {
+		if c.SummaryReport.IntervalHours <= 0 {
+			c.SummaryReport.IntervalHours = defaultSummaryInterval
+		}
+		if len(c.SummaryReport.Destinations) == 0 {
+			problems = append(problems, "warning: summary_report is enabled but has no destinations, no summaries will be sent")
+		}
+		for _, dest := range c.SummaryReport.Destinations {
+			if !summaryDestination(dest) {
+				problems = append(problems, fmt.Sprintf("warning: summary_report destination %s is unknown and will be skipped, valid choices are %s", dest, strings.Join(summaryDestinations, ", ")))
+			}
+		}
+	}
+
+	if c.NotifyMaxRetries == nil {
+		retries := 3
+		c.NotifyMaxRetries = &retries
+	} else if *c.NotifyMaxRetries < 0 {
+		problems = append(problems, "warning: 'notify_max_retries' is negative, failed notifications will not be retried")
+	}
+
+	if c.NodeDownGraceChecks == nil {
+		grace := 1
+		c.NodeDownGraceChecks = &grace
+	} else if *c.NodeDownGraceChecks < 1 {
+		problems = append(problems, "warning: 'node_down_grace_checks' must be at least 1, using 1")
+		*c.NodeDownGraceChecks = 1
+	}
+
+	if c.ResolveCooldownMinutes == nil {
+		cooldown := 5
+		c.ResolveCooldownMinutes = &cooldown
+	} else if *c.ResolveCooldownMinutes < 0 {
+		problems = append(problems, "warning: 'resolve_cooldown_minutes' is negative, resolves will not be held back")
+	}
+
 	// when undefined, or invalid, we set 6 as the default value
 	if c.GovernanceAlertsReminderInterval <= 0 {
 		c.GovernanceAlertsReminderInterval = 6
 	}
 
+	switch {
+	case c.BlockHistorySize == 0:
+		c.BlockHistorySize = showBLocks
+	case c.BlockHistorySize < minBlockHistory || c.BlockHistorySize > maxBlockHistory:
+		problems = append(problems, fmt.Sprintf("warning: 'block_history_size' must be between %d and %d, using %d", minBlockHistory, maxBlockHistory, showBLocks))
+		c.BlockHistorySize = showBLocks
+	}
+
+	if c.AlertHistorySize > 0 {
+		c.history = newMemoryAlertHistory(c.AlertHistorySize)
+	} else if c.AlertHistorySize < 0 {
+		problems = append(problems, "warning: 'alert_history_size' is negative, the alert history is disabled")
+	}
+
+	if c.AuditLogPath != "" {
+		if c.AuditLogMaxMB <= 0 {
+			c.AuditLogMaxMB = defaultAuditLogMaxMB
+		}
+		if c.audit, err = openAuditLog(c.AuditLogPath, int64(c.AuditLogMaxMB)<<20); err != nil {
+			fatal = true
+			problems = append(problems, fmt.Sprintf("error: audit_log_path %s could not be opened: %s", c.AuditLogPath, err))
+		}
+	}
+
 	var wantsPublic bool
 	for k, v := range c.Chains {
-		if v.blocksResults == nil {
-			v.blocksResults = make([]int, showBLocks)
-			for i := range v.blocksResults {
-				v.blocksResults[i] = -1
-			}
-		}
+		// the history restored from the saved state may have been kept with a different size
+		v.blocksResults = resizeBlockHistory(v.blocksResults, c.BlockHistorySize)
 		if v.name == "" {
 			v.name = k
 		}
 		if v.PublicFallback {
 			wantsPublic = true
 		}
+		if v.FromRegistry {
+			if e := v.resolveFromRegistry(registryJson); e != nil {
+				fatal = true
+				problems = append(problems, fmt.Sprintf("error: could not look up %s in the chain registry: %s", v.name, e))
+			} else {
+				l(fmt.Sprintf("⚙️ %s: using chain-id %s and %d nodes from the chain registry", v.name, v.ChainId, len(v.Nodes)))
+			}
+		}
 
 		v.valInfo = &ValInfo{Moniker: "not connected"}
 
 		applyAlertDefaults(&v.Alerts, &c.DefaultAlertConfig)
+		if severityProblems := normalizeSeverities(&v.Alerts, v.name); len(severityProblems) > 0 {
+			fatal = true
+			problems = append(problems, severityProblems...)
+		}
+		if boolVal(c.DefaultAlertConfig.SNS.Enabled) && boolVal(v.Alerts.SNS.Enabled) && v.Alerts.SNS.TopicARN == "" {
+			problems = append(problems, fmt.Sprintf("warning: sns alerts are enabled for %s but no topic_arn is set", v.name))
+		}
+		if boolVal(c.DefaultAlertConfig.Exec.Enabled) && boolVal(v.Alerts.Exec.Enabled) && v.Alerts.Exec.Command == "" {
+			problems = append(problems, fmt.Sprintf("warning: exec alerts are enabled for %s but no command is set", v.name))
+		}
+		if v.displayLocation, err = loadDisplayLocation(c.DisplayTimezone, v.DisplayTimezone); err != nil {
+			problems = append(problems, fmt.Sprintf("warning: display_timezone for %s is not valid, showing times in UTC: %s", v.name, err))
+		}
+		if v.CometVersion != "" && parseCometVersion(v.CometVersion) == cometUnknown {
+			problems = append(problems, fmt.Sprintf("warning: comet_version %s for %s is not recognized, it will be detected from the node", v.CometVersion, v.name))
+		}
+		if v.DenomExponentOverride != nil && (*v.DenomExponentOverride < 0 || v.DisplayDenomOverride == "") {
+			problems = append(problems, fmt.Sprintf("warning: denom_exponent_override for %s needs a display_denom_override and can't be negative, it is ignored", v.name))
+		}
+		if v.EvalIntervalSeconds != nil && *v.EvalIntervalSeconds < 1 {
+			problems = append(problems, fmt.Sprintf("warning: eval_interval_seconds for %s must be at least 1, using %d", v.name, defaultEvalInterval))
+		}
+		for _, node := range v.Nodes {
+			if node.ChainId != "" && node.ChainId != v.ChainId {
+				fatal = true
+				problems = append(problems, fmt.Sprintf("error: node %s on %s serves chain_id %s, expected %s", node.Url, v.name, node.ChainId, v.ChainId))
+			}
+		}
 
 		if td.EnableDash {
 			td.updateChan <- &dash.ChainStatus{
@@ -494,56 +1510,71 @@ func loadChainConfig(yamlFile string) (*ChainConfig, error) {
 	return c, nil
 }
 
-// loadConfig creates a new Config from a file.
-func loadConfig(yamlFile, stateFile, chainConfigDirectory string, password *string) (*Config, error) {
-	c := &Config{}
-	if strings.HasPrefix(yamlFile, "http://") || strings.HasPrefix(yamlFile, "https://") {
-		if *password == "" {
-			return nil, errors.New("a password is required if loading a remote configuration")
-		}
-		//#nosec -- url is specified on command line
-		resp, err := http.Get(yamlFile)
-		if err != nil {
-			return nil, err
+// loadNodesFile adds the nodes listed in the files matching NodesFile, nodes with a URL that is already configured
+// are skipped so the inline settings win.
+func (cc *ChainConfig) loadNodesFile() error {
+	if cc.NodesFile == "" {
+		return nil
+	}
+	files, e := filepath.Glob(cc.NodesFile)
+	if e != nil {
+		return e
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no files match nodes_file %s", cc.NodesFile)
+	}
+	known := make(map[string]bool)
+	for _, node := range cc.Nodes {
+		known[node.Url] = true
+	}
+	for _, file := range files {
+		//#nosec -- path specified in the config file
+		b, e := os.ReadFile(file)
+		if e != nil {
+			return e
 		}
-		b, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
+		nodes := make([]*NodeConfig, 0)
+		if e = yaml.Unmarshal(b, &nodes); e != nil {
+			return fmt.Errorf("could not parse %s: %w", file, e)
 		}
-		_ = resp.Body.Close()
-		log.Printf("downloaded %d bytes from %s", len(b), yamlFile)
-		decrypted, err := decrypt(b, *password)
-		if err != nil {
-			return nil, err
+		for _, node := range nodes {
+			if node == nil || node.Url == "" || known[node.Url] {
+				continue
+			}
+			known[node.Url] = true
+			cc.Nodes = append(cc.Nodes, node)
 		}
-		empty := ""
-		password = &empty             // let gc get password out of memory, it's still referenced in main()
-		_ = os.Setenv("PASSWORD", "") // also clear the ENV var
-		err = yaml.Unmarshal(decrypted, c)
+	}
+	return nil
+}
+
+// readConfig parses the config files and the chain configs in chainConfigDirectory, without merging defaults or
+// touching any saved state.
+func readConfig(configFiles []string, chainConfigDirectory string, password *string) (*Config, error) {
+	c := &Config{}
+	// later files are layered over the earlier ones: maps, including chains, are merged key by key and anything else,
+	// scalars and lists alike, is replaced
+	merged := make(map[interface{}]interface{})
+	for _, configFile := range configFiles {
+		b, err := readConfigFile(configFile, password)
 		if err != nil {
 			return nil, err
 		}
-	} else {
-		//#nosec -- variable specified on command line
-		f, e := os.OpenFile(yamlFile, os.O_RDONLY, 0600)
-		if e != nil {
-			return nil, e
-		}
-		i, e := f.Stat()
-		if e != nil {
-			_ = f.Close()
-			return nil, e
-		}
-		b := make([]byte, int(i.Size()))
-		_, e = f.Read(b)
-		_ = f.Close()
-		if e != nil {
-			return nil, e
-		}
-		e = yaml.Unmarshal(b, c)
-		if e != nil {
-			return nil, e
+		layer := make(map[interface{}]interface{})
+		if err = yaml.Unmarshal(b, &layer); err != nil {
+			return nil, fmt.Errorf("%s: %w", configFile, err)
 		}
+		mergeConfigMaps(merged, layer)
+	}
+	if hasRemoteConfig(configFiles) {
+		_ = os.Setenv("PASSWORD", "") // only needed to decrypt the remote config, clear the ENV var
+	}
+	b, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	if err = yaml.Unmarshal(b, c); err != nil {
+		return nil, err
 	}
 
 	// Load additional chain configuration files
@@ -582,6 +1613,73 @@ func loadConfig(yamlFile, stateFile, chainConfigDirectory string, password *stri
 		return nil, errors.New("no chains configured")
 	}
 
+	for name, chain := range c.Chains {
+		if e = chain.loadNodesFile(); e != nil {
+			return nil, fmt.Errorf("loading nodes for %s: %w", name, e)
+		}
+	}
+	return c, nil
+}
+
+// readConfigFile returns the yaml in a config file, a http(s) url is downloaded and decrypted with the password.
+func readConfigFile(yamlFile string, password *string) ([]byte, error) {
+	if !isRemoteConfig(yamlFile) {
+		//#nosec -- variable specified on command line
+		return os.ReadFile(yamlFile)
+	}
+	if password == nil || *password == "" {
+		return nil, errors.New("a password is required if loading a remote configuration")
+	}
+	//#nosec -- url is specified on command line
+	resp, err := newHTTPClient(0).Get(yamlFile)
+	if err != nil {
+		return nil, err
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	log.Printf("downloaded %d bytes from %s", len(b), yamlFile)
+	return decrypt(b, *password)
+}
+
+func isRemoteConfig(yamlFile string) bool {
+	return strings.HasPrefix(yamlFile, "http://") || strings.HasPrefix(yamlFile, "https://")
+}
+
+func hasRemoteConfig(configFiles []string) bool {
+	for _, f := range configFiles {
+		if isRemoteConfig(f) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeConfigMaps layers src over dst, nested maps are merged and every other value in src replaces the one in dst.
+func mergeConfigMaps(dst, src map[interface{}]interface{}) {
+	for k, v := range src {
+		srcMap, srcIsMap := v.(map[interface{}]interface{})
+		dstMap, dstIsMap := dst[k].(map[interface{}]interface{})
+		if srcIsMap && dstIsMap {
+			mergeConfigMaps(dstMap, srcMap)
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+// loadConfig creates a new Config from one or more files, see readConfig for how they are merged.
+func loadConfig(configFiles []string, stateFile, chainConfigDirectory string, password *string) (*Config, error) {
+	c, e := readConfig(configFiles, chainConfigDirectory, password)
+	if e != nil {
+		return nil, e
+	}
+	if e = resolveKeyringSecrets(c); e != nil {
+		return nil, e
+	}
+
 	c.alertChan = make(chan *alertMsg)
 	c.logChan = make(chan dash.LogMessage)
 	// buffer enough to get through validateConfig()
@@ -591,12 +1689,14 @@ func loadConfig(yamlFile, stateFile, chainConfigDirectory string, password *stri
 
 	// handle cached data. FIXME: incomplete.
 	c.alarms = &alarmCache{
-		SentPdAlarms:  make(map[string]alertMsgCache),
-		SentTgAlarms:  make(map[string]alertMsgCache),
-		SentDiAlarms:  make(map[string]alertMsgCache),
-		SentSlkAlarms: make(map[string]alertMsgCache),
-		AllAlarms:     make(map[string]map[string]alertMsgCache),
-		notifyMux:     sync.RWMutex{},
+		SentPdAlarms:   make(map[string]alertMsgCache),
+		SentTgAlarms:   make(map[string]alertMsgCache),
+		SentDiAlarms:   make(map[string]alertMsgCache),
+		SentSlkAlarms:  make(map[string]alertMsgCache),
+		SentSnsAlarms:  make(map[string]alertMsgCache),
+		SentExecAlarms: make(map[string]alertMsgCache),
+		AllAlarms:      make(map[string]map[string]alertMsgCache),
+		notifyMux:      sync.RWMutex{},
 	}
 
 	//#nosec -- variable specified on command line
@@ -619,6 +1719,7 @@ func loadConfig(yamlFile, stateFile, chainConfigDirectory string, password *stri
 			c.Chains[k].blocksResults = v
 		}
 	}
+	c.restoreStats(saved.Stats)
 
 	// restore alarm state to prevent duplicate alerts
 	if saved.Alarms != nil {
@@ -638,6 +1739,14 @@ func loadConfig(yamlFile, stateFile, chainConfigDirectory string, password *stri
 			alarms.SentSlkAlarms = saved.Alarms.SentSlkAlarms
 			clearStale(alarms.SentSlkAlarms, "Slack", boolVal(c.DefaultAlertConfig.Pagerduty.Enabled), staleHours)
 		}
+		if saved.Alarms.SentSnsAlarms != nil {
+			alarms.SentSnsAlarms = saved.Alarms.SentSnsAlarms
+			clearStale(alarms.SentSnsAlarms, "SNS", boolVal(c.DefaultAlertConfig.Pagerduty.Enabled), staleHours)
+		}
+		if saved.Alarms.SentExecAlarms != nil {
+			alarms.SentExecAlarms = saved.Alarms.SentExecAlarms
+			clearStale(alarms.SentExecAlarms, "exec", boolVal(c.DefaultAlertConfig.Pagerduty.Enabled), staleHours)
+		}
 		if saved.Alarms.AllAlarms != nil {
 			alarms.AllAlarms = saved.Alarms.AllAlarms
 			for _, alrm := range saved.Alarms.AllAlarms {
@@ -699,12 +1808,19 @@ func loadConfig(yamlFile, stateFile, chainConfigDirectory string, password *stri
 		}
 
 		c.coinMarketCapClient = utils.NewCoinMarketCapClient(c.CoinMarketCapAPIToken, currency, c.tenderdutyCache, cacheExpiration, slugs)
+		utils.WithHTTPClient(newHTTPClient(0))(c.coinMarketCapClient)
+		for _, chain := range c.Chains {
+			if chain.Slug != "" && chain.PriceCacheExpirationMinutes > 0 {
+				c.coinMarketCapClient.SetCacheExpiration(chain.Slug, time.Duration(chain.PriceCacheExpirationMinutes)*time.Minute)
+			}
+		}
 		_, err := c.coinMarketCapClient.GetPrices(c.ctx)
 		if err == nil {
 			l("💸 price conversion enabled")
 		} else {
 			c.PriceConversion.Enabled = false
-			l("🛑 failed to enable price conversion, found error:", err)
+			c.priceConversionDown = true
+			l("🛑 failed to enable price conversion, retrying every", priceRetryInterval, "found error:", err)
 		}
 	}
 
@@ -734,4 +1850,8 @@ type ChainProvider interface {
 	QueryValidatorVotingPool(ctx context.Context) (votingPool *staking.Pool, err error)
 	QueryValidatorSelfDelegationRewardsAndCommission(ctx context.Context) (rewards *github_com_cosmos_cosmos_sdk_types.DecCoins, commission *github_com_cosmos_cosmos_sdk_types.DecCoins, err error)
 	QueryDenomMetadata(ctx context.Context, denom string) (medatada *bank.Metadata, err error)
+	QueryUnbondingDelegations(ctx context.Context) ([]staking.UnbondingDelegationEntry, error)
+	QueryIBCClientStatus(ctx context.Context, clientID string) (*IBCClientStatus, error)
+	QueryAccountBalance(ctx context.Context, denom string) (balance float64, err error)
+	QueryUpgradePlan(ctx context.Context) (*upgrade.Plan, error)
 }
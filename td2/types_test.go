@@ -1,19 +1,32 @@
 package tenderduty
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/firstset/tenderduty/v2/td2/utils"
+	"github.com/go-yaml/yaml"
 )
 
 // alertConfigsEqual compares two AlertConfig structs, handling pointer comparisons properly
 func alertConfigsEqual(a, b AlertConfig) bool {
 	return intPtrEqual(a.Stalled, b.Stalled) &&
 		boolPtrEqual(a.StalledAlerts, b.StalledAlerts) &&
+		a.StalledPriority == b.StalledPriority &&
 		intPtrEqual(a.ConsecutiveMissed, b.ConsecutiveMissed) &&
 		a.ConsecutivePriority == b.ConsecutivePriority &&
 		boolPtrEqual(a.ConsecutiveAlerts, b.ConsecutiveAlerts) &&
-		intPtrEqual(a.Window, b.Window) &&
+		reflect.DeepEqual(a.Window, b.Window) &&
 		a.PercentagePriority == b.PercentagePriority &&
 		boolPtrEqual(a.PercentageAlerts, b.PercentageAlerts) &&
 		intPtrEqual(a.ConsecutiveEmpty, b.ConsecutiveEmpty) &&
@@ -24,6 +37,7 @@ func alertConfigsEqual(a, b AlertConfig) bool {
 		boolPtrEqual(a.EmptyPercentageAlerts, b.EmptyPercentageAlerts) &&
 		boolPtrEqual(a.AlertIfInactive, b.AlertIfInactive) &&
 		boolPtrEqual(a.AlertIfNoServers, b.AlertIfNoServers) &&
+		a.NoServersPriority == b.NoServersPriority &&
 		boolPtrEqual(a.GovernanceAlerts, b.GovernanceAlerts) &&
 		boolPtrEqual(a.StakeChangeAlerts, b.StakeChangeAlerts) &&
 		floatPtrEqual(a.StakeChangeDropThreshold, b.StakeChangeDropThreshold) &&
@@ -123,7 +137,7 @@ func TestApplyAlertDefaults(t *testing.T) {
 				ConsecutiveMissed:            intPtr(5),
 				ConsecutivePriority:          "high",
 				ConsecutiveAlerts:            boolPtr(true),
-				Window:                       intPtr(20),
+				Window:                       WindowLadder{{Percent: 20}},
 				PercentagePriority:           "medium",
 				PercentageAlerts:             boolPtr(false),
 				ConsecutiveEmpty:             intPtr(15),
@@ -147,7 +161,7 @@ func TestApplyAlertDefaults(t *testing.T) {
 				ConsecutiveMissed:            intPtr(5),
 				ConsecutivePriority:          "high",
 				ConsecutiveAlerts:            boolPtr(true),
-				Window:                       intPtr(20),
+				Window:                       WindowLadder{{Percent: 20}},
 				PercentagePriority:           "medium",
 				PercentageAlerts:             boolPtr(false),
 				ConsecutiveEmpty:             intPtr(15),
@@ -173,7 +187,7 @@ func TestApplyAlertDefaults(t *testing.T) {
 				StalledAlerts:            boolPtr(false),
 				ConsecutiveMissed:        intPtr(8),
 				ConsecutivePriority:      "critical",
-				Window:                   intPtr(50),
+				Window:                   WindowLadder{{Percent: 50}},
 				PercentagePriority:       "high",
 				StakeChangeDropThreshold: floatPtr(15.0),
 			},
@@ -183,7 +197,7 @@ func TestApplyAlertDefaults(t *testing.T) {
 				ConsecutiveMissed:        intPtr(5),
 				ConsecutivePriority:      "medium",
 				ConsecutiveAlerts:        boolPtr(true),
-				Window:                   intPtr(20),
+				Window:                   WindowLadder{{Percent: 20}},
 				PercentagePriority:       "low",
 				PercentageAlerts:         boolPtr(false),
 				ConsecutiveEmpty:         intPtr(15),
@@ -193,19 +207,19 @@ func TestApplyAlertDefaults(t *testing.T) {
 				StakeChangeDropThreshold: floatPtr(5.0),
 			},
 			expected: AlertConfig{
-				Stalled:                  intPtr(25),     // preserved
-				StalledAlerts:            boolPtr(false), // preserved
-				ConsecutiveMissed:        intPtr(8),      // preserved
-				ConsecutivePriority:      "critical",     // preserved
-				ConsecutiveAlerts:        boolPtr(true),  // filled from src
-				Window:                   intPtr(50),     // preserved
-				PercentagePriority:       "high",         // preserved
-				PercentageAlerts:         boolPtr(false), // filled from src
-				ConsecutiveEmpty:         intPtr(15),     // filled from src
-				ConsecutiveEmptyPriority: "low",          // filled from src
-				ConsecutiveEmptyAlerts:   boolPtr(true),  // filled from src
-				AlertIfInactive:          boolPtr(true),  // filled from src
-				StakeChangeDropThreshold: floatPtr(15.0), // preserved
+				Stalled:                  intPtr(25),                  // preserved
+				StalledAlerts:            boolPtr(false),              // preserved
+				ConsecutiveMissed:        intPtr(8),                   // preserved
+				ConsecutivePriority:      "critical",                  // preserved
+				ConsecutiveAlerts:        boolPtr(true),               // filled from src
+				Window:                   WindowLadder{{Percent: 50}}, // preserved
+				PercentagePriority:       "high",                      // preserved
+				PercentageAlerts:         boolPtr(false),              // filled from src
+				ConsecutiveEmpty:         intPtr(15),                  // filled from src
+				ConsecutiveEmptyPriority: "low",                       // filled from src
+				ConsecutiveEmptyAlerts:   boolPtr(true),               // filled from src
+				AlertIfInactive:          boolPtr(true),               // filled from src
+				StakeChangeDropThreshold: floatPtr(15.0),              // preserved
 			},
 		},
 		{
@@ -500,3 +514,810 @@ func TestFloatVal(t *testing.T) {
 	}
 }
 
+func TestSetPolledHeight(t *testing.T) {
+	cc := &ChainConfig{}
+
+	cc.setPolledHeight(100)
+	if cc.polledHeight != 100 || cc.polledSeen.IsZero() {
+		t.Fatalf("expected polled height 100 with a timestamp, got %d at %v", cc.polledHeight, cc.polledSeen)
+	}
+
+	seen := cc.polledSeen
+	cc.setPolledHeight(90)
+	if cc.polledHeight != 100 || cc.polledSeen != seen {
+		t.Errorf("an older height should not move the polled height back, got %d", cc.polledHeight)
+	}
+
+	cc.setPolledHeight(101)
+	if cc.polledHeight != 101 {
+		t.Errorf("expected a newer height to advance the polled height, got %d", cc.polledHeight)
+	}
+	if !cc.polledAdvancedSince(seen.Add(-time.Nanosecond)) {
+		t.Error("expected polledAdvancedSince to report the new height")
+	}
+}
+
+func TestWsConnected(t *testing.T) {
+	cc := &ChainConfig{}
+
+	cc.wsConnected()
+	if cc.wsSeen.IsZero() {
+		t.Fatal("expected wsConnected to seed the websocket timer")
+	}
+
+	seeded := time.Now().Add(-time.Hour)
+	cc.wsSeen = seeded
+	cc.wsConnected()
+	if cc.wsSeen != seeded {
+		t.Error("a reconnect should not reset the websocket timer")
+	}
+
+	cc.setWsHeight(50)
+	if cc.wsHeight != 50 || cc.wsSeen == seeded {
+		t.Errorf("expected a delivered block to advance the websocket timer, got height %d", cc.wsHeight)
+	}
+}
+
+func TestLogLevelFilter(t *testing.T) {
+	originalTd := td
+	td = &Config{LogLevel: "warn"}
+	defer func() { td = originalTd }()
+	if fatal, problems := validateConfig(td); fatal {
+		t.Fatal(problems)
+	}
+
+	// the logging goroutine keeps reading from the original channel, capture entries with a buffered one
+	originalLogs := logs
+	logs = make(chan logEntry, 10)
+	defer func() { logs = originalLogs }()
+
+	lDebug("debug message")
+	l("info message")
+	lWarn("warn message")
+	lChainError("test-chain", "error message")
+	close(logs)
+
+	got := make([]string, 0)
+	for entry := range logs {
+		got = append(got, entry.level.String()+": "+entry.text())
+	}
+	expected := []string{"warn: warn message", "error: test-chain error message"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestFirstCoinAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		coins    sdk.DecCoins
+		expected float64
+	}{
+		{
+			name:     "empty coins",
+			coins:    sdk.DecCoins{},
+			expected: 0,
+		},
+		{
+			name:     "uses the first coin only",
+			coins:    sdk.DecCoins{sdk.NewDecCoinFromDec("atom", sdk.MustNewDecFromStr("12.5")), sdk.NewDecCoinFromDec("ibc/abc", sdk.NewDec(3))},
+			expected: 12.5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := firstCoinAmount(tt.coins); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWindowLadderUnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		yaml     string
+		expected WindowLadder
+	}{
+		{
+			name:     "single percentage shorthand",
+			yaml:     "percentage_missed: 10",
+			expected: WindowLadder{{Percent: 10}},
+		},
+		{
+			name:     "ladder of thresholds",
+			yaml:     "percentage_missed:\n  - percent: 5\n    severity: warning\n  - percent: 10\n    severity: critical",
+			expected: WindowLadder{{Percent: 5, Severity: "warning"}, {Percent: 10, Severity: "critical"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ac AlertConfig
+			if err := yaml.Unmarshal([]byte(tt.yaml), &ac); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(ac.Window, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, ac.Window)
+			}
+		})
+	}
+}
+
+func TestNamadaDenomMetadata(t *testing.T) {
+	provider := &NamadaProvider{ChainConfig: &ChainConfig{}}
+
+	if _, err := provider.QueryDenomMetadata(context.Background(), "uatom"); err == nil {
+		t.Error("expected an error for a denom other than unam")
+	}
+
+	meta, err := provider.QueryDenomMetadata(context.Background(), "unam")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		unam          float64
+		expectedValue float64
+	}{
+		{
+			name:          "whole NAM",
+			unam:          2000000,
+			expectedValue: 2,
+		},
+		{
+			name:          "fractional NAM",
+			unam:          1500000,
+			expectedValue: 1.5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, unit, err := utils.ConvertFloatInBaseUnitToDisplayUnit(tt.unam, *meta)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if unit != "NAM" {
+				t.Errorf("expected unit NAM, got %s", unit)
+			}
+			if value != tt.expectedValue {
+				t.Errorf("expected %v NAM, got %v", tt.expectedValue, value)
+			}
+		})
+	}
+}
+
+func TestNamadaQueryChainInfo(t *testing.T) {
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/chain/parameters":
+			_, _ = w.Write([]byte(`{"apr":"0.12","nativeTokenAddress":"tnam1native"}`))
+		case "/api/v1/chain/token-supply":
+			if r.URL.Query().Get("address") != "tnam1native" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"address":"tnam1native","totalSupply":"1000000000000000"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name              string
+		indexers          []any
+		totalBondedTokens float64
+		expectError       bool
+	}{
+		{
+			name:              "falls back to the next indexer",
+			indexers:          []any{"http://127.0.0.1:1", server.URL},
+			totalBondedTokens: 400000000000000,
+		},
+		{
+			name:              "bonded tokens not known yet",
+			indexers:          []any{server.URL},
+			totalBondedTokens: 0,
+			expectError:       true,
+		},
+		{
+			name:              "no working indexer",
+			indexers:          []any{server.URL + "/missing"},
+			totalBondedTokens: 400000000000000,
+			expectError:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &NamadaProvider{ChainConfig: &ChainConfig{
+				totalBondedTokens: tt.totalBondedTokens,
+				Provider:          ProviderConfig{Name: "namada", Configs: map[string]any{"indexers": tt.indexers}},
+			}}
+
+			totalSupply, communityTax, inflationRate, err := provider.QueryChainInfo(context.Background())
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if totalSupply != 1e15 || communityTax != 0 {
+				t.Errorf("expected a supply of 1e15 and no community tax, got %v and %v", totalSupply, communityTax)
+			}
+			// the APR calculated in GetValInfo has to match the indexer's
+			baseAPR := inflationRate * (1 - communityTax) * totalSupply / tt.totalBondedTokens
+			if math.Abs(baseAPR-0.12) > 1e-9 {
+				t.Errorf("expected a base APR of 0.12, got %v", baseAPR)
+			}
+		})
+	}
+}
+
+func TestResizeBlockHistory(t *testing.T) {
+	tests := []struct {
+		name     string
+		blocks   []int
+		size     int
+		expected []int
+	}{
+		{
+			name:     "empty history is filled with no data",
+			blocks:   nil,
+			size:     3,
+			expected: []int{-1, -1, -1},
+		},
+		{
+			name:     "same size is kept",
+			blocks:   []int{3, 0, 4},
+			size:     3,
+			expected: []int{3, 0, 4},
+		},
+		{
+			name:     "smaller size drops the oldest blocks",
+			blocks:   []int{3, 0, 4, 3},
+			size:     2,
+			expected: []int{3, 0},
+		},
+		{
+			name:     "larger size is padded with no data",
+			blocks:   []int{3, 0},
+			size:     4,
+			expected: []int{3, 0, -1, -1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resizeBlockHistory(tt.blocks, tt.size)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestValidateConfigResizesBlockHistory(t *testing.T) {
+	c := &Config{
+		NodeDownMin:      5,
+		BlockHistorySize: 100,
+		Chains: map[string]*ChainConfig{
+			// restored from a saved state that used the default size
+			"restored": {ChainId: "restored-1", blocksResults: make([]int, showBLocks)},
+			"new":      {ChainId: "new-1"},
+		},
+	}
+	validateConfig(c)
+	for name, cc := range c.Chains {
+		if len(cc.blocksResults) != 100 {
+			t.Errorf("expected %s to keep 100 blocks, got %d", name, len(cc.blocksResults))
+		}
+	}
+}
+
+func TestUpdateBlockTime(t *testing.T) {
+	tests := []struct {
+		name      string
+		intervals []time.Duration
+		expected  float64
+	}{
+		{
+			name:     "no blocks yet",
+			expected: 0,
+		},
+		{
+			name:      "first interval seeds the average",
+			intervals: []time.Duration{6 * time.Second},
+			expected:  6,
+		},
+		{
+			name:      "later intervals are weighted by alpha",
+			intervals: []time.Duration{6 * time.Second, 16 * time.Second},
+			expected:  7,
+		},
+		{
+			name:      "steady block time keeps the average",
+			intervals: []time.Duration{5 * time.Second, 5 * time.Second, 5 * time.Second},
+			expected:  5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := &ChainConfig{}
+			for _, interval := range tt.intervals {
+				cc.updateBlockTime(interval)
+			}
+			if got := cc.averageBlockTime(); math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("expected average %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestQuietHoursActive(t *testing.T) {
+	tests := []struct {
+		name     string
+		quiet    QuietHoursConfig
+		now      time.Time
+		expected bool
+	}{
+		{
+			name:     "disabled",
+			quiet:    QuietHoursConfig{Start: "22:00", End: "07:00"},
+			now:      time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC),
+			expected: false,
+		},
+		{
+			name:     "inside an overnight window before midnight",
+			quiet:    QuietHoursConfig{Enabled: true, Start: "22:00", End: "07:00"},
+			now:      time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name:     "inside an overnight window after midnight",
+			quiet:    QuietHoursConfig{Enabled: true, Start: "22:00", End: "07:00"},
+			now:      time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name:     "end of the window is not quiet",
+			quiet:    QuietHoursConfig{Enabled: true, Start: "22:00", End: "07:00"},
+			now:      time.Date(2024, 1, 1, 7, 0, 0, 0, time.UTC),
+			expected: false,
+		},
+		{
+			name:     "outside an overnight window",
+			quiet:    QuietHoursConfig{Enabled: true, Start: "22:00", End: "07:00"},
+			now:      time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+			expected: false,
+		},
+		{
+			name:     "inside a daytime window",
+			quiet:    QuietHoursConfig{Enabled: true, Start: "12:00", End: "13:30"},
+			now:      time.Date(2024, 1, 1, 13, 15, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name:     "timezone moves the window",
+			quiet:    QuietHoursConfig{Enabled: true, Timezone: "Asia/Tokyo", Start: "22:00", End: "07:00"},
+			now:      time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC), // 23:00 in Tokyo
+			expected: true,
+		},
+		{
+			name:     "timezone outside the window",
+			quiet:    QuietHoursConfig{Enabled: true, Timezone: "Asia/Tokyo", Start: "22:00", End: "07:00"},
+			now:      time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC), // 08:00 in Tokyo
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.quiet.parse(); err != nil {
+				t.Fatal(err)
+			}
+			if got := tt.quiet.active(tt.now); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestQuietHoursParseErrors(t *testing.T) {
+	for _, q := range []QuietHoursConfig{
+		{Timezone: "Nowhere/Invalid", Start: "22:00", End: "07:00"},
+		{Start: "10pm", End: "07:00"},
+		{Start: "22:00", End: "25:00"},
+	} {
+		if err := q.parse(); err == nil {
+			t.Errorf("expected an error for %+v", q)
+		}
+	}
+}
+
+func TestFormatTimeDisplayTimezone(t *testing.T) {
+	// a winter and a summer time, to see the daylight saving switch
+	winter := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+	summer := time.Date(2024, 7, 15, 14, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		global    string
+		chain     string
+		expWinter string
+		expSummer string
+		expectErr bool
+	}{
+		{name: "unset is utc", expWinter: "2024-01-15 14:30 UTC", expSummer: "2024-07-15 14:30 UTC"},
+		{name: "global timezone", global: "Europe/Berlin", expWinter: "2024-01-15 15:30 CET", expSummer: "2024-07-15 16:30 CEST"},
+		{name: "chain overrides global", global: "Europe/Berlin", chain: "America/New_York", expWinter: "2024-01-15 09:30 EST", expSummer: "2024-07-15 10:30 EDT"},
+		{name: "invalid falls back to utc", chain: "Nowhere/Invalid", expWinter: "2024-01-15 14:30 UTC", expSummer: "2024-07-15 14:30 UTC", expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := loadDisplayLocation(tt.global, tt.chain)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			cc := &ChainConfig{displayLocation: loc}
+			if got := cc.formatTime(winter); got != tt.expWinter {
+				t.Errorf("expected %s, got %s", tt.expWinter, got)
+			}
+			if got := cc.formatTime(summer); got != tt.expSummer {
+				t.Errorf("expected %s, got %s", tt.expSummer, got)
+			}
+		})
+	}
+}
+
+func TestReadConfigLayers(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"base.yml": `
+prometheus_enabled: yes
+node_down_alert_minutes: 3
+default_alert_config:
+  stalled_minutes: 10
+  discord:
+    enabled: no
+    webhook: https://discord.com/api/webhooks/base
+chains:
+  "Chain A":
+    chain_id: chain-a-1
+    valoper_address: valoper1a
+    nodes:
+      - url: tcp://base-a:26657
+      - url: tcp://base-b:26657
+    alerts:
+      stalled_minutes: 20
+      percentage_missed: 10
+`,
+		"prod.yml": `
+node_down_alert_minutes: 5
+default_alert_config:
+  discord:
+    enabled: yes
+chains:
+  "Chain A":
+    nodes:
+      - url: tcp://prod:26657
+    alerts:
+      percentage_missed: 5
+  "Chain B":
+    chain_id: chain-b-1
+    valoper_address: valoper1b
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	password := ""
+	c, err := readConfig([]string{filepath.Join(dir, "base.yml"), filepath.Join(dir, "prod.yml")}, filepath.Join(dir, "chains.d"), &password)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		got      any
+		expected any
+	}{
+		{name: "scalar only in the base is kept", got: c.Prom, expected: true},
+		{name: "later scalar overrides", got: c.NodeDownMin, expected: 5},
+		{name: "nested map is merged", got: boolVal(c.DefaultAlertConfig.Discord.Enabled), expected: true},
+		{name: "nested key only in the base is kept", got: c.DefaultAlertConfig.Discord.Webhook, expected: "https://discord.com/api/webhooks/base"},
+		{name: "chains are merged", got: len(c.Chains), expected: 2},
+		{name: "chain field only in the base is kept", got: c.Chains["Chain A"].ChainId, expected: "chain-a-1"},
+		{name: "chain alert only in the base is kept", got: intVal(c.Chains["Chain A"].Alerts.Stalled), expected: 20},
+		{name: "chain alert is overridden", got: c.Chains["Chain A"].Alerts.Window[0].Percent, expected: 5},
+		{name: "lists are replaced", got: len(c.Chains["Chain A"].Nodes), expected: 1},
+		{name: "chain from the later file is added", got: c.Chains["Chain B"].ChainId, expected: "chain-b-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, tt.got)
+			}
+		})
+	}
+}
+
+func TestLoadNodesFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.yml": "- url: tcp://10.0.0.1:26657\n  alert_if_down: yes\n- url: tcp://10.0.0.2:26657\n",
+		"b.yml": "- url: tcp://10.0.0.2:26657\n  alert_if_down: yes\n- url: tcp://10.0.0.3:26657\n",
+		"c.yml": "url: not-a-list\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name         string
+		nodesFile    string
+		inline       []*NodeConfig
+		expected     []string
+		expectedDown []bool
+		expectErr    bool
+	}{
+		{
+			name:         "no nodes file keeps the inline nodes",
+			inline:       []*NodeConfig{{Url: "tcp://localhost:26657", AlertIfDown: true}},
+			expected:     []string{"tcp://localhost:26657"},
+			expectedDown: []bool{true},
+		},
+		{
+			name:         "single file is merged with the inline nodes",
+			nodesFile:    filepath.Join(dir, "a.yml"),
+			inline:       []*NodeConfig{{Url: "tcp://localhost:26657"}},
+			expected:     []string{"tcp://localhost:26657", "tcp://10.0.0.1:26657", "tcp://10.0.0.2:26657"},
+			expectedDown: []bool{false, true, false},
+		},
+		{
+			name:         "glob skips nodes that are already configured",
+			nodesFile:    filepath.Join(dir, "[ab].yml"),
+			inline:       []*NodeConfig{{Url: "tcp://10.0.0.1:26657"}},
+			expected:     []string{"tcp://10.0.0.1:26657", "tcp://10.0.0.2:26657", "tcp://10.0.0.3:26657"},
+			expectedDown: []bool{false, false, false},
+		},
+		{
+			name:      "no matching files",
+			nodesFile: filepath.Join(dir, "missing*.yml"),
+			expectErr: true,
+		},
+		{
+			name:      "file that is not a list",
+			nodesFile: filepath.Join(dir, "c.yml"),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := &ChainConfig{Nodes: tt.inline, NodesFile: tt.nodesFile}
+			err := cc.loadNodesFile()
+			if tt.expectErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			urls := make([]string, 0)
+			down := make([]bool, 0)
+			for _, node := range cc.Nodes {
+				urls = append(urls, node.Url)
+				down = append(down, node.AlertIfDown)
+			}
+			if !reflect.DeepEqual(urls, tt.expected) || !reflect.DeepEqual(down, tt.expectedDown) {
+				t.Errorf("expected %v %v, got %v %v", tt.expected, tt.expectedDown, urls, down)
+			}
+		})
+	}
+}
+
+func TestCheckHeightProgress(t *testing.T) {
+	cc := &ChainConfig{}
+	// nothing is known yet, so it can't be stuck
+	cc.checkHeightProgress()
+	if _, refreshes := cc.heightStuck(); refreshes != 0 {
+		t.Errorf("expected 0 refreshes before any height, got %d", refreshes)
+	}
+
+	cc.setWsHeight(100)
+	cc.checkHeightProgress()
+	cc.checkHeightProgress()
+	cc.checkHeightProgress()
+	if height, refreshes := cc.heightStuck(); height != 100 || refreshes != 2 {
+		t.Errorf("expected height 100 stuck for 2 refreshes, got %d for %d", height, refreshes)
+	}
+
+	// a node ahead of the websocket counts as progress
+	cc.setPolledHeight(101)
+	cc.checkHeightProgress()
+	if height, refreshes := cc.heightStuck(); height != 101 || refreshes != 0 {
+		t.Errorf("expected height 101 with no stuck refreshes, got %d for %d", height, refreshes)
+	}
+}
+
+func TestCountConsecutiveMiss(t *testing.T) {
+	tests := []struct {
+		name     string
+		strict   bool
+		statuses []StatusType
+		expected float64
+	}{
+		{
+			name:     "should count missed blocks of any kind",
+			statuses: []StatusType{Statusmissed, StatusPrevote, StatusPrecommit},
+			expected: 3,
+		},
+		{
+			name:     "should reset on any signed block by default",
+			statuses: []StatusType{Statusmissed, Statusmissed, StatusSigned, Statusmissed},
+			expected: 1,
+		},
+		{
+			name:     "should reset on a proposed block by default",
+			statuses: []StatusType{Statusmissed, Statusmissed, StatusProposedEmpty},
+			expected: 0,
+		},
+		{
+			name:     "should keep counting over a single signed block when strict",
+			strict:   true,
+			statuses: []StatusType{Statusmissed, Statusmissed, StatusSigned, Statusmissed},
+			expected: 3,
+		},
+		{
+			name:     "should reset after two signed blocks in a row when strict",
+			strict:   true,
+			statuses: []StatusType{Statusmissed, Statusmissed, StatusSigned, StatusProposed},
+			expected: 0,
+		},
+		{
+			name:     "should not carry signed blocks over a miss when strict",
+			strict:   true,
+			statuses: []StatusType{StatusSigned, Statusmissed, StatusSigned},
+			expected: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strict := tt.strict
+			cc := &ChainConfig{Alerts: AlertConfig{ConsecutiveResetStrict: &strict}}
+			for _, status := range tt.statuses {
+				cc.countConsecutiveMiss(status)
+			}
+			if cc.statConsecutiveMiss != tt.expected {
+				t.Errorf("expected %v consecutive misses, got %v", tt.expected, cc.statConsecutiveMiss)
+			}
+		})
+	}
+}
+
+func TestChainStatsRoundTrip(t *testing.T) {
+	running := &ChainConfig{
+		statTotalSigns:      1000,
+		statTotalProps:      12,
+		statTotalPropsEmpty: 2,
+		statTotalMiss:       7,
+		statPrevoteMiss:     3,
+		statPrecommitMiss:   4,
+		statConsecutiveMiss: 5,
+	}
+	b, err := json.Marshal(&savedState{Stats: map[string]chainStats{"test-chain": running.stats()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		reset    bool
+		expected chainStats
+	}{
+		{
+			name:     "should restore the counters",
+			expected: running.stats(),
+		},
+		{
+			name:     "should start from zero when reset on restart",
+			reset:    true,
+			expected: chainStats{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved := &savedState{}
+			if err := json.Unmarshal(b, saved); err != nil {
+				t.Fatal(err)
+			}
+			cc := &ChainConfig{}
+			c := &Config{ResetStatsOnRestart: tt.reset, Chains: map[string]*ChainConfig{"test-chain": cc}}
+			c.restoreStats(saved.Stats)
+
+			if cc.stats() != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, cc.stats())
+			}
+			if cc.statConsecutiveMiss != 0 {
+				t.Errorf("expected the consecutive counter to start over, got %v", cc.statConsecutiveMiss)
+			}
+		})
+	}
+}
+
+func TestNodeMarkFailed(t *testing.T) {
+	tests := []struct {
+		name     string
+		grace    int
+		checks   []bool // true is a failed check
+		expected []bool // whether the node is down after each check
+	}{
+		{
+			name:     "down on the first failure by default",
+			grace:    1,
+			checks:   []bool{true, true},
+			expected: []bool{true, true},
+		},
+		{
+			name:     "down after the grace checks",
+			grace:    3,
+			checks:   []bool{true, true, true, true},
+			expected: []bool{false, false, true, true},
+		},
+		{
+			name:     "a healthy check starts the count over",
+			grace:    2,
+			checks:   []bool{true, false, true, true},
+			expected: []bool{false, false, false, true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &NodeConfig{Url: "http://node1.example.com"}
+			var wentDown time.Time
+			for i, failed := range tt.checks {
+				if failed {
+					node.markFailed(tt.grace)
+				} else {
+					// what the health check does for a healthy node
+					node.down = false
+					node.failures = 0
+					node.downSince = time.Unix(0, 0)
+				}
+				if node.down != tt.expected[i] {
+					t.Fatalf("check %d: expected down %v, got %v", i+1, tt.expected[i], node.down)
+				}
+				if !node.down {
+					continue
+				}
+				if wentDown.IsZero() {
+					wentDown = node.downSince
+				}
+				if !node.downSince.Equal(wentDown) {
+					t.Errorf("check %d: the down time should start at the check that marked the node down", i+1)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package tenderduty
+
+import (
+	"context"
+	"fmt"
+
+	upgrade "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+)
+
+// upgradeResumeBlocks is how many blocks past a scheduled upgrade height the stall and missed block alerts stay
+// suppressed, while the validators restart with the new binary.
+const upgradeResumeBlocks = 20
+
+// refreshUpgradePlan queries the chain's scheduled software upgrade. A failed query keeps the plan that is known, the
+// nodes are often down during the upgrade.
+func (cc *ChainConfig) refreshUpgradePlan(ctx context.Context, provider ChainProvider) {
+	plan, err := provider.QueryUpgradePlan(ctx)
+	if err != nil {
+		l(fmt.Errorf("failed to query the upgrade plan for chain %s, err: %w", cc.name, err))
+		return
+	}
+	cc.setUpgradePlan(plan)
+}
+
+// setUpgradePlan remembers the height of a scheduled upgrade. The chain removes the plan once the upgrade ran, its
+// height is kept until the blocks are past upgradeResumeBlocks after it, so the alerts stay quiet until the chain
+// resumes.
+func (cc *ChainConfig) setUpgradePlan(plan *upgrade.Plan) {
+	if plan != nil && plan.Height > 0 {
+		if plan.Height != cc.upgradeHeight {
+			l(fmt.Sprintf("⬆️ %s has upgrade %s scheduled at height %d", cc.ChainId, plan.Name, plan.Height))
+		}
+		cc.upgradeHeight, cc.upgradeName = plan.Height, plan.Name
+		return
+	}
+	if !cc.atUpgrade() {
+		cc.upgradeHeight, cc.upgradeName = 0, ""
+	}
+}
+
+// atUpgrade reports whether the chain is at the scheduled upgrade height, halted at the block before it, or less than
+// upgradeResumeBlocks past it.
+func (cc *ChainConfig) atUpgrade() bool {
+	return cc.upgradeHeight > 0 && cc.lastBlockNum >= cc.upgradeHeight-1 && cc.lastBlockNum <= cc.upgradeHeight+upgradeResumeBlocks
+}
+
+// upgradeHalted reports whether suppress_during_upgrade is set and the chain is at a scheduled upgrade, see atUpgrade.
+func (cc *ChainConfig) upgradeHalted() bool {
+	return boolVal(cc.Alerts.SuppressDuringUpgrade) && cc.atUpgrade()
+}
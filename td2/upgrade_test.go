@@ -0,0 +1,67 @@
+package tenderduty
+
+import (
+	"context"
+	"testing"
+
+	upgrade "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+)
+
+func TestUpgradeHalted(t *testing.T) {
+	planned, err := (&upgrade.QueryCurrentPlanResponse{Plan: &upgrade.Plan{Name: "v2", Height: 100}}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the steps run in order against one chain, the node answers with the plan, without one, or fails
+	const (
+		withPlan = iota
+		noPlan
+		failing
+	)
+	var answer int
+	client := newAbciLogTestClient(t, func(path string) (uint32, string, []byte) {
+		if path != "/cosmos.upgrade.v1beta1.Query/CurrentPlan" {
+			return 6, "unknown query path", nil
+		}
+		switch answer {
+		case withPlan:
+			return 0, "", planned
+		case noPlan:
+			return 0, "", nil
+		}
+		return 1, "internal error", nil
+	})
+	cc := &ChainConfig{name: "test-chain", ChainId: "test-chain-1", client: client, Alerts: AlertConfig{SuppressDuringUpgrade: boolPtr(true)}}
+	provider := &DefaultProvider{ChainConfig: cc}
+
+	steps := []struct {
+		name        string
+		height      int64
+		answer      int
+		disabled    bool
+		wantHalted  bool
+		wantUpgrade int64
+	}{
+		{name: "not suppressed before the upgrade height", height: 50, answer: withPlan, wantUpgrade: 100},
+		{name: "suppressed while halted before the upgrade height", height: 99, answer: withPlan, wantHalted: true, wantUpgrade: 100},
+		{name: "not suppressed when disabled", height: 99, answer: withPlan, disabled: true, wantUpgrade: 100},
+		{name: "a failed query keeps the plan", height: 99, answer: failing, wantHalted: true, wantUpgrade: 100},
+		{name: "suppressed just past the upgrade height once the plan ran", height: 105, answer: noPlan, wantHalted: true, wantUpgrade: 100},
+		{name: "resolves normally once the blocks resumed", height: 100 + upgradeResumeBlocks + 1, answer: noPlan},
+	}
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			answer = step.answer
+			cc.Alerts.SuppressDuringUpgrade = boolPtr(!step.disabled)
+			cc.lastBlockNum = step.height
+			cc.refreshUpgradePlan(context.Background(), provider)
+			if got := cc.upgradeHalted(); got != step.wantHalted {
+				t.Errorf("expected halted %v, got %v", step.wantHalted, got)
+			}
+			if cc.upgradeHeight != step.wantUpgrade {
+				t.Errorf("expected upgrade height %d, got %d", step.wantUpgrade, cc.upgradeHeight)
+			}
+		})
+	}
+}
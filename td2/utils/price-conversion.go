@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
@@ -56,6 +57,8 @@ type CoinMarketCapClient struct {
 	apiEndpoint     string
 	httpClient      *http.Client
 	cacheClient     *TenderdutyCache
+	// slugCacheExpiration overrides cacheExpiration for individual slugs
+	slugCacheExpiration map[string]time.Duration
 }
 
 // NewCoinMarketCapClient creates a new client with the provided API key
@@ -89,8 +92,77 @@ func WithTimeout(timeout time.Duration) func(*CoinMarketCapClient) {
 	}
 }
 
-// GetPrices fetches cryptocurrency prices, using cache when available
+// WithHTTPClient replaces the HTTP client, keeping the configured timeout
+func WithHTTPClient(httpClient *http.Client) func(*CoinMarketCapClient) {
+	return func(c *CoinMarketCapClient) {
+		httpClient.Timeout = c.httpClient.Timeout
+		c.httpClient = httpClient
+	}
+}
+
+// SetCacheExpiration caches the price of slug for ttl instead of the client's default. If it is set more than once,
+// for example by two chains with the same token, the shortest ttl is kept.
+func (c *CoinMarketCapClient) SetCacheExpiration(slug string, ttl time.Duration) {
+	slug = strings.ToLower(slug)
+	if c.slugCacheExpiration == nil {
+		c.slugCacheExpiration = make(map[string]time.Duration)
+	}
+	if current, ok := c.slugCacheExpiration[slug]; !ok || ttl < current {
+		c.slugCacheExpiration[slug] = ttl
+	}
+}
+
+// sharedSlugs are the slugs without their own cache expiration, they are fetched and cached together.
+func (c *CoinMarketCapClient) sharedSlugs() []string {
+	slugs := make([]string, 0, len(c.slugs))
+	for _, slug := range c.slugs {
+		if _, ok := c.slugCacheExpiration[strings.ToLower(slug)]; !ok {
+			slugs = append(slugs, slug)
+		}
+	}
+	return slugs
+}
+
+// GetPrices fetches the prices of all slugs, using cache when available. Slugs with their own cache expiration are
+// fetched and cached on their own. It fails when none of the prices could be fetched.
 func (c *CoinMarketCapClient) GetPrices(ctx context.Context) (map[string]CryptoPrice, error) {
+	result := make(map[string]CryptoPrice)
+	// the last failure is returned when none of the prices could be fetched
+	var lastErr error
+
+	if len(c.sharedSlugs()) > 0 {
+		prices, err := c.getSharedPrices(ctx)
+		if err != nil {
+			lastErr = err
+		}
+		for slug, price := range prices {
+			result[slug] = price
+		}
+	}
+	for slug, ttl := range c.slugCacheExpiration {
+		prices, err := c.getSlugPrice(ctx, slug, ttl)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		for s, price := range prices {
+			result[s] = price
+		}
+	}
+
+	if len(result) == 0 {
+		if lastErr != nil {
+			return nil, lastErr
+		}
+		if len(c.slugs) > 0 {
+			return nil, fmt.Errorf("no prices returned for %s", joinStrings(c.slugs, ", "))
+		}
+	}
+	return result, nil
+}
+
+// getSharedPrices fetches the prices of the slugs without their own cache expiration, using cache when available
+func (c *CoinMarketCapClient) getSharedPrices(ctx context.Context) (map[string]CryptoPrice, error) {
 	// try to find the data from cache first
 	cache, ok1 := c.cacheClient.Get(cacheKey)
 	prices, ok2 := cache.(map[string]CryptoPrice)
@@ -98,7 +170,7 @@ func (c *CoinMarketCapClient) GetPrices(ctx context.Context) (map[string]CryptoP
 	if !ok1 || !ok2 {
 		// cache nout found, fetch and cache it
 		var err error
-		prices, err = c.fetchPricesFromAPI(ctx, c.slugs, c.currency)
+		prices, err = c.fetchPricesFromAPI(ctx, c.sharedSlugs(), c.currency)
 		if err != nil {
 			return nil, err
 		}
@@ -111,7 +183,13 @@ func (c *CoinMarketCapClient) GetPrices(ctx context.Context) (map[string]CryptoP
 
 // GetPrice fetches the price for a specific cryptocurrency slug, using cache when available
 func (c *CoinMarketCapClient) GetPrice(ctx context.Context, slug string) (*CryptoPrice, error) {
-	prices, err := c.GetPrices(ctx)
+	var prices map[string]CryptoPrice
+	var err error
+	if ttl, ok := c.slugCacheExpiration[strings.ToLower(slug)]; ok {
+		prices, err = c.getSlugPrice(ctx, strings.ToLower(slug), ttl)
+	} else {
+		prices, err = c.getSharedPrices(ctx)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -127,15 +205,36 @@ func (c *CoinMarketCapClient) GetPrice(ctx context.Context, slug string) (*Crypt
 	return nil, fmt.Errorf("slug '%s' not found", slug)
 }
 
+// getSlugPrice caches a slug with its own expiration separately from the other prices, only this slug is fetched when
+// it expires.
+func (c *CoinMarketCapClient) getSlugPrice(ctx context.Context, slug string, ttl time.Duration) (map[string]CryptoPrice, error) {
+	key := cacheKey + "_" + slug
+	cache, ok1 := c.cacheClient.Get(key)
+	prices, ok2 := cache.(map[string]CryptoPrice)
+	if ok1 && ok2 {
+		return prices, nil
+	}
+
+	prices, err := c.fetchPricesFromAPI(ctx, []string{slug}, c.currency)
+	if err != nil {
+		return nil, err
+	}
+	c.cacheClient.Set(key, prices, ttl)
+	return prices, nil
+}
+
 // fetchPricesFromAPI makes the actual API call to CoinMarketCap
 func (c *CoinMarketCapClient) fetchPricesFromAPI(ctx context.Context, slugs []string, currency string) (map[string]CryptoPrice, error) {
 	result := make(map[string]CryptoPrice)
 	url := c.apiEndpoint + "/v2/cryptocurrency/quotes/latest"
+	// the last failure is returned when none of the prices could be fetched
+	var lastErr error
 
 	// Process each slug individually as some of the slugs may not be valid
 	for _, slug := range slugs {
 		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
+			lastErr = err
 			continue // Skip this slug and try the next one
 		}
 
@@ -153,6 +252,7 @@ func (c *CoinMarketCapClient) fetchPricesFromAPI(ctx context.Context, slugs []st
 		if err != nil {
 			// Log the error and continue with next slug
 			fmt.Printf("Error fetching data for slug %s: %v\n", slug, err)
+			lastErr = err
 			continue
 		}
 
@@ -167,6 +267,7 @@ func (c *CoinMarketCapClient) fetchPricesFromAPI(ctx context.Context, slugs []st
 		if resp.StatusCode != http.StatusOK {
 			bodyBytes, _ := io.ReadAll(resp.Body)
 			fmt.Printf("API error for slug %s (status %d): %s\n", slug, resp.StatusCode, string(bodyBytes))
+			lastErr = fmt.Errorf("status %d for slug %s", resp.StatusCode, slug)
 			continue
 		}
 
@@ -174,12 +275,14 @@ func (c *CoinMarketCapClient) fetchPricesFromAPI(ctx context.Context, slugs []st
 		var cmcResp CMCResponse
 		if err := json.NewDecoder(resp.Body).Decode(&cmcResp); err != nil {
 			fmt.Printf("Failed to parse API response for slug %s: %v\n", slug, err)
+			lastErr = err
 			continue
 		}
 
 		// Check for API error
 		if cmcResp.Status.ErrorCode != 0 {
 			fmt.Printf("API returned error for slug %s: %s\n", slug, cmcResp.Status.ErrorMessage)
+			lastErr = fmt.Errorf("error for slug %s: %s", slug, cmcResp.Status.ErrorMessage)
 			continue
 		}
 
@@ -207,7 +310,10 @@ func (c *CoinMarketCapClient) fetchPricesFromAPI(ctx context.Context, slugs []st
 		}
 	}
 
-	// Return whatever valid data we were able to gather
+	// Return whatever valid data we were able to gather, an empty result is not cached
+	if len(result) == 0 && lastErr != nil {
+		return nil, fmt.Errorf("could not fetch any prices from CoinMarketCap: %w", lastErr)
+	}
 	return result, nil
 }
 
@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGetPricePerSlugCacheExpiration(t *testing.T) {
+	var requestsMux sync.Mutex
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		slug := r.URL.Query().Get("slug")
+		requestsMux.Lock()
+		requests[slug]++
+		requestsMux.Unlock()
+		_, _ = w.Write([]byte(`{"status":{"error_code":0},"data":{"1":{"id":1,"name":"` + slug + `","symbol":"TKN","slug":"` + slug + `","quote":{"USD":{"price":1.5,"last_updated":"2024-01-01T00:00:00.000Z"}}}}}`))
+	}))
+	defer server.Close()
+
+	client := NewCoinMarketCapClient("test-key", "USD", NewCache(), 8, []string{"volatile", "stable"})
+	WithEndpoint(server.URL)(client)
+	client.SetCacheExpiration("Volatile", 50*time.Millisecond)
+	// the shortest expiration wins when a slug is configured twice
+	client.SetCacheExpiration("volatile", time.Hour)
+
+	getPrices := func() {
+		for _, slug := range []string{"volatile", "stable"} {
+			price, err := client.GetPrice(context.Background(), slug)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if price.Price != 1.5 {
+				t.Errorf("expected a price of 1.5 for %s, got %v", slug, price.Price)
+			}
+		}
+	}
+
+	tests := []struct {
+		name             string
+		wait             time.Duration
+		expectedVolatile int
+		expectedStable   int
+	}{
+		{
+			name:             "first lookup fetches both slugs",
+			expectedVolatile: 1,
+			expectedStable:   1,
+		},
+		{
+			name:             "both prices are cached",
+			expectedVolatile: 1,
+			expectedStable:   1,
+		},
+		{
+			name:             "only the slug with the short expiration is fetched again",
+			wait:             100 * time.Millisecond,
+			expectedVolatile: 2,
+			expectedStable:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			time.Sleep(tt.wait)
+			getPrices()
+			requestsMux.Lock()
+			defer requestsMux.Unlock()
+			if requests["volatile"] != tt.expectedVolatile {
+				t.Errorf("expected %d requests for volatile, got %d", tt.expectedVolatile, requests["volatile"])
+			}
+			if requests["stable"] != tt.expectedStable {
+				t.Errorf("expected %d requests for stable, got %d", tt.expectedStable, requests["stable"])
+			}
+		})
+	}
+}
+
+func TestGetPricesChecksEverySlug(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         int
+		slugs          []string
+		ownExpiration  []string
+		expectedPrices int
+		expectError    bool
+	}{
+		{
+			name:           "should fetch the slugs with their own expiration",
+			status:         http.StatusOK,
+			slugs:          []string{"volatile", "stable"},
+			ownExpiration:  []string{"volatile", "stable"},
+			expectedPrices: 2,
+		},
+		{
+			name:           "should fetch shared and own expiration slugs together",
+			status:         http.StatusOK,
+			slugs:          []string{"volatile", "stable"},
+			ownExpiration:  []string{"volatile"},
+			expectedPrices: 2,
+		},
+		{
+			name:          "should fail when no slug with its own expiration can be fetched",
+			status:        http.StatusInternalServerError,
+			slugs:         []string{"volatile", "stable"},
+			ownExpiration: []string{"volatile", "stable"},
+			expectError:   true,
+		},
+		{
+			name:        "should fail when no shared slug can be fetched",
+			status:      http.StatusInternalServerError,
+			slugs:       []string{"stable"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				slug := r.URL.Query().Get("slug")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"status":{"error_code":0},"data":{"1":{"id":1,"name":"` + slug + `","symbol":"TKN","slug":"` + slug + `","quote":{"USD":{"price":1.5,"last_updated":"2024-01-01T00:00:00.000Z"}}}}}`))
+			}))
+			defer server.Close()
+
+			client := NewCoinMarketCapClient("test-key", "USD", NewCache(), 8, tt.slugs)
+			WithEndpoint(server.URL)(client)
+			for _, slug := range tt.ownExpiration {
+				client.SetCacheExpiration(slug, time.Hour)
+			}
+
+			prices, err := client.GetPrices(context.Background())
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected an error, got %v", prices)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(prices) != tt.expectedPrices {
+				t.Errorf("expected %d prices, got %v", tt.expectedPrices, prices)
+			}
+		})
+	}
+}
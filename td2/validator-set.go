@@ -0,0 +1,80 @@
+package tenderduty
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+)
+
+// defaultValidatorSetCache is how many minutes a validator set snapshot is reused when validator_set_cache_minutes is
+// not set.
+const defaultValidatorSetCache = 10
+
+// validatorSetSnapshot is the chain's consensus validator set at a height, sorted by voting power, highest first.
+type validatorSetSnapshot struct {
+	Height     int64
+	Validators []cometValidator
+	TotalPower int64
+}
+
+// rank returns the 1-based position of the validator with the consensus address in the set and its share of the
+// voting power, found is false when it is not in the set.
+func (s *validatorSetSnapshot) rank(address []byte) (rank int, share float64, found bool) {
+	for i, v := range s.Validators {
+		if !bytes.Equal(ToBytes(v.Address), address) {
+			continue
+		}
+		if s.TotalPower > 0 {
+			share = float64(v.VotingPower) / float64(s.TotalPower)
+		}
+		return i + 1, share, true
+	}
+	return 0, 0, false
+}
+
+func validatorSetCacheKey(cc *ChainConfig) string {
+	return "validator_set_" + cc.ChainId
+}
+
+// getValidatorSetCached returns the chain's validator set, queried at most once every validator_set_cache_minutes
+// instead of on each evaluation, the whole set can be a few pages.
+func getValidatorSetCached(cc *ChainConfig) (*validatorSetSnapshot, error) {
+	key := validatorSetCacheKey(cc)
+	if cached, ok := td.tenderdutyCache.Get(key); ok {
+		if snapshot, ok := cached.(*validatorSetSnapshot); ok {
+			return snapshot, nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	snapshot, err := queryValidatorSet(ctx, cc)
+	if err != nil {
+		return nil, err
+	}
+	minutes := td.ValidatorSetCacheMinutes
+	if minutes <= 0 {
+		minutes = defaultValidatorSetCache
+	}
+	td.tenderdutyCache.Set(key, snapshot, time.Duration(minutes)*time.Minute)
+	return snapshot, nil
+}
+
+// queryValidatorSet fetches every page of the latest validator set. The raw rpc response is read so that key types the
+// tendermint library doesn't know, such as ethermint's, don't fail the query.
+func queryValidatorSet(ctx context.Context, cc *ChainConfig) (*validatorSetSnapshot, error) {
+	vals, height, err := cc.getValidatorSet(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &validatorSetSnapshot{Height: height, Validators: vals}
+
+	sort.SliceStable(snapshot.Validators, func(i, j int) bool {
+		return snapshot.Validators[i].VotingPower > snapshot.Validators[j].VotingPower
+	})
+	for _, v := range snapshot.Validators {
+		snapshot.TotalPower += v.VotingPower
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,168 @@
+package tenderduty
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/firstset/tenderduty/v2/td2/utils"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+)
+
+// newValidatorsTestClient returns an rpc client for a server that answers /validators requests with powers, two per
+// page, and counts the requests it was sent. The pages after the first have to ask for the first page's height.
+func newValidatorsTestClient(t *testing.T, powers []int64, requests *int) *rpchttp.HTTP {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if r.URL.Path != "/validators" || page < 1 || (page > 1 && r.URL.Query().Get("height") != "100") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		*requests++
+		validators := make([]map[string]any, 0, 2)
+		for i := 2 * (page - 1); i < len(powers) && i < 2*page; i++ {
+			validators = append(validators, map[string]any{
+				"address":           strconv.Itoa(10 + i),
+				"pub_key":           map[string]any{"type": "tendermint/PubKeyEd25519", "value": "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="},
+				"voting_power":      strconv.FormatInt(powers[i], 10),
+				"proposer_priority": "0",
+			})
+		}
+		resp := map[string]any{
+			"jsonrpc": "2.0",
+			"id":      -1,
+			"result": map[string]any{
+				"block_height": "100",
+				"validators":   validators,
+				"count":        strconv.Itoa(len(validators)),
+				"total":        strconv.Itoa(len(powers)),
+			},
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := rpchttp.New(server.URL, "/websocket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestGetValidatorSetCached(t *testing.T) {
+	originalTd := td
+	td = createTestConfig()
+	td.tenderdutyCache = utils.NewCache()
+	defer func() { td = originalTd }()
+
+	requests := 0
+	cc := &ChainConfig{
+		name:    "test-chain",
+		ChainId: "test-chain-1",
+		client:  newValidatorsTestClient(t, []int64{10, 40, 30, 20}, &requests),
+	}
+
+	snapshot, err := getValidatorSetCached(cc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// four validators are two pages
+	if requests != 2 {
+		t.Errorf("expected 2 requests on a cache miss, got %d", requests)
+	}
+	if len(snapshot.Validators) != 4 || snapshot.TotalPower != 100 || snapshot.Height != 100 {
+		t.Fatalf("expected 4 validators with 100 power at height 100, got %+v", snapshot)
+	}
+	rank, share, found := snapshot.rank([]byte{0x11})
+	if !found || rank != 1 || share != 0.4 {
+		t.Errorf("expected the 40 power validator ranked #1 with 40%%, got #%d %v %v", rank, share, found)
+	}
+	if _, _, found = snapshot.rank([]byte{0x99}); found {
+		t.Error("expected an unknown address not to be found")
+	}
+
+	if _, err = getValidatorSetCached(cc); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("expected no requests on a cache hit, got %d", requests-2)
+	}
+
+	// an expired snapshot is queried again
+	td.tenderdutyCache.Set(validatorSetCacheKey(cc), snapshot, time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if _, err = getValidatorSetCached(cc); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 4 {
+		t.Errorf("expected the set to be queried again after expiry, got %d requests", requests)
+	}
+}
+
+func TestEvaluateValidatorRankAlert(t *testing.T) {
+	testAlarms := &alarmCache{
+		AllAlarms: make(map[string]map[string]alertMsgCache),
+		notifyMux: sync.RWMutex{},
+	}
+	originalAlarms := alarms
+	alarms = testAlarms
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	td.tenderdutyCache = utils.NewCache()
+	defer func() { td = originalTd }()
+
+	requests := 0
+	client := newValidatorsTestClient(t, []int64{10, 40, 30, 20}, &requests)
+
+	tests := []struct {
+		name             string
+		conspub          []byte
+		maxRank          int
+		existingAlert    bool
+		expectedAlert    bool
+		expectedResolved bool
+	}{
+		{name: "should alert when ranked below the maximum", conspub: []byte{0x10}, maxRank: 3, expectedAlert: true},
+		{name: "should not alert within the maximum", conspub: []byte{0x12}, maxRank: 3},
+		{name: "should resolve once back within the maximum", conspub: []byte{0x11}, maxRank: 3, existingAlert: true, expectedResolved: true},
+		{name: "should not alert outside the set", conspub: []byte{0x99}, maxRank: 1},
+		{name: "should not alert without a maximum", conspub: []byte{0x10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAlarms.AllAlarms = map[string]map[string]alertMsgCache{"test-chain": {}}
+			if tt.existingAlert {
+				testAlarms.AllAlarms["test-chain"]["ValidatorRank_testval123"] = alertMsgCache{Message: "test alert", SentTime: time.Now()}
+			}
+			cc := &ChainConfig{
+				name:       "test-chain",
+				ChainId:    "test-chain-1",
+				ValAddress: "testval123",
+				client:     client,
+				valInfo:    &ValInfo{Moniker: "test-validator", Conspub: tt.conspub},
+				Alerts:     AlertConfig{MaxValidatorRank: &tt.maxRank},
+			}
+
+			alert, resolved := evaluateValidatorRankAlert(cc)
+			if alert != tt.expectedAlert {
+				t.Errorf("expected alert %v, got %v", tt.expectedAlert, alert)
+			}
+			if resolved != tt.expectedResolved {
+				t.Errorf("expected resolved %v, got %v", tt.expectedResolved, resolved)
+			}
+			for len(td.alertChan) > 0 {
+				<-td.alertChan
+			}
+		})
+	}
+	if requests != 2 {
+		t.Errorf("expected the validator set to be queried once, got %d requests", requests)
+	}
+}
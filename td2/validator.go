@@ -13,6 +13,7 @@ import (
 	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/bech32"
 	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
 	utils "github.com/firstset/tenderduty/v2/td2/utils"
 )
 
@@ -33,6 +34,47 @@ type ValInfo struct {
 	Projected30DRewards   float64                                      `json:"projected_30d_rewards"`
 	SelfDelegationRewards *github_com_cosmos_cosmos_sdk_types.DecCoins `json:"self_delegation_rewards"`
 	Commission            *github_com_cosmos_cosmos_sdk_types.DecCoins `json:"commission"`
+	// Unbonding is nil until the unbonding entries have been queried successfully
+	Unbonding []staking.UnbondingDelegationEntry `json:"unbonding"`
+	// IBCClients is nil until the watched IBC clients have been queried
+	IBCClients []IBCClientStatus `json:"ibc_clients"`
+	// GasBalance is the operator account's balance of the fee denom in base units, nil until it has been queried
+	GasBalance *float64 `json:"gas_balance"`
+}
+
+// hasSlashing reports whether the chain's signing info and slashing params can be queried.
+func (cc *ChainConfig) hasSlashing() bool {
+	return !cc.NoSlashingModule && !cc.slashingMissing
+}
+
+// checkSlashingModule reports whether err means the chain has no slashing module. The first time it logs that the
+// window based alerts are skipped, and the slashing queries are not sent again.
+func (cc *ChainConfig) checkSlashingModule(err error) bool {
+	if !errors.Is(err, errNoSlashingModule) {
+		return false
+	}
+	if !cc.slashingMissing {
+		cc.slashingMissing = true
+		l(fmt.Sprintf("ℹ️ %s has no slashing module, skipping the missed blocks window alerts", cc.ChainId))
+	}
+	return true
+}
+
+// denomMetadataOverride builds the metadata of base from denom_exponent_override and display_denom_override, for chains
+// that don't publish their denom metadata. It returns nil unless both are set.
+func (cc *ChainConfig) denomMetadataOverride(base string) *bank.Metadata {
+	if cc.DenomExponentOverride == nil || *cc.DenomExponentOverride < 0 || cc.DisplayDenomOverride == "" {
+		return nil
+	}
+	return &bank.Metadata{
+		DenomUnits: []*bank.DenomUnit{
+			{Denom: base, Exponent: 0},
+			{Denom: cc.DisplayDenomOverride, Exponent: uint32(*cc.DenomExponentOverride)},
+		},
+		Base:    base,
+		Display: cc.DisplayDenomOverride,
+		Symbol:  strings.ToUpper(cc.DisplayDenomOverride),
+	}
 }
 
 // GetMinSignedPerWindow The check the minimum signed threshold of the validator.
@@ -50,13 +92,23 @@ func (cc *ChainConfig) GetMinSignedPerWindow() (err error) {
 		provider = &NamadaProvider{
 			ChainConfig: cc,
 		}
+	case "generic":
+		provider = &GenericHTTPProvider{
+			ChainConfig: cc,
+		}
 	default:
 		provider = &DefaultProvider{
 			ChainConfig: cc,
 		}
 	}
 
+	if !cc.hasSlashing() {
+		return nil
+	}
 	slashingParams, err := provider.QuerySlashingParams(ctx)
+	if cc.checkSlashingModule(err) {
+		return nil
+	}
 	if err != nil {
 		return
 	}
@@ -73,7 +125,7 @@ func (cc *ChainConfig) fetchBankMetadataFromGitHub() (metadata *bank.Metadata, e
 	if !ok1 || !ok2 {
 		// cache not found, fetch and cache it
 		json_file := "https://raw.githubusercontent.com/Firstset/tenderduty/refs/heads/main/static/tenderduty_bank_metadata.json"
-		resp, err := http.Get(json_file)
+		resp, err := newHTTPClient(0).Get(json_file)
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch bank metadata from GitHub: %w", err)
 		}
@@ -118,6 +170,10 @@ func (cc *ChainConfig) GetValInfo(first bool) (err error) {
 		provider = &NamadaProvider{
 			ChainConfig: cc,
 		}
+	case "generic":
+		provider = &GenericHTTPProvider{
+			ChainConfig: cc,
+		}
 	default:
 		provider = &DefaultProvider{
 			ChainConfig: cc,
@@ -129,9 +185,15 @@ func (cc *ChainConfig) GetValInfo(first bool) (err error) {
 	// explorers, so make it easy and lookup the consensus key for them.
 	conspub, moniker, jailed, bonded, delegatedTokens, commissionRate, err := provider.QueryValidatorInfo(ctx)
 	if err != nil {
+		// a failed query says nothing about the validator, only the chain answering that it is gone does
+		if errors.Is(err, errValidatorNotFound) {
+			cc.validatorMissing = true
+		}
 		return
 	}
+	cc.validatorMissing = false
 
+	cc.valInfoUpdated = time.Now()
 	cc.valInfo.Conspub = conspub
 	cc.valInfo.Moniker = moniker
 	cc.valInfo.Jailed = jailed
@@ -140,8 +202,15 @@ func (cc *ChainConfig) GetValInfo(first bool) (err error) {
 	cc.valInfo.CommissionRate = commissionRate
 	if td.PriceConversion.Enabled {
 		cryptoPrice, err := td.coinMarketCapClient.GetPrice(ctx, cc.Slug)
+		cc.countQueryError("price", err)
+		cc.countPriceFailure(err)
 		if err == nil {
 			cc.cryptoPrice = cryptoPrice
+			if td.Prom {
+				update := cc.mkUpdate(metricCryptoPrice, cryptoPrice.Price, "")
+				update.currency = cryptoPrice.Currency
+				td.sendStat(update)
+			}
 		}
 	}
 
@@ -155,27 +224,15 @@ func (cc *ChainConfig) GetValInfo(first bool) (err error) {
 		// no need to change prefix for signing info query
 		cc.valInfo.Valcons = cc.ValAddress
 	} else {
-		// need to know the prefix for when we serialize the slashing info query, this is too fragile.
-		// for now, we perform specific chain overrides based on known values because the valoper is used
-		// in so many places.
+		// need to know the prefix for when we serialize the slashing info query
 		var prefix string
-		split := strings.Split(cc.ValAddress, "valoper")
-		if len(split) != 2 {
-			if pre, ok := altValopers.getAltPrefix(cc.ValAddress); ok {
-				cc.valInfo.Valcons, err = bech32.ConvertAndEncode(pre, cc.valInfo.Conspub[:20])
-				if err != nil {
-					return
-				}
-			} else {
-				err = errors.New("❓ could not determine bech32 prefix from valoper address: " + cc.ValAddress)
-				return
-			}
-		} else {
-			prefix = split[0] + "valcons"
-			cc.valInfo.Valcons, err = bech32.ConvertAndEncode(prefix, cc.valInfo.Conspub[:20])
-			if err != nil {
-				return
-			}
+		prefix, err = cc.valconsPrefix()
+		if err != nil {
+			return
+		}
+		cc.valInfo.Valcons, err = bech32.ConvertAndEncode(prefix, cc.valInfo.Conspub[:20])
+		if err != nil {
+			return
 		}
 		if first {
 			l("⚙️", cc.ValAddress[:20], "... is using consensus key:", cc.valInfo.Valcons)
@@ -194,15 +251,22 @@ func (cc *ChainConfig) GetValInfo(first bool) (err error) {
 
 	// Query the chain's outstanding rewards
 	rewards, commission, err := provider.QueryValidatorSelfDelegationRewardsAndCommission(ctx)
+	cc.countRewardsFailure(err)
 	if err == nil {
 		// query the chain's denom metadata, only query once since this does not change
 		if first && rewards != nil && len(*rewards) > 0 {
-			bankMeta, err := provider.QueryDenomMetadata(ctx, (*rewards)[0].Denom)
+			// a configured override is used instead of the chain's metadata
+			bankMeta := cc.denomMetadataOverride((*rewards)[0].Denom)
+			var err error
+			if bankMeta == nil {
+				bankMeta, err = provider.QueryDenomMetadata(ctx, (*rewards)[0].Denom)
+			}
 			if err == nil {
 				cc.denomMetadata = bankMeta
 			} else {
 				l(fmt.Errorf("cannot query bank metadata for chain %s, err: %w, now fallback to query the GitHub JSON file", cc.name, err))
 				bankMeta, err = cc.fetchBankMetadataFromGitHub()
+				cc.countQueryError("denom_metadata_github", err)
 				if err == nil {
 					cc.denomMetadata = bankMeta
 				} else {
@@ -232,8 +296,6 @@ func (cc *ChainConfig) GetValInfo(first bool) (err error) {
 
 		cc.valInfo.SelfDelegationRewards = rewards
 		cc.valInfo.Commission = commission
-
-		// TODO:update statsChan
 	} else {
 		l(fmt.Errorf("failed to query rewards and commission information for chain %s, err: %w", cc.name, err))
 	}
@@ -283,12 +345,41 @@ func (cc *ChainConfig) GetValInfo(first bool) (err error) {
 		}
 	}
 
+	cc.exportEconomicStats()
+
+	if boolVal(cc.Alerts.UnbondingAlerts) {
+		unbonding, err := provider.QueryUnbondingDelegations(ctx)
+		if err == nil {
+			cc.valInfo.Unbonding = unbonding
+		} else {
+			l(fmt.Errorf("failed to query unbonding delegations for chain %s, err: %w", cc.name, err))
+		}
+	}
+
+	// the fee denom is only known from the denom metadata, without it the balance can't be compared in display units
+	if boolVal(cc.Alerts.GasBalanceAlerts) && cc.denomMetadata != nil {
+		balance, err := provider.QueryAccountBalance(ctx, cc.denomMetadata.Base)
+		if err == nil {
+			cc.valInfo.GasBalance = &balance
+		} else {
+			l(fmt.Errorf("failed to query the account balance for chain %s, err: %w", cc.name, err))
+		}
+	}
+
+	if boolVal(cc.Alerts.IBCClientExpiryAlerts) && len(cc.IBCClients) > 0 {
+		cc.valInfo.IBCClients = queryIBCClients(ctx, provider, cc.IBCClients, cc.valInfo.IBCClients)
+	}
+
+	if boolVal(cc.Alerts.SuppressDuringUpgrade) || boolVal(cc.Alerts.UpcomingUpgradeAlerts) {
+		cc.refreshUpgradePlan(ctx, provider)
+	}
+
 	// Query for unvoted proposals regardless of alert setting
 	unvotedProposals, err := provider.QueryUnvotedOpenProposals(ctx)
 	if err == nil {
 		cc.unvotedOpenGovProposals = unvotedProposals
 		if td.Prom {
-			td.statsChan <- cc.mkUpdate(metricUnvotedProposals, float64(len(cc.unvotedOpenGovProposals)), "")
+			td.sendStat(cc.mkUpdate(metricUnvotedProposals, float64(len(cc.unvotedOpenGovProposals)), ""))
 		}
 	} else {
 		l(err)
@@ -299,7 +390,14 @@ func (cc *ChainConfig) GetValInfo(first bool) (err error) {
 		l(fmt.Sprintf("ℹ️ Governance alerts disabled for %s (%s)", cc.ValAddress, cc.valInfo.Moniker))
 	}
 
+	// the remaining queries need the slashing module, without it the other alerts keep working
+	if !cc.hasSlashing() {
+		return nil
+	}
 	signingInfo, err := provider.QuerySigningInfo(ctx)
+	if cc.checkSlashingModule(err) {
+		return nil
+	}
 	if err != nil {
 		return
 	}
@@ -309,7 +407,7 @@ func (cc *ChainConfig) GetValInfo(first bool) (err error) {
 	}
 	cc.valInfo.Missed = signingInfo.MissedBlocksCounter
 	if td.Prom {
-		td.statsChan <- cc.mkUpdate(metricWindowMissed, float64(cc.valInfo.Missed), "")
+		td.sendStat(cc.mkUpdate(metricWindowMissed, float64(cc.valInfo.Missed), ""))
 	}
 
 	// finally get the signed blocks window
@@ -319,15 +417,124 @@ func (cc *ChainConfig) GetValInfo(first bool) (err error) {
 			return
 		}
 		if first && td.Prom {
-			td.statsChan <- cc.mkUpdate(metricWindowSize, float64(slashingParams.SignedBlocksWindow), "")
-			td.statsChan <- cc.mkUpdate(metricTotalNodes, float64(len(cc.Nodes)), "")
+			td.sendStat(cc.mkUpdate(metricWindowSize, float64(slashingParams.SignedBlocksWindow), ""))
+			td.sendStat(cc.mkUpdate(metricTotalNodes, float64(len(cc.Nodes)), ""))
 		}
 		cc.valInfo.Window = slashingParams.SignedBlocksWindow
 	}
 	return
 }
 
+// queryIBCClients queries the status of each watched IBC client. A client that could not be queried keeps its
+// previous status, so a failed query neither resolves nor raises its alarms.
+func queryIBCClients(ctx context.Context, provider ChainProvider, clientIDs []string, previous []IBCClientStatus) []IBCClientStatus {
+	statuses := make([]IBCClientStatus, 0, len(clientIDs))
+	for _, clientID := range clientIDs {
+		status, err := provider.QueryIBCClientStatus(ctx, clientID)
+		if err == nil {
+			statuses = append(statuses, *status)
+			continue
+		}
+		l(fmt.Errorf("failed to query ibc client %s, err: %w", clientID, err))
+		for _, prev := range previous {
+			if prev.ClientID == clientID {
+				statuses = append(statuses, prev)
+			}
+		}
+	}
+	return statuses
+}
+
 func ToBytes(address string) []byte {
 	bz, _ := hex.DecodeString(strings.ToLower(address))
 	return bz
 }
+
+// exportEconomicStats sends the delegation, APR and reward values shown on the dashboard to prometheus.
+func (cc *ChainConfig) exportEconomicStats() {
+	if !td.Prom {
+		return
+	}
+	delegated := cc.valInfo.DelegatedTokens
+	if cc.denomMetadata != nil {
+		if converted, _, err := utils.ConvertFloatInBaseUnitToDisplayUnit(delegated, *cc.denomMetadata); err == nil {
+			delegated = converted
+		}
+	}
+	td.sendStat(cc.mkUpdate(metricDelegatedTokens, delegated, ""))
+	// the APR can't be calculated without the denom metadata, don't report a misleading 0
+	if cc.baseAPR != 0 {
+		td.sendStat(cc.mkUpdate(metricValidatorAPR, cc.valInfo.ValidatorAPR, ""))
+	}
+	if cc.valInfo.SelfDelegationRewards != nil {
+		td.sendStat(cc.mkUpdate(metricSelfDelegationRewards, firstCoinAmount(*cc.valInfo.SelfDelegationRewards), ""))
+	}
+	if cc.valInfo.Commission != nil {
+		td.sendStat(cc.mkUpdate(metricCommission, firstCoinAmount(*cc.valInfo.Commission), ""))
+	}
+}
+
+// rewardsFailingAlertID is the alarm sent when the rewards and commission can't be queried.
+const rewardsFailingAlertID = "RewardsQueryFailing"
+
+// countRewardsFailure alerts when the rewards and commission query failed rewards_query_fail_threshold times in a row,
+// and resolves the alert once the query succeeds again.
+func (cc *ChainConfig) countRewardsFailure(err error) {
+	id := rewardsFailingAlertID
+	if err == nil {
+		cc.rewardsFailures = 0
+		if alarms.exist(cc.name, id) {
+			td.alert(cc.name, fmt.Sprintf("could not query the rewards and commission of %s on %s", cc.valInfo.Moniker, cc.ChainId), "warning", true, &id)
+		}
+		return
+	}
+	cc.rewardsFailures++
+	threshold := intVal(cc.Alerts.RewardsQueryFailThreshold)
+	if threshold > 0 && cc.rewardsFailures >= threshold && !alarms.exist(cc.name, id) {
+		td.alert(
+			cc.name,
+			fmt.Sprintf("could not query the rewards and commission of %s on %s %d times in a row, unclaimed rewards alerts are not sent: %s", cc.valInfo.Moniker, cc.ChainId, cc.rewardsFailures, err),
+			"warning",
+			false,
+			&id,
+		)
+	}
+}
+
+// firstCoinAmount returns the amount of the first coin, which is the staking denom for rewards and commission. Other
+// denoms are ignored the same way the unclaimed rewards alarm does.
+func firstCoinAmount(coins github_com_cosmos_cosmos_sdk_types.DecCoins) float64 {
+	if len(coins) == 0 {
+		return 0
+	}
+	f, err := coins[0].Amount.Float64()
+	if err != nil {
+		return 0
+	}
+	return f
+}
+
+// valconsPrefix returns the bech32 prefix of the validator's consensus address. A configured bech32_prefix wins,
+// otherwise it is derived from the valoper address, falling back to altValopers for chains using non-standard naming.
+func (cc *ChainConfig) valconsPrefix() (string, error) {
+	if cc.Bech32Prefix != "" {
+		return cc.Bech32Prefix, nil
+	}
+	split := strings.Split(cc.ValAddress, "valoper")
+	if len(split) == 2 {
+		return split[0] + "valcons", nil
+	}
+	if pre, ok := altValopers.getAltPrefix(cc.ValAddress); ok {
+		return pre, nil
+	}
+	return "", errors.New("❓ could not determine bech32 prefix from valoper address, set bech32_prefix: " + cc.ValAddress)
+}
+
+// valInfoStaleAfter is how long the validator info can go without a successful refresh, it is refreshed every minute.
+const valInfoStaleAfter = 5 * time.Minute
+
+// monitoringUp reports whether tenderduty can currently monitor the chain: it has a working rpc node and the
+// validator info is not stale.
+func (cc *ChainConfig) monitoringUp() bool {
+	return !cc.noNodes && !cc.valInfoUpdated.IsZero() && time.Since(cc.valInfoUpdated) < valInfoStaleAfter
+}
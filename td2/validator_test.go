@@ -0,0 +1,311 @@
+package tenderduty
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	slashing "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	"github.com/firstset/tenderduty/v2/td2/utils"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+)
+
+func TestValconsPrefix(t *testing.T) {
+	tests := []struct {
+		name         string
+		valAddress   string
+		bech32Prefix string
+		expected     string
+		expectErr    bool
+	}{
+		{
+			name:       "derived from the valoper address",
+			valAddress: "osmovaloper1xxxxxxx",
+			expected:   "osmovalcons",
+		},
+		{
+			name:       "known non-standard prefix",
+			valAddress: "ival1xxxxxxx",
+			expected:   "ica",
+		},
+		{
+			name:         "custom prefix from the config",
+			valAddress:   "customop1xxxxxxx",
+			bech32Prefix: "customcons",
+			expected:     "customcons",
+		},
+		{
+			name:         "custom prefix wins over the derived one",
+			valAddress:   "osmovaloper1xxxxxxx",
+			bech32Prefix: "customcons",
+			expected:     "customcons",
+		},
+		{
+			name:       "unknown prefix",
+			valAddress: "customop1xxxxxxx",
+			expectErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := &ChainConfig{ValAddress: tt.valAddress, Bech32Prefix: tt.bech32Prefix}
+			prefix, err := cc.valconsPrefix()
+			if tt.expectErr != (err != nil) {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+			if prefix != tt.expected {
+				t.Errorf("expected prefix %q, got %q", tt.expected, prefix)
+			}
+		})
+	}
+}
+
+func TestGetMinSignedPerWindowWithoutSlashing(t *testing.T) {
+	params := slashing.QueryParamsResponse{Params: slashing.Params{MinSignedPerWindow: sdk.NewDecWithPrec(5, 2)}}
+	paramsValue, err := params.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name             string
+		noSlashingModule bool
+		code             uint32
+		expectedQueries  int
+		expectedMissing  bool
+		expectedMinSign  float64
+		expectedLogLines int
+	}{
+		{
+			name:            "should read the slashing params",
+			expectedQueries: 2,
+			expectedMinSign: 0.05,
+		},
+		{
+			name:             "should skip the window once the slashing module is found missing",
+			code:             6,
+			expectedQueries:  1,
+			expectedMissing:  true,
+			expectedLogLines: 1,
+		},
+		{
+			name:             "should not query a chain configured without a slashing module",
+			noSlashingModule: true,
+		},
+	}
+
+	originalTd := td
+	td = &Config{}
+	defer func() { td = originalTd }()
+	originalLogs := logs
+	defer func() { logs = originalLogs }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs = make(chan logEntry, 10)
+			queries := 0
+			cc := &ChainConfig{ChainId: "test-chain", NoSlashingModule: tt.noSlashingModule}
+			cc.client = newAbciTestClient(t, func(path string) (uint32, []byte) {
+				queries++
+				if tt.code != 0 {
+					return tt.code, nil
+				}
+				return 0, paramsValue
+			})
+
+			// a chain known to have no slashing module must not be queried again on the second call
+			for i := 0; i < 2; i++ {
+				if err := cc.GetMinSignedPerWindow(); err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+			}
+			if queries != tt.expectedQueries {
+				t.Errorf("expected %d queries, got %d", tt.expectedQueries, queries)
+			}
+			if cc.hasSlashing() == (tt.expectedMissing || tt.noSlashingModule) {
+				t.Errorf("expected hasSlashing to be %v", !tt.expectedMissing && !tt.noSlashingModule)
+			}
+			if cc.minSignedPerWindow != tt.expectedMinSign {
+				t.Errorf("expected min signed per window %v, got %v", tt.expectedMinSign, cc.minSignedPerWindow)
+			}
+			if len(logs) != tt.expectedLogLines {
+				t.Errorf("expected %d log lines, got %d", tt.expectedLogLines, len(logs))
+			}
+		})
+	}
+}
+
+func TestDenomMetadataOverride(t *testing.T) {
+	tests := []struct {
+		name          string
+		exponent      *int
+		display       string
+		expectedNil   bool
+		expectedValue float64
+		expectedUnit  string
+	}{
+		{
+			name:          "should convert with the configured exponent",
+			exponent:      intPtr(6),
+			display:       "osmo",
+			expectedValue: 1.5,
+			expectedUnit:  "osmo",
+		},
+		{
+			name:          "should allow a zero exponent",
+			exponent:      intPtr(0),
+			display:       "token",
+			expectedValue: 1500000,
+			expectedUnit:  "token",
+		},
+		{
+			name:        "should not override without an exponent",
+			display:     "osmo",
+			expectedNil: true,
+		},
+		{
+			name:        "should not override without a display denom",
+			exponent:    intPtr(6),
+			expectedNil: true,
+		},
+		{
+			name:        "should not override with a negative exponent",
+			exponent:    intPtr(-1),
+			display:     "osmo",
+			expectedNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := &ChainConfig{DenomExponentOverride: tt.exponent, DisplayDenomOverride: tt.display}
+			metadata := cc.denomMetadataOverride("uosmo")
+			if tt.expectedNil {
+				if metadata != nil {
+					t.Errorf("expected no metadata, got %+v", metadata)
+				}
+				return
+			}
+			if metadata == nil {
+				t.Fatal("expected metadata")
+			}
+			value, unit, err := utils.ConvertFloatInBaseUnitToDisplayUnit(1500000, *metadata)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if value != tt.expectedValue || unit != tt.expectedUnit {
+				t.Errorf("expected %v %s, got %v %s", tt.expectedValue, tt.expectedUnit, value, unit)
+			}
+		})
+	}
+}
+
+func TestValidatorMissing(t *testing.T) {
+	originalAlarms := alarms
+	alarms = &alarmCache{AllAlarms: make(map[string]map[string]alertMsgCache), notifyMux: sync.RWMutex{}}
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	cc := td.Chains["test-chain"]
+	// queries that fail before reaching the chain
+	unreachable, err := rpchttp.New("http://127.0.0.1:1", "/websocket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	answering := func(code uint32, log string) *rpchttp.HTTP {
+		return newAbciLogTestClient(t, func(path string) (uint32, string, []byte) {
+			return code, log, nil
+		})
+	}
+
+	steps := []struct {
+		name     string
+		client   *rpchttp.HTTP
+		found    bool
+		missing  bool
+		firing   bool
+		notified int
+	}{
+		{name: "an unreachable node is not a missing validator", client: unreachable},
+		{name: "a failed query is not a missing validator", client: answering(6, "unknown query path")},
+		{name: "the chain not knowing the validator alerts", client: answering(5, "rpc error: code = NotFound desc = validator testval123 not found"), missing: true, firing: true, notified: 1},
+		{name: "a node failing afterwards keeps the alert without repeating it", client: unreachable, missing: true, firing: true},
+		{name: "an empty answer also counts as not found", client: answering(0, ""), missing: true, firing: true},
+		{name: "the validator reappearing resolves the alert", found: true, notified: 1},
+	}
+	for _, step := range steps {
+		if step.found {
+			// what a successful refresh does
+			cc.validatorMissing = false
+		} else {
+			cc.client = step.client
+			if err := cc.GetValInfo(false); err == nil {
+				t.Fatalf("%s: expected the validator query to fail", step.name)
+			}
+		}
+		if cc.validatorMissing != step.missing {
+			t.Errorf("%s: expected missing %v, got %v", step.name, step.missing, cc.validatorMissing)
+		}
+		evaluateValidatorMissingAlert(cc)
+		if firing := alarms.exist(cc.name, "ValidatorMissing_"+cc.ValAddress); firing != step.firing {
+			t.Errorf("%s: expected firing %v, got %v", step.name, step.firing, firing)
+		}
+		if len(td.alertChan) != step.notified {
+			t.Errorf("%s: expected %d notifications, got %d", step.name, step.notified, len(td.alertChan))
+		}
+		for len(td.alertChan) > 0 {
+			<-td.alertChan
+		}
+	}
+}
+
+func TestCountRewardsFailure(t *testing.T) {
+	originalAlarms := alarms
+	alarms = &alarmCache{AllAlarms: make(map[string]map[string]alertMsgCache), notifyMux: sync.RWMutex{}}
+	defer func() { alarms = originalAlarms }()
+
+	originalTd := td
+	td = createTestConfig()
+	defer func() { td = originalTd }()
+
+	cc := td.Chains["test-chain"]
+	cc.valInfo = &ValInfo{Moniker: "test-validator"}
+	failure := errors.New("rpc error: code = Unknown desc = distribution query failed")
+
+	steps := []struct {
+		name      string
+		threshold *int
+		err       error
+		firing    bool
+		notified  int
+	}{
+		{name: "disabled by default", err: failure},
+		{name: "still disabled", err: failure},
+		{name: "alerts once the count reaches the threshold", threshold: intPtr(3), err: failure, firing: true, notified: 1},
+		{name: "further failures are not repeated", threshold: intPtr(3), err: failure, firing: true},
+		{name: "a successful query resolves the alert", threshold: intPtr(3), notified: 1},
+		{name: "the count starts over", threshold: intPtr(3), err: failure},
+		{name: "second failure after the restart", threshold: intPtr(3), err: failure},
+		{name: "third failure in a row alerts again", threshold: intPtr(3), err: failure, firing: true, notified: 1},
+	}
+	for _, step := range steps {
+		cc.Alerts.RewardsQueryFailThreshold = step.threshold
+		cc.countRewardsFailure(step.err)
+		if firing := alarms.exist(cc.name, rewardsFailingAlertID); firing != step.firing {
+			t.Errorf("%s: expected firing %v, got %v", step.name, step.firing, firing)
+		}
+		if len(td.alertChan) != step.notified {
+			t.Errorf("%s: expected %d notifications, got %d", step.name, step.notified, len(td.alertChan))
+		}
+		for len(td.alertChan) > 0 {
+			if alert := <-td.alertChan; alert.severity != "warning" {
+				t.Errorf("%s: expected a warning, got %s", step.name, alert.severity)
+			}
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
@@ -44,6 +45,7 @@ type StatusUpdate struct {
 	Status StatusType
 	Final  bool
 	Empty  bool
+	Slash  *slashEvent // set when the block's events slashed the validator
 }
 
 // WsReply is a trimmed down version of the JSON sent from a tendermint websocket subscription.
@@ -82,7 +84,7 @@ func (cc *ChainConfig) WsRun() {
 		// wait until our RPC client is connected and running. We will use the same URL for the websocket
 		if cc.client == nil || cc.valInfo == nil || cc.valInfo.Conspub == nil {
 			if started.Before(time.Now().Add(-2 * time.Minute)) {
-				l(cc.name, "websocket client timed out waiting for a working rpc endpoint, restarting")
+				lChain(cc.name, "websocket client timed out waiting for a working rpc endpoint, restarting")
 				return
 			}
 			l("⏰ waiting for a healthy client for", cc.ChainId)
@@ -93,7 +95,7 @@ func (cc *ChainConfig) WsRun() {
 	}
 
 	//#nosec G402 -- configurable option
-	cc.wsclient, err = NewClient(cc.client.Remote(), td.TLSSkipVerify)
+	cc.wsclient, err = NewClient(cc.client.Remote(), td.TLSSkipVerify, cc.nodeByUrl(cc.client.Remote()).authHeader())
 	if err != nil {
 		l(err)
 		cancel()
@@ -104,6 +106,7 @@ func (cc *ChainConfig) WsRun() {
 	if err != nil {
 		log.Println(err)
 	}
+	cc.wsConnected()
 
 	// This go func processes the results returned by the listeners. It has most of the logic on where data is sent,
 	// like dashboards or prometheus.
@@ -119,14 +122,26 @@ func (cc *ChainConfig) WsRun() {
 				if update.Status > signState && cc.valInfo.Bonded {
 					signState = update.Status
 				}
+				if update.Slash != nil {
+					cc.recordSlash(update.Slash)
+				}
 				if update.Final {
 					cc.lastBlockNum = update.Height
+					cc.setWsHeight(update.Height)
 					if td.Prom {
-						td.statsChan <- cc.mkUpdate(metricLastBlockSeconds, time.Since(cc.lastBlockTime).Seconds(), "")
+						td.sendStat(cc.mkUpdate(metricLastBlockSeconds, time.Since(cc.lastBlockTime).Seconds(), ""))
+						td.sendStat(cc.mkUpdate(metricLastBlockHeight, float64(update.Height), ""))
+					}
+					if !cc.lastBlockTime.IsZero() {
+						cc.updateBlockTime(time.Since(cc.lastBlockTime))
+						if td.Prom {
+							td.sendStat(cc.mkUpdate(metricBlockTimeEMA, cc.averageBlockTime(), ""))
+						}
 					}
 					cc.lastBlockTime = time.Now()
 					info := getAlarms(cc.name)
 					cc.blocksResults = append([]int{int(signState)}, cc.blocksResults[:len(cc.blocksResults)-1]...)
+					cc.setConsensusMisses(cc.blocksResults)
 					if signState < 3 && cc.valInfo.Bonded {
 						warn := fmt.Sprintf("❌ warning      %s missed block %d on %s", cc.valInfo.Moniker, update.Height, cc.ChainId)
 						info += warn + "\n"
@@ -134,31 +149,27 @@ func (cc *ChainConfig) WsRun() {
 						l(warn)
 					}
 
+					cc.recordVoteStreak(signState)
+					cc.countConsecutiveMiss(signState)
 					switch signState {
 					case Statusmissed:
 						cc.statTotalMiss += 1
-						cc.statConsecutiveMiss += 1
 					case StatusPrecommit:
 						cc.statPrecommitMiss += 1
 						cc.statTotalMiss += 1
-						cc.statConsecutiveMiss += 1
 					case StatusPrevote:
 						cc.statPrevoteMiss += 1
 						cc.statTotalMiss += 1
-						cc.statConsecutiveMiss += 1
 					case StatusSigned:
 						cc.statTotalSigns += 1
-						cc.statConsecutiveMiss = 0
 					case StatusProposed:
 						cc.statTotalProps += 1
 						cc.statTotalSigns += 1
-						cc.statConsecutiveMiss = 0
 						cc.statConsecutiveEmpty = 0
 					case StatusProposedEmpty:
 						cc.statTotalPropsEmpty += 1
 						cc.statTotalProps += 1
 						cc.statTotalSigns += 1
-						cc.statConsecutiveMiss = 0
 						cc.statConsecutiveEmpty += 1
 					}
 					signState = -1
@@ -179,51 +190,19 @@ func (cc *ChainConfig) WsRun() {
 
 					cc.activeAlerts = alarms.getCount(cc.name)
 					if td.EnableDash {
-						td.updateChan <- &dash.ChainStatus{
-							MsgType:                 "status",
-							Name:                    cc.name,
-							ChainId:                 cc.ChainId,
-							Moniker:                 cc.valInfo.Moniker,
-							Bonded:                  cc.valInfo.Bonded,
-							Jailed:                  cc.valInfo.Jailed,
-							Tombstoned:              cc.valInfo.Tombstoned,
-							Missed:                  cc.valInfo.Missed,
-							Window:                  cc.valInfo.Window,
-							MinSignedPerWindow:      cc.minSignedPerWindow,
-							Nodes:                   len(cc.Nodes),
-							HealthyNodes:            healthyNodes,
-							ActiveAlerts:            cc.activeAlerts,
-							Height:                  update.Height,
-							LastError:               info,
-							Blocks:                  cc.blocksResults,
-							UnvotedOpenGovProposals: len(cc.unvotedOpenGovProposals),
-							TotalBondedTokens:       cc.totalBondedTokens,
-							TotalSupply:             cc.totalSupply,
-							CommunityTax:            cc.communityTax,
-							InflationRate:           cc.inflationRate,
-							BaseAPR:                 cc.baseAPR,
-							VotingPowerPercent:      cc.valInfo.VotingPowerPercent,
-							DelegatedTokens:         cc.valInfo.DelegatedTokens,
-							CommissionRate:          cc.valInfo.CommissionRate,
-							ValidatorAPR:            cc.valInfo.ValidatorAPR,
-							SelfDelegationRewards:   cc.valInfo.SelfDelegationRewards,
-							Commission:              cc.valInfo.Commission,
-							CryptoPrice:             cc.cryptoPrice,
-							DenomMetadata:           cc.denomMetadata,
-							Projected30DRewards:     cc.valInfo.Projected30DRewards,
-						}
+						cc.sendDashUpdate(cc.dashStatus(update.Height, healthyNodes, info))
 					}
 
 					if td.Prom {
-						td.statsChan <- cc.mkUpdate(metricSigned, cc.statTotalSigns, "")
-						td.statsChan <- cc.mkUpdate(metricProposed, cc.statTotalProps, "")
-						td.statsChan <- cc.mkUpdate(metricMissed, cc.statTotalMiss, "")
-						td.statsChan <- cc.mkUpdate(metricPrevote, cc.statPrevoteMiss, "")
-						td.statsChan <- cc.mkUpdate(metricPrecommit, cc.statPrecommitMiss, "")
-						td.statsChan <- cc.mkUpdate(metricConsecutive, cc.statConsecutiveMiss, "")
-						td.statsChan <- cc.mkUpdate(metricEmptyBlocks, float64(cc.statTotalPropsEmpty), "")
-						td.statsChan <- cc.mkUpdate(metricConsecutiveEmpty, float64(cc.statConsecutiveEmpty), "")
-						td.statsChan <- cc.mkUpdate(metricUnealthyNodes, float64(len(cc.Nodes)-healthyNodes), "")
+						td.sendStat(cc.mkUpdate(metricSigned, cc.statTotalSigns, ""))
+						td.sendStat(cc.mkUpdate(metricProposed, cc.statTotalProps, ""))
+						td.sendStat(cc.mkUpdate(metricMissed, cc.statTotalMiss, ""))
+						td.sendStat(cc.mkUpdate(metricPrevote, cc.statPrevoteMiss, ""))
+						td.sendStat(cc.mkUpdate(metricPrecommit, cc.statPrecommitMiss, ""))
+						td.sendStat(cc.mkUpdate(metricConsecutive, cc.statConsecutiveMiss, ""))
+						td.sendStat(cc.mkUpdate(metricEmptyBlocks, float64(cc.statTotalPropsEmpty), ""))
+						td.sendStat(cc.mkUpdate(metricConsecutiveEmpty, float64(cc.statConsecutiveEmpty), ""))
+						td.sendStat(cc.mkUpdate(metricUnealthyNodes, float64(len(cc.Nodes)-healthyNodes), ""))
 					}
 				}
 			case <-ctx.Done():
@@ -237,7 +216,7 @@ func (cc *ChainConfig) WsRun() {
 
 	blockChan := make(chan *WsReply)
 	go func() {
-		e := handleBlocks(ctx, blockChan, resultChan, strings.ToUpper(hex.EncodeToString(cc.valInfo.Conspub)))
+		e := handleBlocks(ctx, blockChan, resultChan, strings.ToUpper(hex.EncodeToString(cc.valInfo.Conspub)), cc.valInfo.Valcons, cc.rpcVersion())
 		if e != nil {
 			l("🛑", cc.ChainId, e)
 			cancel()
@@ -317,6 +296,71 @@ type rawBlock struct {
 			Txs []json.RawMessage `json:"txs"`
 		} `json:"data"`
 	} `json:"block"`
+	// slashing happens in begin block, or in finalize block from CometBFT v0.38
+	ResultBeginBlock struct {
+		Events []abciEvent `json:"events"`
+	} `json:"result_begin_block"`
+	ResultFinalizeBlock struct {
+		Events []abciEvent `json:"events"`
+	} `json:"result_finalize_block"`
+}
+
+type abciEvent struct {
+	Type       string          `json:"type"`
+	Attributes []abciAttribute `json:"attributes"`
+}
+
+type abciAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// slashEvent is what the slashing module reports when it slashes a validator.
+type slashEvent struct {
+	Height int64
+	Reason string
+	Power  string
+	Amount string // burned_coins, only reported by newer SDK versions
+}
+
+// findSlash looks for a slash event for the validator's consensus address in the block's events, read as the node's
+// RPC dialect sends them. Both the begin and finalize block events are checked while the dialect is unknown.
+func (rb rawBlock) findSlash(valcons string, v cometVersion) *slashEvent {
+	if valcons == "" {
+		return nil
+	}
+	var events []abciEvent
+	switch v {
+	case comet34, comet37:
+		events = rb.ResultBeginBlock.Events
+	case comet38:
+		events = rb.ResultFinalizeBlock.Events
+	default:
+		events = append(rb.ResultBeginBlock.Events, rb.ResultFinalizeBlock.Events...)
+	}
+	for _, event := range decodeEvents(events, v) {
+		if event.Type != "slash" {
+			continue
+		}
+		slash := &slashEvent{Height: rb.Block.Header.Height.val()}
+		var address string
+		for _, attr := range event.Attributes {
+			switch attr.Key {
+			case "address":
+				address = attr.Value
+			case "reason":
+				slash.Reason = attr.Value
+			case "power":
+				slash.Power = attr.Value
+			case "burned_coins":
+				slash.Amount = attr.Value
+			}
+		}
+		if address == valcons {
+			return slash
+		}
+	}
+	return nil
 }
 
 // find determines if a validator's pre-commit was included in a finalized block.
@@ -334,7 +378,7 @@ func (rb rawBlock) find(val string) bool {
 
 // handleBlocks consumes the channel for new blocks and when it sees one sends a status update. It's also
 // responsible for stalled chain detection and will shutdown the client if there are no blocks for a minute.
-func handleBlocks(ctx context.Context, blocks chan *WsReply, results chan StatusUpdate, address string) error {
+func handleBlocks(ctx context.Context, blocks chan *WsReply, results chan StatusUpdate, address string, valcons string, v cometVersion) error {
 	live := time.NewTicker(time.Minute)
 	defer live.Stop()
 	lastBlock := time.Now()
@@ -358,6 +402,7 @@ func handleBlocks(ctx context.Context, blocks chan *WsReply, results chan Status
 				Status: Statusmissed,
 				Final:  true,
 				Empty:  len(b.Block.Data.Txs) == 0,
+				Slash:  b.findSlash(valcons, v),
 			}
 			if b.Block.Header.ProposerAddress == address {
 				if upd.Empty {
@@ -421,9 +466,9 @@ type TmConn struct {
 	*websocket.Conn
 }
 
-// NewClient returns a websocket client.
+// NewClient returns a websocket client, header is sent with the handshake.
 // FIXME: need to handle UDS and insecure TLS
-func NewClient(u string, allowInsecure bool) (*TmConn, error) {
+func NewClient(u string, allowInsecure bool, header http.Header) (*TmConn, error) {
 	// dialUnix is used to determine if the connection is to a UDS and requires a custom dialer.
 	var dialUnix bool
 
@@ -465,23 +510,58 @@ func NewClient(u string, allowInsecure bool) (*TmConn, error) {
 
 	case allowInsecure && endpoint.Scheme == "wss":
 		// Add custom TLS dialer to allow self-signed certs
-		dialer := &websocket.Dialer{
-			TLSClientConfig: &tls.Config{
-				//#nosec G402 -- allowInsecure is true and that is configured by the user
-				InsecureSkipVerify: true,
-			},
-			HandshakeTimeout: 10 * time.Second,
-		}
-		conn, _, err = dialer.Dial(endpoint.String(), nil)
+		dialer := newWebsocketDialer()
+		//#nosec G402 -- allowInsecure is true and that is configured by the user
+		dialer.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+		dialer.HandshakeTimeout = 10 * time.Second
+		conn, _, err = dialer.Dial(endpoint.String(), header)
 		if err != nil {
 			return nil, fmt.Errorf("could not dial wss client to %s: %s", endpoint.String(), err.Error())
 		}
 
 	default:
-		conn, _, err = websocket.DefaultDialer.Dial(endpoint.String(), nil)
+		conn, _, err = newWebsocketDialer().Dial(endpoint.String(), header)
 		if err != nil {
 			return nil, fmt.Errorf("could not dial ws client to %s: %s", endpoint.String(), err.Error())
 		}
 	}
 	return &TmConn{Conn: conn}, nil
 }
+
+// dashStatus is the chain's status as the dashboard shows it, it is also what the summary report is built from.
+func (cc *ChainConfig) dashStatus(height int64, healthyNodes int, lastError string) *dash.ChainStatus {
+	return &dash.ChainStatus{
+		MsgType:                 "status",
+		Name:                    cc.name,
+		ChainId:                 cc.ChainId,
+		Moniker:                 cc.valInfo.Moniker,
+		Bonded:                  cc.valInfo.Bonded,
+		Jailed:                  cc.valInfo.Jailed,
+		Tombstoned:              cc.valInfo.Tombstoned,
+		Missed:                  cc.valInfo.Missed,
+		Window:                  cc.valInfo.Window,
+		MinSignedPerWindow:      cc.minSignedPerWindow,
+		Nodes:                   len(cc.Nodes),
+		HealthyNodes:            healthyNodes,
+		ActiveAlerts:            cc.activeAlerts,
+		ActiveSeverity:          alarms.worstSeverity(cc.name),
+		Height:                  height,
+		LastError:               lastError,
+		Blocks:                  cc.blocksResults,
+		UnvotedOpenGovProposals: len(cc.unvotedOpenGovProposals),
+		TotalBondedTokens:       cc.totalBondedTokens,
+		TotalSupply:             cc.totalSupply,
+		CommunityTax:            cc.communityTax,
+		InflationRate:           cc.inflationRate,
+		BaseAPR:                 cc.baseAPR,
+		VotingPowerPercent:      cc.valInfo.VotingPowerPercent,
+		DelegatedTokens:         cc.valInfo.DelegatedTokens,
+		CommissionRate:          cc.valInfo.CommissionRate,
+		ValidatorAPR:            cc.valInfo.ValidatorAPR,
+		SelfDelegationRewards:   cc.valInfo.SelfDelegationRewards,
+		Commission:              cc.valInfo.Commission,
+		CryptoPrice:             cc.cryptoPrice,
+		DenomMetadata:           cc.denomMetadata,
+		Projected30DRewards:     cc.valInfo.Projected30DRewards,
+	}
+}
@@ -0,0 +1,160 @@
+package tenderduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newBlockValue builds a trimmed NewBlock event value with the given begin block events.
+func newBlockValue(events string) []byte {
+	return []byte(`{
+		"block": {
+			"header": {"height": "1234", "proposer_address": "AAAA"},
+			"last_commit": {"signatures": [{"validator_address": "BBBB"}]},
+			"data": {"txs": []}
+		},
+		"result_begin_block": {"events": ` + events + `}
+	}`)
+}
+
+func TestFindSlash(t *testing.T) {
+	valcons := "cosmosvalcons1test"
+	tests := []struct {
+		name     string
+		events   string
+		version  cometVersion
+		finalize bool // the events are sent as finalize block events
+		expected *slashEvent
+	}{
+		{
+			name: "plain attributes with burned coins",
+			events: `[
+				{"type": "slash", "attributes": [
+					{"key": "address", "value": "cosmosvalcons1test"},
+					{"key": "power", "value": "1000"},
+					{"key": "reason", "value": "missing_signature"},
+					{"key": "jailed", "value": "cosmosvalcons1test"},
+					{"key": "burned_coins", "value": "10000000"}
+				]}
+			]`,
+			expected: &slashEvent{Height: 1234, Reason: "missing_signature", Power: "1000", Amount: "10000000"},
+		},
+		{
+			name: "base64 attributes from tendermint v0.34",
+			events: `[
+				{"type": "slash", "attributes": [
+					{"key": "YWRkcmVzcw==", "value": "Y29zbW9zdmFsY29uczF0ZXN0", "index": true},
+					{"key": "cG93ZXI=", "value": "MTAwMA==", "index": true},
+					{"key": "cmVhc29u", "value": "ZG91YmxlX3NpZ24=", "index": true}
+				]}
+			]`,
+			expected: &slashEvent{Height: 1234, Reason: "double_sign", Power: "1000"},
+		},
+		{
+			name: "base64 attributes from a known v0.34 node",
+			events: `[
+				{"type": "slash", "attributes": [
+					{"key": "YWRkcmVzcw==", "value": "Y29zbW9zdmFsY29uczF0ZXN0", "index": true},
+					{"key": "cmVhc29u", "value": "ZG91YmxlX3NpZ24=", "index": true}
+				]}
+			]`,
+			version:  comet34,
+			expected: &slashEvent{Height: 1234, Reason: "double_sign"},
+		},
+		{
+			name: "plain attributes from a v0.37 node are not decoded",
+			events: `[
+				{"type": "slash", "attributes": [
+					{"key": "address", "value": "cosmosvalcons1test"},
+					{"key": "reason", "value": "bWlzc2luZw=="}
+				]}
+			]`,
+			version:  comet37,
+			expected: &slashEvent{Height: 1234, Reason: "bWlzc2luZw=="},
+		},
+		{
+			name: "finalize block events from v0.38",
+			events: `[
+				{"type": "slash", "attributes": [
+					{"key": "address", "value": "cosmosvalcons1test"},
+					{"key": "reason", "value": "missing_signature"}
+				]}
+			]`,
+			version:  comet38,
+			finalize: true,
+			expected: &slashEvent{Height: 1234, Reason: "missing_signature"},
+		},
+		{
+			name: "begin block events are not read from v0.38",
+			events: `[
+				{"type": "slash", "attributes": [
+					{"key": "address", "value": "cosmosvalcons1test"},
+					{"key": "reason", "value": "missing_signature"}
+				]}
+			]`,
+			version: comet38,
+		},
+		{
+			name: "another validator was slashed",
+			events: `[
+				{"type": "liveness", "attributes": [{"key": "address", "value": "cosmosvalcons1test"}]},
+				{"type": "slash", "attributes": [
+					{"key": "address", "value": "cosmosvalcons1other"},
+					{"key": "reason", "value": "missing_signature"}
+				]}
+			]`,
+		},
+		{
+			name:   "no events",
+			events: `[]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := newBlockValue(tt.events)
+			if tt.finalize {
+				value = bytes.Replace(value, []byte("result_begin_block"), []byte("result_finalize_block"), 1)
+			}
+			b := &rawBlock{}
+			if err := json.Unmarshal(value, b); err != nil {
+				t.Fatal(err)
+			}
+			if got := b.findSlash(valcons, tt.version); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestHandleBlocksReportsSlash(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	blocks := make(chan *WsReply)
+	results := make(chan StatusUpdate)
+	go func() { _ = handleBlocks(ctx, blocks, results, "BBBB", "cosmosvalcons1test", cometUnknown) }()
+
+	reply := &WsReply{}
+	reply.Result.Data.Type = "tendermint/event/NewBlock"
+	reply.Result.Data.Value = newBlockValue(`[{"type": "slash", "attributes": [
+		{"key": "address", "value": "cosmosvalcons1test"},
+		{"key": "reason", "value": "missing_signature"}
+	]}]`)
+	blocks <- reply
+
+	select {
+	case upd := <-results:
+		if upd.Height != 1234 || upd.Status != StatusSigned {
+			t.Errorf("unexpected update %+v", upd)
+		}
+		if upd.Slash == nil || upd.Slash.Reason != "missing_signature" {
+			t.Errorf("expected the slash to be reported, got %+v", upd.Slash)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no status update was sent")
+	}
+}